@@ -109,11 +109,6 @@ func runApp() {
 		}
 	}()
 
-	orch, err := orchestrator.NewOrchestrator(resticPath, configMgr, opLog, logStore)
-	if err != nil {
-		zap.L().Fatal("error creating orchestrator", zap.Error(err))
-	}
-
 	kvdbPath := filepath.Join(env.DataDir(), "kvdb.sqlite")
 	sharedKvdb, err := kvstore.NewSqliteDbForKvStore(kvdbPath)
 	if err != nil {
@@ -121,6 +116,16 @@ func runApp() {
 	}
 	defer sharedKvdb.Close()
 
+	orchestratorKv, err := kvstore.NewSqliteKVStore(sharedKvdb, "orchestrator_state")
+	if err != nil {
+		zap.L().Fatal("error creating orchestrator state kvstore", zap.Error(err))
+	}
+
+	orch, err := orchestrator.NewOrchestrator(resticPath, configMgr, opLog, logStore, orchestratorKv)
+	if err != nil {
+		zap.L().Fatal("error creating orchestrator", zap.Error(err))
+	}
+
 	peerStateManager, err := syncapi.NewSqlitePeerStateManager(sharedKvdb)
 	if err != nil {
 		zap.L().Fatal("error creating peer state manager", zap.Error(err))
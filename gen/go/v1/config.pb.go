@@ -111,7 +111,7 @@ func (x Multihost_Permission_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Multihost_Permission_Type.Descriptor instead.
 func (Multihost_Permission_Type) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 2, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{2, 2, 0}
 }
 
 type CommandPrefix_IONiceLevel int32
@@ -163,7 +163,7 @@ func (x CommandPrefix_IONiceLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CommandPrefix_IONiceLevel.Descriptor instead.
 func (CommandPrefix_IONiceLevel) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{4, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type CommandPrefix_CPUNiceLevel int32
@@ -212,7 +212,7 @@ func (x CommandPrefix_CPUNiceLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CommandPrefix_CPUNiceLevel.Descriptor instead.
 func (CommandPrefix_CPUNiceLevel) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{4, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{6, 1}
 }
 
 type Schedule_Clock int32
@@ -264,7 +264,7 @@ func (x Schedule_Clock) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Schedule_Clock.Descriptor instead.
 func (Schedule_Clock) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 0}
 }
 
 type Hook_Condition int32
@@ -290,6 +290,23 @@ const (
 	Hook_CONDITION_FORGET_START   Hook_Condition = 300 // forget started.
 	Hook_CONDITION_FORGET_ERROR   Hook_Condition = 301 // forget failed.
 	Hook_CONDITION_FORGET_SUCCESS Hook_Condition = 302 // forget succeeded.
+	// copy conditions
+	Hook_CONDITION_COPY_START   Hook_Condition = 400 // copy started.
+	Hook_CONDITION_COPY_ERROR   Hook_Condition = 401 // copy failed.
+	Hook_CONDITION_COPY_SUCCESS Hook_Condition = 402 // copy succeeded.
+	// restore test conditions
+	Hook_CONDITION_RESTORE_TEST_START   Hook_Condition = 500 // restore test started.
+	Hook_CONDITION_RESTORE_TEST_ERROR   Hook_Condition = 501 // restore test failed to run or found files that didn't restore correctly.
+	Hook_CONDITION_RESTORE_TEST_SUCCESS Hook_Condition = 502 // restore test passed.
+	// backup overdue conditions
+	Hook_CONDITION_BACKUP_OVERDUE           Hook_Condition = 600 // the plan has gone longer than its schedule allows without a successful backup.
+	Hook_CONDITION_BACKUP_OVERDUE_RECOVERED Hook_Condition = 601 // the plan backed up successfully after being overdue.
+	// digest conditions
+	Hook_CONDITION_DIGEST Hook_Condition = 700 // periodic digest report, only runs hooks configured in the digest policy.
+	// restore conditions
+	Hook_CONDITION_RESTORE_START   Hook_Condition = 800 // restore started.
+	Hook_CONDITION_RESTORE_ERROR   Hook_Condition = 801 // restore failed.
+	Hook_CONDITION_RESTORE_SUCCESS Hook_Condition = 802 // restore succeeded.
 )
 
 // Enum value maps for Hook_Condition.
@@ -312,25 +329,49 @@ var (
 		300: "CONDITION_FORGET_START",
 		301: "CONDITION_FORGET_ERROR",
 		302: "CONDITION_FORGET_SUCCESS",
+		400: "CONDITION_COPY_START",
+		401: "CONDITION_COPY_ERROR",
+		402: "CONDITION_COPY_SUCCESS",
+		500: "CONDITION_RESTORE_TEST_START",
+		501: "CONDITION_RESTORE_TEST_ERROR",
+		502: "CONDITION_RESTORE_TEST_SUCCESS",
+		600: "CONDITION_BACKUP_OVERDUE",
+		601: "CONDITION_BACKUP_OVERDUE_RECOVERED",
+		700: "CONDITION_DIGEST",
+		800: "CONDITION_RESTORE_START",
+		801: "CONDITION_RESTORE_ERROR",
+		802: "CONDITION_RESTORE_SUCCESS",
 	}
 	Hook_Condition_value = map[string]int32{
-		"CONDITION_UNKNOWN":          0,
-		"CONDITION_ANY_ERROR":        1,
-		"CONDITION_SNAPSHOT_START":   2,
-		"CONDITION_SNAPSHOT_END":     3,
-		"CONDITION_SNAPSHOT_ERROR":   4,
-		"CONDITION_SNAPSHOT_WARNING": 5,
-		"CONDITION_SNAPSHOT_SUCCESS": 6,
-		"CONDITION_SNAPSHOT_SKIPPED": 7,
-		"CONDITION_PRUNE_START":      100,
-		"CONDITION_PRUNE_ERROR":      101,
-		"CONDITION_PRUNE_SUCCESS":    102,
-		"CONDITION_CHECK_START":      200,
-		"CONDITION_CHECK_ERROR":      201,
-		"CONDITION_CHECK_SUCCESS":    202,
-		"CONDITION_FORGET_START":     300,
-		"CONDITION_FORGET_ERROR":     301,
-		"CONDITION_FORGET_SUCCESS":   302,
+		"CONDITION_UNKNOWN":                  0,
+		"CONDITION_ANY_ERROR":                1,
+		"CONDITION_SNAPSHOT_START":           2,
+		"CONDITION_SNAPSHOT_END":             3,
+		"CONDITION_SNAPSHOT_ERROR":           4,
+		"CONDITION_SNAPSHOT_WARNING":         5,
+		"CONDITION_SNAPSHOT_SUCCESS":         6,
+		"CONDITION_SNAPSHOT_SKIPPED":         7,
+		"CONDITION_PRUNE_START":              100,
+		"CONDITION_PRUNE_ERROR":              101,
+		"CONDITION_PRUNE_SUCCESS":            102,
+		"CONDITION_CHECK_START":              200,
+		"CONDITION_CHECK_ERROR":              201,
+		"CONDITION_CHECK_SUCCESS":            202,
+		"CONDITION_FORGET_START":             300,
+		"CONDITION_FORGET_ERROR":             301,
+		"CONDITION_FORGET_SUCCESS":           302,
+		"CONDITION_COPY_START":               400,
+		"CONDITION_COPY_ERROR":               401,
+		"CONDITION_COPY_SUCCESS":             402,
+		"CONDITION_RESTORE_TEST_START":       500,
+		"CONDITION_RESTORE_TEST_ERROR":       501,
+		"CONDITION_RESTORE_TEST_SUCCESS":     502,
+		"CONDITION_BACKUP_OVERDUE":           600,
+		"CONDITION_BACKUP_OVERDUE_RECOVERED": 601,
+		"CONDITION_DIGEST":                   700,
+		"CONDITION_RESTORE_START":            800,
+		"CONDITION_RESTORE_ERROR":            801,
+		"CONDITION_RESTORE_SUCCESS":          802,
 	}
 )
 
@@ -358,7 +399,7 @@ func (x Hook_Condition) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Hook_Condition.Descriptor instead.
 func (Hook_Condition) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 0}
 }
 
 type Hook_OnError int32
@@ -416,15 +457,67 @@ func (x Hook_OnError) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Hook_OnError.Descriptor instead.
 func (Hook_OnError) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 1}
+}
+
+type Hook_NotifyMode int32
+
+const (
+	Hook_NOTIFY_ALWAYS       Hook_NotifyMode = 0 // run the hook every time one of its conditions fires.
+	Hook_NOTIFY_ON_CHANGE    Hook_NotifyMode = 1 // run the hook only when the outcome (e.g. error or success) differs from the previous run of the same plan and condition group. Start conditions always run.
+	Hook_NOTIFY_RATE_LIMITED Hook_NotifyMode = 2 // run the hook at most once per rate_limit_minutes.
+)
+
+// Enum value maps for Hook_NotifyMode.
+var (
+	Hook_NotifyMode_name = map[int32]string{
+		0: "NOTIFY_ALWAYS",
+		1: "NOTIFY_ON_CHANGE",
+		2: "NOTIFY_RATE_LIMITED",
+	}
+	Hook_NotifyMode_value = map[string]int32{
+		"NOTIFY_ALWAYS":       0,
+		"NOTIFY_ON_CHANGE":    1,
+		"NOTIFY_RATE_LIMITED": 2,
+	}
+)
+
+func (x Hook_NotifyMode) Enum() *Hook_NotifyMode {
+	p := new(Hook_NotifyMode)
+	*p = x
+	return p
+}
+
+func (x Hook_NotifyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Hook_NotifyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_config_proto_enumTypes[6].Descriptor()
+}
+
+func (Hook_NotifyMode) Type() protoreflect.EnumType {
+	return &file_v1_config_proto_enumTypes[6]
+}
+
+func (x Hook_NotifyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Hook_NotifyMode.Descriptor instead.
+func (Hook_NotifyMode) EnumDescriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 2}
 }
 
 type Hook_Webhook_Method int32
 
 const (
-	Hook_Webhook_UNKNOWN Hook_Webhook_Method = 0
+	Hook_Webhook_UNKNOWN Hook_Webhook_Method = 0 // defaults to POST.
 	Hook_Webhook_GET     Hook_Webhook_Method = 1
 	Hook_Webhook_POST    Hook_Webhook_Method = 2
+	Hook_Webhook_PUT     Hook_Webhook_Method = 3
+	Hook_Webhook_PATCH   Hook_Webhook_Method = 4
+	Hook_Webhook_DELETE  Hook_Webhook_Method = 5
 )
 
 // Enum value maps for Hook_Webhook_Method.
@@ -433,11 +526,17 @@ var (
 		0: "UNKNOWN",
 		1: "GET",
 		2: "POST",
+		3: "PUT",
+		4: "PATCH",
+		5: "DELETE",
 	}
 	Hook_Webhook_Method_value = map[string]int32{
 		"UNKNOWN": 0,
 		"GET":     1,
 		"POST":    2,
+		"PUT":     3,
+		"PATCH":   4,
+		"DELETE":  5,
 	}
 )
 
@@ -452,11 +551,11 @@ func (x Hook_Webhook_Method) String() string {
 }
 
 func (Hook_Webhook_Method) Descriptor() protoreflect.EnumDescriptor {
-	return file_v1_config_proto_enumTypes[6].Descriptor()
+	return file_v1_config_proto_enumTypes[7].Descriptor()
 }
 
 func (Hook_Webhook_Method) Type() protoreflect.EnumType {
-	return &file_v1_config_proto_enumTypes[6]
+	return &file_v1_config_proto_enumTypes[7]
 }
 
 func (x Hook_Webhook_Method) Number() protoreflect.EnumNumber {
@@ -465,7 +564,56 @@ func (x Hook_Webhook_Method) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Hook_Webhook_Method.Descriptor instead.
 func (Hook_Webhook_Method) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 1, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 1, 0}
+}
+
+type Hook_Email_Security int32
+
+const (
+	Hook_Email_SECURITY_STARTTLS Hook_Email_Security = 0 // upgrade the connection with STARTTLS, fails if the server doesn't support it.
+	Hook_Email_SECURITY_TLS      Hook_Email_Security = 1 // connect with implicit TLS, typically on port 465.
+	Hook_Email_SECURITY_NONE     Hook_Email_Security = 2 // send in plaintext, only suitable for a trusted local relay.
+)
+
+// Enum value maps for Hook_Email_Security.
+var (
+	Hook_Email_Security_name = map[int32]string{
+		0: "SECURITY_STARTTLS",
+		1: "SECURITY_TLS",
+		2: "SECURITY_NONE",
+	}
+	Hook_Email_Security_value = map[string]int32{
+		"SECURITY_STARTTLS": 0,
+		"SECURITY_TLS":      1,
+		"SECURITY_NONE":     2,
+	}
+)
+
+func (x Hook_Email_Security) Enum() *Hook_Email_Security {
+	p := new(Hook_Email_Security)
+	*p = x
+	return p
+}
+
+func (x Hook_Email_Security) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Hook_Email_Security) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_config_proto_enumTypes[8].Descriptor()
+}
+
+func (Hook_Email_Security) Type() protoreflect.EnumType {
+	return &file_v1_config_proto_enumTypes[8]
+}
+
+func (x Hook_Email_Security) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Hook_Email_Security.Descriptor instead.
+func (Hook_Email_Security) EnumDescriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 8, 0}
 }
 
 // Config is the top level config object for restic UI.
@@ -476,11 +624,13 @@ type Config struct {
 	Version int32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"` // version of the config file format. Used to determine when to run migrations.
 	// The instance name for the Backrest installation.
 	// This identifies backups created by this instance and is displayed in the UI.
-	Instance      string     `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
-	Repos         []*Repo    `protobuf:"bytes,3,rep,name=repos,proto3" json:"repos,omitempty"`
-	Plans         []*Plan    `protobuf:"bytes,4,rep,name=plans,proto3" json:"plans,omitempty"`
-	Auth          *Auth      `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
-	Multihost     *Multihost `protobuf:"bytes,7,opt,name=multihost,json=sync,proto3" json:"multihost,omitempty"`
+	Instance      string        `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
+	Repos         []*Repo       `protobuf:"bytes,3,rep,name=repos,proto3" json:"repos,omitempty"`
+	Plans         []*Plan       `protobuf:"bytes,4,rep,name=plans,proto3" json:"plans,omitempty"`
+	Auth          *Auth         `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	Multihost     *Multihost    `protobuf:"bytes,7,opt,name=multihost,json=sync,proto3" json:"multihost,omitempty"`
+	Digest        *DigestPolicy `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"` // optional periodic summary report of every plan and repo.
+	Hooks         []*Hook       `protobuf:"bytes,9,rep,name=hooks,proto3" json:"hooks,omitempty"`   // named hook definitions that plans, repos and the digest reference by id, they don't run on their own.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -564,6 +714,73 @@ func (x *Config) GetMultihost() *Multihost {
 	return nil
 }
 
+func (x *Config) GetDigest() *DigestPolicy {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *Config) GetHooks() []*Hook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+// DigestPolicy schedules a periodic report summarizing the backups of every plan and repo on this instance.
+type DigestPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"` // schedule for the digest, the report covers the time since the previous digest.
+	Hooks         []*Hook                `protobuf:"bytes,2,rep,name=hooks,proto3" json:"hooks,omitempty"`       // hooks that deliver the digest, each must include CONDITION_DIGEST.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DigestPolicy) Reset() {
+	*x = DigestPolicy{}
+	mi := &file_v1_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DigestPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DigestPolicy) ProtoMessage() {}
+
+func (x *DigestPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DigestPolicy.ProtoReflect.Descriptor instead.
+func (*DigestPolicy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DigestPolicy) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *DigestPolicy) GetHooks() []*Hook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
 type Multihost struct {
 	state             protoimpl.MessageState    `protogen:"open.v1"`
 	Identity          *PrivateKey               `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
@@ -576,7 +793,7 @@ type Multihost struct {
 
 func (x *Multihost) Reset() {
 	*x = Multihost{}
-	mi := &file_v1_config_proto_msgTypes[1]
+	mi := &file_v1_config_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -588,7 +805,7 @@ func (x *Multihost) String() string {
 func (*Multihost) ProtoMessage() {}
 
 func (x *Multihost) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[1]
+	mi := &file_v1_config_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,7 +818,7 @@ func (x *Multihost) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost.ProtoReflect.Descriptor instead.
 func (*Multihost) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1}
+	return file_v1_config_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Multihost) GetIdentity() *PrivateKey {
@@ -649,13 +866,14 @@ type Repo struct {
 	Shared           bool                   `protobuf:"varint,13,opt,name=shared,proto3" json:"shared,omitempty"`                                              // if true, this repo is pushed to all authorized clients with read-config permission
 	OriginInstanceId string                 `protobuf:"bytes,14,opt,name=origin_instance_id,json=originInstanceId,proto3" json:"origin_instance_id,omitempty"` // set when this repo was pushed from a remote instance; marks it as non-editable
 	ForgetPolicy     *ForgetPolicy          `protobuf:"bytes,15,opt,name=forget_policy,json=forgetPolicy,proto3" json:"forget_policy,omitempty"`               // optional repo-level forget policy. If set, overrides per-plan retention policies.
+	CopyPolicy       *CopyPolicy            `protobuf:"bytes,16,opt,name=copy_policy,json=copyPolicy,proto3" json:"copy_policy,omitempty"`                     // optional policy for replicating snapshots to a secondary repo.
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Repo) Reset() {
 	*x = Repo{}
-	mi := &file_v1_config_proto_msgTypes[2]
+	mi := &file_v1_config_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -667,7 +885,7 @@ func (x *Repo) String() string {
 func (*Repo) ProtoMessage() {}
 
 func (x *Repo) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[2]
+	mi := &file_v1_config_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,7 +898,7 @@ func (x *Repo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Repo.ProtoReflect.Descriptor instead.
 func (*Repo) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{2}
+	return file_v1_config_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Repo) GetId() string {
@@ -788,25 +1006,35 @@ func (x *Repo) GetForgetPolicy() *ForgetPolicy {
 	return nil
 }
 
+func (x *Repo) GetCopyPolicy() *CopyPolicy {
+	if x != nil {
+		return x.CopyPolicy
+	}
+	return nil
+}
+
 type Plan struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                      // unique but human readable ID for this plan.
-	Repo            string                 `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`                                                  // ID of the repo to use.
-	Paths           []string               `protobuf:"bytes,4,rep,name=paths,proto3" json:"paths,omitempty"`                                                // paths to include in the backup.
-	Excludes        []string               `protobuf:"bytes,5,rep,name=excludes,proto3" json:"excludes,omitempty"`                                          // glob patterns to exclude.
-	Iexcludes       []string               `protobuf:"bytes,9,rep,name=iexcludes,proto3" json:"iexcludes,omitempty"`                                        // case insensitive glob patterns to exclude.
-	Schedule        *Schedule              `protobuf:"bytes,12,opt,name=schedule,proto3" json:"schedule,omitempty"`                                         // schedule for the backup.
-	Retention       *RetentionPolicy       `protobuf:"bytes,7,opt,name=retention,proto3" json:"retention,omitempty"`                                        // retention policy for snapshots.
-	Hooks           []*Hook                `protobuf:"bytes,8,rep,name=hooks,proto3" json:"hooks,omitempty"`                                                // hooks to run on events for this plan.
-	BackupFlags     []string               `protobuf:"bytes,10,rep,name=backup_flags,proto3" json:"backup_flags,omitempty"`                                 // extra flags to set when running a backup command.
-	SkipIfUnchanged bool                   `protobuf:"varint,13,opt,name=skip_if_unchanged,json=skipIfUnchanged,proto3" json:"skip_if_unchanged,omitempty"` // skip the backup if no changes are detected.
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                            // unique but human readable ID for this plan.
+	Repo                   string                 `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`                                                                        // ID of the repo to use.
+	Paths                  []string               `protobuf:"bytes,4,rep,name=paths,proto3" json:"paths,omitempty"`                                                                      // paths to include in the backup.
+	Excludes               []string               `protobuf:"bytes,5,rep,name=excludes,proto3" json:"excludes,omitempty"`                                                                // glob patterns to exclude.
+	Iexcludes              []string               `protobuf:"bytes,9,rep,name=iexcludes,proto3" json:"iexcludes,omitempty"`                                                              // case insensitive glob patterns to exclude.
+	Schedule               *Schedule              `protobuf:"bytes,12,opt,name=schedule,proto3" json:"schedule,omitempty"`                                                               // schedule for the backup.
+	Retention              *RetentionPolicy       `protobuf:"bytes,7,opt,name=retention,proto3" json:"retention,omitempty"`                                                              // retention policy for snapshots.
+	Hooks                  []*Hook                `protobuf:"bytes,8,rep,name=hooks,proto3" json:"hooks,omitempty"`                                                                      // hooks to run on events for this plan.
+	BackupFlags            []string               `protobuf:"bytes,10,rep,name=backup_flags,proto3" json:"backup_flags,omitempty"`                                                       // extra flags to set when running a backup command.
+	SkipIfUnchanged        bool                   `protobuf:"varint,13,opt,name=skip_if_unchanged,json=skipIfUnchanged,proto3" json:"skip_if_unchanged,omitempty"`                       // skip the backup if no changes are detected.
+	StdinCommand           *StdinCommand          `protobuf:"bytes,14,opt,name=stdin_command,json=stdinCommand,proto3" json:"stdin_command,omitempty"`                                   // back up the output of a command instead of paths.
+	RestoreTestPolicy      *RestoreTestPolicy     `protobuf:"bytes,15,opt,name=restore_test_policy,json=restoreTestPolicy,proto3" json:"restore_test_policy,omitempty"`                  // optional policy for periodically test-restoring the plan's latest snapshot.
+	OverdueGraceMultiplier float64                `protobuf:"fixed64,16,opt,name=overdue_grace_multiplier,json=overdueGraceMultiplier,proto3" json:"overdue_grace_multiplier,omitempty"` // multiple of the schedule's period the plan may go without a successful backup before it is overdue, defaults to 1.25.
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *Plan) Reset() {
 	*x = Plan{}
-	mi := &file_v1_config_proto_msgTypes[3]
+	mi := &file_v1_config_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -818,7 +1046,7 @@ func (x *Plan) String() string {
 func (*Plan) ProtoMessage() {}
 
 func (x *Plan) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[3]
+	mi := &file_v1_config_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -831,7 +1059,7 @@ func (x *Plan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Plan.ProtoReflect.Descriptor instead.
 func (*Plan) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{3}
+	return file_v1_config_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Plan) GetId() string {
@@ -904,6 +1132,80 @@ func (x *Plan) GetSkipIfUnchanged() bool {
 	return false
 }
 
+func (x *Plan) GetStdinCommand() *StdinCommand {
+	if x != nil {
+		return x.StdinCommand
+	}
+	return nil
+}
+
+func (x *Plan) GetRestoreTestPolicy() *RestoreTestPolicy {
+	if x != nil {
+		return x.RestoreTestPolicy
+	}
+	return nil
+}
+
+func (x *Plan) GetOverdueGraceMultiplier() float64 {
+	if x != nil {
+		return x.OverdueGraceMultiplier
+	}
+	return 0
+}
+
+// StdinCommand is a backup source that stores the stdout of a command as a single file in the snapshot, e.g. a database dump.
+type StdinCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`   // command to run, split into arguments following shell quoting rules.
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"` // name of the file the command's output is stored as in the snapshot.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StdinCommand) Reset() {
+	*x = StdinCommand{}
+	mi := &file_v1_config_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StdinCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StdinCommand) ProtoMessage() {}
+
+func (x *StdinCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StdinCommand.ProtoReflect.Descriptor instead.
+func (*StdinCommand) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StdinCommand) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *StdinCommand) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
 type CommandPrefix struct {
 	state         protoimpl.MessageState     `protogen:"open.v1"`
 	IoNice        CommandPrefix_IONiceLevel  `protobuf:"varint,1,opt,name=io_nice,json=ioNice,proto3,enum=v1.CommandPrefix_IONiceLevel" json:"io_nice,omitempty"`     // ionice level to set.
@@ -914,7 +1216,7 @@ type CommandPrefix struct {
 
 func (x *CommandPrefix) Reset() {
 	*x = CommandPrefix{}
-	mi := &file_v1_config_proto_msgTypes[4]
+	mi := &file_v1_config_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -926,7 +1228,7 @@ func (x *CommandPrefix) String() string {
 func (*CommandPrefix) ProtoMessage() {}
 
 func (x *CommandPrefix) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[4]
+	mi := &file_v1_config_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,7 +1241,7 @@ func (x *CommandPrefix) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandPrefix.ProtoReflect.Descriptor instead.
 func (*CommandPrefix) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{4}
+	return file_v1_config_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CommandPrefix) GetIoNice() CommandPrefix_IONiceLevel {
@@ -964,13 +1266,14 @@ type RetentionPolicy struct {
 	//	*RetentionPolicy_PolicyTimeBucketed
 	//	*RetentionPolicy_PolicyKeepAll
 	Policy        isRetentionPolicy_Policy `protobuf_oneof:"policy"`
+	KeepTags      []string                 `protobuf:"bytes,13,rep,name=keep_tags,json=keepTags,proto3" json:"keep_tags,omitempty"` // also keep snapshots with these tags, an entry of comma separated tags matches snapshots with all of them. Ignored by policy_keep_all.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RetentionPolicy) Reset() {
 	*x = RetentionPolicy{}
-	mi := &file_v1_config_proto_msgTypes[5]
+	mi := &file_v1_config_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -982,7 +1285,7 @@ func (x *RetentionPolicy) String() string {
 func (*RetentionPolicy) ProtoMessage() {}
 
 func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[5]
+	mi := &file_v1_config_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -995,7 +1298,7 @@ func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
 func (*RetentionPolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{5}
+	return file_v1_config_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RetentionPolicy) GetPolicy() isRetentionPolicy_Policy {
@@ -1032,6 +1335,13 @@ func (x *RetentionPolicy) GetPolicyKeepAll() bool {
 	return false
 }
 
+func (x *RetentionPolicy) GetKeepTags() []string {
+	if x != nil {
+		return x.KeepTags
+	}
+	return nil
+}
+
 type isRetentionPolicy_Policy interface {
 	isRetentionPolicy_Policy()
 }
@@ -1058,13 +1368,14 @@ type ForgetPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
 	Retention     *RetentionPolicy       `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,3,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"` // comma separated snapshot fields the retention is applied per group of e.g. "host,tags". Any of host, paths and tags, defaults to tags.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ForgetPolicy) Reset() {
 	*x = ForgetPolicy{}
-	mi := &file_v1_config_proto_msgTypes[6]
+	mi := &file_v1_config_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1076,7 +1387,7 @@ func (x *ForgetPolicy) String() string {
 func (*ForgetPolicy) ProtoMessage() {}
 
 func (x *ForgetPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[6]
+	mi := &file_v1_config_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1089,7 +1400,7 @@ func (x *ForgetPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForgetPolicy.ProtoReflect.Descriptor instead.
 func (*ForgetPolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{6}
+	return file_v1_config_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ForgetPolicy) GetSchedule() *Schedule {
@@ -1106,6 +1417,13 @@ func (x *ForgetPolicy) GetRetention() *RetentionPolicy {
 	return nil
 }
 
+func (x *ForgetPolicy) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
 type PrunePolicy struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Schedule         *Schedule              `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
@@ -1117,7 +1435,7 @@ type PrunePolicy struct {
 
 func (x *PrunePolicy) Reset() {
 	*x = PrunePolicy{}
-	mi := &file_v1_config_proto_msgTypes[7]
+	mi := &file_v1_config_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1129,7 +1447,7 @@ func (x *PrunePolicy) String() string {
 func (*PrunePolicy) ProtoMessage() {}
 
 func (x *PrunePolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[7]
+	mi := &file_v1_config_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,7 +1460,7 @@ func (x *PrunePolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrunePolicy.ProtoReflect.Descriptor instead.
 func (*PrunePolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{7}
+	return file_v1_config_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PrunePolicy) GetSchedule() *Schedule {
@@ -1180,7 +1498,7 @@ type CheckPolicy struct {
 
 func (x *CheckPolicy) Reset() {
 	*x = CheckPolicy{}
-	mi := &file_v1_config_proto_msgTypes[8]
+	mi := &file_v1_config_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1192,7 +1510,7 @@ func (x *CheckPolicy) String() string {
 func (*CheckPolicy) ProtoMessage() {}
 
 func (x *CheckPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[8]
+	mi := &file_v1_config_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,7 +1523,7 @@ func (x *CheckPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckPolicy.ProtoReflect.Descriptor instead.
 func (*CheckPolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{8}
+	return file_v1_config_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CheckPolicy) GetSchedule() *Schedule {
@@ -1256,35 +1574,30 @@ func (*CheckPolicy_StructureOnly) isCheckPolicy_Mode() {}
 
 func (*CheckPolicy_ReadDataSubsetPercent) isCheckPolicy_Mode() {}
 
-type Schedule struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Schedule:
-	//
-	//	*Schedule_Disabled
-	//	*Schedule_Cron
-	//	*Schedule_MaxFrequencyDays
-	//	*Schedule_MaxFrequencyHours
-	Schedule      isSchedule_Schedule `protobuf_oneof:"schedule"`
-	Clock         Schedule_Clock      `protobuf:"varint,5,opt,name=clock,proto3,enum=v1.Schedule_Clock" json:"clock,omitempty"` // clock to use for scheduling.
+// CopyPolicy replicates snapshots from a repo into a secondary repo using `restic copy`.
+type CopyPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	TargetRepo    string                 `protobuf:"bytes,2,opt,name=target_repo,json=targetRepo,proto3" json:"target_repo,omitempty"` // ID of the repo to copy snapshots into.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Schedule) Reset() {
-	*x = Schedule{}
-	mi := &file_v1_config_proto_msgTypes[9]
+func (x *CopyPolicy) Reset() {
+	*x = CopyPolicy{}
+	mi := &file_v1_config_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Schedule) String() string {
+func (x *CopyPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Schedule) ProtoMessage() {}
+func (*CopyPolicy) ProtoMessage() {}
 
-func (x *Schedule) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[9]
+func (x *CopyPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1295,25 +1608,144 @@ func (x *Schedule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
-func (*Schedule) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CopyPolicy.ProtoReflect.Descriptor instead.
+func (*CopyPolicy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Schedule) GetSchedule() isSchedule_Schedule {
+func (x *CopyPolicy) GetSchedule() *Schedule {
 	if x != nil {
 		return x.Schedule
 	}
 	return nil
 }
 
-func (x *Schedule) GetDisabled() bool {
+func (x *CopyPolicy) GetTargetRepo() string {
 	if x != nil {
-		if x, ok := x.Schedule.(*Schedule_Disabled); ok {
-			return x.Disabled
-		}
+		return x.TargetRepo
 	}
-	return false
+	return ""
+}
+
+// RestoreTestPolicy periodically restores files from a plan's latest snapshot into a temporary directory and verifies them.
+type RestoreTestPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	SampleSize    int32                  `protobuf:"varint,2,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"` // number of randomly chosen files to restore and verify, defaults to 10.
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                                // optional, restore everything under this path instead of a random sample of files.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTestPolicy) Reset() {
+	*x = RestoreTestPolicy{}
+	mi := &file_v1_config_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTestPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTestPolicy) ProtoMessage() {}
+
+func (x *RestoreTestPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTestPolicy.ProtoReflect.Descriptor instead.
+func (*RestoreTestPolicy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RestoreTestPolicy) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *RestoreTestPolicy) GetSampleSize() int32 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+func (x *RestoreTestPolicy) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Schedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Schedule:
+	//
+	//	*Schedule_Disabled
+	//	*Schedule_Cron
+	//	*Schedule_MaxFrequencyDays
+	//	*Schedule_MaxFrequencyHours
+	Schedule      isSchedule_Schedule `protobuf_oneof:"schedule"`
+	Clock         Schedule_Clock      `protobuf:"varint,5,opt,name=clock,proto3,enum=v1.Schedule_Clock" json:"clock,omitempty"` // clock to use for scheduling.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_v1_config_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Schedule) GetSchedule() isSchedule_Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *Schedule) GetDisabled() bool {
+	if x != nil {
+		if x, ok := x.Schedule.(*Schedule_Disabled); ok {
+			return x.Disabled
+		}
+	}
+	return false
 }
 
 func (x *Schedule) GetCron() string {
@@ -1379,9 +1811,14 @@ func (*Schedule_MaxFrequencyDays) isSchedule_Schedule() {}
 func (*Schedule_MaxFrequencyHours) isSchedule_Schedule() {}
 
 type Hook struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	Conditions []Hook_Condition       `protobuf:"varint,1,rep,packed,name=conditions,proto3,enum=v1.Hook_Condition" json:"conditions,omitempty"`
-	OnError    Hook_OnError           `protobuf:"varint,2,opt,name=on_error,json=onError,proto3,enum=v1.Hook_OnError" json:"on_error,omitempty"`
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Conditions       []Hook_Condition       `protobuf:"varint,1,rep,packed,name=conditions,proto3,enum=v1.Hook_Condition" json:"conditions,omitempty"`
+	OnError          Hook_OnError           `protobuf:"varint,2,opt,name=on_error,json=onError,proto3,enum=v1.Hook_OnError" json:"on_error,omitempty"`
+	NotifyMode       Hook_NotifyMode        `protobuf:"varint,3,opt,name=notify_mode,json=notifyMode,proto3,enum=v1.Hook_NotifyMode" json:"notify_mode,omitempty"`
+	RateLimitMinutes int32                  `protobuf:"varint,4,opt,name=rate_limit_minutes,json=rateLimitMinutes,proto3" json:"rate_limit_minutes,omitempty"` // minimum minutes between runs of the hook when notify_mode is NOTIFY_RATE_LIMITED.
+	Id               string                 `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`                                                        // id of a hook defined in Config.hooks, required for and only valid on those definitions.
+	Ref              string                 `protobuf:"bytes,6,opt,name=ref,proto3" json:"ref,omitempty"`                                                      // id of a hook in Config.hooks to run, the hook must not set an action. Its conditions replace the definition's if set.
+	TimeoutSeconds   int32                  `protobuf:"varint,7,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`         // time the hook may run before it fails, command hooks are killed with their child processes. 0 for no timeout.
 	// Types that are valid to be assigned to Action:
 	//
 	//	*Hook_ActionCommand
@@ -1392,6 +1829,9 @@ type Hook struct {
 	//	*Hook_ActionShoutrrr
 	//	*Hook_ActionHealthchecks
 	//	*Hook_ActionTelegram
+	//	*Hook_ActionEmail
+	//	*Hook_ActionMqtt
+	//	*Hook_ActionNtfy
 	Action        isHook_Action `protobuf_oneof:"action"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1399,7 +1839,7 @@ type Hook struct {
 
 func (x *Hook) Reset() {
 	*x = Hook{}
-	mi := &file_v1_config_proto_msgTypes[10]
+	mi := &file_v1_config_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1411,7 +1851,7 @@ func (x *Hook) String() string {
 func (*Hook) ProtoMessage() {}
 
 func (x *Hook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[10]
+	mi := &file_v1_config_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,7 +1864,7 @@ func (x *Hook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook.ProtoReflect.Descriptor instead.
 func (*Hook) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10}
+	return file_v1_config_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Hook) GetConditions() []Hook_Condition {
@@ -1441,6 +1881,41 @@ func (x *Hook) GetOnError() Hook_OnError {
 	return Hook_ON_ERROR_IGNORE
 }
 
+func (x *Hook) GetNotifyMode() Hook_NotifyMode {
+	if x != nil {
+		return x.NotifyMode
+	}
+	return Hook_NOTIFY_ALWAYS
+}
+
+func (x *Hook) GetRateLimitMinutes() int32 {
+	if x != nil {
+		return x.RateLimitMinutes
+	}
+	return 0
+}
+
+func (x *Hook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Hook) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *Hook) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
 func (x *Hook) GetAction() isHook_Action {
 	if x != nil {
 		return x.Action
@@ -1520,6 +1995,33 @@ func (x *Hook) GetActionTelegram() *Hook_Telegram {
 	return nil
 }
 
+func (x *Hook) GetActionEmail() *Hook_Email {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionEmail); ok {
+			return x.ActionEmail
+		}
+	}
+	return nil
+}
+
+func (x *Hook) GetActionMqtt() *Hook_Mqtt {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionMqtt); ok {
+			return x.ActionMqtt
+		}
+	}
+	return nil
+}
+
+func (x *Hook) GetActionNtfy() *Hook_Ntfy {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionNtfy); ok {
+			return x.ActionNtfy
+		}
+	}
+	return nil
+}
+
 type isHook_Action interface {
 	isHook_Action()
 }
@@ -1556,6 +2058,18 @@ type Hook_ActionTelegram struct {
 	ActionTelegram *Hook_Telegram `protobuf:"bytes,107,opt,name=action_telegram,json=actionTelegram,proto3,oneof"`
 }
 
+type Hook_ActionEmail struct {
+	ActionEmail *Hook_Email `protobuf:"bytes,108,opt,name=action_email,json=actionEmail,proto3,oneof"`
+}
+
+type Hook_ActionMqtt struct {
+	ActionMqtt *Hook_Mqtt `protobuf:"bytes,109,opt,name=action_mqtt,json=actionMqtt,proto3,oneof"`
+}
+
+type Hook_ActionNtfy struct {
+	ActionNtfy *Hook_Ntfy `protobuf:"bytes,110,opt,name=action_ntfy,json=actionNtfy,proto3,oneof"`
+}
+
 func (*Hook_ActionCommand) isHook_Action() {}
 
 func (*Hook_ActionWebhook) isHook_Action() {}
@@ -1572,6 +2086,12 @@ func (*Hook_ActionHealthchecks) isHook_Action() {}
 
 func (*Hook_ActionTelegram) isHook_Action() {}
 
+func (*Hook_ActionEmail) isHook_Action() {}
+
+func (*Hook_ActionMqtt) isHook_Action() {}
+
+func (*Hook_ActionNtfy) isHook_Action() {}
+
 type Auth struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Disabled      bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"` // disable authentication.
@@ -1582,7 +2102,7 @@ type Auth struct {
 
 func (x *Auth) Reset() {
 	*x = Auth{}
-	mi := &file_v1_config_proto_msgTypes[11]
+	mi := &file_v1_config_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1594,7 +2114,7 @@ func (x *Auth) String() string {
 func (*Auth) ProtoMessage() {}
 
 func (x *Auth) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[11]
+	mi := &file_v1_config_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1607,7 +2127,7 @@ func (x *Auth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Auth.ProtoReflect.Descriptor instead.
 func (*Auth) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{11}
+	return file_v1_config_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Auth) GetDisabled() bool {
@@ -1637,7 +2157,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_v1_config_proto_msgTypes[12]
+	mi := &file_v1_config_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1649,7 +2169,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[12]
+	mi := &file_v1_config_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1662,7 +2182,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{12}
+	return file_v1_config_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *User) GetName() string {
@@ -1712,7 +2232,7 @@ type Multihost_Peer struct {
 
 func (x *Multihost_Peer) Reset() {
 	*x = Multihost_Peer{}
-	mi := &file_v1_config_proto_msgTypes[13]
+	mi := &file_v1_config_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1724,7 +2244,7 @@ func (x *Multihost_Peer) String() string {
 func (*Multihost_Peer) ProtoMessage() {}
 
 func (x *Multihost_Peer) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[13]
+	mi := &file_v1_config_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,7 +2257,7 @@ func (x *Multihost_Peer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost_Peer.ProtoReflect.Descriptor instead.
 func (*Multihost_Peer) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{2, 0}
 }
 
 func (x *Multihost_Peer) GetInstanceId() string {
@@ -1790,7 +2310,7 @@ type Multihost_PairingToken struct {
 
 func (x *Multihost_PairingToken) Reset() {
 	*x = Multihost_PairingToken{}
-	mi := &file_v1_config_proto_msgTypes[14]
+	mi := &file_v1_config_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1802,7 +2322,7 @@ func (x *Multihost_PairingToken) String() string {
 func (*Multihost_PairingToken) ProtoMessage() {}
 
 func (x *Multihost_PairingToken) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[14]
+	mi := &file_v1_config_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1815,7 +2335,7 @@ func (x *Multihost_PairingToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost_PairingToken.ProtoReflect.Descriptor instead.
 func (*Multihost_PairingToken) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{2, 1}
 }
 
 func (x *Multihost_PairingToken) GetSecret() string {
@@ -1880,7 +2400,7 @@ type Multihost_Permission struct {
 
 func (x *Multihost_Permission) Reset() {
 	*x = Multihost_Permission{}
-	mi := &file_v1_config_proto_msgTypes[15]
+	mi := &file_v1_config_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1892,7 +2412,7 @@ func (x *Multihost_Permission) String() string {
 func (*Multihost_Permission) ProtoMessage() {}
 
 func (x *Multihost_Permission) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[15]
+	mi := &file_v1_config_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1905,7 +2425,7 @@ func (x *Multihost_Permission) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost_Permission.ProtoReflect.Descriptor instead.
 func (*Multihost_Permission) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 2}
+	return file_v1_config_proto_rawDescGZIP(), []int{2, 2}
 }
 
 func (x *Multihost_Permission) GetType() Multihost_Permission_Type {
@@ -1922,21 +2442,28 @@ func (x *Multihost_Permission) GetScopes() []string {
 	return nil
 }
 
+// Durations are restic durations relative to the latest snapshot e.g. "1y6m", "7d" or "12h".
 type RetentionPolicy_TimeBucketedCounts struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hourly        int32                  `protobuf:"varint,1,opt,name=hourly,proto3" json:"hourly,omitempty"`                          // keep the last n hourly snapshots.
-	Daily         int32                  `protobuf:"varint,2,opt,name=daily,proto3" json:"daily,omitempty"`                            // keep the last n daily snapshots.
-	Weekly        int32                  `protobuf:"varint,3,opt,name=weekly,proto3" json:"weekly,omitempty"`                          // keep the last n weekly snapshots.
-	Monthly       int32                  `protobuf:"varint,4,opt,name=monthly,proto3" json:"monthly,omitempty"`                        // keep the last n monthly snapshots.
-	Yearly        int32                  `protobuf:"varint,5,opt,name=yearly,proto3" json:"yearly,omitempty"`                          // keep the last n yearly snapshots.
-	KeepLastN     int32                  `protobuf:"varint,6,opt,name=keep_last_n,json=keepLastN,proto3" json:"keep_last_n,omitempty"` // keep the last n snapshots regardless of age.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Hourly            int32                  `protobuf:"varint,1,opt,name=hourly,proto3" json:"hourly,omitempty"`                                                  // keep the last n hourly snapshots.
+	Daily             int32                  `protobuf:"varint,2,opt,name=daily,proto3" json:"daily,omitempty"`                                                    // keep the last n daily snapshots.
+	Weekly            int32                  `protobuf:"varint,3,opt,name=weekly,proto3" json:"weekly,omitempty"`                                                  // keep the last n weekly snapshots.
+	Monthly           int32                  `protobuf:"varint,4,opt,name=monthly,proto3" json:"monthly,omitempty"`                                                // keep the last n monthly snapshots.
+	Yearly            int32                  `protobuf:"varint,5,opt,name=yearly,proto3" json:"yearly,omitempty"`                                                  // keep the last n yearly snapshots.
+	KeepLastN         int32                  `protobuf:"varint,6,opt,name=keep_last_n,json=keepLastN,proto3" json:"keep_last_n,omitempty"`                         // keep the last n snapshots regardless of age.
+	KeepWithin        string                 `protobuf:"bytes,7,opt,name=keep_within,json=keepWithin,proto3" json:"keep_within,omitempty"`                         // keep all snapshots within the duration.
+	KeepWithinHourly  string                 `protobuf:"bytes,8,opt,name=keep_within_hourly,json=keepWithinHourly,proto3" json:"keep_within_hourly,omitempty"`     // keep the last hourly snapshots within the duration.
+	KeepWithinDaily   string                 `protobuf:"bytes,9,opt,name=keep_within_daily,json=keepWithinDaily,proto3" json:"keep_within_daily,omitempty"`        // keep the last daily snapshots within the duration.
+	KeepWithinWeekly  string                 `protobuf:"bytes,10,opt,name=keep_within_weekly,json=keepWithinWeekly,proto3" json:"keep_within_weekly,omitempty"`    // keep the last weekly snapshots within the duration.
+	KeepWithinMonthly string                 `protobuf:"bytes,11,opt,name=keep_within_monthly,json=keepWithinMonthly,proto3" json:"keep_within_monthly,omitempty"` // keep the last monthly snapshots within the duration.
+	KeepWithinYearly  string                 `protobuf:"bytes,12,opt,name=keep_within_yearly,json=keepWithinYearly,proto3" json:"keep_within_yearly,omitempty"`    // keep the last yearly snapshots within the duration.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *RetentionPolicy_TimeBucketedCounts) Reset() {
 	*x = RetentionPolicy_TimeBucketedCounts{}
-	mi := &file_v1_config_proto_msgTypes[16]
+	mi := &file_v1_config_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1948,7 +2475,7 @@ func (x *RetentionPolicy_TimeBucketedCounts) String() string {
 func (*RetentionPolicy_TimeBucketedCounts) ProtoMessage() {}
 
 func (x *RetentionPolicy_TimeBucketedCounts) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[16]
+	mi := &file_v1_config_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1961,7 +2488,7 @@ func (x *RetentionPolicy_TimeBucketedCounts) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use RetentionPolicy_TimeBucketedCounts.ProtoReflect.Descriptor instead.
 func (*RetentionPolicy_TimeBucketedCounts) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{5, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *RetentionPolicy_TimeBucketedCounts) GetHourly() int32 {
@@ -2006,6 +2533,48 @@ func (x *RetentionPolicy_TimeBucketedCounts) GetKeepLastN() int32 {
 	return 0
 }
 
+func (x *RetentionPolicy_TimeBucketedCounts) GetKeepWithin() string {
+	if x != nil {
+		return x.KeepWithin
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedCounts) GetKeepWithinHourly() string {
+	if x != nil {
+		return x.KeepWithinHourly
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedCounts) GetKeepWithinDaily() string {
+	if x != nil {
+		return x.KeepWithinDaily
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedCounts) GetKeepWithinWeekly() string {
+	if x != nil {
+		return x.KeepWithinWeekly
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedCounts) GetKeepWithinMonthly() string {
+	if x != nil {
+		return x.KeepWithinMonthly
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedCounts) GetKeepWithinYearly() string {
+	if x != nil {
+		return x.KeepWithinYearly
+	}
+	return ""
+}
+
 type Hook_Command struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
@@ -2015,7 +2584,7 @@ type Hook_Command struct {
 
 func (x *Hook_Command) Reset() {
 	*x = Hook_Command{}
-	mi := &file_v1_config_proto_msgTypes[17]
+	mi := &file_v1_config_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2027,7 +2596,7 @@ func (x *Hook_Command) String() string {
 func (*Hook_Command) ProtoMessage() {}
 
 func (x *Hook_Command) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[17]
+	mi := &file_v1_config_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2040,7 +2609,7 @@ func (x *Hook_Command) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Command.ProtoReflect.Descriptor instead.
 func (*Hook_Command) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 0}
 }
 
 func (x *Hook_Command) GetCommand() string {
@@ -2051,17 +2620,26 @@ func (x *Hook_Command) GetCommand() string {
 }
 
 type Hook_Webhook struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WebhookUrl    string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
-	Method        Hook_Webhook_Method    `protobuf:"varint,2,opt,name=method,proto3,enum=v1.Hook_Webhook_Method" json:"method,omitempty"`
-	Template      string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WebhookUrl        string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	Method            Hook_Webhook_Method    `protobuf:"varint,2,opt,name=method,proto3,enum=v1.Hook_Webhook_Method" json:"method,omitempty"`
+	Headers           []string               `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`                                                // extra request headers formatted as "Name: value".
+	ContentType       string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`                     // content type of the rendered template, defaults to text/plain.
+	BasicAuthUsername string                 `protobuf:"bytes,5,opt,name=basic_auth_username,json=basicAuthUsername,proto3" json:"basic_auth_username,omitempty"` // sends basic auth credentials if set.
+	BasicAuthPassword string                 `protobuf:"bytes,6,opt,name=basic_auth_password,json=basicAuthPassword,proto3" json:"basic_auth_password,omitempty"`
+	BearerToken       string                 `protobuf:"bytes,7,opt,name=bearer_token,json=bearerToken,proto3" json:"bearer_token,omitempty"`            // sends an Authorization: Bearer header if set.
+	HmacSecret        string                 `protobuf:"bytes,8,opt,name=hmac_secret,json=hmacSecret,proto3" json:"hmac_secret,omitempty"`               // signs the body with HMAC-SHA256 if set, see hmac_header.
+	HmacHeader        string                 `protobuf:"bytes,9,opt,name=hmac_header,json=hmacHeader,proto3" json:"hmac_header,omitempty"`               // header the hex encoded signature is sent in as sha256=<signature>, defaults to X-Backrest-Signature-256.
+	TimeoutSeconds    int32                  `protobuf:"varint,10,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // request timeout, defaults to 30 seconds.
+	Retries           int32                  `protobuf:"varint,11,opt,name=retries,proto3" json:"retries,omitempty"`                                     // retries after connection errors and 429 or 5xx responses, with exponential backoff.
+	Template          string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Hook_Webhook) Reset() {
 	*x = Hook_Webhook{}
-	mi := &file_v1_config_proto_msgTypes[18]
+	mi := &file_v1_config_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2073,7 +2651,7 @@ func (x *Hook_Webhook) String() string {
 func (*Hook_Webhook) ProtoMessage() {}
 
 func (x *Hook_Webhook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[18]
+	mi := &file_v1_config_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2086,7 +2664,7 @@ func (x *Hook_Webhook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Webhook.ProtoReflect.Descriptor instead.
 func (*Hook_Webhook) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 1}
 }
 
 func (x *Hook_Webhook) GetWebhookUrl() string {
@@ -2103,24 +2681,87 @@ func (x *Hook_Webhook) GetMethod() Hook_Webhook_Method {
 	return Hook_Webhook_UNKNOWN
 }
 
-func (x *Hook_Webhook) GetTemplate() string {
+func (x *Hook_Webhook) GetHeaders() []string {
 	if x != nil {
-		return x.Template
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Hook_Webhook) GetContentType() string {
+	if x != nil {
+		return x.ContentType
 	}
 	return ""
 }
 
-type Hook_Discord struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WebhookUrl    string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
-	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"` // template for the webhook payload.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Hook_Webhook) GetBasicAuthUsername() string {
+	if x != nil {
+		return x.BasicAuthUsername
+	}
+	return ""
 }
 
-func (x *Hook_Discord) Reset() {
-	*x = Hook_Discord{}
-	mi := &file_v1_config_proto_msgTypes[19]
+func (x *Hook_Webhook) GetBasicAuthPassword() string {
+	if x != nil {
+		return x.BasicAuthPassword
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetBearerToken() string {
+	if x != nil {
+		return x.BearerToken
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetHmacSecret() string {
+	if x != nil {
+		return x.HmacSecret
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetHmacHeader() string {
+	if x != nil {
+		return x.HmacHeader
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *Hook_Webhook) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *Hook_Webhook) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type Hook_Discord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookUrl    string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"` // template for the webhook payload.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hook_Discord) Reset() {
+	*x = Hook_Discord{}
+	mi := &file_v1_config_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2132,7 +2773,7 @@ func (x *Hook_Discord) String() string {
 func (*Hook_Discord) ProtoMessage() {}
 
 func (x *Hook_Discord) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[19]
+	mi := &file_v1_config_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2145,7 +2786,7 @@ func (x *Hook_Discord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Discord.ProtoReflect.Descriptor instead.
 func (*Hook_Discord) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 2}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 2}
 }
 
 func (x *Hook_Discord) GetWebhookUrl() string {
@@ -2175,7 +2816,7 @@ type Hook_Gotify struct {
 
 func (x *Hook_Gotify) Reset() {
 	*x = Hook_Gotify{}
-	mi := &file_v1_config_proto_msgTypes[20]
+	mi := &file_v1_config_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2187,7 +2828,7 @@ func (x *Hook_Gotify) String() string {
 func (*Hook_Gotify) ProtoMessage() {}
 
 func (x *Hook_Gotify) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[20]
+	mi := &file_v1_config_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2200,7 +2841,7 @@ func (x *Hook_Gotify) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Gotify.ProtoReflect.Descriptor instead.
 func (*Hook_Gotify) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 3}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 3}
 }
 
 func (x *Hook_Gotify) GetBaseUrl() string {
@@ -2248,7 +2889,7 @@ type Hook_Slack struct {
 
 func (x *Hook_Slack) Reset() {
 	*x = Hook_Slack{}
-	mi := &file_v1_config_proto_msgTypes[21]
+	mi := &file_v1_config_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2260,7 +2901,7 @@ func (x *Hook_Slack) String() string {
 func (*Hook_Slack) ProtoMessage() {}
 
 func (x *Hook_Slack) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[21]
+	mi := &file_v1_config_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2273,7 +2914,7 @@ func (x *Hook_Slack) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Slack.ProtoReflect.Descriptor instead.
 func (*Hook_Slack) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 4}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 4}
 }
 
 func (x *Hook_Slack) GetWebhookUrl() string {
@@ -2300,7 +2941,7 @@ type Hook_Shoutrrr struct {
 
 func (x *Hook_Shoutrrr) Reset() {
 	*x = Hook_Shoutrrr{}
-	mi := &file_v1_config_proto_msgTypes[22]
+	mi := &file_v1_config_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2312,7 +2953,7 @@ func (x *Hook_Shoutrrr) String() string {
 func (*Hook_Shoutrrr) ProtoMessage() {}
 
 func (x *Hook_Shoutrrr) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[22]
+	mi := &file_v1_config_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2325,7 +2966,7 @@ func (x *Hook_Shoutrrr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Shoutrrr.ProtoReflect.Descriptor instead.
 func (*Hook_Shoutrrr) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 5}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 5}
 }
 
 func (x *Hook_Shoutrrr) GetShoutrrrUrl() string {
@@ -2352,7 +2993,7 @@ type Hook_Healthchecks struct {
 
 func (x *Hook_Healthchecks) Reset() {
 	*x = Hook_Healthchecks{}
-	mi := &file_v1_config_proto_msgTypes[23]
+	mi := &file_v1_config_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2364,7 +3005,7 @@ func (x *Hook_Healthchecks) String() string {
 func (*Hook_Healthchecks) ProtoMessage() {}
 
 func (x *Hook_Healthchecks) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[23]
+	mi := &file_v1_config_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2377,7 +3018,7 @@ func (x *Hook_Healthchecks) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Healthchecks.ProtoReflect.Descriptor instead.
 func (*Hook_Healthchecks) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 6}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 6}
 }
 
 func (x *Hook_Healthchecks) GetWebhookUrl() string {
@@ -2405,7 +3046,7 @@ type Hook_Telegram struct {
 
 func (x *Hook_Telegram) Reset() {
 	*x = Hook_Telegram{}
-	mi := &file_v1_config_proto_msgTypes[24]
+	mi := &file_v1_config_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2417,7 +3058,7 @@ func (x *Hook_Telegram) String() string {
 func (*Hook_Telegram) ProtoMessage() {}
 
 func (x *Hook_Telegram) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[24]
+	mi := &file_v1_config_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2430,7 +3071,7 @@ func (x *Hook_Telegram) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Telegram.ProtoReflect.Descriptor instead.
 func (*Hook_Telegram) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10, 7}
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 7}
 }
 
 func (x *Hook_Telegram) GetBotToken() string {
@@ -2454,11 +3095,327 @@ func (x *Hook_Telegram) GetTemplate() string {
 	return ""
 }
 
+type Hook_Email struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Host            string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port            int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"` // defaults to 587, 465 with SECURITY_TLS or 25 with SECURITY_NONE.
+	Security        Hook_Email_Security    `protobuf:"varint,3,opt,name=security,proto3,enum=v1.Hook_Email_Security" json:"security,omitempty"`
+	Username        string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"` // authenticates with PLAIN auth if set.
+	Password        string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	From            string                 `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To              []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc              []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	SubjectTemplate string                 `protobuf:"bytes,9,opt,name=subject_template,json=subjectTemplate,proto3" json:"subject_template,omitempty"` // template for the subject line.
+	Template        string                 `protobuf:"bytes,10,opt,name=template,proto3" json:"template,omitempty"`                                     // template for the plain text body.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Hook_Email) Reset() {
+	*x = Hook_Email{}
+	mi := &file_v1_config_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Email) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Email) ProtoMessage() {}
+
+func (x *Hook_Email) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Email.ProtoReflect.Descriptor instead.
+func (*Hook_Email) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 8}
+}
+
+func (x *Hook_Email) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Hook_Email) GetSecurity() Hook_Email_Security {
+	if x != nil {
+		return x.Security
+	}
+	return Hook_Email_SECURITY_STARTTLS
+}
+
+func (x *Hook_Email) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Hook_Email) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *Hook_Email) GetSubjectTemplate() string {
+	if x != nil {
+		return x.SubjectTemplate
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type Hook_Mqtt struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	BrokerUrl              string                 `protobuf:"bytes,1,opt,name=broker_url,json=brokerUrl,proto3" json:"broker_url,omitempty"` // e.g. tcp://localhost:1883, use ssl:// or mqtts:// to connect with TLS.
+	Username               string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password               string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	ClientId               string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                              // defaults to backrest-<instance id>.
+	Topic                  string                 `protobuf:"bytes,5,opt,name=topic,proto3" json:"topic,omitempty"`                                                                    // base topic, each plan's state is published retained to <topic>/<plan id>/state. Defaults to backrest/<instance id>.
+	Qos                    int32                  `protobuf:"varint,6,opt,name=qos,proto3" json:"qos,omitempty"`                                                                       // QoS of published messages, 0, 1 or 2.
+	HomeAssistantDiscovery bool                   `protobuf:"varint,7,opt,name=home_assistant_discovery,json=homeAssistantDiscovery,proto3" json:"home_assistant_discovery,omitempty"` // publish Home Assistant MQTT discovery configs for each plan's sensors.
+	DiscoveryPrefix        string                 `protobuf:"bytes,8,opt,name=discovery_prefix,json=discoveryPrefix,proto3" json:"discovery_prefix,omitempty"`                         // Home Assistant discovery prefix, defaults to homeassistant.
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Hook_Mqtt) Reset() {
+	*x = Hook_Mqtt{}
+	mi := &file_v1_config_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Mqtt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Mqtt) ProtoMessage() {}
+
+func (x *Hook_Mqtt) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Mqtt.ProtoReflect.Descriptor instead.
+func (*Hook_Mqtt) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 9}
+}
+
+func (x *Hook_Mqtt) GetBrokerUrl() string {
+	if x != nil {
+		return x.BrokerUrl
+	}
+	return ""
+}
+
+func (x *Hook_Mqtt) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Hook_Mqtt) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Hook_Mqtt) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Hook_Mqtt) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Hook_Mqtt) GetQos() int32 {
+	if x != nil {
+		return x.Qos
+	}
+	return 0
+}
+
+func (x *Hook_Mqtt) GetHomeAssistantDiscovery() bool {
+	if x != nil {
+		return x.HomeAssistantDiscovery
+	}
+	return false
+}
+
+func (x *Hook_Mqtt) GetDiscoveryPrefix() string {
+	if x != nil {
+		return x.DiscoveryPrefix
+	}
+	return ""
+}
+
+type Hook_Ntfy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerUrl     string                 `protobuf:"bytes,1,opt,name=server_url,json=serverUrl,proto3" json:"server_url,omitempty"` // defaults to https://ntfy.sh.
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`                                        // access token, sent as a bearer token if set.
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                                          // tags or emoji shortcodes shown with the notification.
+	ClickUrl      string                 `protobuf:"bytes,5,opt,name=click_url,json=clickUrl,proto3" json:"click_url,omitempty"`                  // URL opened when the notification is clicked.
+	Priority      int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`                                 // priority 1-5, 0 picks the priority from the event: high for errors, low for successes.
+	Template      string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`                                // template for the message.
+	TitleTemplate string                 `protobuf:"bytes,101,opt,name=title_template,json=titleTemplate,proto3" json:"title_template,omitempty"` // template for the title.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hook_Ntfy) Reset() {
+	*x = Hook_Ntfy{}
+	mi := &file_v1_config_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Ntfy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Ntfy) ProtoMessage() {}
+
+func (x *Hook_Ntfy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Ntfy.ProtoReflect.Descriptor instead.
+func (*Hook_Ntfy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{14, 10}
+}
+
+func (x *Hook_Ntfy) GetServerUrl() string {
+	if x != nil {
+		return x.ServerUrl
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Hook_Ntfy) GetClickUrl() string {
+	if x != nil {
+		return x.ClickUrl
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Hook_Ntfy) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetTitleTemplate() string {
+	if x != nil {
+		return x.TitleTemplate
+	}
+	return ""
+}
+
 var File_v1_config_proto protoreflect.FileDescriptor
 
 const file_v1_config_proto_rawDesc = "" +
 	"\n" +
-	"\x0fv1/config.proto\x12\x02v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x0fv1/crypto.proto\"\xda\x01\n" +
+	"\x0fv1/config.proto\x12\x02v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x0fv1/crypto.proto\"\xa4\x02\n" +
 	"\x06Config\x12\x14\n" +
 	"\x05modno\x18\x01 \x01(\x05R\x05modno\x12\x18\n" +
 	"\aversion\x18\x06 \x01(\x05R\aversion\x12\x1a\n" +
@@ -2466,7 +3423,12 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x05repos\x18\x03 \x03(\v2\b.v1.RepoR\x05repos\x12\x1e\n" +
 	"\x05plans\x18\x04 \x03(\v2\b.v1.PlanR\x05plans\x12\x1c\n" +
 	"\x04auth\x18\x05 \x01(\v2\b.v1.AuthR\x04auth\x12&\n" +
-	"\tmultihost\x18\a \x01(\v2\r.v1.MultihostR\x04sync\"\xc5\a\n" +
+	"\tmultihost\x18\a \x01(\v2\r.v1.MultihostR\x04sync\x12(\n" +
+	"\x06digest\x18\b \x01(\v2\x10.v1.DigestPolicyR\x06digest\x12\x1e\n" +
+	"\x05hooks\x18\t \x03(\v2\b.v1.HookR\x05hooks\"X\n" +
+	"\fDigestPolicy\x12(\n" +
+	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x12\x1e\n" +
+	"\x05hooks\x18\x02 \x03(\v2\b.v1.HookR\x05hooks\"\xc5\a\n" +
 	"\tMultihost\x12*\n" +
 	"\bidentity\x18\x01 \x01(\v2\x0e.v1.PrivateKeyR\bidentity\x123\n" +
 	"\vknown_hosts\x18\x02 \x03(\v2\x12.v1.Multihost.PeerR\n" +
@@ -2497,7 +3459,7 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x1aPERMISSION_READ_OPERATIONS\x10\x01\x12\x1a\n" +
 	"\x16PERMISSION_READ_CONFIG\x10\x02\x12 \n" +
 	"\x1cPERMISSION_READ_WRITE_CONFIG\x10\x03\x12#\n" +
-	"\x1fPERMISSION_RECEIVE_SHARED_REPOS\x10\x04\"\x89\x04\n" +
+	"\x1fPERMISSION_RECEIVE_SHARED_REPOS\x10\x04\"\xba\x04\n" +
 	"\x04Repo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
 	"\x03uri\x18\x02 \x01(\tR\x03uri\x12\x12\n" +
@@ -2515,7 +3477,9 @@ const file_v1_config_proto_rawDesc = "" +
 	" \x01(\v2\x11.v1.CommandPrefixR\rcommandPrefix\x12\x16\n" +
 	"\x06shared\x18\r \x01(\bR\x06shared\x12,\n" +
 	"\x12origin_instance_id\x18\x0e \x01(\tR\x10originInstanceId\x125\n" +
-	"\rforget_policy\x18\x0f \x01(\v2\x10.v1.ForgetPolicyR\fforgetPolicy\"\xd9\x02\n" +
+	"\rforget_policy\x18\x0f \x01(\v2\x10.v1.ForgetPolicyR\fforgetPolicy\x12/\n" +
+	"\vcopy_policy\x18\x10 \x01(\v2\x0e.v1.CopyPolicyR\n" +
+	"copyPolicy\"\x91\x04\n" +
 	"\x04Plan\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04repo\x18\x02 \x01(\tR\x04repo\x12\x14\n" +
@@ -2527,7 +3491,13 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x05hooks\x18\b \x03(\v2\b.v1.HookR\x05hooks\x12\"\n" +
 	"\fbackup_flags\x18\n" +
 	" \x03(\tR\fbackup_flags\x12*\n" +
-	"\x11skip_if_unchanged\x18\r \x01(\bR\x0fskipIfUnchangedJ\x04\b\x03\x10\x04J\x04\b\x06\x10\aJ\x04\b\v\x10\f\"\x9b\x02\n" +
+	"\x11skip_if_unchanged\x18\r \x01(\bR\x0fskipIfUnchanged\x125\n" +
+	"\rstdin_command\x18\x0e \x01(\v2\x10.v1.StdinCommandR\fstdinCommand\x12E\n" +
+	"\x13restore_test_policy\x18\x0f \x01(\v2\x15.v1.RestoreTestPolicyR\x11restoreTestPolicy\x128\n" +
+	"\x18overdue_grace_multiplier\x18\x10 \x01(\x01R\x16overdueGraceMultiplierJ\x04\b\x03\x10\x04J\x04\b\x06\x10\aJ\x04\b\v\x10\f\"D\n" +
+	"\fStdinCommand\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"\x9b\x02\n" +
 	"\rCommandPrefix\x126\n" +
 	"\aio_nice\x18\x01 \x01(\x0e2\x1d.v1.CommandPrefix.IONiceLevelR\x06ioNice\x129\n" +
 	"\bcpu_nice\x18\x02 \x01(\x0e2\x1e.v1.CommandPrefix.CPUNiceLevelR\acpuNice\"[\n" +
@@ -2540,23 +3510,33 @@ const file_v1_config_proto_rawDesc = "" +
 	"\fCPUNiceLevel\x12\x0f\n" +
 	"\vCPU_DEFAULT\x10\x00\x12\f\n" +
 	"\bCPU_HIGH\x10\x01\x12\v\n" +
-	"\aCPU_LOW\x10\x02\"\xff\x02\n" +
+	"\aCPU_LOW\x10\x02\"\xa3\x05\n" +
 	"\x0fRetentionPolicy\x12-\n" +
 	"\x12policy_keep_last_n\x18\n" +
 	" \x01(\x05H\x00R\x0fpolicyKeepLastN\x12Z\n" +
 	"\x14policy_time_bucketed\x18\v \x01(\v2&.v1.RetentionPolicy.TimeBucketedCountsH\x00R\x12policyTimeBucketed\x12(\n" +
-	"\x0fpolicy_keep_all\x18\f \x01(\bH\x00R\rpolicyKeepAll\x1a\xac\x01\n" +
+	"\x0fpolicy_keep_all\x18\f \x01(\bH\x00R\rpolicyKeepAll\x12\x1b\n" +
+	"\tkeep_tags\x18\r \x03(\tR\bkeepTags\x1a\xb3\x03\n" +
 	"\x12TimeBucketedCounts\x12\x16\n" +
 	"\x06hourly\x18\x01 \x01(\x05R\x06hourly\x12\x14\n" +
 	"\x05daily\x18\x02 \x01(\x05R\x05daily\x12\x16\n" +
 	"\x06weekly\x18\x03 \x01(\x05R\x06weekly\x12\x18\n" +
 	"\amonthly\x18\x04 \x01(\x05R\amonthly\x12\x16\n" +
 	"\x06yearly\x18\x05 \x01(\x05R\x06yearly\x12\x1e\n" +
-	"\vkeep_last_n\x18\x06 \x01(\x05R\tkeepLastNB\b\n" +
-	"\x06policy\"k\n" +
+	"\vkeep_last_n\x18\x06 \x01(\x05R\tkeepLastN\x12\x1f\n" +
+	"\vkeep_within\x18\a \x01(\tR\n" +
+	"keepWithin\x12,\n" +
+	"\x12keep_within_hourly\x18\b \x01(\tR\x10keepWithinHourly\x12*\n" +
+	"\x11keep_within_daily\x18\t \x01(\tR\x0fkeepWithinDaily\x12,\n" +
+	"\x12keep_within_weekly\x18\n" +
+	" \x01(\tR\x10keepWithinWeekly\x12.\n" +
+	"\x13keep_within_monthly\x18\v \x01(\tR\x11keepWithinMonthly\x12,\n" +
+	"\x12keep_within_yearly\x18\f \x01(\tR\x10keepWithinYearlyB\b\n" +
+	"\x06policy\"\x86\x01\n" +
 	"\fForgetPolicy\x12(\n" +
 	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x121\n" +
-	"\tretention\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\tretention\"\x8f\x01\n" +
+	"\tretention\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\tretention\x12\x19\n" +
+	"\bgroup_by\x18\x03 \x01(\tR\agroupBy\"\x8f\x01\n" +
 	"\vPrunePolicy\x12(\n" +
 	"\bschedule\x18\x02 \x01(\v2\f.v1.ScheduleR\bschedule\x12(\n" +
 	"\x10max_unused_bytes\x18\x03 \x01(\x03R\x0emaxUnusedBytes\x12,\n" +
@@ -2565,7 +3545,17 @@ const file_v1_config_proto_rawDesc = "" +
 	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x12'\n" +
 	"\x0estructure_only\x18d \x01(\bH\x00R\rstructureOnly\x129\n" +
 	"\x18read_data_subset_percent\x18e \x01(\x01H\x00R\x15readDataSubsetPercentB\x06\n" +
-	"\x04mode\"\xa7\x02\n" +
+	"\x04mode\"W\n" +
+	"\n" +
+	"CopyPolicy\x12(\n" +
+	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x12\x1f\n" +
+	"\vtarget_repo\x18\x02 \x01(\tR\n" +
+	"targetRepo\"r\n" +
+	"\x11RestoreTestPolicy\x12(\n" +
+	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x12\x1f\n" +
+	"\vsample_size\x18\x02 \x01(\x05R\n" +
+	"sampleSize\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\"\xa7\x02\n" +
 	"\bSchedule\x12\x1c\n" +
 	"\bdisabled\x18\x01 \x01(\bH\x00R\bdisabled\x12\x14\n" +
 	"\x04cron\x18\x02 \x01(\tH\x00R\x04cron\x12,\n" +
@@ -2578,12 +3568,18 @@ const file_v1_config_proto_rawDesc = "" +
 	"\tCLOCK_UTC\x10\x02\x12\x17\n" +
 	"\x13CLOCK_LAST_RUN_TIME\x10\x03B\n" +
 	"\n" +
-	"\bschedule\"\xe1\x0f\n" +
+	"\bschedule\"\xa7\x1f\n" +
 	"\x04Hook\x122\n" +
 	"\n" +
 	"conditions\x18\x01 \x03(\x0e2\x12.v1.Hook.ConditionR\n" +
 	"conditions\x12+\n" +
-	"\bon_error\x18\x02 \x01(\x0e2\x10.v1.Hook.OnErrorR\aonError\x129\n" +
+	"\bon_error\x18\x02 \x01(\x0e2\x10.v1.Hook.OnErrorR\aonError\x124\n" +
+	"\vnotify_mode\x18\x03 \x01(\x0e2\x13.v1.Hook.NotifyModeR\n" +
+	"notifyMode\x12,\n" +
+	"\x12rate_limit_minutes\x18\x04 \x01(\x05R\x10rateLimitMinutes\x12\x0e\n" +
+	"\x02id\x18\x05 \x01(\tR\x02id\x12\x10\n" +
+	"\x03ref\x18\x06 \x01(\tR\x03ref\x12'\n" +
+	"\x0ftimeout_seconds\x18\a \x01(\x05R\x0etimeoutSeconds\x129\n" +
 	"\x0eaction_command\x18d \x01(\v2\x10.v1.Hook.CommandH\x00R\ractionCommand\x129\n" +
 	"\x0eaction_webhook\x18e \x01(\v2\x10.v1.Hook.WebhookH\x00R\ractionWebhook\x129\n" +
 	"\x0eaction_discord\x18f \x01(\v2\x10.v1.Hook.DiscordH\x00R\ractionDiscord\x126\n" +
@@ -2591,18 +3587,39 @@ const file_v1_config_proto_rawDesc = "" +
 	"\faction_slack\x18h \x01(\v2\x0e.v1.Hook.SlackH\x00R\vactionSlack\x12<\n" +
 	"\x0faction_shoutrrr\x18i \x01(\v2\x11.v1.Hook.ShoutrrrH\x00R\x0eactionShoutrrr\x12H\n" +
 	"\x13action_healthchecks\x18j \x01(\v2\x15.v1.Hook.HealthchecksH\x00R\x12actionHealthchecks\x12<\n" +
-	"\x0faction_telegram\x18k \x01(\v2\x11.v1.Hook.TelegramH\x00R\x0eactionTelegram\x1a#\n" +
+	"\x0faction_telegram\x18k \x01(\v2\x11.v1.Hook.TelegramH\x00R\x0eactionTelegram\x123\n" +
+	"\faction_email\x18l \x01(\v2\x0e.v1.Hook.EmailH\x00R\vactionEmail\x120\n" +
+	"\vaction_mqtt\x18m \x01(\v2\r.v1.Hook.MqttH\x00R\n" +
+	"actionMqtt\x120\n" +
+	"\vaction_ntfy\x18n \x01(\v2\r.v1.Hook.NtfyH\x00R\n" +
+	"actionNtfy\x1a#\n" +
 	"\aCommand\x12\x18\n" +
-	"\acommand\x18\x01 \x01(\tR\acommand\x1a\xa1\x01\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x1a\x86\x04\n" +
 	"\aWebhook\x12\x1f\n" +
 	"\vwebhook_url\x18\x01 \x01(\tR\n" +
 	"webhookUrl\x12/\n" +
-	"\x06method\x18\x02 \x01(\x0e2\x17.v1.Hook.Webhook.MethodR\x06method\x12\x1a\n" +
-	"\btemplate\x18d \x01(\tR\btemplate\"(\n" +
+	"\x06method\x18\x02 \x01(\x0e2\x17.v1.Hook.Webhook.MethodR\x06method\x12\x18\n" +
+	"\aheaders\x18\x03 \x03(\tR\aheaders\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12.\n" +
+	"\x13basic_auth_username\x18\x05 \x01(\tR\x11basicAuthUsername\x12.\n" +
+	"\x13basic_auth_password\x18\x06 \x01(\tR\x11basicAuthPassword\x12!\n" +
+	"\fbearer_token\x18\a \x01(\tR\vbearerToken\x12\x1f\n" +
+	"\vhmac_secret\x18\b \x01(\tR\n" +
+	"hmacSecret\x12\x1f\n" +
+	"\vhmac_header\x18\t \x01(\tR\n" +
+	"hmacHeader\x12'\n" +
+	"\x0ftimeout_seconds\x18\n" +
+	" \x01(\x05R\x0etimeoutSeconds\x12\x18\n" +
+	"\aretries\x18\v \x01(\x05R\aretries\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\"H\n" +
 	"\x06Method\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\a\n" +
 	"\x03GET\x10\x01\x12\b\n" +
-	"\x04POST\x10\x02\x1aF\n" +
+	"\x04POST\x10\x02\x12\a\n" +
+	"\x03PUT\x10\x03\x12\t\n" +
+	"\x05PATCH\x10\x04\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x05\x1aF\n" +
 	"\aDiscord\x12\x1f\n" +
 	"\vwebhook_url\x18\x01 \x01(\tR\n" +
 	"webhookUrl\x12\x1a\n" +
@@ -2627,7 +3644,43 @@ const file_v1_config_proto_rawDesc = "" +
 	"\bTelegram\x12\x1b\n" +
 	"\tbot_token\x18\x01 \x01(\tR\bbotToken\x12\x17\n" +
 	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1a\n" +
-	"\btemplate\x18\x03 \x01(\tR\btemplate\"\xf5\x03\n" +
+	"\btemplate\x18\x03 \x01(\tR\btemplate\x1a\xdf\x02\n" +
+	"\x05Email\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x123\n" +
+	"\bsecurity\x18\x03 \x01(\x0e2\x17.v1.Hook.Email.SecurityR\bsecurity\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04from\x18\x06 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12)\n" +
+	"\x10subject_template\x18\t \x01(\tR\x0fsubjectTemplate\x12\x1a\n" +
+	"\btemplate\x18\n" +
+	" \x01(\tR\btemplate\"F\n" +
+	"\bSecurity\x12\x15\n" +
+	"\x11SECURITY_STARTTLS\x10\x00\x12\x10\n" +
+	"\fSECURITY_TLS\x10\x01\x12\x11\n" +
+	"\rSECURITY_NONE\x10\x02\x1a\x87\x02\n" +
+	"\x04Mqtt\x12\x1d\n" +
+	"\n" +
+	"broker_url\x18\x01 \x01(\tR\tbrokerUrl\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x14\n" +
+	"\x05topic\x18\x05 \x01(\tR\x05topic\x12\x10\n" +
+	"\x03qos\x18\x06 \x01(\x05R\x03qos\x128\n" +
+	"\x18home_assistant_discovery\x18\a \x01(\bR\x16homeAssistantDiscovery\x12)\n" +
+	"\x10discovery_prefix\x18\b \x01(\tR\x0fdiscoveryPrefix\x1a\xe1\x01\n" +
+	"\x04Ntfy\x12\x1d\n" +
+	"\n" +
+	"server_url\x18\x01 \x01(\tR\tserverUrl\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1b\n" +
+	"\tclick_url\x18\x05 \x01(\tR\bclickUrl\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\x12%\n" +
+	"\x0etitle_template\x18e \x01(\tR\rtitleTemplate\"\xee\x06\n" +
 	"\tCondition\x12\x15\n" +
 	"\x11CONDITION_UNKNOWN\x10\x00\x12\x17\n" +
 	"\x13CONDITION_ANY_ERROR\x10\x01\x12\x1c\n" +
@@ -2645,14 +3698,31 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x17CONDITION_CHECK_SUCCESS\x10\xca\x01\x12\x1b\n" +
 	"\x16CONDITION_FORGET_START\x10\xac\x02\x12\x1b\n" +
 	"\x16CONDITION_FORGET_ERROR\x10\xad\x02\x12\x1d\n" +
-	"\x18CONDITION_FORGET_SUCCESS\x10\xae\x02\"\xa9\x01\n" +
+	"\x18CONDITION_FORGET_SUCCESS\x10\xae\x02\x12\x19\n" +
+	"\x14CONDITION_COPY_START\x10\x90\x03\x12\x19\n" +
+	"\x14CONDITION_COPY_ERROR\x10\x91\x03\x12\x1b\n" +
+	"\x16CONDITION_COPY_SUCCESS\x10\x92\x03\x12!\n" +
+	"\x1cCONDITION_RESTORE_TEST_START\x10\xf4\x03\x12!\n" +
+	"\x1cCONDITION_RESTORE_TEST_ERROR\x10\xf5\x03\x12#\n" +
+	"\x1eCONDITION_RESTORE_TEST_SUCCESS\x10\xf6\x03\x12\x1d\n" +
+	"\x18CONDITION_BACKUP_OVERDUE\x10\xd8\x04\x12'\n" +
+	"\"CONDITION_BACKUP_OVERDUE_RECOVERED\x10\xd9\x04\x12\x15\n" +
+	"\x10CONDITION_DIGEST\x10\xbc\x05\x12\x1c\n" +
+	"\x17CONDITION_RESTORE_START\x10\xa0\x06\x12\x1c\n" +
+	"\x17CONDITION_RESTORE_ERROR\x10\xa1\x06\x12\x1e\n" +
+	"\x19CONDITION_RESTORE_SUCCESS\x10\xa2\x06\"\xa9\x01\n" +
 	"\aOnError\x12\x13\n" +
 	"\x0fON_ERROR_IGNORE\x10\x00\x12\x13\n" +
 	"\x0fON_ERROR_CANCEL\x10\x01\x12\x12\n" +
 	"\x0eON_ERROR_FATAL\x10\x02\x12\x1a\n" +
 	"\x16ON_ERROR_RETRY_1MINUTE\x10d\x12\x1c\n" +
 	"\x18ON_ERROR_RETRY_10MINUTES\x10e\x12&\n" +
-	"\"ON_ERROR_RETRY_EXPONENTIAL_BACKOFF\x10gB\b\n" +
+	"\"ON_ERROR_RETRY_EXPONENTIAL_BACKOFF\x10g\"N\n" +
+	"\n" +
+	"NotifyMode\x12\x11\n" +
+	"\rNOTIFY_ALWAYS\x10\x00\x12\x14\n" +
+	"\x10NOTIFY_ON_CHANGE\x10\x01\x12\x17\n" +
+	"\x13NOTIFY_RATE_LIMITED\x10\x02B\b\n" +
 	"\x06action\"B\n" +
 	"\x04Auth\x12\x1a\n" +
 	"\bdisabled\x18\x01 \x01(\bR\bdisabled\x12\x1e\n" +
@@ -2675,8 +3745,8 @@ func file_v1_config_proto_rawDescGZIP() []byte {
 	return file_v1_config_proto_rawDescData
 }
 
-var file_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_v1_config_proto_goTypes = []any{
 	(Multihost_Permission_Type)(0),             // 0: v1.Multihost.Permission.Type
 	(CommandPrefix_IONiceLevel)(0),             // 1: v1.CommandPrefix.IONiceLevel
@@ -2684,79 +3754,102 @@ var file_v1_config_proto_goTypes = []any{
 	(Schedule_Clock)(0),                        // 3: v1.Schedule.Clock
 	(Hook_Condition)(0),                        // 4: v1.Hook.Condition
 	(Hook_OnError)(0),                          // 5: v1.Hook.OnError
-	(Hook_Webhook_Method)(0),                   // 6: v1.Hook.Webhook.Method
-	(*Config)(nil),                             // 7: v1.Config
-	(*Multihost)(nil),                          // 8: v1.Multihost
-	(*Repo)(nil),                               // 9: v1.Repo
-	(*Plan)(nil),                               // 10: v1.Plan
-	(*CommandPrefix)(nil),                      // 11: v1.CommandPrefix
-	(*RetentionPolicy)(nil),                    // 12: v1.RetentionPolicy
-	(*ForgetPolicy)(nil),                       // 13: v1.ForgetPolicy
-	(*PrunePolicy)(nil),                        // 14: v1.PrunePolicy
-	(*CheckPolicy)(nil),                        // 15: v1.CheckPolicy
-	(*Schedule)(nil),                           // 16: v1.Schedule
-	(*Hook)(nil),                               // 17: v1.Hook
-	(*Auth)(nil),                               // 18: v1.Auth
-	(*User)(nil),                               // 19: v1.User
-	(*Multihost_Peer)(nil),                     // 20: v1.Multihost.Peer
-	(*Multihost_PairingToken)(nil),             // 21: v1.Multihost.PairingToken
-	(*Multihost_Permission)(nil),               // 22: v1.Multihost.Permission
-	(*RetentionPolicy_TimeBucketedCounts)(nil), // 23: v1.RetentionPolicy.TimeBucketedCounts
-	(*Hook_Command)(nil),                       // 24: v1.Hook.Command
-	(*Hook_Webhook)(nil),                       // 25: v1.Hook.Webhook
-	(*Hook_Discord)(nil),                       // 26: v1.Hook.Discord
-	(*Hook_Gotify)(nil),                        // 27: v1.Hook.Gotify
-	(*Hook_Slack)(nil),                         // 28: v1.Hook.Slack
-	(*Hook_Shoutrrr)(nil),                      // 29: v1.Hook.Shoutrrr
-	(*Hook_Healthchecks)(nil),                  // 30: v1.Hook.Healthchecks
-	(*Hook_Telegram)(nil),                      // 31: v1.Hook.Telegram
-	(*PrivateKey)(nil),                         // 32: v1.PrivateKey
+	(Hook_NotifyMode)(0),                       // 6: v1.Hook.NotifyMode
+	(Hook_Webhook_Method)(0),                   // 7: v1.Hook.Webhook.Method
+	(Hook_Email_Security)(0),                   // 8: v1.Hook.Email.Security
+	(*Config)(nil),                             // 9: v1.Config
+	(*DigestPolicy)(nil),                       // 10: v1.DigestPolicy
+	(*Multihost)(nil),                          // 11: v1.Multihost
+	(*Repo)(nil),                               // 12: v1.Repo
+	(*Plan)(nil),                               // 13: v1.Plan
+	(*StdinCommand)(nil),                       // 14: v1.StdinCommand
+	(*CommandPrefix)(nil),                      // 15: v1.CommandPrefix
+	(*RetentionPolicy)(nil),                    // 16: v1.RetentionPolicy
+	(*ForgetPolicy)(nil),                       // 17: v1.ForgetPolicy
+	(*PrunePolicy)(nil),                        // 18: v1.PrunePolicy
+	(*CheckPolicy)(nil),                        // 19: v1.CheckPolicy
+	(*CopyPolicy)(nil),                         // 20: v1.CopyPolicy
+	(*RestoreTestPolicy)(nil),                  // 21: v1.RestoreTestPolicy
+	(*Schedule)(nil),                           // 22: v1.Schedule
+	(*Hook)(nil),                               // 23: v1.Hook
+	(*Auth)(nil),                               // 24: v1.Auth
+	(*User)(nil),                               // 25: v1.User
+	(*Multihost_Peer)(nil),                     // 26: v1.Multihost.Peer
+	(*Multihost_PairingToken)(nil),             // 27: v1.Multihost.PairingToken
+	(*Multihost_Permission)(nil),               // 28: v1.Multihost.Permission
+	(*RetentionPolicy_TimeBucketedCounts)(nil), // 29: v1.RetentionPolicy.TimeBucketedCounts
+	(*Hook_Command)(nil),                       // 30: v1.Hook.Command
+	(*Hook_Webhook)(nil),                       // 31: v1.Hook.Webhook
+	(*Hook_Discord)(nil),                       // 32: v1.Hook.Discord
+	(*Hook_Gotify)(nil),                        // 33: v1.Hook.Gotify
+	(*Hook_Slack)(nil),                         // 34: v1.Hook.Slack
+	(*Hook_Shoutrrr)(nil),                      // 35: v1.Hook.Shoutrrr
+	(*Hook_Healthchecks)(nil),                  // 36: v1.Hook.Healthchecks
+	(*Hook_Telegram)(nil),                      // 37: v1.Hook.Telegram
+	(*Hook_Email)(nil),                         // 38: v1.Hook.Email
+	(*Hook_Mqtt)(nil),                          // 39: v1.Hook.Mqtt
+	(*Hook_Ntfy)(nil),                          // 40: v1.Hook.Ntfy
+	(*PrivateKey)(nil),                         // 41: v1.PrivateKey
 }
 var file_v1_config_proto_depIdxs = []int32{
-	9,  // 0: v1.Config.repos:type_name -> v1.Repo
-	10, // 1: v1.Config.plans:type_name -> v1.Plan
-	18, // 2: v1.Config.auth:type_name -> v1.Auth
-	8,  // 3: v1.Config.multihost:type_name -> v1.Multihost
-	32, // 4: v1.Multihost.identity:type_name -> v1.PrivateKey
-	20, // 5: v1.Multihost.known_hosts:type_name -> v1.Multihost.Peer
-	20, // 6: v1.Multihost.authorized_clients:type_name -> v1.Multihost.Peer
-	21, // 7: v1.Multihost.pairing_tokens:type_name -> v1.Multihost.PairingToken
-	14, // 8: v1.Repo.prune_policy:type_name -> v1.PrunePolicy
-	15, // 9: v1.Repo.check_policy:type_name -> v1.CheckPolicy
-	17, // 10: v1.Repo.hooks:type_name -> v1.Hook
-	11, // 11: v1.Repo.command_prefix:type_name -> v1.CommandPrefix
-	13, // 12: v1.Repo.forget_policy:type_name -> v1.ForgetPolicy
-	16, // 13: v1.Plan.schedule:type_name -> v1.Schedule
-	12, // 14: v1.Plan.retention:type_name -> v1.RetentionPolicy
-	17, // 15: v1.Plan.hooks:type_name -> v1.Hook
-	1,  // 16: v1.CommandPrefix.io_nice:type_name -> v1.CommandPrefix.IONiceLevel
-	2,  // 17: v1.CommandPrefix.cpu_nice:type_name -> v1.CommandPrefix.CPUNiceLevel
-	23, // 18: v1.RetentionPolicy.policy_time_bucketed:type_name -> v1.RetentionPolicy.TimeBucketedCounts
-	16, // 19: v1.ForgetPolicy.schedule:type_name -> v1.Schedule
-	12, // 20: v1.ForgetPolicy.retention:type_name -> v1.RetentionPolicy
-	16, // 21: v1.PrunePolicy.schedule:type_name -> v1.Schedule
-	16, // 22: v1.CheckPolicy.schedule:type_name -> v1.Schedule
-	3,  // 23: v1.Schedule.clock:type_name -> v1.Schedule.Clock
-	4,  // 24: v1.Hook.conditions:type_name -> v1.Hook.Condition
-	5,  // 25: v1.Hook.on_error:type_name -> v1.Hook.OnError
-	24, // 26: v1.Hook.action_command:type_name -> v1.Hook.Command
-	25, // 27: v1.Hook.action_webhook:type_name -> v1.Hook.Webhook
-	26, // 28: v1.Hook.action_discord:type_name -> v1.Hook.Discord
-	27, // 29: v1.Hook.action_gotify:type_name -> v1.Hook.Gotify
-	28, // 30: v1.Hook.action_slack:type_name -> v1.Hook.Slack
-	29, // 31: v1.Hook.action_shoutrrr:type_name -> v1.Hook.Shoutrrr
-	30, // 32: v1.Hook.action_healthchecks:type_name -> v1.Hook.Healthchecks
-	31, // 33: v1.Hook.action_telegram:type_name -> v1.Hook.Telegram
-	19, // 34: v1.Auth.users:type_name -> v1.User
-	22, // 35: v1.Multihost.Peer.permissions:type_name -> v1.Multihost.Permission
-	22, // 36: v1.Multihost.PairingToken.permissions:type_name -> v1.Multihost.Permission
-	0,  // 37: v1.Multihost.Permission.type:type_name -> v1.Multihost.Permission.Type
-	6,  // 38: v1.Hook.Webhook.method:type_name -> v1.Hook.Webhook.Method
-	39, // [39:39] is the sub-list for method output_type
-	39, // [39:39] is the sub-list for method input_type
-	39, // [39:39] is the sub-list for extension type_name
-	39, // [39:39] is the sub-list for extension extendee
-	0,  // [0:39] is the sub-list for field type_name
+	12, // 0: v1.Config.repos:type_name -> v1.Repo
+	13, // 1: v1.Config.plans:type_name -> v1.Plan
+	24, // 2: v1.Config.auth:type_name -> v1.Auth
+	11, // 3: v1.Config.multihost:type_name -> v1.Multihost
+	10, // 4: v1.Config.digest:type_name -> v1.DigestPolicy
+	23, // 5: v1.Config.hooks:type_name -> v1.Hook
+	22, // 6: v1.DigestPolicy.schedule:type_name -> v1.Schedule
+	23, // 7: v1.DigestPolicy.hooks:type_name -> v1.Hook
+	41, // 8: v1.Multihost.identity:type_name -> v1.PrivateKey
+	26, // 9: v1.Multihost.known_hosts:type_name -> v1.Multihost.Peer
+	26, // 10: v1.Multihost.authorized_clients:type_name -> v1.Multihost.Peer
+	27, // 11: v1.Multihost.pairing_tokens:type_name -> v1.Multihost.PairingToken
+	18, // 12: v1.Repo.prune_policy:type_name -> v1.PrunePolicy
+	19, // 13: v1.Repo.check_policy:type_name -> v1.CheckPolicy
+	23, // 14: v1.Repo.hooks:type_name -> v1.Hook
+	15, // 15: v1.Repo.command_prefix:type_name -> v1.CommandPrefix
+	17, // 16: v1.Repo.forget_policy:type_name -> v1.ForgetPolicy
+	20, // 17: v1.Repo.copy_policy:type_name -> v1.CopyPolicy
+	22, // 18: v1.Plan.schedule:type_name -> v1.Schedule
+	16, // 19: v1.Plan.retention:type_name -> v1.RetentionPolicy
+	23, // 20: v1.Plan.hooks:type_name -> v1.Hook
+	14, // 21: v1.Plan.stdin_command:type_name -> v1.StdinCommand
+	21, // 22: v1.Plan.restore_test_policy:type_name -> v1.RestoreTestPolicy
+	1,  // 23: v1.CommandPrefix.io_nice:type_name -> v1.CommandPrefix.IONiceLevel
+	2,  // 24: v1.CommandPrefix.cpu_nice:type_name -> v1.CommandPrefix.CPUNiceLevel
+	29, // 25: v1.RetentionPolicy.policy_time_bucketed:type_name -> v1.RetentionPolicy.TimeBucketedCounts
+	22, // 26: v1.ForgetPolicy.schedule:type_name -> v1.Schedule
+	16, // 27: v1.ForgetPolicy.retention:type_name -> v1.RetentionPolicy
+	22, // 28: v1.PrunePolicy.schedule:type_name -> v1.Schedule
+	22, // 29: v1.CheckPolicy.schedule:type_name -> v1.Schedule
+	22, // 30: v1.CopyPolicy.schedule:type_name -> v1.Schedule
+	22, // 31: v1.RestoreTestPolicy.schedule:type_name -> v1.Schedule
+	3,  // 32: v1.Schedule.clock:type_name -> v1.Schedule.Clock
+	4,  // 33: v1.Hook.conditions:type_name -> v1.Hook.Condition
+	5,  // 34: v1.Hook.on_error:type_name -> v1.Hook.OnError
+	6,  // 35: v1.Hook.notify_mode:type_name -> v1.Hook.NotifyMode
+	30, // 36: v1.Hook.action_command:type_name -> v1.Hook.Command
+	31, // 37: v1.Hook.action_webhook:type_name -> v1.Hook.Webhook
+	32, // 38: v1.Hook.action_discord:type_name -> v1.Hook.Discord
+	33, // 39: v1.Hook.action_gotify:type_name -> v1.Hook.Gotify
+	34, // 40: v1.Hook.action_slack:type_name -> v1.Hook.Slack
+	35, // 41: v1.Hook.action_shoutrrr:type_name -> v1.Hook.Shoutrrr
+	36, // 42: v1.Hook.action_healthchecks:type_name -> v1.Hook.Healthchecks
+	37, // 43: v1.Hook.action_telegram:type_name -> v1.Hook.Telegram
+	38, // 44: v1.Hook.action_email:type_name -> v1.Hook.Email
+	39, // 45: v1.Hook.action_mqtt:type_name -> v1.Hook.Mqtt
+	40, // 46: v1.Hook.action_ntfy:type_name -> v1.Hook.Ntfy
+	25, // 47: v1.Auth.users:type_name -> v1.User
+	28, // 48: v1.Multihost.Peer.permissions:type_name -> v1.Multihost.Permission
+	28, // 49: v1.Multihost.PairingToken.permissions:type_name -> v1.Multihost.Permission
+	0,  // 50: v1.Multihost.Permission.type:type_name -> v1.Multihost.Permission.Type
+	7,  // 51: v1.Hook.Webhook.method:type_name -> v1.Hook.Webhook.Method
+	8,  // 52: v1.Hook.Email.security:type_name -> v1.Hook.Email.Security
+	53, // [53:53] is the sub-list for method output_type
+	53, // [53:53] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_v1_config_proto_init() }
@@ -2765,22 +3858,22 @@ func file_v1_config_proto_init() {
 		return
 	}
 	file_v1_crypto_proto_init()
-	file_v1_config_proto_msgTypes[5].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[7].OneofWrappers = []any{
 		(*RetentionPolicy_PolicyKeepLastN)(nil),
 		(*RetentionPolicy_PolicyTimeBucketed)(nil),
 		(*RetentionPolicy_PolicyKeepAll)(nil),
 	}
-	file_v1_config_proto_msgTypes[8].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[10].OneofWrappers = []any{
 		(*CheckPolicy_StructureOnly)(nil),
 		(*CheckPolicy_ReadDataSubsetPercent)(nil),
 	}
-	file_v1_config_proto_msgTypes[9].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[13].OneofWrappers = []any{
 		(*Schedule_Disabled)(nil),
 		(*Schedule_Cron)(nil),
 		(*Schedule_MaxFrequencyDays)(nil),
 		(*Schedule_MaxFrequencyHours)(nil),
 	}
-	file_v1_config_proto_msgTypes[10].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[14].OneofWrappers = []any{
 		(*Hook_ActionCommand)(nil),
 		(*Hook_ActionWebhook)(nil),
 		(*Hook_ActionDiscord)(nil),
@@ -2789,8 +3882,11 @@ func file_v1_config_proto_init() {
 		(*Hook_ActionShoutrrr)(nil),
 		(*Hook_ActionHealthchecks)(nil),
 		(*Hook_ActionTelegram)(nil),
+		(*Hook_ActionEmail)(nil),
+		(*Hook_ActionMqtt)(nil),
+		(*Hook_ActionNtfy)(nil),
 	}
-	file_v1_config_proto_msgTypes[12].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[16].OneofWrappers = []any{
 		(*User_PasswordBcrypt)(nil),
 	}
 	type x struct{}
@@ -2798,8 +3894,8 @@ func file_v1_config_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_config_proto_rawDesc), len(file_v1_config_proto_rawDesc)),
-			NumEnums:      7,
-			NumMessages:   25,
+			NumEnums:      9,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -139,6 +139,61 @@ func (OperationStatus) EnumDescriptor() ([]byte, []int) {
 	return file_v1_operations_proto_rawDescGZIP(), []int{1}
 }
 
+type RestoreOptions_OverwriteMode int32
+
+const (
+	RestoreOptions_OVERWRITE_DEFAULT    RestoreOptions_OverwriteMode = 0 // the target must not already exist.
+	RestoreOptions_OVERWRITE_ALWAYS     RestoreOptions_OverwriteMode = 1 // overwrite existing files.
+	RestoreOptions_OVERWRITE_IF_CHANGED RestoreOptions_OverwriteMode = 2 // overwrite existing files if their content differs.
+	RestoreOptions_OVERWRITE_IF_NEWER   RestoreOptions_OverwriteMode = 3 // overwrite existing files if the file in the snapshot is newer.
+	RestoreOptions_OVERWRITE_NEVER      RestoreOptions_OverwriteMode = 4 // never overwrite existing files.
+)
+
+// Enum value maps for RestoreOptions_OverwriteMode.
+var (
+	RestoreOptions_OverwriteMode_name = map[int32]string{
+		0: "OVERWRITE_DEFAULT",
+		1: "OVERWRITE_ALWAYS",
+		2: "OVERWRITE_IF_CHANGED",
+		3: "OVERWRITE_IF_NEWER",
+		4: "OVERWRITE_NEVER",
+	}
+	RestoreOptions_OverwriteMode_value = map[string]int32{
+		"OVERWRITE_DEFAULT":    0,
+		"OVERWRITE_ALWAYS":     1,
+		"OVERWRITE_IF_CHANGED": 2,
+		"OVERWRITE_IF_NEWER":   3,
+		"OVERWRITE_NEVER":      4,
+	}
+)
+
+func (x RestoreOptions_OverwriteMode) Enum() *RestoreOptions_OverwriteMode {
+	p := new(RestoreOptions_OverwriteMode)
+	*p = x
+	return p
+}
+
+func (x RestoreOptions_OverwriteMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RestoreOptions_OverwriteMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_operations_proto_enumTypes[2].Descriptor()
+}
+
+func (RestoreOptions_OverwriteMode) Type() protoreflect.EnumType {
+	return &file_v1_operations_proto_enumTypes[2]
+}
+
+func (x RestoreOptions_OverwriteMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RestoreOptions_OverwriteMode.Descriptor instead.
+func (RestoreOptions_OverwriteMode) EnumDescriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{15, 0}
+}
+
 type OperationList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
@@ -226,6 +281,9 @@ type Operation struct {
 	//	*Operation_OperationRunHook
 	//	*Operation_OperationCheck
 	//	*Operation_OperationRunCommand
+	//	*Operation_OperationCopy
+	//	*Operation_OperationFindFiles
+	//	*Operation_OperationRestoreTest
 	Op            isOperation_Op `protobuf_oneof:"op"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -461,6 +519,33 @@ func (x *Operation) GetOperationRunCommand() *OperationRunCommand {
 	return nil
 }
 
+func (x *Operation) GetOperationCopy() *OperationCopy {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_OperationCopy); ok {
+			return x.OperationCopy
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationFindFiles() *OperationFindFiles {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_OperationFindFiles); ok {
+			return x.OperationFindFiles
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationRestoreTest() *OperationRestoreTest {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_OperationRestoreTest); ok {
+			return x.OperationRestoreTest
+		}
+	}
+	return nil
+}
+
 type isOperation_Op interface {
 	isOperation_Op()
 }
@@ -501,6 +586,18 @@ type Operation_OperationRunCommand struct {
 	OperationRunCommand *OperationRunCommand `protobuf:"bytes,108,opt,name=operation_run_command,json=operationRunCommand,proto3,oneof"`
 }
 
+type Operation_OperationCopy struct {
+	OperationCopy *OperationCopy `protobuf:"bytes,109,opt,name=operation_copy,json=operationCopy,proto3,oneof"`
+}
+
+type Operation_OperationFindFiles struct {
+	OperationFindFiles *OperationFindFiles `protobuf:"bytes,110,opt,name=operation_find_files,json=operationFindFiles,proto3,oneof"`
+}
+
+type Operation_OperationRestoreTest struct {
+	OperationRestoreTest *OperationRestoreTest `protobuf:"bytes,111,opt,name=operation_restore_test,json=operationRestoreTest,proto3,oneof"`
+}
+
 func (*Operation_OperationBackup) isOperation_Op() {}
 
 func (*Operation_OperationIndexSnapshot) isOperation_Op() {}
@@ -519,6 +616,12 @@ func (*Operation_OperationCheck) isOperation_Op() {}
 
 func (*Operation_OperationRunCommand) isOperation_Op() {}
 
+func (*Operation_OperationCopy) isOperation_Op() {}
+
+func (*Operation_OperationFindFiles) isOperation_Op() {}
+
+func (*Operation_OperationRestoreTest) isOperation_Op() {}
+
 // OperationEvent is used in the wireformat to stream operation changes to clients
 type OperationEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -699,6 +802,7 @@ type OperationIndexSnapshot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Snapshot      *ResticSnapshot        `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // the snapshot that was indexed.
 	Forgot        bool                   `protobuf:"varint,3,opt,name=forgot,proto3" json:"forgot,omitempty"`    // tracks whether this snapshot is forgotten yet.
+	Pinned        bool                   `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`    // true if the snapshot is pinned, pinned snapshots are never forgotten by a retention policy.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -747,6 +851,13 @@ func (x *OperationIndexSnapshot) GetForgot() bool {
 	return false
 }
 
+func (x *OperationIndexSnapshot) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
 // OperationForget tracks a forget operation.
 type OperationForget struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -804,8 +915,9 @@ func (x *OperationForget) GetPolicy() *RetentionPolicy {
 type OperationPrune struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Deprecated: Marked as deprecated in v1/operations.proto.
-	Output        string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`                                 // output of the prune.
-	OutputLogref  string `protobuf:"bytes,2,opt,name=output_logref,json=outputLogref,proto3" json:"output_logref,omitempty"` // logref of the prune output.
+	Output        string      `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`                                 // output of the prune.
+	OutputLogref  string      `protobuf:"bytes,2,opt,name=output_logref,json=outputLogref,proto3" json:"output_logref,omitempty"` // logref of the prune output.
+	Stats         *PruneStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`                                   // summary parsed from the prune output, unset if it couldn't be parsed.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -855,6 +967,13 @@ func (x *OperationPrune) GetOutputLogref() string {
 	return ""
 }
 
+func (x *OperationPrune) GetStats() *PruneStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
 // OperationCheck tracks a check operation.
 type OperationCheck struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -910,6 +1029,366 @@ func (x *OperationCheck) GetOutputLogref() string {
 	return ""
 }
 
+// OperationCopy tracks a copy of snapshots from the operation's repo into a target repo.
+type OperationCopy struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetRepoId   string                 `protobuf:"bytes,1,opt,name=target_repo_id,json=targetRepoId,proto3" json:"target_repo_id,omitempty"`       // ID of the repo snapshots were copied into.
+	TargetRepoGuid string                 `protobuf:"bytes,2,opt,name=target_repo_guid,json=targetRepoGuid,proto3" json:"target_repo_guid,omitempty"` // GUID of the repo snapshots were copied into.
+	OutputLogref   string                 `protobuf:"bytes,3,opt,name=output_logref,json=outputLogref,proto3" json:"output_logref,omitempty"`         // logref of the copy output.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OperationCopy) Reset() {
+	*x = OperationCopy{}
+	mi := &file_v1_operations_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationCopy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationCopy) ProtoMessage() {}
+
+func (x *OperationCopy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationCopy.ProtoReflect.Descriptor instead.
+func (*OperationCopy) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OperationCopy) GetTargetRepoId() string {
+	if x != nil {
+		return x.TargetRepoId
+	}
+	return ""
+}
+
+func (x *OperationCopy) GetTargetRepoGuid() string {
+	if x != nil {
+		return x.TargetRepoGuid
+	}
+	return ""
+}
+
+func (x *OperationCopy) GetOutputLogref() string {
+	if x != nil {
+		return x.OutputLogref
+	}
+	return ""
+}
+
+// OperationRestoreTest tracks a restore test of the snapshot identified by the operation's snapshot_id.
+type OperationRestoreTest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                      // path that was restored, empty if a random sample of files was restored.
+	FilesChecked  int64                  `protobuf:"varint,2,opt,name=files_checked,json=filesChecked,proto3" json:"files_checked,omitempty"` // number of restored files that were verified.
+	FilesFailed   int64                  `protobuf:"varint,3,opt,name=files_failed,json=filesFailed,proto3" json:"files_failed,omitempty"`    // number of restored files that failed verification.
+	BytesChecked  int64                  `protobuf:"varint,4,opt,name=bytes_checked,json=bytesChecked,proto3" json:"bytes_checked,omitempty"`
+	Passed        bool                   `protobuf:"varint,5,opt,name=passed,proto3" json:"passed,omitempty"`    // true if every verified file matched the snapshot.
+	Failures      []*RestoreTestFailure  `protobuf:"bytes,6,rep,name=failures,proto3" json:"failures,omitempty"` // the first files that failed verification.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationRestoreTest) Reset() {
+	*x = OperationRestoreTest{}
+	mi := &file_v1_operations_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationRestoreTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRestoreTest) ProtoMessage() {}
+
+func (x *OperationRestoreTest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRestoreTest.ProtoReflect.Descriptor instead.
+func (*OperationRestoreTest) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OperationRestoreTest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *OperationRestoreTest) GetFilesChecked() int64 {
+	if x != nil {
+		return x.FilesChecked
+	}
+	return 0
+}
+
+func (x *OperationRestoreTest) GetFilesFailed() int64 {
+	if x != nil {
+		return x.FilesFailed
+	}
+	return 0
+}
+
+func (x *OperationRestoreTest) GetBytesChecked() int64 {
+	if x != nil {
+		return x.BytesChecked
+	}
+	return 0
+}
+
+func (x *OperationRestoreTest) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *OperationRestoreTest) GetFailures() []*RestoreTestFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type RestoreTestFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTestFailure) Reset() {
+	*x = RestoreTestFailure{}
+	mi := &file_v1_operations_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTestFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTestFailure) ProtoMessage() {}
+
+func (x *RestoreTestFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTestFailure.ProtoReflect.Descriptor instead.
+func (*RestoreTestFailure) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreTestFailure) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RestoreTestFailure) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// OperationFindFiles tracks a search for files across the snapshots of a repo.
+type OperationFindFiles struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Query            *FindFilesQuery        `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	ResultsLogref    string                 `protobuf:"bytes,2,opt,name=results_logref,json=resultsLogref,proto3" json:"results_logref,omitempty"`           // logref of the matches, one JSON encoded FileMatch per line.
+	MatchCount       int64                  `protobuf:"varint,3,opt,name=match_count,json=matchCount,proto3" json:"match_count,omitempty"`                   // number of matches found.
+	SnapshotsMatched int64                  `protobuf:"varint,4,opt,name=snapshots_matched,json=snapshotsMatched,proto3" json:"snapshots_matched,omitempty"` // number of snapshots containing at least one match.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OperationFindFiles) Reset() {
+	*x = OperationFindFiles{}
+	mi := &file_v1_operations_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationFindFiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationFindFiles) ProtoMessage() {}
+
+func (x *OperationFindFiles) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationFindFiles.ProtoReflect.Descriptor instead.
+func (*OperationFindFiles) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OperationFindFiles) GetQuery() *FindFilesQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *OperationFindFiles) GetResultsLogref() string {
+	if x != nil {
+		return x.ResultsLogref
+	}
+	return ""
+}
+
+func (x *OperationFindFiles) GetMatchCount() int64 {
+	if x != nil {
+		return x.MatchCount
+	}
+	return 0
+}
+
+func (x *OperationFindFiles) GetSnapshotsMatched() int64 {
+	if x != nil {
+		return x.SnapshotsMatched
+	}
+	return 0
+}
+
+// FindFilesQuery describes a search for files across the snapshots of a repo. All filters are optional.
+type FindFilesQuery struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Pattern             string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`                                                         // glob pattern matched against file names, or against the full path if it contains a '/'.
+	IgnoreCase          bool                   `protobuf:"varint,2,opt,name=ignore_case,json=ignoreCase,proto3" json:"ignore_case,omitempty"`                                // match the pattern case insensitively.
+	SnapshotIds         []string               `protobuf:"bytes,3,rep,name=snapshot_ids,json=snapshotIds,proto3" json:"snapshot_ids,omitempty"`                              // only search these snapshots.
+	PlanId              string                 `protobuf:"bytes,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                                             // only search snapshots created by this plan.
+	Hostname            string                 `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`                                                       // only search snapshots taken on this host.
+	SnapshotTimeStartMs int64                  `protobuf:"varint,6,opt,name=snapshot_time_start_ms,json=snapshotTimeStartMs,proto3" json:"snapshot_time_start_ms,omitempty"` // only search snapshots taken at or after this time.
+	SnapshotTimeEndMs   int64                  `protobuf:"varint,7,opt,name=snapshot_time_end_ms,json=snapshotTimeEndMs,proto3" json:"snapshot_time_end_ms,omitempty"`       // only search snapshots taken at or before this time.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *FindFilesQuery) Reset() {
+	*x = FindFilesQuery{}
+	mi := &file_v1_operations_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindFilesQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindFilesQuery) ProtoMessage() {}
+
+func (x *FindFilesQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindFilesQuery.ProtoReflect.Descriptor instead.
+func (*FindFilesQuery) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FindFilesQuery) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *FindFilesQuery) GetIgnoreCase() bool {
+	if x != nil {
+		return x.IgnoreCase
+	}
+	return false
+}
+
+func (x *FindFilesQuery) GetSnapshotIds() []string {
+	if x != nil {
+		return x.SnapshotIds
+	}
+	return nil
+}
+
+func (x *FindFilesQuery) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *FindFilesQuery) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *FindFilesQuery) GetSnapshotTimeStartMs() int64 {
+	if x != nil {
+		return x.SnapshotTimeStartMs
+	}
+	return 0
+}
+
+func (x *FindFilesQuery) GetSnapshotTimeEndMs() int64 {
+	if x != nil {
+		return x.SnapshotTimeEndMs
+	}
+	return 0
+}
+
 // OperationRunCommand tracks a long running command. Commands are grouped into a flow ID for each session.
 type OperationRunCommand struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -922,7 +1401,7 @@ type OperationRunCommand struct {
 
 func (x *OperationRunCommand) Reset() {
 	*x = OperationRunCommand{}
-	mi := &file_v1_operations_proto_msgTypes[8]
+	mi := &file_v1_operations_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -934,7 +1413,7 @@ func (x *OperationRunCommand) String() string {
 func (*OperationRunCommand) ProtoMessage() {}
 
 func (x *OperationRunCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[8]
+	mi := &file_v1_operations_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -947,7 +1426,7 @@ func (x *OperationRunCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRunCommand.ProtoReflect.Descriptor instead.
 func (*OperationRunCommand) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{8}
+	return file_v1_operations_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *OperationRunCommand) GetCommand() string {
@@ -977,13 +1456,14 @@ type OperationRestore struct {
 	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                               // path in the snapshot to restore.
 	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                           // location to restore it to.
 	LastStatus    *RestoreProgressEntry  `protobuf:"bytes,3,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"` // status of the restore.
+	Options       *RestoreOptions        `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`                         // options the restore was run with.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationRestore) Reset() {
 	*x = OperationRestore{}
-	mi := &file_v1_operations_proto_msgTypes[9]
+	mi := &file_v1_operations_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -995,7 +1475,7 @@ func (x *OperationRestore) String() string {
 func (*OperationRestore) ProtoMessage() {}
 
 func (x *OperationRestore) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[9]
+	mi := &file_v1_operations_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1008,7 +1488,7 @@ func (x *OperationRestore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRestore.ProtoReflect.Descriptor instead.
 func (*OperationRestore) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{9}
+	return file_v1_operations_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *OperationRestore) GetPath() string {
@@ -1032,6 +1512,90 @@ func (x *OperationRestore) GetLastStatus() *RestoreProgressEntry {
 	return nil
 }
 
+func (x *OperationRestore) GetOptions() *RestoreOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// RestoreOptions customize which files a restore writes and how it treats existing files in the target.
+type RestoreOptions struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Includes      []string                     `protobuf:"bytes,1,rep,name=includes,proto3" json:"includes,omitempty"`                                         // glob patterns to restore, relative to the restored path. Restores everything if empty.
+	Excludes      []string                     `protobuf:"bytes,2,rep,name=excludes,proto3" json:"excludes,omitempty"`                                         // glob patterns to skip, relative to the restored path. Can't be combined with includes.
+	Overwrite     RestoreOptions_OverwriteMode `protobuf:"varint,3,opt,name=overwrite,proto3,enum=v1.RestoreOptions_OverwriteMode" json:"overwrite,omitempty"` // how to handle files that already exist in the target.
+	Delete        bool                         `protobuf:"varint,4,opt,name=delete,proto3" json:"delete,omitempty"`                                            // delete files in the target that are not in the snapshot.
+	Verify        bool                         `protobuf:"varint,5,opt,name=verify,proto3" json:"verify,omitempty"`                                            // verify the restored files' content after the restore.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreOptions) Reset() {
+	*x = RestoreOptions{}
+	mi := &file_v1_operations_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreOptions) ProtoMessage() {}
+
+func (x *RestoreOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreOptions.ProtoReflect.Descriptor instead.
+func (*RestoreOptions) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreOptions) GetIncludes() []string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
+func (x *RestoreOptions) GetExcludes() []string {
+	if x != nil {
+		return x.Excludes
+	}
+	return nil
+}
+
+func (x *RestoreOptions) GetOverwrite() RestoreOptions_OverwriteMode {
+	if x != nil {
+		return x.Overwrite
+	}
+	return RestoreOptions_OVERWRITE_DEFAULT
+}
+
+func (x *RestoreOptions) GetDelete() bool {
+	if x != nil {
+		return x.Delete
+	}
+	return false
+}
+
+func (x *RestoreOptions) GetVerify() bool {
+	if x != nil {
+		return x.Verify
+	}
+	return false
+}
+
 // OperationStats tracks a stats operation.
 type OperationStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1042,7 +1606,7 @@ type OperationStats struct {
 
 func (x *OperationStats) Reset() {
 	*x = OperationStats{}
-	mi := &file_v1_operations_proto_msgTypes[10]
+	mi := &file_v1_operations_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1054,7 +1618,7 @@ func (x *OperationStats) String() string {
 func (*OperationStats) ProtoMessage() {}
 
 func (x *OperationStats) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[10]
+	mi := &file_v1_operations_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1067,7 +1631,7 @@ func (x *OperationStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationStats.ProtoReflect.Descriptor instead.
 func (*OperationStats) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{10}
+	return file_v1_operations_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *OperationStats) GetStats() *RepoStats {
@@ -1084,13 +1648,14 @@ type OperationRunHook struct {
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // description of the hook that was run. typically repo/hook_idx or plan/hook_idx.
 	OutputLogref  string                 `protobuf:"bytes,2,opt,name=output_logref,json=outputLogref,proto3" json:"output_logref,omitempty"` // logref of the hook's output. DEPRECATED.
 	Condition     Hook_Condition         `protobuf:"varint,3,opt,name=condition,proto3,enum=v1.Hook_Condition" json:"condition,omitempty"`   // triggering condition of the hook.
+	TimedOut      bool                   `protobuf:"varint,5,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`            // true if the hook failed because it exceeded its timeout.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationRunHook) Reset() {
 	*x = OperationRunHook{}
-	mi := &file_v1_operations_proto_msgTypes[11]
+	mi := &file_v1_operations_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1102,7 +1667,7 @@ func (x *OperationRunHook) String() string {
 func (*OperationRunHook) ProtoMessage() {}
 
 func (x *OperationRunHook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[11]
+	mi := &file_v1_operations_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,7 +1680,7 @@ func (x *OperationRunHook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRunHook.ProtoReflect.Descriptor instead.
 func (*OperationRunHook) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{11}
+	return file_v1_operations_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *OperationRunHook) GetParentOp() int64 {
@@ -1146,6 +1711,13 @@ func (x *OperationRunHook) GetCondition() Hook_Condition {
 	return Hook_CONDITION_UNKNOWN
 }
 
+func (x *OperationRunHook) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
 var File_v1_operations_proto protoreflect.FileDescriptor
 
 const file_v1_operations_proto_rawDesc = "" +
@@ -1154,7 +1726,8 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\rOperationList\x12-\n" +
 	"\n" +
 	"operations\x18\x01 \x03(\v2\r.v1.OperationR\n" +
-	"operations\"\x9b\t\n" +
+	"operations\"\xf5\n" +
+	"\n" +
 	"\tOperation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\voriginal_id\x18\r \x01(\x03R\n" +
@@ -1184,7 +1757,10 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\x0foperation_stats\x18i \x01(\v2\x12.v1.OperationStatsH\x00R\x0eoperationStats\x12D\n" +
 	"\x12operation_run_hook\x18j \x01(\v2\x14.v1.OperationRunHookH\x00R\x10operationRunHook\x12=\n" +
 	"\x0foperation_check\x18k \x01(\v2\x12.v1.OperationCheckH\x00R\x0eoperationCheck\x12M\n" +
-	"\x15operation_run_command\x18l \x01(\v2\x17.v1.OperationRunCommandH\x00R\x13operationRunCommandB\x04\n" +
+	"\x15operation_run_command\x18l \x01(\v2\x17.v1.OperationRunCommandH\x00R\x13operationRunCommand\x12:\n" +
+	"\x0eoperation_copy\x18m \x01(\v2\x11.v1.OperationCopyH\x00R\roperationCopy\x12J\n" +
+	"\x14operation_find_files\x18n \x01(\v2\x16.v1.OperationFindFilesH\x00R\x12operationFindFiles\x12P\n" +
+	"\x16operation_restore_test\x18o \x01(\v2\x18.v1.OperationRestoreTestH\x00R\x14operationRestoreTestB\x04\n" +
 	"\x02op\"\x93\x02\n" +
 	"\x0eOperationEvent\x12-\n" +
 	"\n" +
@@ -1197,35 +1773,80 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\vlast_status\x18\x03 \x01(\v2\x17.v1.BackupProgressEntryR\n" +
 	"lastStatus\x12/\n" +
 	"\x06errors\x18\x04 \x03(\v2\x17.v1.BackupProgressErrorR\x06errors\x12\x17\n" +
-	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"`\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"x\n" +
 	"\x16OperationIndexSnapshot\x12.\n" +
 	"\bsnapshot\x18\x02 \x01(\v2\x12.v1.ResticSnapshotR\bsnapshot\x12\x16\n" +
-	"\x06forgot\x18\x03 \x01(\bR\x06forgot\"j\n" +
+	"\x06forgot\x18\x03 \x01(\bR\x06forgot\x12\x16\n" +
+	"\x06pinned\x18\x04 \x01(\bR\x06pinned\"j\n" +
 	"\x0fOperationForget\x12*\n" +
 	"\x06forget\x18\x01 \x03(\v2\x12.v1.ResticSnapshotR\x06forget\x12+\n" +
-	"\x06policy\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\x06policy\"Q\n" +
+	"\x06policy\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\x06policy\"w\n" +
 	"\x0eOperationPrune\x12\x1a\n" +
 	"\x06output\x18\x01 \x01(\tB\x02\x18\x01R\x06output\x12#\n" +
-	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\"Q\n" +
+	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\x12$\n" +
+	"\x05stats\x18\x03 \x01(\v2\x0e.v1.PruneStatsR\x05stats\"Q\n" +
 	"\x0eOperationCheck\x12\x1a\n" +
 	"\x06output\x18\x01 \x01(\tB\x02\x18\x01R\x06output\x12#\n" +
-	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\"\x80\x01\n" +
+	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\"\x84\x01\n" +
+	"\rOperationCopy\x12$\n" +
+	"\x0etarget_repo_id\x18\x01 \x01(\tR\ftargetRepoId\x12(\n" +
+	"\x10target_repo_guid\x18\x02 \x01(\tR\x0etargetRepoGuid\x12#\n" +
+	"\routput_logref\x18\x03 \x01(\tR\foutputLogref\"\xe3\x01\n" +
+	"\x14OperationRestoreTest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12#\n" +
+	"\rfiles_checked\x18\x02 \x01(\x03R\ffilesChecked\x12!\n" +
+	"\ffiles_failed\x18\x03 \x01(\x03R\vfilesFailed\x12#\n" +
+	"\rbytes_checked\x18\x04 \x01(\x03R\fbytesChecked\x12\x16\n" +
+	"\x06passed\x18\x05 \x01(\bR\x06passed\x122\n" +
+	"\bfailures\x18\x06 \x03(\v2\x16.v1.RestoreTestFailureR\bfailures\"@\n" +
+	"\x12RestoreTestFailure\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xb3\x01\n" +
+	"\x12OperationFindFiles\x12(\n" +
+	"\x05query\x18\x01 \x01(\v2\x12.v1.FindFilesQueryR\x05query\x12%\n" +
+	"\x0eresults_logref\x18\x02 \x01(\tR\rresultsLogref\x12\x1f\n" +
+	"\vmatch_count\x18\x03 \x01(\x03R\n" +
+	"matchCount\x12+\n" +
+	"\x11snapshots_matched\x18\x04 \x01(\x03R\x10snapshotsMatched\"\x89\x02\n" +
+	"\x0eFindFilesQuery\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\x12\x1f\n" +
+	"\vignore_case\x18\x02 \x01(\bR\n" +
+	"ignoreCase\x12!\n" +
+	"\fsnapshot_ids\x18\x03 \x03(\tR\vsnapshotIds\x12\x17\n" +
+	"\aplan_id\x18\x04 \x01(\tR\x06planId\x12\x1a\n" +
+	"\bhostname\x18\x05 \x01(\tR\bhostname\x123\n" +
+	"\x16snapshot_time_start_ms\x18\x06 \x01(\x03R\x13snapshotTimeStartMs\x12/\n" +
+	"\x14snapshot_time_end_ms\x18\a \x01(\x03R\x11snapshotTimeEndMs\"\x80\x01\n" +
 	"\x13OperationRunCommand\x12\x18\n" +
 	"\acommand\x18\x01 \x01(\tR\acommand\x12#\n" +
 	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\x12*\n" +
-	"\x11output_size_bytes\x18\x03 \x01(\x03R\x0foutputSizeBytes\"y\n" +
+	"\x11output_size_bytes\x18\x03 \x01(\x03R\x0foutputSizeBytes\"\xa7\x01\n" +
 	"\x10OperationRestore\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x129\n" +
 	"\vlast_status\x18\x03 \x01(\v2\x18.v1.RestoreProgressEntryR\n" +
-	"lastStatus\"5\n" +
+	"lastStatus\x12,\n" +
+	"\aoptions\x18\x04 \x01(\v2\x12.v1.RestoreOptionsR\aoptions\"\xbe\x02\n" +
+	"\x0eRestoreOptions\x12\x1a\n" +
+	"\bincludes\x18\x01 \x03(\tR\bincludes\x12\x1a\n" +
+	"\bexcludes\x18\x02 \x03(\tR\bexcludes\x12>\n" +
+	"\toverwrite\x18\x03 \x01(\x0e2 .v1.RestoreOptions.OverwriteModeR\toverwrite\x12\x16\n" +
+	"\x06delete\x18\x04 \x01(\bR\x06delete\x12\x16\n" +
+	"\x06verify\x18\x05 \x01(\bR\x06verify\"\x83\x01\n" +
+	"\rOverwriteMode\x12\x15\n" +
+	"\x11OVERWRITE_DEFAULT\x10\x00\x12\x14\n" +
+	"\x10OVERWRITE_ALWAYS\x10\x01\x12\x18\n" +
+	"\x14OVERWRITE_IF_CHANGED\x10\x02\x12\x16\n" +
+	"\x12OVERWRITE_IF_NEWER\x10\x03\x12\x13\n" +
+	"\x0fOVERWRITE_NEVER\x10\x04\"5\n" +
 	"\x0eOperationStats\x12#\n" +
-	"\x05stats\x18\x01 \x01(\v2\r.v1.RepoStatsR\x05stats\"\x9a\x01\n" +
+	"\x05stats\x18\x01 \x01(\v2\r.v1.RepoStatsR\x05stats\"\xb7\x01\n" +
 	"\x10OperationRunHook\x12\x1b\n" +
 	"\tparent_op\x18\x04 \x01(\x03R\bparentOp\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
 	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\x120\n" +
-	"\tcondition\x18\x03 \x01(\x0e2\x12.v1.Hook.ConditionR\tcondition*`\n" +
+	"\tcondition\x18\x03 \x01(\x0e2\x12.v1.Hook.ConditionR\tcondition\x12\x1b\n" +
+	"\ttimed_out\x18\x05 \x01(\bR\btimedOut*`\n" +
 	"\x12OperationEventType\x12\x11\n" +
 	"\rEVENT_UNKNOWN\x10\x00\x12\x11\n" +
 	"\rEVENT_CREATED\x10\x01\x12\x11\n" +
@@ -1253,62 +1874,78 @@ func file_v1_operations_proto_rawDescGZIP() []byte {
 	return file_v1_operations_proto_rawDescData
 }
 
-var file_v1_operations_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_v1_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_v1_operations_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_v1_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_v1_operations_proto_goTypes = []any{
-	(OperationEventType)(0),        // 0: v1.OperationEventType
-	(OperationStatus)(0),           // 1: v1.OperationStatus
-	(*OperationList)(nil),          // 2: v1.OperationList
-	(*Operation)(nil),              // 3: v1.Operation
-	(*OperationEvent)(nil),         // 4: v1.OperationEvent
-	(*OperationBackup)(nil),        // 5: v1.OperationBackup
-	(*OperationIndexSnapshot)(nil), // 6: v1.OperationIndexSnapshot
-	(*OperationForget)(nil),        // 7: v1.OperationForget
-	(*OperationPrune)(nil),         // 8: v1.OperationPrune
-	(*OperationCheck)(nil),         // 9: v1.OperationCheck
-	(*OperationRunCommand)(nil),    // 10: v1.OperationRunCommand
-	(*OperationRestore)(nil),       // 11: v1.OperationRestore
-	(*OperationStats)(nil),         // 12: v1.OperationStats
-	(*OperationRunHook)(nil),       // 13: v1.OperationRunHook
-	(*types.Empty)(nil),            // 14: types.Empty
-	(*types.Int64List)(nil),        // 15: types.Int64List
-	(*BackupProgressEntry)(nil),    // 16: v1.BackupProgressEntry
-	(*BackupProgressError)(nil),    // 17: v1.BackupProgressError
-	(*ResticSnapshot)(nil),         // 18: v1.ResticSnapshot
-	(*RetentionPolicy)(nil),        // 19: v1.RetentionPolicy
-	(*RestoreProgressEntry)(nil),   // 20: v1.RestoreProgressEntry
-	(*RepoStats)(nil),              // 21: v1.RepoStats
-	(Hook_Condition)(0),            // 22: v1.Hook.Condition
+	(OperationEventType)(0),           // 0: v1.OperationEventType
+	(OperationStatus)(0),              // 1: v1.OperationStatus
+	(RestoreOptions_OverwriteMode)(0), // 2: v1.RestoreOptions.OverwriteMode
+	(*OperationList)(nil),             // 3: v1.OperationList
+	(*Operation)(nil),                 // 4: v1.Operation
+	(*OperationEvent)(nil),            // 5: v1.OperationEvent
+	(*OperationBackup)(nil),           // 6: v1.OperationBackup
+	(*OperationIndexSnapshot)(nil),    // 7: v1.OperationIndexSnapshot
+	(*OperationForget)(nil),           // 8: v1.OperationForget
+	(*OperationPrune)(nil),            // 9: v1.OperationPrune
+	(*OperationCheck)(nil),            // 10: v1.OperationCheck
+	(*OperationCopy)(nil),             // 11: v1.OperationCopy
+	(*OperationRestoreTest)(nil),      // 12: v1.OperationRestoreTest
+	(*RestoreTestFailure)(nil),        // 13: v1.RestoreTestFailure
+	(*OperationFindFiles)(nil),        // 14: v1.OperationFindFiles
+	(*FindFilesQuery)(nil),            // 15: v1.FindFilesQuery
+	(*OperationRunCommand)(nil),       // 16: v1.OperationRunCommand
+	(*OperationRestore)(nil),          // 17: v1.OperationRestore
+	(*RestoreOptions)(nil),            // 18: v1.RestoreOptions
+	(*OperationStats)(nil),            // 19: v1.OperationStats
+	(*OperationRunHook)(nil),          // 20: v1.OperationRunHook
+	(*types.Empty)(nil),               // 21: types.Empty
+	(*types.Int64List)(nil),           // 22: types.Int64List
+	(*BackupProgressEntry)(nil),       // 23: v1.BackupProgressEntry
+	(*BackupProgressError)(nil),       // 24: v1.BackupProgressError
+	(*ResticSnapshot)(nil),            // 25: v1.ResticSnapshot
+	(*RetentionPolicy)(nil),           // 26: v1.RetentionPolicy
+	(*PruneStats)(nil),                // 27: v1.PruneStats
+	(*RestoreProgressEntry)(nil),      // 28: v1.RestoreProgressEntry
+	(*RepoStats)(nil),                 // 29: v1.RepoStats
+	(Hook_Condition)(0),               // 30: v1.Hook.Condition
 }
 var file_v1_operations_proto_depIdxs = []int32{
-	3,  // 0: v1.OperationList.operations:type_name -> v1.Operation
+	4,  // 0: v1.OperationList.operations:type_name -> v1.Operation
 	1,  // 1: v1.Operation.status:type_name -> v1.OperationStatus
-	5,  // 2: v1.Operation.operation_backup:type_name -> v1.OperationBackup
-	6,  // 3: v1.Operation.operation_index_snapshot:type_name -> v1.OperationIndexSnapshot
-	7,  // 4: v1.Operation.operation_forget:type_name -> v1.OperationForget
-	8,  // 5: v1.Operation.operation_prune:type_name -> v1.OperationPrune
-	11, // 6: v1.Operation.operation_restore:type_name -> v1.OperationRestore
-	12, // 7: v1.Operation.operation_stats:type_name -> v1.OperationStats
-	13, // 8: v1.Operation.operation_run_hook:type_name -> v1.OperationRunHook
-	9,  // 9: v1.Operation.operation_check:type_name -> v1.OperationCheck
-	10, // 10: v1.Operation.operation_run_command:type_name -> v1.OperationRunCommand
-	14, // 11: v1.OperationEvent.keep_alive:type_name -> types.Empty
-	2,  // 12: v1.OperationEvent.created_operations:type_name -> v1.OperationList
-	2,  // 13: v1.OperationEvent.updated_operations:type_name -> v1.OperationList
-	15, // 14: v1.OperationEvent.deleted_operations:type_name -> types.Int64List
-	16, // 15: v1.OperationBackup.last_status:type_name -> v1.BackupProgressEntry
-	17, // 16: v1.OperationBackup.errors:type_name -> v1.BackupProgressError
-	18, // 17: v1.OperationIndexSnapshot.snapshot:type_name -> v1.ResticSnapshot
-	18, // 18: v1.OperationForget.forget:type_name -> v1.ResticSnapshot
-	19, // 19: v1.OperationForget.policy:type_name -> v1.RetentionPolicy
-	20, // 20: v1.OperationRestore.last_status:type_name -> v1.RestoreProgressEntry
-	21, // 21: v1.OperationStats.stats:type_name -> v1.RepoStats
-	22, // 22: v1.OperationRunHook.condition:type_name -> v1.Hook.Condition
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	6,  // 2: v1.Operation.operation_backup:type_name -> v1.OperationBackup
+	7,  // 3: v1.Operation.operation_index_snapshot:type_name -> v1.OperationIndexSnapshot
+	8,  // 4: v1.Operation.operation_forget:type_name -> v1.OperationForget
+	9,  // 5: v1.Operation.operation_prune:type_name -> v1.OperationPrune
+	17, // 6: v1.Operation.operation_restore:type_name -> v1.OperationRestore
+	19, // 7: v1.Operation.operation_stats:type_name -> v1.OperationStats
+	20, // 8: v1.Operation.operation_run_hook:type_name -> v1.OperationRunHook
+	10, // 9: v1.Operation.operation_check:type_name -> v1.OperationCheck
+	16, // 10: v1.Operation.operation_run_command:type_name -> v1.OperationRunCommand
+	11, // 11: v1.Operation.operation_copy:type_name -> v1.OperationCopy
+	14, // 12: v1.Operation.operation_find_files:type_name -> v1.OperationFindFiles
+	12, // 13: v1.Operation.operation_restore_test:type_name -> v1.OperationRestoreTest
+	21, // 14: v1.OperationEvent.keep_alive:type_name -> types.Empty
+	3,  // 15: v1.OperationEvent.created_operations:type_name -> v1.OperationList
+	3,  // 16: v1.OperationEvent.updated_operations:type_name -> v1.OperationList
+	22, // 17: v1.OperationEvent.deleted_operations:type_name -> types.Int64List
+	23, // 18: v1.OperationBackup.last_status:type_name -> v1.BackupProgressEntry
+	24, // 19: v1.OperationBackup.errors:type_name -> v1.BackupProgressError
+	25, // 20: v1.OperationIndexSnapshot.snapshot:type_name -> v1.ResticSnapshot
+	25, // 21: v1.OperationForget.forget:type_name -> v1.ResticSnapshot
+	26, // 22: v1.OperationForget.policy:type_name -> v1.RetentionPolicy
+	27, // 23: v1.OperationPrune.stats:type_name -> v1.PruneStats
+	13, // 24: v1.OperationRestoreTest.failures:type_name -> v1.RestoreTestFailure
+	15, // 25: v1.OperationFindFiles.query:type_name -> v1.FindFilesQuery
+	28, // 26: v1.OperationRestore.last_status:type_name -> v1.RestoreProgressEntry
+	18, // 27: v1.OperationRestore.options:type_name -> v1.RestoreOptions
+	2,  // 28: v1.RestoreOptions.overwrite:type_name -> v1.RestoreOptions.OverwriteMode
+	29, // 29: v1.OperationStats.stats:type_name -> v1.RepoStats
+	30, // 30: v1.OperationRunHook.condition:type_name -> v1.Hook.Condition
+	31, // [31:31] is the sub-list for method output_type
+	31, // [31:31] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_v1_operations_proto_init() }
@@ -1328,6 +1965,9 @@ func file_v1_operations_proto_init() {
 		(*Operation_OperationRunHook)(nil),
 		(*Operation_OperationCheck)(nil),
 		(*Operation_OperationRunCommand)(nil),
+		(*Operation_OperationCopy)(nil),
+		(*Operation_OperationFindFiles)(nil),
+		(*Operation_OperationRestoreTest)(nil),
 	}
 	file_v1_operations_proto_msgTypes[2].OneofWrappers = []any{
 		(*OperationEvent_KeepAlive)(nil),
@@ -1340,8 +1980,8 @@ func file_v1_operations_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_operations_proto_rawDesc), len(file_v1_operations_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
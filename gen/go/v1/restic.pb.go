@@ -688,7 +688,10 @@ type RestoreProgressEntry struct {
 	BytesRestored  int64                  `protobuf:"varint,4,opt,name=bytes_restored,json=bytesRestored,proto3" json:"bytes_restored,omitempty"`
 	TotalFiles     int64                  `protobuf:"varint,5,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
 	FilesRestored  int64                  `protobuf:"varint,6,opt,name=files_restored,json=filesRestored,proto3" json:"files_restored,omitempty"`
-	PercentDone    float64                `protobuf:"fixed64,7,opt,name=percent_done,json=percentDone,proto3" json:"percent_done,omitempty"` // 0.0 - 1.0
+	PercentDone    float64                `protobuf:"fixed64,7,opt,name=percent_done,json=percentDone,proto3" json:"percent_done,omitempty"`   // 0.0 - 1.0
+	FilesSkipped   int64                  `protobuf:"varint,8,opt,name=files_skipped,json=filesSkipped,proto3" json:"files_skipped,omitempty"` // files left unchanged because they already existed in the target.
+	BytesSkipped   int64                  `protobuf:"varint,9,opt,name=bytes_skipped,json=bytesSkipped,proto3" json:"bytes_skipped,omitempty"`
+	FilesDeleted   int64                  `protobuf:"varint,10,opt,name=files_deleted,json=filesDeleted,proto3" json:"files_deleted,omitempty"` // files deleted from the target, see RestoreOptions.delete.
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -772,6 +775,258 @@ func (x *RestoreProgressEntry) GetPercentDone() float64 {
 	return 0
 }
 
+func (x *RestoreProgressEntry) GetFilesSkipped() int64 {
+	if x != nil {
+		return x.FilesSkipped
+	}
+	return 0
+}
+
+func (x *RestoreProgressEntry) GetBytesSkipped() int64 {
+	if x != nil {
+		return x.BytesSkipped
+	}
+	return 0
+}
+
+func (x *RestoreProgressEntry) GetFilesDeleted() int64 {
+	if x != nil {
+		return x.FilesDeleted
+	}
+	return 0
+}
+
+// SnapshotDiffStats summarizes the changes between two snapshots as reported by `restic diff`. Sizes are only reported
+// in aggregate, not per changed entry.
+type SnapshotDiffStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangedFiles  int64                  `protobuf:"varint,1,opt,name=changed_files,json=changedFiles,proto3" json:"changed_files,omitempty"`
+	Added         *SnapshotDiffCounts    `protobuf:"bytes,2,opt,name=added,proto3" json:"added,omitempty"`
+	Removed       *SnapshotDiffCounts    `protobuf:"bytes,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	BytesDelta    int64                  `protobuf:"varint,4,opt,name=bytes_delta,json=bytesDelta,proto3" json:"bytes_delta,omitempty"` // bytes added minus bytes removed, negative if the data shrank.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotDiffStats) Reset() {
+	*x = SnapshotDiffStats{}
+	mi := &file_v1_restic_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotDiffStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotDiffStats) ProtoMessage() {}
+
+func (x *SnapshotDiffStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_restic_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotDiffStats.ProtoReflect.Descriptor instead.
+func (*SnapshotDiffStats) Descriptor() ([]byte, []int) {
+	return file_v1_restic_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SnapshotDiffStats) GetChangedFiles() int64 {
+	if x != nil {
+		return x.ChangedFiles
+	}
+	return 0
+}
+
+func (x *SnapshotDiffStats) GetAdded() *SnapshotDiffCounts {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *SnapshotDiffStats) GetRemoved() *SnapshotDiffCounts {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *SnapshotDiffStats) GetBytesDelta() int64 {
+	if x != nil {
+		return x.BytesDelta
+	}
+	return 0
+}
+
+type SnapshotDiffCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         int64                  `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	Dirs          int64                  `protobuf:"varint,2,opt,name=dirs,proto3" json:"dirs,omitempty"`
+	Others        int64                  `protobuf:"varint,3,opt,name=others,proto3" json:"others,omitempty"`
+	DataBlobs     int64                  `protobuf:"varint,4,opt,name=data_blobs,json=dataBlobs,proto3" json:"data_blobs,omitempty"`
+	TreeBlobs     int64                  `protobuf:"varint,5,opt,name=tree_blobs,json=treeBlobs,proto3" json:"tree_blobs,omitempty"`
+	Bytes         int64                  `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotDiffCounts) Reset() {
+	*x = SnapshotDiffCounts{}
+	mi := &file_v1_restic_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotDiffCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotDiffCounts) ProtoMessage() {}
+
+func (x *SnapshotDiffCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_restic_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotDiffCounts.ProtoReflect.Descriptor instead.
+func (*SnapshotDiffCounts) Descriptor() ([]byte, []int) {
+	return file_v1_restic_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SnapshotDiffCounts) GetFiles() int64 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *SnapshotDiffCounts) GetDirs() int64 {
+	if x != nil {
+		return x.Dirs
+	}
+	return 0
+}
+
+func (x *SnapshotDiffCounts) GetOthers() int64 {
+	if x != nil {
+		return x.Others
+	}
+	return 0
+}
+
+func (x *SnapshotDiffCounts) GetDataBlobs() int64 {
+	if x != nil {
+		return x.DataBlobs
+	}
+	return 0
+}
+
+func (x *SnapshotDiffCounts) GetTreeBlobs() int64 {
+	if x != nil {
+		return x.TreeBlobs
+	}
+	return 0
+}
+
+func (x *SnapshotDiffCounts) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// FileMatch is a file found in a snapshot by `restic find`.
+type FileMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SnapshotId    string                 `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // "file", "dir", "symlink", etc.
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Mtime         string                 `protobuf:"bytes,5,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileMatch) Reset() {
+	*x = FileMatch{}
+	mi := &file_v1_restic_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileMatch) ProtoMessage() {}
+
+func (x *FileMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_restic_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMatch.ProtoReflect.Descriptor instead.
+func (*FileMatch) Descriptor() ([]byte, []int) {
+	return file_v1_restic_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FileMatch) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *FileMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileMatch) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FileMatch) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileMatch) GetMtime() string {
+	if x != nil {
+		return x.Mtime
+	}
+	return ""
+}
+
 type RepoStats struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	TotalSize             int64                  `protobuf:"varint,1,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
@@ -785,7 +1040,7 @@ type RepoStats struct {
 
 func (x *RepoStats) Reset() {
 	*x = RepoStats{}
-	mi := &file_v1_restic_proto_msgTypes[8]
+	mi := &file_v1_restic_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -797,7 +1052,7 @@ func (x *RepoStats) String() string {
 func (*RepoStats) ProtoMessage() {}
 
 func (x *RepoStats) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_restic_proto_msgTypes[8]
+	mi := &file_v1_restic_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -810,7 +1065,7 @@ func (x *RepoStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepoStats.ProtoReflect.Descriptor instead.
 func (*RepoStats) Descriptor() ([]byte, []int) {
-	return file_v1_restic_proto_rawDescGZIP(), []int{8}
+	return file_v1_restic_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RepoStats) GetTotalSize() int64 {
@@ -848,6 +1103,216 @@ func (x *RepoStats) GetSnapshotCount() int64 {
 	return 0
 }
 
+// PruneStats is the summary of a restic prune, sizes are in bytes.
+type PruneStats struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	PacksToKeep           int64                  `protobuf:"varint,1,opt,name=packs_to_keep,json=packsToKeep,proto3" json:"packs_to_keep,omitempty"`
+	PacksToRepack         int64                  `protobuf:"varint,2,opt,name=packs_to_repack,json=packsToRepack,proto3" json:"packs_to_repack,omitempty"`
+	PacksToDelete         int64                  `protobuf:"varint,3,opt,name=packs_to_delete,json=packsToDelete,proto3" json:"packs_to_delete,omitempty"`
+	BlobsToRepack         int64                  `protobuf:"varint,4,opt,name=blobs_to_repack,json=blobsToRepack,proto3" json:"blobs_to_repack,omitempty"`
+	BytesToRepack         int64                  `protobuf:"varint,5,opt,name=bytes_to_repack,json=bytesToRepack,proto3" json:"bytes_to_repack,omitempty"`
+	BlobsToDelete         int64                  `protobuf:"varint,6,opt,name=blobs_to_delete,json=blobsToDelete,proto3" json:"blobs_to_delete,omitempty"`
+	BytesToDelete         int64                  `protobuf:"varint,7,opt,name=bytes_to_delete,json=bytesToDelete,proto3" json:"bytes_to_delete,omitempty"`
+	BlobsPruned           int64                  `protobuf:"varint,8,opt,name=blobs_pruned,json=blobsPruned,proto3" json:"blobs_pruned,omitempty"` // blobs deleted or removed by repacking.
+	BytesPruned           int64                  `protobuf:"varint,9,opt,name=bytes_pruned,json=bytesPruned,proto3" json:"bytes_pruned,omitempty"` // the space freed by the prune.
+	RemainingBlobs        int64                  `protobuf:"varint,10,opt,name=remaining_blobs,json=remainingBlobs,proto3" json:"remaining_blobs,omitempty"`
+	RemainingBytes        int64                  `protobuf:"varint,11,opt,name=remaining_bytes,json=remainingBytes,proto3" json:"remaining_bytes,omitempty"`
+	UnusedBytesAfterPrune int64                  `protobuf:"varint,12,opt,name=unused_bytes_after_prune,json=unusedBytesAfterPrune,proto3" json:"unused_bytes_after_prune,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *PruneStats) Reset() {
+	*x = PruneStats{}
+	mi := &file_v1_restic_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneStats) ProtoMessage() {}
+
+func (x *PruneStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_restic_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneStats.ProtoReflect.Descriptor instead.
+func (*PruneStats) Descriptor() ([]byte, []int) {
+	return file_v1_restic_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PruneStats) GetPacksToKeep() int64 {
+	if x != nil {
+		return x.PacksToKeep
+	}
+	return 0
+}
+
+func (x *PruneStats) GetPacksToRepack() int64 {
+	if x != nil {
+		return x.PacksToRepack
+	}
+	return 0
+}
+
+func (x *PruneStats) GetPacksToDelete() int64 {
+	if x != nil {
+		return x.PacksToDelete
+	}
+	return 0
+}
+
+func (x *PruneStats) GetBlobsToRepack() int64 {
+	if x != nil {
+		return x.BlobsToRepack
+	}
+	return 0
+}
+
+func (x *PruneStats) GetBytesToRepack() int64 {
+	if x != nil {
+		return x.BytesToRepack
+	}
+	return 0
+}
+
+func (x *PruneStats) GetBlobsToDelete() int64 {
+	if x != nil {
+		return x.BlobsToDelete
+	}
+	return 0
+}
+
+func (x *PruneStats) GetBytesToDelete() int64 {
+	if x != nil {
+		return x.BytesToDelete
+	}
+	return 0
+}
+
+func (x *PruneStats) GetBlobsPruned() int64 {
+	if x != nil {
+		return x.BlobsPruned
+	}
+	return 0
+}
+
+func (x *PruneStats) GetBytesPruned() int64 {
+	if x != nil {
+		return x.BytesPruned
+	}
+	return 0
+}
+
+func (x *PruneStats) GetRemainingBlobs() int64 {
+	if x != nil {
+		return x.RemainingBlobs
+	}
+	return 0
+}
+
+func (x *PruneStats) GetRemainingBytes() int64 {
+	if x != nil {
+		return x.RemainingBytes
+	}
+	return 0
+}
+
+func (x *PruneStats) GetUnusedBytesAfterPrune() int64 {
+	if x != nil {
+		return x.UnusedBytesAfterPrune
+	}
+	return 0
+}
+
+// RepoKey is a key (password) that can open a repo, see `restic key list`.
+type RepoKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Current       bool                   `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"` // true if this is the key backrest uses to open the repo.
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Hostname      string                 `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Created       string                 `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"` // creation time formatted by restic e.g. "2024-01-02 15:04:05".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepoKey) Reset() {
+	*x = RepoKey{}
+	mi := &file_v1_restic_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepoKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoKey) ProtoMessage() {}
+
+func (x *RepoKey) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_restic_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoKey.ProtoReflect.Descriptor instead.
+func (*RepoKey) Descriptor() ([]byte, []int) {
+	return file_v1_restic_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RepoKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RepoKey) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *RepoKey) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RepoKey) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *RepoKey) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
 var File_v1_restic_proto protoreflect.FileDescriptor
 
 const file_v1_restic_proto_rawDesc = "" +
@@ -920,7 +1385,7 @@ const file_v1_restic_proto_rawDesc = "" +
 	"\x13BackupProgressError\x12\x12\n" +
 	"\x04item\x18\x01 \x01(\tR\x04item\x12\x16\n" +
 	"\x06during\x18\x02 \x01(\tR\x06during\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x95\x02\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x84\x03\n" +
 	"\x14RestoreProgressEntry\x12!\n" +
 	"\fmessage_type\x18\x01 \x01(\tR\vmessageType\x12'\n" +
 	"\x0fseconds_elapsed\x18\x02 \x01(\x01R\x0esecondsElapsed\x12\x1f\n" +
@@ -930,14 +1395,61 @@ const file_v1_restic_proto_rawDesc = "" +
 	"\vtotal_files\x18\x05 \x01(\x03R\n" +
 	"totalFiles\x12%\n" +
 	"\x0efiles_restored\x18\x06 \x01(\x03R\rfilesRestored\x12!\n" +
-	"\fpercent_done\x18\a \x01(\x01R\vpercentDone\"\xe0\x01\n" +
+	"\fpercent_done\x18\a \x01(\x01R\vpercentDone\x12#\n" +
+	"\rfiles_skipped\x18\b \x01(\x03R\ffilesSkipped\x12#\n" +
+	"\rbytes_skipped\x18\t \x01(\x03R\fbytesSkipped\x12#\n" +
+	"\rfiles_deleted\x18\n" +
+	" \x01(\x03R\ffilesDeleted\"\xb9\x01\n" +
+	"\x11SnapshotDiffStats\x12#\n" +
+	"\rchanged_files\x18\x01 \x01(\x03R\fchangedFiles\x12,\n" +
+	"\x05added\x18\x02 \x01(\v2\x16.v1.SnapshotDiffCountsR\x05added\x120\n" +
+	"\aremoved\x18\x03 \x01(\v2\x16.v1.SnapshotDiffCountsR\aremoved\x12\x1f\n" +
+	"\vbytes_delta\x18\x04 \x01(\x03R\n" +
+	"bytesDelta\"\xaa\x01\n" +
+	"\x12SnapshotDiffCounts\x12\x14\n" +
+	"\x05files\x18\x01 \x01(\x03R\x05files\x12\x12\n" +
+	"\x04dirs\x18\x02 \x01(\x03R\x04dirs\x12\x16\n" +
+	"\x06others\x18\x03 \x01(\x03R\x06others\x12\x1d\n" +
+	"\n" +
+	"data_blobs\x18\x04 \x01(\x03R\tdataBlobs\x12\x1d\n" +
+	"\n" +
+	"tree_blobs\x18\x05 \x01(\x03R\ttreeBlobs\x12\x14\n" +
+	"\x05bytes\x18\x06 \x01(\x03R\x05bytes\"~\n" +
+	"\tFileMatch\x12\x1f\n" +
+	"\vsnapshot_id\x18\x01 \x01(\tR\n" +
+	"snapshotId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05mtime\x18\x05 \x01(\tR\x05mtime\"\xe0\x01\n" +
 	"\tRepoStats\x12\x1d\n" +
 	"\n" +
 	"total_size\x18\x01 \x01(\x03R\ttotalSize\x126\n" +
 	"\x17total_uncompressed_size\x18\x02 \x01(\x03R\x15totalUncompressedSize\x12+\n" +
 	"\x11compression_ratio\x18\x03 \x01(\x01R\x10compressionRatio\x12(\n" +
 	"\x10total_blob_count\x18\x05 \x01(\x03R\x0etotalBlobCount\x12%\n" +
-	"\x0esnapshot_count\x18\x06 \x01(\x03R\rsnapshotCountB,Z*github.com/garethgeorge/backrest/gen/go/v1b\x06proto3"
+	"\x0esnapshot_count\x18\x06 \x01(\x03R\rsnapshotCount\"\xf1\x03\n" +
+	"\n" +
+	"PruneStats\x12\"\n" +
+	"\rpacks_to_keep\x18\x01 \x01(\x03R\vpacksToKeep\x12&\n" +
+	"\x0fpacks_to_repack\x18\x02 \x01(\x03R\rpacksToRepack\x12&\n" +
+	"\x0fpacks_to_delete\x18\x03 \x01(\x03R\rpacksToDelete\x12&\n" +
+	"\x0fblobs_to_repack\x18\x04 \x01(\x03R\rblobsToRepack\x12&\n" +
+	"\x0fbytes_to_repack\x18\x05 \x01(\x03R\rbytesToRepack\x12&\n" +
+	"\x0fblobs_to_delete\x18\x06 \x01(\x03R\rblobsToDelete\x12&\n" +
+	"\x0fbytes_to_delete\x18\a \x01(\x03R\rbytesToDelete\x12!\n" +
+	"\fblobs_pruned\x18\b \x01(\x03R\vblobsPruned\x12!\n" +
+	"\fbytes_pruned\x18\t \x01(\x03R\vbytesPruned\x12'\n" +
+	"\x0fremaining_blobs\x18\n" +
+	" \x01(\x03R\x0eremainingBlobs\x12'\n" +
+	"\x0fremaining_bytes\x18\v \x01(\x03R\x0eremainingBytes\x127\n" +
+	"\x18unused_bytes_after_prune\x18\f \x01(\x03R\x15unusedBytesAfterPrune\"\x85\x01\n" +
+	"\aRepoKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\bR\acurrent\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\tR\acreatedB,Z*github.com/garethgeorge/backrest/gen/go/v1b\x06proto3"
 
 var (
 	file_v1_restic_proto_rawDescOnce sync.Once
@@ -951,7 +1463,7 @@ func file_v1_restic_proto_rawDescGZIP() []byte {
 	return file_v1_restic_proto_rawDescData
 }
 
-var file_v1_restic_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_v1_restic_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_v1_restic_proto_goTypes = []any{
 	(*ResticSnapshot)(nil),            // 0: v1.ResticSnapshot
 	(*SnapshotSummary)(nil),           // 1: v1.SnapshotSummary
@@ -961,18 +1473,25 @@ var file_v1_restic_proto_goTypes = []any{
 	(*BackupProgressSummary)(nil),     // 5: v1.BackupProgressSummary
 	(*BackupProgressError)(nil),       // 6: v1.BackupProgressError
 	(*RestoreProgressEntry)(nil),      // 7: v1.RestoreProgressEntry
-	(*RepoStats)(nil),                 // 8: v1.RepoStats
+	(*SnapshotDiffStats)(nil),         // 8: v1.SnapshotDiffStats
+	(*SnapshotDiffCounts)(nil),        // 9: v1.SnapshotDiffCounts
+	(*FileMatch)(nil),                 // 10: v1.FileMatch
+	(*RepoStats)(nil),                 // 11: v1.RepoStats
+	(*PruneStats)(nil),                // 12: v1.PruneStats
+	(*RepoKey)(nil),                   // 13: v1.RepoKey
 }
 var file_v1_restic_proto_depIdxs = []int32{
 	1, // 0: v1.ResticSnapshot.summary:type_name -> v1.SnapshotSummary
 	0, // 1: v1.ResticSnapshotList.snapshots:type_name -> v1.ResticSnapshot
 	4, // 2: v1.BackupProgressEntry.status:type_name -> v1.BackupProgressStatusEntry
 	5, // 3: v1.BackupProgressEntry.summary:type_name -> v1.BackupProgressSummary
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	9, // 4: v1.SnapshotDiffStats.added:type_name -> v1.SnapshotDiffCounts
+	9, // 5: v1.SnapshotDiffStats.removed:type_name -> v1.SnapshotDiffCounts
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_v1_restic_proto_init() }
@@ -990,7 +1509,7 @@ func file_v1_restic_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_restic_proto_rawDesc), len(file_v1_restic_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
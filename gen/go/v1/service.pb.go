@@ -34,6 +34,7 @@ const (
 	DoRepoTaskRequest_TASK_STATS           DoRepoTaskRequest_Task = 4
 	DoRepoTaskRequest_TASK_UNLOCK          DoRepoTaskRequest_Task = 5
 	DoRepoTaskRequest_TASK_FORGET          DoRepoTaskRequest_Task = 6
+	DoRepoTaskRequest_TASK_COPY            DoRepoTaskRequest_Task = 7
 )
 
 // Enum value maps for DoRepoTaskRequest_Task.
@@ -46,6 +47,7 @@ var (
 		4: "TASK_STATS",
 		5: "TASK_UNLOCK",
 		6: "TASK_FORGET",
+		7: "TASK_COPY",
 	}
 	DoRepoTaskRequest_Task_value = map[string]int32{
 		"TASK_NONE":            0,
@@ -55,6 +57,7 @@ var (
 		"TASK_STATS":           4,
 		"TASK_UNLOCK":          5,
 		"TASK_FORGET":          6,
+		"TASK_COPY":            7,
 	}
 )
 
@@ -619,6 +622,266 @@ func (x *DoRepoTaskRequest) GetTask() DoRepoTaskRequest_Task {
 	return DoRepoTaskRequest_TASK_NONE
 }
 
+type ListRepoKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRepoKeysRequest) Reset() {
+	*x = ListRepoKeysRequest{}
+	mi := &file_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRepoKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepoKeysRequest) ProtoMessage() {}
+
+func (x *ListRepoKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepoKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListRepoKeysRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRepoKeysRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+type ListRepoKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*RepoKey             `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRepoKeysResponse) Reset() {
+	*x = ListRepoKeysResponse{}
+	mi := &file_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRepoKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepoKeysResponse) ProtoMessage() {}
+
+func (x *ListRepoKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepoKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListRepoKeysResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRepoKeysResponse) GetKeys() []*RepoKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type AddRepoKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"` // optional, username recorded with the key.
+	Hostname      string                 `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"` // optional, hostname recorded with the key.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRepoKeyRequest) Reset() {
+	*x = AddRepoKeyRequest{}
+	mi := &file_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRepoKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRepoKeyRequest) ProtoMessage() {}
+
+func (x *AddRepoKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRepoKeyRequest.ProtoReflect.Descriptor instead.
+func (*AddRepoKeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddRepoKeyRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *AddRepoKeyRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *AddRepoKeyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AddRepoKeyRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type RemoveRepoKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRepoKeyRequest) Reset() {
+	*x = RemoveRepoKeyRequest{}
+	mi := &file_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRepoKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRepoKeyRequest) ProtoMessage() {}
+
+func (x *RemoveRepoKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRepoKeyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRepoKeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveRepoKeyRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *RemoveRepoKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type RotateRepoPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateRepoPasswordRequest) Reset() {
+	*x = RotateRepoPasswordRequest{}
+	mi := &file_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateRepoPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateRepoPasswordRequest) ProtoMessage() {}
+
+func (x *RotateRepoPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateRepoPasswordRequest.ProtoReflect.Descriptor instead.
+func (*RotateRepoPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RotateRepoPasswordRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *RotateRepoPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
 type ClearHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Selector      *OpSelector            `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
@@ -629,7 +892,7 @@ type ClearHistoryRequest struct {
 
 func (x *ClearHistoryRequest) Reset() {
 	*x = ClearHistoryRequest{}
-	mi := &file_v1_service_proto_msgTypes[9]
+	mi := &file_v1_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -641,7 +904,7 @@ func (x *ClearHistoryRequest) String() string {
 func (*ClearHistoryRequest) ProtoMessage() {}
 
 func (x *ClearHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[9]
+	mi := &file_v1_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -654,7 +917,7 @@ func (x *ClearHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearHistoryRequest.ProtoReflect.Descriptor instead.
 func (*ClearHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{9}
+	return file_v1_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ClearHistoryRequest) GetSelector() *OpSelector {
@@ -682,7 +945,7 @@ type ForgetRequest struct {
 
 func (x *ForgetRequest) Reset() {
 	*x = ForgetRequest{}
-	mi := &file_v1_service_proto_msgTypes[10]
+	mi := &file_v1_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -694,7 +957,7 @@ func (x *ForgetRequest) String() string {
 func (*ForgetRequest) ProtoMessage() {}
 
 func (x *ForgetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[10]
+	mi := &file_v1_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +970,7 @@ func (x *ForgetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForgetRequest.ProtoReflect.Descriptor instead.
 func (*ForgetRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{10}
+	return file_v1_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ForgetRequest) GetRepoId() string {
@@ -731,29 +994,29 @@ func (x *ForgetRequest) GetSnapshotId() string {
 	return ""
 }
 
-type ListSnapshotsRequest struct {
+type PinSnapshotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
-	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSnapshotsRequest) Reset() {
-	*x = ListSnapshotsRequest{}
-	mi := &file_v1_service_proto_msgTypes[11]
+func (x *PinSnapshotRequest) Reset() {
+	*x = PinSnapshotRequest{}
+	mi := &file_v1_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSnapshotsRequest) String() string {
+func (x *PinSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSnapshotsRequest) ProtoMessage() {}
+func (*PinSnapshotRequest) ProtoMessage() {}
 
-func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[11]
+func (x *PinSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,48 +1027,48 @@ func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
-func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use PinSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*PinSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListSnapshotsRequest) GetRepoId() string {
+func (x *PinSnapshotRequest) GetRepoId() string {
 	if x != nil {
 		return x.RepoId
 	}
 	return ""
 }
 
-func (x *ListSnapshotsRequest) GetPlanId() string {
+func (x *PinSnapshotRequest) GetSnapshotId() string {
 	if x != nil {
-		return x.PlanId
+		return x.SnapshotId
 	}
 	return ""
 }
 
-type GetOperationsRequest struct {
+type UnpinSnapshotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Selector      *OpSelector            `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
-	LastN         int64                  `protobuf:"varint,2,opt,name=last_n,json=lastN,proto3" json:"last_n,omitempty"` // limit to the last n operations
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOperationsRequest) Reset() {
-	*x = GetOperationsRequest{}
-	mi := &file_v1_service_proto_msgTypes[12]
+func (x *UnpinSnapshotRequest) Reset() {
+	*x = UnpinSnapshotRequest{}
+	mi := &file_v1_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOperationsRequest) String() string {
+func (x *UnpinSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOperationsRequest) ProtoMessage() {}
+func (*UnpinSnapshotRequest) ProtoMessage() {}
 
-func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[12]
+func (x *UnpinSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,21 +1079,245 @@ func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOperationsRequest.ProtoReflect.Descriptor instead.
-func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UnpinSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*UnpinSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetOperationsRequest) GetSelector() *OpSelector {
+func (x *UnpinSnapshotRequest) GetRepoId() string {
 	if x != nil {
-		return x.Selector
+		return x.RepoId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetOperationsRequest) GetLastN() int64 {
+func (x *UnpinSnapshotRequest) GetSnapshotId() string {
 	if x != nil {
-		return x.LastN
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type PreviewForgetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`    // optional, previews the plan's snapshots. If empty, previews the repo's forget policy scope i.e. all snapshots grouped by tags.
+	Retention     *RetentionPolicy       `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`            // the proposed retention policy, need not be saved in the config.
+	GroupBy       string                 `protobuf:"bytes,4,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"` // optional, the proposed forget policy group by when previewing the repo's forget policy. Defaults to the repo's configured group by.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewForgetRequest) Reset() {
+	*x = PreviewForgetRequest{}
+	mi := &file_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewForgetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewForgetRequest) ProtoMessage() {}
+
+func (x *PreviewForgetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewForgetRequest.ProtoReflect.Descriptor instead.
+func (*PreviewForgetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PreviewForgetRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *PreviewForgetRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *PreviewForgetRequest) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *PreviewForgetRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+type PreviewForgetResponse struct {
+	state         protoimpl.MessageState                `protogen:"open.v1"`
+	Keep          []*PreviewForgetResponse_KeptSnapshot `protobuf:"bytes,1,rep,name=keep,proto3" json:"keep,omitempty"`     // snapshots the policy would keep.
+	Remove        []*ResticSnapshot                     `protobuf:"bytes,2,rep,name=remove,proto3" json:"remove,omitempty"` // snapshots the policy would remove.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewForgetResponse) Reset() {
+	*x = PreviewForgetResponse{}
+	mi := &file_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewForgetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewForgetResponse) ProtoMessage() {}
+
+func (x *PreviewForgetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewForgetResponse.ProtoReflect.Descriptor instead.
+func (*PreviewForgetResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PreviewForgetResponse) GetKeep() []*PreviewForgetResponse_KeptSnapshot {
+	if x != nil {
+		return x.Keep
+	}
+	return nil
+}
+
+func (x *PreviewForgetResponse) GetRemove() []*ResticSnapshot {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListSnapshotsRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *ListSnapshotsRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type GetOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selector      *OpSelector            `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	LastN         int64                  `protobuf:"varint,2,opt,name=last_n,json=lastN,proto3" json:"last_n,omitempty"` // limit to the last n operations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationsRequest) Reset() {
+	*x = GetOperationsRequest{}
+	mi := &file_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationsRequest) ProtoMessage() {}
+
+func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationsRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetOperationsRequest) GetSelector() *OpSelector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *GetOperationsRequest) GetLastN() int64 {
+	if x != nil {
+		return x.LastN
 	}
 	return 0
 }
@@ -842,25 +1329,331 @@ type RestoreSnapshotRequest struct {
 	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
 	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
 	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Options       *RestoreOptions        `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"` // optional, defaults to restoring everything into a new target.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotRequest) Reset() {
+	*x = RestoreSnapshotRequest{}
+	mi := &file_v1_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotRequest) ProtoMessage() {}
+
+func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RestoreSnapshotRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetOptions() *RestoreOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ListSnapshotFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotFilesRequest) Reset() {
+	*x = ListSnapshotFilesRequest{}
+	mi := &file_v1_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotFilesRequest) ProtoMessage() {}
+
+func (x *ListSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotFilesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListSnapshotFilesRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *ListSnapshotFilesRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *ListSnapshotFilesRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListSnapshotFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Entries       []*LsEntry             `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotFilesResponse) Reset() {
+	*x = ListSnapshotFilesResponse{}
+	mi := &file_v1_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotFilesResponse) ProtoMessage() {}
+
+func (x *ListSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotFilesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSnapshotFilesResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ListSnapshotFilesResponse) GetEntries() []*LsEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type FindFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	Query         *FindFilesQuery        `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindFilesRequest) Reset() {
+	*x = FindFilesRequest{}
+	mi := &file_v1_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindFilesRequest) ProtoMessage() {}
+
+func (x *FindFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindFilesRequest.ProtoReflect.Descriptor instead.
+func (*FindFilesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FindFilesRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *FindFilesRequest) GetQuery() *FindFilesQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type GetFindFilesResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationId   int64                  `protobuf:"varint,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"` // ID of the FindFiles operation.
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                              // number of matches to skip.
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                // max number of matches to return, defaults to 100 and is capped at 1000.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFindFilesResultsRequest) Reset() {
+	*x = GetFindFilesResultsRequest{}
+	mi := &file_v1_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFindFilesResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFindFilesResultsRequest) ProtoMessage() {}
+
+func (x *GetFindFilesResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFindFilesResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetFindFilesResultsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetFindFilesResultsRequest) GetOperationId() int64 {
+	if x != nil {
+		return x.OperationId
+	}
+	return 0
+}
+
+func (x *GetFindFilesResultsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetFindFilesResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetFindFilesResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*FileMatch           `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                             // total number of matches found by the operation.
+	NextOffset    int64                  `protobuf:"varint,3,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"` // offset of the next page, or 0 if there are no more matches.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreSnapshotRequest) Reset() {
-	*x = RestoreSnapshotRequest{}
-	mi := &file_v1_service_proto_msgTypes[13]
+func (x *GetFindFilesResultsResponse) Reset() {
+	*x = GetFindFilesResultsResponse{}
+	mi := &file_v1_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreSnapshotRequest) String() string {
+func (x *GetFindFilesResultsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreSnapshotRequest) ProtoMessage() {}
+func (*GetFindFilesResultsResponse) ProtoMessage() {}
 
-func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[13]
+func (x *GetFindFilesResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,70 +1664,57 @@ func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
-func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *RestoreSnapshotRequest) GetPlanId() string {
-	if x != nil {
-		return x.PlanId
-	}
-	return ""
-}
-
-func (x *RestoreSnapshotRequest) GetRepoId() string {
-	if x != nil {
-		return x.RepoId
-	}
-	return ""
+// Deprecated: Use GetFindFilesResultsResponse.ProtoReflect.Descriptor instead.
+func (*GetFindFilesResultsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *RestoreSnapshotRequest) GetSnapshotId() string {
+func (x *GetFindFilesResultsResponse) GetMatches() []*FileMatch {
 	if x != nil {
-		return x.SnapshotId
+		return x.Matches
 	}
-	return ""
+	return nil
 }
 
-func (x *RestoreSnapshotRequest) GetPath() string {
+func (x *GetFindFilesResultsResponse) GetTotal() int64 {
 	if x != nil {
-		return x.Path
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-func (x *RestoreSnapshotRequest) GetTarget() string {
+func (x *GetFindFilesResultsResponse) GetNextOffset() int64 {
 	if x != nil {
-		return x.Target
+		return x.NextOffset
 	}
-	return ""
+	return 0
 }
 
-type ListSnapshotFilesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
-	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DiffSnapshotsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RepoId          string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	SnapshotId      string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`                  // snapshot to compare from e.g. the older snapshot.
+	OtherSnapshotId string                 `protobuf:"bytes,3,opt,name=other_snapshot_id,json=otherSnapshotId,proto3" json:"other_snapshot_id,omitempty"` // snapshot to compare to e.g. the newer snapshot.
+	Path            string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`                                                // optional, only compare entries under this path.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ListSnapshotFilesRequest) Reset() {
-	*x = ListSnapshotFilesRequest{}
-	mi := &file_v1_service_proto_msgTypes[14]
+func (x *DiffSnapshotsRequest) Reset() {
+	*x = DiffSnapshotsRequest{}
+	mi := &file_v1_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSnapshotFilesRequest) String() string {
+func (x *DiffSnapshotsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSnapshotFilesRequest) ProtoMessage() {}
+func (*DiffSnapshotsRequest) ProtoMessage() {}
 
-func (x *ListSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[14]
+func (x *DiffSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,55 +1725,65 @@ func (x *ListSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSnapshotFilesRequest.ProtoReflect.Descriptor instead.
-func (*ListSnapshotFilesRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use DiffSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*DiffSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ListSnapshotFilesRequest) GetRepoId() string {
+func (x *DiffSnapshotsRequest) GetRepoId() string {
 	if x != nil {
 		return x.RepoId
 	}
 	return ""
 }
 
-func (x *ListSnapshotFilesRequest) GetSnapshotId() string {
+func (x *DiffSnapshotsRequest) GetSnapshotId() string {
 	if x != nil {
 		return x.SnapshotId
 	}
 	return ""
 }
 
-func (x *ListSnapshotFilesRequest) GetPath() string {
+func (x *DiffSnapshotsRequest) GetOtherSnapshotId() string {
+	if x != nil {
+		return x.OtherSnapshotId
+	}
+	return ""
+}
+
+func (x *DiffSnapshotsRequest) GetPath() string {
 	if x != nil {
 		return x.Path
 	}
 	return ""
 }
 
-type ListSnapshotFilesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Entries       []*LsEntry             `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+type DiffSnapshotsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// logref of the changed entries, one JSON object per line e.g. {"path":"/etc/hosts","modifier":"M"}.
+	// Modifiers are "+" added, "-" removed, "M" content modified, "T" type changed, "U" metadata changed, "?" bitrot.
+	// Entries have no size, see stats.
+	Logref        string             `protobuf:"bytes,1,opt,name=logref,proto3" json:"logref,omitempty"`
+	Stats         *SnapshotDiffStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"` // totals of the diff, including the aggregate size delta in bytes_delta.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSnapshotFilesResponse) Reset() {
-	*x = ListSnapshotFilesResponse{}
-	mi := &file_v1_service_proto_msgTypes[15]
+func (x *DiffSnapshotsResponse) Reset() {
+	*x = DiffSnapshotsResponse{}
+	mi := &file_v1_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSnapshotFilesResponse) String() string {
+func (x *DiffSnapshotsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSnapshotFilesResponse) ProtoMessage() {}
+func (*DiffSnapshotsResponse) ProtoMessage() {}
 
-func (x *ListSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[15]
+func (x *DiffSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,21 +1794,21 @@ func (x *ListSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSnapshotFilesResponse.ProtoReflect.Descriptor instead.
-func (*ListSnapshotFilesResponse) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use DiffSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*DiffSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ListSnapshotFilesResponse) GetPath() string {
+func (x *DiffSnapshotsResponse) GetLogref() string {
 	if x != nil {
-		return x.Path
+		return x.Logref
 	}
 	return ""
 }
 
-func (x *ListSnapshotFilesResponse) GetEntries() []*LsEntry {
+func (x *DiffSnapshotsResponse) GetStats() *SnapshotDiffStats {
 	if x != nil {
-		return x.Entries
+		return x.Stats
 	}
 	return nil
 }
@@ -1032,7 +1822,7 @@ type LogDataRequest struct {
 
 func (x *LogDataRequest) Reset() {
 	*x = LogDataRequest{}
-	mi := &file_v1_service_proto_msgTypes[16]
+	mi := &file_v1_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1044,7 +1834,7 @@ func (x *LogDataRequest) String() string {
 func (*LogDataRequest) ProtoMessage() {}
 
 func (x *LogDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[16]
+	mi := &file_v1_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,7 +1847,7 @@ func (x *LogDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogDataRequest.ProtoReflect.Descriptor instead.
 func (*LogDataRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{16}
+	return file_v1_service_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *LogDataRequest) GetRef() string {
@@ -1077,7 +1867,7 @@ type GetDownloadURLRequest struct {
 
 func (x *GetDownloadURLRequest) Reset() {
 	*x = GetDownloadURLRequest{}
-	mi := &file_v1_service_proto_msgTypes[17]
+	mi := &file_v1_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1089,7 +1879,7 @@ func (x *GetDownloadURLRequest) String() string {
 func (*GetDownloadURLRequest) ProtoMessage() {}
 
 func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[17]
+	mi := &file_v1_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1892,7 @@ func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDownloadURLRequest.ProtoReflect.Descriptor instead.
 func (*GetDownloadURLRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{17}
+	return file_v1_service_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetDownloadURLRequest) GetOpId() int64 {
@@ -1137,7 +1927,7 @@ type LsEntry struct {
 
 func (x *LsEntry) Reset() {
 	*x = LsEntry{}
-	mi := &file_v1_service_proto_msgTypes[18]
+	mi := &file_v1_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1149,7 +1939,7 @@ func (x *LsEntry) String() string {
 func (*LsEntry) ProtoMessage() {}
 
 func (x *LsEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[18]
+	mi := &file_v1_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,7 +1952,7 @@ func (x *LsEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LsEntry.ProtoReflect.Descriptor instead.
 func (*LsEntry) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{18}
+	return file_v1_service_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *LsEntry) GetName() string {
@@ -1245,7 +2035,7 @@ type RunCommandRequest struct {
 
 func (x *RunCommandRequest) Reset() {
 	*x = RunCommandRequest{}
-	mi := &file_v1_service_proto_msgTypes[19]
+	mi := &file_v1_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1257,7 +2047,7 @@ func (x *RunCommandRequest) String() string {
 func (*RunCommandRequest) ProtoMessage() {}
 
 func (x *RunCommandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[19]
+	mi := &file_v1_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,7 +2060,7 @@ func (x *RunCommandRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunCommandRequest.ProtoReflect.Descriptor instead.
 func (*RunCommandRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{19}
+	return file_v1_service_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *RunCommandRequest) GetRepoId() string {
@@ -1296,7 +2086,7 @@ type RunCommandResponse struct {
 
 func (x *RunCommandResponse) Reset() {
 	*x = RunCommandResponse{}
-	mi := &file_v1_service_proto_msgTypes[20]
+	mi := &file_v1_service_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1308,7 +2098,7 @@ func (x *RunCommandResponse) String() string {
 func (*RunCommandResponse) ProtoMessage() {}
 
 func (x *RunCommandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[20]
+	mi := &file_v1_service_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1321,7 +2111,7 @@ func (x *RunCommandResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunCommandResponse.ProtoReflect.Descriptor instead.
 func (*RunCommandResponse) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{20}
+	return file_v1_service_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *RunCommandResponse) GetOperationId() int64 {
@@ -1331,6 +2121,134 @@ func (x *RunCommandResponse) GetOperationId() int64 {
 	return 0
 }
 
+type TestHookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hook          *Hook                  `protobuf:"bytes,1,opt,name=hook,proto3" json:"hook,omitempty"`                                   // the hook to fire, need not be saved in the config.
+	Condition     Hook_Condition         `protobuf:"varint,2,opt,name=condition,proto3,enum=v1.Hook_Condition" json:"condition,omitempty"` // the event to fire the hook for.
+	RepoId        string                 `protobuf:"bytes,3,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`                 // optional, defaults to the plan's repo.
+	PlanId        string                 `protobuf:"bytes,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                 // optional, variables are filled from the plan's most recent backup if it has one, otherwise with sample values.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestHookRequest) Reset() {
+	*x = TestHookRequest{}
+	mi := &file_v1_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestHookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestHookRequest) ProtoMessage() {}
+
+func (x *TestHookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestHookRequest.ProtoReflect.Descriptor instead.
+func (*TestHookRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TestHookRequest) GetHook() *Hook {
+	if x != nil {
+		return x.Hook
+	}
+	return nil
+}
+
+func (x *TestHookRequest) GetCondition() Hook_Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return Hook_CONDITION_UNKNOWN
+}
+
+func (x *TestHookRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *TestHookRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type TestHookResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RenderedTemplate string                 `protobuf:"bytes,1,opt,name=rendered_template,json=renderedTemplate,proto3" json:"rendered_template,omitempty"` // the hook's message template, or command, rendered with the test variables.
+	Output           string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`                                             // output logged by the hook e.g. a command's output.
+	Error            string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                               // the error returned by the hook, empty if it succeeded.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TestHookResponse) Reset() {
+	*x = TestHookResponse{}
+	mi := &file_v1_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestHookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestHookResponse) ProtoMessage() {}
+
+func (x *TestHookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestHookResponse.ProtoReflect.Descriptor instead.
+func (*TestHookResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TestHookResponse) GetRenderedTemplate() string {
+	if x != nil {
+		return x.RenderedTemplate
+	}
+	return ""
+}
+
+func (x *TestHookResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *TestHookResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type RemoveRepoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
@@ -1340,7 +2258,7 @@ type RemoveRepoRequest struct {
 
 func (x *RemoveRepoRequest) Reset() {
 	*x = RemoveRepoRequest{}
-	mi := &file_v1_service_proto_msgTypes[21]
+	mi := &file_v1_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1352,7 +2270,7 @@ func (x *RemoveRepoRequest) String() string {
 func (*RemoveRepoRequest) ProtoMessage() {}
 
 func (x *RemoveRepoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[21]
+	mi := &file_v1_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1365,7 +2283,7 @@ func (x *RemoveRepoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveRepoRequest.ProtoReflect.Descriptor instead.
 func (*RemoveRepoRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{21}
+	return file_v1_service_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *RemoveRepoRequest) GetRepoId() string {
@@ -1384,7 +2302,7 @@ type CancelOperationRequest struct {
 
 func (x *CancelOperationRequest) Reset() {
 	*x = CancelOperationRequest{}
-	mi := &file_v1_service_proto_msgTypes[22]
+	mi := &file_v1_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1396,7 +2314,7 @@ func (x *CancelOperationRequest) String() string {
 func (*CancelOperationRequest) ProtoMessage() {}
 
 func (x *CancelOperationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[22]
+	mi := &file_v1_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1409,7 +2327,7 @@ func (x *CancelOperationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOperationRequest.ProtoReflect.Descriptor instead.
 func (*CancelOperationRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{22}
+	return file_v1_service_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *CancelOperationRequest) GetOperationId() int64 {
@@ -1431,7 +2349,7 @@ type SummaryDashboardResponse struct {
 
 func (x *SummaryDashboardResponse) Reset() {
 	*x = SummaryDashboardResponse{}
-	mi := &file_v1_service_proto_msgTypes[23]
+	mi := &file_v1_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1443,7 +2361,7 @@ func (x *SummaryDashboardResponse) String() string {
 func (*SummaryDashboardResponse) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[23]
+	mi := &file_v1_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1456,7 +2374,7 @@ func (x *SummaryDashboardResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SummaryDashboardResponse.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{23}
+	return file_v1_service_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *SummaryDashboardResponse) GetRepoSummaries() []*SummaryDashboardResponse_Summary {
@@ -1499,7 +2417,7 @@ type GeneratePairingTokenRequest struct {
 
 func (x *GeneratePairingTokenRequest) Reset() {
 	*x = GeneratePairingTokenRequest{}
-	mi := &file_v1_service_proto_msgTypes[24]
+	mi := &file_v1_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1511,7 +2429,7 @@ func (x *GeneratePairingTokenRequest) String() string {
 func (*GeneratePairingTokenRequest) ProtoMessage() {}
 
 func (x *GeneratePairingTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[24]
+	mi := &file_v1_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,7 +2442,7 @@ func (x *GeneratePairingTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeneratePairingTokenRequest.ProtoReflect.Descriptor instead.
 func (*GeneratePairingTokenRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{24}
+	return file_v1_service_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *GeneratePairingTokenRequest) GetLabel() string {
@@ -1564,7 +2482,7 @@ type GeneratePairingTokenResponse struct {
 
 func (x *GeneratePairingTokenResponse) Reset() {
 	*x = GeneratePairingTokenResponse{}
-	mi := &file_v1_service_proto_msgTypes[25]
+	mi := &file_v1_service_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1576,7 +2494,7 @@ func (x *GeneratePairingTokenResponse) String() string {
 func (*GeneratePairingTokenResponse) ProtoMessage() {}
 
 func (x *GeneratePairingTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[25]
+	mi := &file_v1_service_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1589,7 +2507,7 @@ func (x *GeneratePairingTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeneratePairingTokenResponse.ProtoReflect.Descriptor instead.
 func (*GeneratePairingTokenResponse) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{25}
+	return file_v1_service_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *GeneratePairingTokenResponse) GetToken() string {
@@ -1599,6 +2517,58 @@ func (x *GeneratePairingTokenResponse) GetToken() string {
 	return ""
 }
 
+type PreviewForgetResponse_KeptSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *ResticSnapshot        `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"` // the keep rules the snapshot matched as reported by restic e.g. "daily snapshot".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewForgetResponse_KeptSnapshot) Reset() {
+	*x = PreviewForgetResponse_KeptSnapshot{}
+	mi := &file_v1_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewForgetResponse_KeptSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewForgetResponse_KeptSnapshot) ProtoMessage() {}
+
+func (x *PreviewForgetResponse_KeptSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewForgetResponse_KeptSnapshot.ProtoReflect.Descriptor instead.
+func (*PreviewForgetResponse_KeptSnapshot) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{19, 0}
+}
+
+func (x *PreviewForgetResponse_KeptSnapshot) GetSnapshot() *ResticSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *PreviewForgetResponse_KeptSnapshot) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
 type SummaryDashboardResponse_Summary struct {
 	state                     protoimpl.MessageState `protogen:"open.v1"`
 	Id                        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1621,7 +2591,7 @@ type SummaryDashboardResponse_Summary struct {
 
 func (x *SummaryDashboardResponse_Summary) Reset() {
 	*x = SummaryDashboardResponse_Summary{}
-	mi := &file_v1_service_proto_msgTypes[26]
+	mi := &file_v1_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1633,7 +2603,7 @@ func (x *SummaryDashboardResponse_Summary) String() string {
 func (*SummaryDashboardResponse_Summary) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse_Summary) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[26]
+	mi := &file_v1_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1646,7 +2616,7 @@ func (x *SummaryDashboardResponse_Summary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SummaryDashboardResponse_Summary.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse_Summary) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{23, 0}
+	return file_v1_service_proto_rawDescGZIP(), []int{39, 0}
 }
 
 func (x *SummaryDashboardResponse_Summary) GetId() string {
@@ -1753,7 +2723,7 @@ type SummaryDashboardResponse_BackupChart struct {
 
 func (x *SummaryDashboardResponse_BackupChart) Reset() {
 	*x = SummaryDashboardResponse_BackupChart{}
-	mi := &file_v1_service_proto_msgTypes[27]
+	mi := &file_v1_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1765,7 +2735,7 @@ func (x *SummaryDashboardResponse_BackupChart) String() string {
 func (*SummaryDashboardResponse_BackupChart) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse_BackupChart) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[27]
+	mi := &file_v1_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1778,7 +2748,7 @@ func (x *SummaryDashboardResponse_BackupChart) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SummaryDashboardResponse_BackupChart.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse_BackupChart) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{23, 1}
+	return file_v1_service_proto_rawDescGZIP(), []int{39, 1}
 }
 
 func (x *SummaryDashboardResponse_BackupChart) GetFlowId() []int64 {
@@ -1829,7 +2799,7 @@ type SummaryDashboardResponse_DayStatusBucket struct {
 
 func (x *SummaryDashboardResponse_DayStatusBucket) Reset() {
 	*x = SummaryDashboardResponse_DayStatusBucket{}
-	mi := &file_v1_service_proto_msgTypes[28]
+	mi := &file_v1_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1841,7 +2811,7 @@ func (x *SummaryDashboardResponse_DayStatusBucket) String() string {
 func (*SummaryDashboardResponse_DayStatusBucket) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse_DayStatusBucket) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[28]
+	mi := &file_v1_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1854,7 +2824,7 @@ func (x *SummaryDashboardResponse_DayStatusBucket) ProtoReflect() protoreflect.M
 
 // Deprecated: Use SummaryDashboardResponse_DayStatusBucket.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse_DayStatusBucket) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{23, 2}
+	return file_v1_service_proto_rawDescGZIP(), []int{39, 2}
 }
 
 func (x *SummaryDashboardResponse_DayStatusBucket) GetTimestampMs() int64 {
@@ -1902,7 +2872,7 @@ type SummaryDashboardResponse_StatusAndCount struct {
 
 func (x *SummaryDashboardResponse_StatusAndCount) Reset() {
 	*x = SummaryDashboardResponse_StatusAndCount{}
-	mi := &file_v1_service_proto_msgTypes[29]
+	mi := &file_v1_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1914,7 +2884,7 @@ func (x *SummaryDashboardResponse_StatusAndCount) String() string {
 func (*SummaryDashboardResponse_StatusAndCount) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse_StatusAndCount) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[29]
+	mi := &file_v1_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1927,7 +2897,7 @@ func (x *SummaryDashboardResponse_StatusAndCount) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use SummaryDashboardResponse_StatusAndCount.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse_StatusAndCount) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{23, 3}
+	return file_v1_service_proto_rawDescGZIP(), []int{39, 3}
 }
 
 func (x *SummaryDashboardResponse_StatusAndCount) GetCount() int64 {
@@ -1996,10 +2966,10 @@ const file_v1_service_proto_rawDesc = "" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12,\n" +
 	"\x12host_key_untrusted\x18\x05 \x01(\bR\x10hostKeyUntrusted\".\n" +
 	"\x0eAddRepoRequest\x12\x1c\n" +
-	"\x04repo\x18\x01 \x01(\v2\b.v1.RepoR\x04repo\"\xe0\x01\n" +
+	"\x04repo\x18\x01 \x01(\v2\b.v1.RepoR\x04repo\"\xef\x01\n" +
 	"\x11DoRepoTaskRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12.\n" +
-	"\x04task\x18\x02 \x01(\x0e2\x1a.v1.DoRepoTaskRequest.TaskR\x04task\"\x81\x01\n" +
+	"\x04task\x18\x02 \x01(\x0e2\x1a.v1.DoRepoTaskRequest.TaskR\x04task\"\x90\x01\n" +
 	"\x04Task\x12\r\n" +
 	"\tTASK_NONE\x10\x00\x12\x18\n" +
 	"\x14TASK_INDEX_SNAPSHOTS\x10\x01\x12\x0e\n" +
@@ -2010,7 +2980,23 @@ const file_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"TASK_STATS\x10\x04\x12\x0f\n" +
 	"\vTASK_UNLOCK\x10\x05\x12\x0f\n" +
-	"\vTASK_FORGET\x10\x06\"b\n" +
+	"\vTASK_FORGET\x10\x06\x12\r\n" +
+	"\tTASK_COPY\x10\a\".\n" +
+	"\x13ListRepoKeysRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\"7\n" +
+	"\x14ListRepoKeysResponse\x12\x1f\n" +
+	"\x04keys\x18\x01 \x03(\v2\v.v1.RepoKeyR\x04keys\"\x80\x01\n" +
+	"\x11AddRepoKeyRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\"F\n" +
+	"\x14RemoveRepoKeyRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"W\n" +
+	"\x19RotateRepoPasswordRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"b\n" +
 	"\x13ClearHistoryRequest\x12*\n" +
 	"\bselector\x18\x01 \x01(\v2\x0e.v1.OpSelectorR\bselector\x12\x1f\n" +
 	"\vonly_failed\x18\x02 \x01(\bR\n" +
@@ -2019,20 +3005,40 @@ const file_v1_service_proto_rawDesc = "" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x17\n" +
 	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12\x1f\n" +
 	"\vsnapshot_id\x18\x03 \x01(\tR\n" +
-	"snapshotId\"H\n" +
+	"snapshotId\"N\n" +
+	"\x12PinSnapshotRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\"P\n" +
+	"\x14UnpinSnapshotRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\"\x96\x01\n" +
+	"\x14PreviewForgetRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\x121\n" +
+	"\tretention\x18\x03 \x01(\v2\x13.v1.RetentionPolicyR\tretention\x12\x19\n" +
+	"\bgroup_by\x18\x04 \x01(\tR\agroupBy\"\xd9\x01\n" +
+	"\x15PreviewForgetResponse\x12:\n" +
+	"\x04keep\x18\x01 \x03(\v2&.v1.PreviewForgetResponse.KeptSnapshotR\x04keep\x12*\n" +
+	"\x06remove\x18\x02 \x03(\v2\x12.v1.ResticSnapshotR\x06remove\x1aX\n" +
+	"\fKeptSnapshot\x12.\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x12.v1.ResticSnapshotR\bsnapshot\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\"H\n" +
 	"\x14ListSnapshotsRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x17\n" +
 	"\aplan_id\x18\x02 \x01(\tR\x06planId\"Y\n" +
 	"\x14GetOperationsRequest\x12*\n" +
 	"\bselector\x18\x01 \x01(\v2\x0e.v1.OpSelectorR\bselector\x12\x15\n" +
-	"\x06last_n\x18\x02 \x01(\x03R\x05lastN\"\x97\x01\n" +
+	"\x06last_n\x18\x02 \x01(\x03R\x05lastN\"\xc5\x01\n" +
 	"\x16RestoreSnapshotRequest\x12\x17\n" +
 	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x17\n" +
 	"\arepo_id\x18\x05 \x01(\tR\x06repoId\x12\x1f\n" +
 	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
 	"snapshotId\x12\x12\n" +
 	"\x04path\x18\x03 \x01(\tR\x04path\x12\x16\n" +
-	"\x06target\x18\x04 \x01(\tR\x06target\"h\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12,\n" +
+	"\aoptions\x18\x06 \x01(\v2\x12.v1.RestoreOptionsR\aoptions\"h\n" +
 	"\x18ListSnapshotFilesRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1f\n" +
 	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
@@ -2040,7 +3046,28 @@ const file_v1_service_proto_rawDesc = "" +
 	"\x04path\x18\x03 \x01(\tR\x04path\"V\n" +
 	"\x19ListSnapshotFilesResponse\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12%\n" +
-	"\aentries\x18\x02 \x03(\v2\v.v1.LsEntryR\aentries\"\"\n" +
+	"\aentries\x18\x02 \x03(\v2\v.v1.LsEntryR\aentries\"U\n" +
+	"\x10FindFilesRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12(\n" +
+	"\x05query\x18\x02 \x01(\v2\x12.v1.FindFilesQueryR\x05query\"m\n" +
+	"\x1aGetFindFilesResultsRequest\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\x03R\voperationId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"}\n" +
+	"\x1bGetFindFilesResultsResponse\x12'\n" +
+	"\amatches\x18\x01 \x03(\v2\r.v1.FileMatchR\amatches\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x1f\n" +
+	"\vnext_offset\x18\x03 \x01(\x03R\n" +
+	"nextOffset\"\x90\x01\n" +
+	"\x14DiffSnapshotsRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\x12*\n" +
+	"\x11other_snapshot_id\x18\x03 \x01(\tR\x0fotherSnapshotId\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\"\\\n" +
+	"\x15DiffSnapshotsResponse\x12\x16\n" +
+	"\x06logref\x18\x01 \x01(\tR\x06logref\x12+\n" +
+	"\x05stats\x18\x02 \x01(\v2\x15.v1.SnapshotDiffStatsR\x05stats\"\"\n" +
 	"\x0eLogDataRequest\x12\x10\n" +
 	"\x03ref\x18\x01 \x01(\tR\x03ref\"I\n" +
 	"\x15GetDownloadURLRequest\x12\x13\n" +
@@ -2062,7 +3089,16 @@ const file_v1_service_proto_rawDesc = "" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x18\n" +
 	"\acommand\x18\x02 \x01(\tR\acommand\"7\n" +
 	"\x12RunCommandResponse\x12!\n" +
-	"\foperation_id\x18\x01 \x01(\x03R\voperationId\",\n" +
+	"\foperation_id\x18\x01 \x01(\x03R\voperationId\"\x93\x01\n" +
+	"\x0fTestHookRequest\x12\x1c\n" +
+	"\x04hook\x18\x01 \x01(\v2\b.v1.HookR\x04hook\x120\n" +
+	"\tcondition\x18\x02 \x01(\x0e2\x12.v1.Hook.ConditionR\tcondition\x12\x17\n" +
+	"\arepo_id\x18\x03 \x01(\tR\x06repoId\x12\x17\n" +
+	"\aplan_id\x18\x04 \x01(\tR\x06planId\"m\n" +
+	"\x10TestHookResponse\x12+\n" +
+	"\x11rendered_template\x18\x01 \x01(\tR\x10renderedTemplate\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\",\n" +
 	"\x11RemoveRepoRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\";\n" +
 	"\x16CancelOperationRequest\x12!\n" +
@@ -2114,7 +3150,7 @@ const file_v1_service_proto_rawDesc = "" +
 	"\bmax_uses\x18\x03 \x01(\x05R\amaxUses\x12:\n" +
 	"\vpermissions\x18\x04 \x03(\v2\x18.v1.Multihost.PermissionR\vpermissions\"4\n" +
 	"\x1cGeneratePairingTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token2\x85\v\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token2\xee\x10\n" +
 	"\bBackrest\x121\n" +
 	"\tGetConfig\x12\x16.google.protobuf.Empty\x1a\n" +
 	".v1.Config\"\x00\x12%\n" +
@@ -2128,19 +3164,32 @@ const file_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"RemoveRepo\x12\x15.v1.RemoveRepoRequest\x1a\n" +
 	".v1.Config\"\x00\x12D\n" +
-	"\x12GetOperationEvents\x12\x16.google.protobuf.Empty\x1a\x12.v1.OperationEvent\"\x000\x01\x12>\n" +
+	"\x12GetOperationEvents\x12\x16.google.protobuf.Empty\x1a\x12.v1.OperationEvent\"\x000\x01\x12C\n" +
+	"\fListRepoKeys\x12\x17.v1.ListRepoKeysRequest\x1a\x18.v1.ListRepoKeysResponse\"\x00\x122\n" +
+	"\n" +
+	"AddRepoKey\x12\x15.v1.AddRepoKeyRequest\x1a\v.v1.RepoKey\"\x00\x12C\n" +
+	"\rRemoveRepoKey\x12\x18.v1.RemoveRepoKeyRequest\x1a\x16.google.protobuf.Empty\"\x00\x12A\n" +
+	"\x12RotateRepoPassword\x12\x1d.v1.RotateRepoPasswordRequest\x1a\n" +
+	".v1.Config\"\x00\x12>\n" +
 	"\rGetOperations\x12\x18.v1.GetOperationsRequest\x1a\x11.v1.OperationList\"\x00\x12C\n" +
 	"\rListSnapshots\x12\x18.v1.ListSnapshotsRequest\x1a\x16.v1.ResticSnapshotList\"\x00\x12R\n" +
-	"\x11ListSnapshotFiles\x12\x1c.v1.ListSnapshotFilesRequest\x1a\x1d.v1.ListSnapshotFilesResponse\"\x00\x125\n" +
+	"\x11ListSnapshotFiles\x12\x1c.v1.ListSnapshotFilesRequest\x1a\x1d.v1.ListSnapshotFilesResponse\"\x00\x12=\n" +
+	"\tFindFiles\x12\x14.v1.FindFilesRequest\x1a\x18.v1.ScheduleTaskResponse\"\x00\x12X\n" +
+	"\x13GetFindFilesResults\x12\x1e.v1.GetFindFilesResultsRequest\x1a\x1f.v1.GetFindFilesResultsResponse\"\x00\x12F\n" +
+	"\rDiffSnapshots\x12\x18.v1.DiffSnapshotsRequest\x1a\x19.v1.DiffSnapshotsResponse\"\x00\x125\n" +
 	"\x06Backup\x12\x11.v1.BackupRequest\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
 	"\n" +
 	"DoRepoTask\x12\x15.v1.DoRepoTaskRequest\x1a\x18.v1.ScheduleTaskResponse\"\x00\x127\n" +
-	"\x06Forget\x12\x11.v1.ForgetRequest\x1a\x18.v1.ScheduleTaskResponse\"\x00\x12A\n" +
+	"\x06Forget\x12\x11.v1.ForgetRequest\x1a\x18.v1.ScheduleTaskResponse\"\x00\x12F\n" +
+	"\rPreviewForget\x12\x18.v1.PreviewForgetRequest\x1a\x19.v1.PreviewForgetResponse\"\x00\x12?\n" +
+	"\vPinSnapshot\x12\x16.v1.PinSnapshotRequest\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
+	"\rUnpinSnapshot\x12\x18.v1.UnpinSnapshotRequest\x1a\x16.google.protobuf.Empty\"\x00\x12A\n" +
 	"\aRestore\x12\x1a.v1.RestoreSnapshotRequest\x1a\x18.v1.ScheduleTaskResponse\"\x00\x12>\n" +
 	"\x06Cancel\x12\x1a.v1.CancelOperationRequest\x1a\x16.google.protobuf.Empty\"\x00\x124\n" +
 	"\aGetLogs\x12\x12.v1.LogDataRequest\x1a\x11.types.BytesValue\"\x000\x01\x12=\n" +
 	"\n" +
-	"RunCommand\x12\x15.v1.RunCommandRequest\x1a\x16.v1.RunCommandResponse\"\x00\x12A\n" +
+	"RunCommand\x12\x15.v1.RunCommandRequest\x1a\x16.v1.RunCommandResponse\"\x00\x127\n" +
+	"\bTestHook\x12\x13.v1.TestHookRequest\x1a\x14.v1.TestHookResponse\"\x00\x12A\n" +
 	"\x0eGetDownloadURL\x12\x19.v1.GetDownloadURLRequest\x1a\x12.types.StringValue\"\x00\x12A\n" +
 	"\fClearHistory\x12\x17.v1.ClearHistoryRequest\x1a\x16.google.protobuf.Empty\"\x00\x12;\n" +
 	"\x10PathAutocomplete\x12\x12.types.StringValue\x1a\x11.types.StringList\"\x00\x12M\n" +
@@ -2160,7 +3209,7 @@ func file_v1_service_proto_rawDescGZIP() []byte {
 }
 
 var file_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_v1_service_proto_goTypes = []any{
 	(DoRepoTaskRequest_Task)(0),                      // 0: v1.DoRepoTaskRequest.Task
 	(*BackupRequest)(nil),                            // 1: v1.BackupRequest
@@ -2172,103 +3221,162 @@ var file_v1_service_proto_goTypes = []any{
 	(*CheckRepoExistsResponse)(nil),                  // 7: v1.CheckRepoExistsResponse
 	(*AddRepoRequest)(nil),                           // 8: v1.AddRepoRequest
 	(*DoRepoTaskRequest)(nil),                        // 9: v1.DoRepoTaskRequest
-	(*ClearHistoryRequest)(nil),                      // 10: v1.ClearHistoryRequest
-	(*ForgetRequest)(nil),                            // 11: v1.ForgetRequest
-	(*ListSnapshotsRequest)(nil),                     // 12: v1.ListSnapshotsRequest
-	(*GetOperationsRequest)(nil),                     // 13: v1.GetOperationsRequest
-	(*RestoreSnapshotRequest)(nil),                   // 14: v1.RestoreSnapshotRequest
-	(*ListSnapshotFilesRequest)(nil),                 // 15: v1.ListSnapshotFilesRequest
-	(*ListSnapshotFilesResponse)(nil),                // 16: v1.ListSnapshotFilesResponse
-	(*LogDataRequest)(nil),                           // 17: v1.LogDataRequest
-	(*GetDownloadURLRequest)(nil),                    // 18: v1.GetDownloadURLRequest
-	(*LsEntry)(nil),                                  // 19: v1.LsEntry
-	(*RunCommandRequest)(nil),                        // 20: v1.RunCommandRequest
-	(*RunCommandResponse)(nil),                       // 21: v1.RunCommandResponse
-	(*RemoveRepoRequest)(nil),                        // 22: v1.RemoveRepoRequest
-	(*CancelOperationRequest)(nil),                   // 23: v1.CancelOperationRequest
-	(*SummaryDashboardResponse)(nil),                 // 24: v1.SummaryDashboardResponse
-	(*GeneratePairingTokenRequest)(nil),              // 25: v1.GeneratePairingTokenRequest
-	(*GeneratePairingTokenResponse)(nil),             // 26: v1.GeneratePairingTokenResponse
-	(*SummaryDashboardResponse_Summary)(nil),         // 27: v1.SummaryDashboardResponse.Summary
-	(*SummaryDashboardResponse_BackupChart)(nil),     // 28: v1.SummaryDashboardResponse.BackupChart
-	(*SummaryDashboardResponse_DayStatusBucket)(nil), // 29: v1.SummaryDashboardResponse.DayStatusBucket
-	(*SummaryDashboardResponse_StatusAndCount)(nil),  // 30: v1.SummaryDashboardResponse.StatusAndCount
-	(*Repo)(nil),                                     // 31: v1.Repo
-	(*Multihost_Permission)(nil),                     // 32: v1.Multihost.Permission
-	(OperationStatus)(0),                             // 33: v1.OperationStatus
-	(*emptypb.Empty)(nil),                            // 34: google.protobuf.Empty
-	(*Config)(nil),                                   // 35: v1.Config
-	(*types.StringValue)(nil),                        // 36: types.StringValue
-	(*OperationEvent)(nil),                           // 37: v1.OperationEvent
-	(*OperationList)(nil),                            // 38: v1.OperationList
-	(*ResticSnapshotList)(nil),                       // 39: v1.ResticSnapshotList
-	(*types.BytesValue)(nil),                         // 40: types.BytesValue
-	(*types.StringList)(nil),                         // 41: types.StringList
+	(*ListRepoKeysRequest)(nil),                      // 10: v1.ListRepoKeysRequest
+	(*ListRepoKeysResponse)(nil),                     // 11: v1.ListRepoKeysResponse
+	(*AddRepoKeyRequest)(nil),                        // 12: v1.AddRepoKeyRequest
+	(*RemoveRepoKeyRequest)(nil),                     // 13: v1.RemoveRepoKeyRequest
+	(*RotateRepoPasswordRequest)(nil),                // 14: v1.RotateRepoPasswordRequest
+	(*ClearHistoryRequest)(nil),                      // 15: v1.ClearHistoryRequest
+	(*ForgetRequest)(nil),                            // 16: v1.ForgetRequest
+	(*PinSnapshotRequest)(nil),                       // 17: v1.PinSnapshotRequest
+	(*UnpinSnapshotRequest)(nil),                     // 18: v1.UnpinSnapshotRequest
+	(*PreviewForgetRequest)(nil),                     // 19: v1.PreviewForgetRequest
+	(*PreviewForgetResponse)(nil),                    // 20: v1.PreviewForgetResponse
+	(*ListSnapshotsRequest)(nil),                     // 21: v1.ListSnapshotsRequest
+	(*GetOperationsRequest)(nil),                     // 22: v1.GetOperationsRequest
+	(*RestoreSnapshotRequest)(nil),                   // 23: v1.RestoreSnapshotRequest
+	(*ListSnapshotFilesRequest)(nil),                 // 24: v1.ListSnapshotFilesRequest
+	(*ListSnapshotFilesResponse)(nil),                // 25: v1.ListSnapshotFilesResponse
+	(*FindFilesRequest)(nil),                         // 26: v1.FindFilesRequest
+	(*GetFindFilesResultsRequest)(nil),               // 27: v1.GetFindFilesResultsRequest
+	(*GetFindFilesResultsResponse)(nil),              // 28: v1.GetFindFilesResultsResponse
+	(*DiffSnapshotsRequest)(nil),                     // 29: v1.DiffSnapshotsRequest
+	(*DiffSnapshotsResponse)(nil),                    // 30: v1.DiffSnapshotsResponse
+	(*LogDataRequest)(nil),                           // 31: v1.LogDataRequest
+	(*GetDownloadURLRequest)(nil),                    // 32: v1.GetDownloadURLRequest
+	(*LsEntry)(nil),                                  // 33: v1.LsEntry
+	(*RunCommandRequest)(nil),                        // 34: v1.RunCommandRequest
+	(*RunCommandResponse)(nil),                       // 35: v1.RunCommandResponse
+	(*TestHookRequest)(nil),                          // 36: v1.TestHookRequest
+	(*TestHookResponse)(nil),                         // 37: v1.TestHookResponse
+	(*RemoveRepoRequest)(nil),                        // 38: v1.RemoveRepoRequest
+	(*CancelOperationRequest)(nil),                   // 39: v1.CancelOperationRequest
+	(*SummaryDashboardResponse)(nil),                 // 40: v1.SummaryDashboardResponse
+	(*GeneratePairingTokenRequest)(nil),              // 41: v1.GeneratePairingTokenRequest
+	(*GeneratePairingTokenResponse)(nil),             // 42: v1.GeneratePairingTokenResponse
+	(*PreviewForgetResponse_KeptSnapshot)(nil),       // 43: v1.PreviewForgetResponse.KeptSnapshot
+	(*SummaryDashboardResponse_Summary)(nil),         // 44: v1.SummaryDashboardResponse.Summary
+	(*SummaryDashboardResponse_BackupChart)(nil),     // 45: v1.SummaryDashboardResponse.BackupChart
+	(*SummaryDashboardResponse_DayStatusBucket)(nil), // 46: v1.SummaryDashboardResponse.DayStatusBucket
+	(*SummaryDashboardResponse_StatusAndCount)(nil),  // 47: v1.SummaryDashboardResponse.StatusAndCount
+	(*Repo)(nil),                                     // 48: v1.Repo
+	(*RepoKey)(nil),                                  // 49: v1.RepoKey
+	(*RetentionPolicy)(nil),                          // 50: v1.RetentionPolicy
+	(*ResticSnapshot)(nil),                           // 51: v1.ResticSnapshot
+	(*RestoreOptions)(nil),                           // 52: v1.RestoreOptions
+	(*FindFilesQuery)(nil),                           // 53: v1.FindFilesQuery
+	(*FileMatch)(nil),                                // 54: v1.FileMatch
+	(*SnapshotDiffStats)(nil),                        // 55: v1.SnapshotDiffStats
+	(*Hook)(nil),                                     // 56: v1.Hook
+	(Hook_Condition)(0),                              // 57: v1.Hook.Condition
+	(*Multihost_Permission)(nil),                     // 58: v1.Multihost.Permission
+	(OperationStatus)(0),                             // 59: v1.OperationStatus
+	(*emptypb.Empty)(nil),                            // 60: google.protobuf.Empty
+	(*Config)(nil),                                   // 61: v1.Config
+	(*types.StringValue)(nil),                        // 62: types.StringValue
+	(*OperationEvent)(nil),                           // 63: v1.OperationEvent
+	(*OperationList)(nil),                            // 64: v1.OperationList
+	(*ResticSnapshotList)(nil),                       // 65: v1.ResticSnapshotList
+	(*types.BytesValue)(nil),                         // 66: types.BytesValue
+	(*types.StringList)(nil),                         // 67: types.StringList
 }
 var file_v1_service_proto_depIdxs = []int32{
-	31, // 0: v1.CheckRepoExistsRequest.repo:type_name -> v1.Repo
-	31, // 1: v1.AddRepoRequest.repo:type_name -> v1.Repo
+	48, // 0: v1.CheckRepoExistsRequest.repo:type_name -> v1.Repo
+	48, // 1: v1.AddRepoRequest.repo:type_name -> v1.Repo
 	0,  // 2: v1.DoRepoTaskRequest.task:type_name -> v1.DoRepoTaskRequest.Task
-	3,  // 3: v1.ClearHistoryRequest.selector:type_name -> v1.OpSelector
-	3,  // 4: v1.GetOperationsRequest.selector:type_name -> v1.OpSelector
-	19, // 5: v1.ListSnapshotFilesResponse.entries:type_name -> v1.LsEntry
-	27, // 6: v1.SummaryDashboardResponse.repo_summaries:type_name -> v1.SummaryDashboardResponse.Summary
-	27, // 7: v1.SummaryDashboardResponse.plan_summaries:type_name -> v1.SummaryDashboardResponse.Summary
-	32, // 8: v1.GeneratePairingTokenRequest.permissions:type_name -> v1.Multihost.Permission
-	28, // 9: v1.SummaryDashboardResponse.Summary.recent_backups:type_name -> v1.SummaryDashboardResponse.BackupChart
-	29, // 10: v1.SummaryDashboardResponse.Summary.history_last_30days:type_name -> v1.SummaryDashboardResponse.DayStatusBucket
-	33, // 11: v1.SummaryDashboardResponse.BackupChart.status:type_name -> v1.OperationStatus
-	30, // 12: v1.SummaryDashboardResponse.DayStatusBucket.status_counts:type_name -> v1.SummaryDashboardResponse.StatusAndCount
-	33, // 13: v1.SummaryDashboardResponse.StatusAndCount.status:type_name -> v1.OperationStatus
-	34, // 14: v1.Backrest.GetConfig:input_type -> google.protobuf.Empty
-	35, // 15: v1.Backrest.SetConfig:input_type -> v1.Config
-	4,  // 16: v1.Backrest.SetupSftp:input_type -> v1.SetupSftpRequest
-	6,  // 17: v1.Backrest.CheckRepoExists:input_type -> v1.CheckRepoExistsRequest
-	8,  // 18: v1.Backrest.AddRepo:input_type -> v1.AddRepoRequest
-	22, // 19: v1.Backrest.RemoveRepo:input_type -> v1.RemoveRepoRequest
-	34, // 20: v1.Backrest.GetOperationEvents:input_type -> google.protobuf.Empty
-	13, // 21: v1.Backrest.GetOperations:input_type -> v1.GetOperationsRequest
-	12, // 22: v1.Backrest.ListSnapshots:input_type -> v1.ListSnapshotsRequest
-	15, // 23: v1.Backrest.ListSnapshotFiles:input_type -> v1.ListSnapshotFilesRequest
-	1,  // 24: v1.Backrest.Backup:input_type -> v1.BackupRequest
-	9,  // 25: v1.Backrest.DoRepoTask:input_type -> v1.DoRepoTaskRequest
-	11, // 26: v1.Backrest.Forget:input_type -> v1.ForgetRequest
-	14, // 27: v1.Backrest.Restore:input_type -> v1.RestoreSnapshotRequest
-	23, // 28: v1.Backrest.Cancel:input_type -> v1.CancelOperationRequest
-	17, // 29: v1.Backrest.GetLogs:input_type -> v1.LogDataRequest
-	20, // 30: v1.Backrest.RunCommand:input_type -> v1.RunCommandRequest
-	18, // 31: v1.Backrest.GetDownloadURL:input_type -> v1.GetDownloadURLRequest
-	10, // 32: v1.Backrest.ClearHistory:input_type -> v1.ClearHistoryRequest
-	36, // 33: v1.Backrest.PathAutocomplete:input_type -> types.StringValue
-	34, // 34: v1.Backrest.GetSummaryDashboard:input_type -> google.protobuf.Empty
-	25, // 35: v1.Backrest.GeneratePairingToken:input_type -> v1.GeneratePairingTokenRequest
-	35, // 36: v1.Backrest.GetConfig:output_type -> v1.Config
-	35, // 37: v1.Backrest.SetConfig:output_type -> v1.Config
-	5,  // 38: v1.Backrest.SetupSftp:output_type -> v1.SetupSftpResponse
-	7,  // 39: v1.Backrest.CheckRepoExists:output_type -> v1.CheckRepoExistsResponse
-	35, // 40: v1.Backrest.AddRepo:output_type -> v1.Config
-	35, // 41: v1.Backrest.RemoveRepo:output_type -> v1.Config
-	37, // 42: v1.Backrest.GetOperationEvents:output_type -> v1.OperationEvent
-	38, // 43: v1.Backrest.GetOperations:output_type -> v1.OperationList
-	39, // 44: v1.Backrest.ListSnapshots:output_type -> v1.ResticSnapshotList
-	16, // 45: v1.Backrest.ListSnapshotFiles:output_type -> v1.ListSnapshotFilesResponse
-	34, // 46: v1.Backrest.Backup:output_type -> google.protobuf.Empty
-	2,  // 47: v1.Backrest.DoRepoTask:output_type -> v1.ScheduleTaskResponse
-	2,  // 48: v1.Backrest.Forget:output_type -> v1.ScheduleTaskResponse
-	2,  // 49: v1.Backrest.Restore:output_type -> v1.ScheduleTaskResponse
-	34, // 50: v1.Backrest.Cancel:output_type -> google.protobuf.Empty
-	40, // 51: v1.Backrest.GetLogs:output_type -> types.BytesValue
-	21, // 52: v1.Backrest.RunCommand:output_type -> v1.RunCommandResponse
-	36, // 53: v1.Backrest.GetDownloadURL:output_type -> types.StringValue
-	34, // 54: v1.Backrest.ClearHistory:output_type -> google.protobuf.Empty
-	41, // 55: v1.Backrest.PathAutocomplete:output_type -> types.StringList
-	24, // 56: v1.Backrest.GetSummaryDashboard:output_type -> v1.SummaryDashboardResponse
-	26, // 57: v1.Backrest.GeneratePairingToken:output_type -> v1.GeneratePairingTokenResponse
-	36, // [36:58] is the sub-list for method output_type
-	14, // [14:36] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	49, // 3: v1.ListRepoKeysResponse.keys:type_name -> v1.RepoKey
+	3,  // 4: v1.ClearHistoryRequest.selector:type_name -> v1.OpSelector
+	50, // 5: v1.PreviewForgetRequest.retention:type_name -> v1.RetentionPolicy
+	43, // 6: v1.PreviewForgetResponse.keep:type_name -> v1.PreviewForgetResponse.KeptSnapshot
+	51, // 7: v1.PreviewForgetResponse.remove:type_name -> v1.ResticSnapshot
+	3,  // 8: v1.GetOperationsRequest.selector:type_name -> v1.OpSelector
+	52, // 9: v1.RestoreSnapshotRequest.options:type_name -> v1.RestoreOptions
+	33, // 10: v1.ListSnapshotFilesResponse.entries:type_name -> v1.LsEntry
+	53, // 11: v1.FindFilesRequest.query:type_name -> v1.FindFilesQuery
+	54, // 12: v1.GetFindFilesResultsResponse.matches:type_name -> v1.FileMatch
+	55, // 13: v1.DiffSnapshotsResponse.stats:type_name -> v1.SnapshotDiffStats
+	56, // 14: v1.TestHookRequest.hook:type_name -> v1.Hook
+	57, // 15: v1.TestHookRequest.condition:type_name -> v1.Hook.Condition
+	44, // 16: v1.SummaryDashboardResponse.repo_summaries:type_name -> v1.SummaryDashboardResponse.Summary
+	44, // 17: v1.SummaryDashboardResponse.plan_summaries:type_name -> v1.SummaryDashboardResponse.Summary
+	58, // 18: v1.GeneratePairingTokenRequest.permissions:type_name -> v1.Multihost.Permission
+	51, // 19: v1.PreviewForgetResponse.KeptSnapshot.snapshot:type_name -> v1.ResticSnapshot
+	45, // 20: v1.SummaryDashboardResponse.Summary.recent_backups:type_name -> v1.SummaryDashboardResponse.BackupChart
+	46, // 21: v1.SummaryDashboardResponse.Summary.history_last_30days:type_name -> v1.SummaryDashboardResponse.DayStatusBucket
+	59, // 22: v1.SummaryDashboardResponse.BackupChart.status:type_name -> v1.OperationStatus
+	47, // 23: v1.SummaryDashboardResponse.DayStatusBucket.status_counts:type_name -> v1.SummaryDashboardResponse.StatusAndCount
+	59, // 24: v1.SummaryDashboardResponse.StatusAndCount.status:type_name -> v1.OperationStatus
+	60, // 25: v1.Backrest.GetConfig:input_type -> google.protobuf.Empty
+	61, // 26: v1.Backrest.SetConfig:input_type -> v1.Config
+	4,  // 27: v1.Backrest.SetupSftp:input_type -> v1.SetupSftpRequest
+	6,  // 28: v1.Backrest.CheckRepoExists:input_type -> v1.CheckRepoExistsRequest
+	8,  // 29: v1.Backrest.AddRepo:input_type -> v1.AddRepoRequest
+	38, // 30: v1.Backrest.RemoveRepo:input_type -> v1.RemoveRepoRequest
+	60, // 31: v1.Backrest.GetOperationEvents:input_type -> google.protobuf.Empty
+	10, // 32: v1.Backrest.ListRepoKeys:input_type -> v1.ListRepoKeysRequest
+	12, // 33: v1.Backrest.AddRepoKey:input_type -> v1.AddRepoKeyRequest
+	13, // 34: v1.Backrest.RemoveRepoKey:input_type -> v1.RemoveRepoKeyRequest
+	14, // 35: v1.Backrest.RotateRepoPassword:input_type -> v1.RotateRepoPasswordRequest
+	22, // 36: v1.Backrest.GetOperations:input_type -> v1.GetOperationsRequest
+	21, // 37: v1.Backrest.ListSnapshots:input_type -> v1.ListSnapshotsRequest
+	24, // 38: v1.Backrest.ListSnapshotFiles:input_type -> v1.ListSnapshotFilesRequest
+	26, // 39: v1.Backrest.FindFiles:input_type -> v1.FindFilesRequest
+	27, // 40: v1.Backrest.GetFindFilesResults:input_type -> v1.GetFindFilesResultsRequest
+	29, // 41: v1.Backrest.DiffSnapshots:input_type -> v1.DiffSnapshotsRequest
+	1,  // 42: v1.Backrest.Backup:input_type -> v1.BackupRequest
+	9,  // 43: v1.Backrest.DoRepoTask:input_type -> v1.DoRepoTaskRequest
+	16, // 44: v1.Backrest.Forget:input_type -> v1.ForgetRequest
+	19, // 45: v1.Backrest.PreviewForget:input_type -> v1.PreviewForgetRequest
+	17, // 46: v1.Backrest.PinSnapshot:input_type -> v1.PinSnapshotRequest
+	18, // 47: v1.Backrest.UnpinSnapshot:input_type -> v1.UnpinSnapshotRequest
+	23, // 48: v1.Backrest.Restore:input_type -> v1.RestoreSnapshotRequest
+	39, // 49: v1.Backrest.Cancel:input_type -> v1.CancelOperationRequest
+	31, // 50: v1.Backrest.GetLogs:input_type -> v1.LogDataRequest
+	34, // 51: v1.Backrest.RunCommand:input_type -> v1.RunCommandRequest
+	36, // 52: v1.Backrest.TestHook:input_type -> v1.TestHookRequest
+	32, // 53: v1.Backrest.GetDownloadURL:input_type -> v1.GetDownloadURLRequest
+	15, // 54: v1.Backrest.ClearHistory:input_type -> v1.ClearHistoryRequest
+	62, // 55: v1.Backrest.PathAutocomplete:input_type -> types.StringValue
+	60, // 56: v1.Backrest.GetSummaryDashboard:input_type -> google.protobuf.Empty
+	41, // 57: v1.Backrest.GeneratePairingToken:input_type -> v1.GeneratePairingTokenRequest
+	61, // 58: v1.Backrest.GetConfig:output_type -> v1.Config
+	61, // 59: v1.Backrest.SetConfig:output_type -> v1.Config
+	5,  // 60: v1.Backrest.SetupSftp:output_type -> v1.SetupSftpResponse
+	7,  // 61: v1.Backrest.CheckRepoExists:output_type -> v1.CheckRepoExistsResponse
+	61, // 62: v1.Backrest.AddRepo:output_type -> v1.Config
+	61, // 63: v1.Backrest.RemoveRepo:output_type -> v1.Config
+	63, // 64: v1.Backrest.GetOperationEvents:output_type -> v1.OperationEvent
+	11, // 65: v1.Backrest.ListRepoKeys:output_type -> v1.ListRepoKeysResponse
+	49, // 66: v1.Backrest.AddRepoKey:output_type -> v1.RepoKey
+	60, // 67: v1.Backrest.RemoveRepoKey:output_type -> google.protobuf.Empty
+	61, // 68: v1.Backrest.RotateRepoPassword:output_type -> v1.Config
+	64, // 69: v1.Backrest.GetOperations:output_type -> v1.OperationList
+	65, // 70: v1.Backrest.ListSnapshots:output_type -> v1.ResticSnapshotList
+	25, // 71: v1.Backrest.ListSnapshotFiles:output_type -> v1.ListSnapshotFilesResponse
+	2,  // 72: v1.Backrest.FindFiles:output_type -> v1.ScheduleTaskResponse
+	28, // 73: v1.Backrest.GetFindFilesResults:output_type -> v1.GetFindFilesResultsResponse
+	30, // 74: v1.Backrest.DiffSnapshots:output_type -> v1.DiffSnapshotsResponse
+	60, // 75: v1.Backrest.Backup:output_type -> google.protobuf.Empty
+	2,  // 76: v1.Backrest.DoRepoTask:output_type -> v1.ScheduleTaskResponse
+	2,  // 77: v1.Backrest.Forget:output_type -> v1.ScheduleTaskResponse
+	20, // 78: v1.Backrest.PreviewForget:output_type -> v1.PreviewForgetResponse
+	60, // 79: v1.Backrest.PinSnapshot:output_type -> google.protobuf.Empty
+	60, // 80: v1.Backrest.UnpinSnapshot:output_type -> google.protobuf.Empty
+	2,  // 81: v1.Backrest.Restore:output_type -> v1.ScheduleTaskResponse
+	60, // 82: v1.Backrest.Cancel:output_type -> google.protobuf.Empty
+	66, // 83: v1.Backrest.GetLogs:output_type -> types.BytesValue
+	35, // 84: v1.Backrest.RunCommand:output_type -> v1.RunCommandResponse
+	37, // 85: v1.Backrest.TestHook:output_type -> v1.TestHookResponse
+	62, // 86: v1.Backrest.GetDownloadURL:output_type -> types.StringValue
+	60, // 87: v1.Backrest.ClearHistory:output_type -> google.protobuf.Empty
+	67, // 88: v1.Backrest.PathAutocomplete:output_type -> types.StringList
+	40, // 89: v1.Backrest.GetSummaryDashboard:output_type -> v1.SummaryDashboardResponse
+	42, // 90: v1.Backrest.GeneratePairingToken:output_type -> v1.GeneratePairingTokenResponse
+	58, // [58:91] is the sub-list for method output_type
+	25, // [25:58] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_v1_service_proto_init() }
@@ -2287,7 +3395,7 @@ func file_v1_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_service_proto_rawDesc), len(file_v1_service_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   30,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
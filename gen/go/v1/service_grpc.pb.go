@@ -28,16 +28,27 @@ const (
 	Backrest_AddRepo_FullMethodName              = "/v1.Backrest/AddRepo"
 	Backrest_RemoveRepo_FullMethodName           = "/v1.Backrest/RemoveRepo"
 	Backrest_GetOperationEvents_FullMethodName   = "/v1.Backrest/GetOperationEvents"
+	Backrest_ListRepoKeys_FullMethodName         = "/v1.Backrest/ListRepoKeys"
+	Backrest_AddRepoKey_FullMethodName           = "/v1.Backrest/AddRepoKey"
+	Backrest_RemoveRepoKey_FullMethodName        = "/v1.Backrest/RemoveRepoKey"
+	Backrest_RotateRepoPassword_FullMethodName   = "/v1.Backrest/RotateRepoPassword"
 	Backrest_GetOperations_FullMethodName        = "/v1.Backrest/GetOperations"
 	Backrest_ListSnapshots_FullMethodName        = "/v1.Backrest/ListSnapshots"
 	Backrest_ListSnapshotFiles_FullMethodName    = "/v1.Backrest/ListSnapshotFiles"
+	Backrest_FindFiles_FullMethodName            = "/v1.Backrest/FindFiles"
+	Backrest_GetFindFilesResults_FullMethodName  = "/v1.Backrest/GetFindFilesResults"
+	Backrest_DiffSnapshots_FullMethodName        = "/v1.Backrest/DiffSnapshots"
 	Backrest_Backup_FullMethodName               = "/v1.Backrest/Backup"
 	Backrest_DoRepoTask_FullMethodName           = "/v1.Backrest/DoRepoTask"
 	Backrest_Forget_FullMethodName               = "/v1.Backrest/Forget"
+	Backrest_PreviewForget_FullMethodName        = "/v1.Backrest/PreviewForget"
+	Backrest_PinSnapshot_FullMethodName          = "/v1.Backrest/PinSnapshot"
+	Backrest_UnpinSnapshot_FullMethodName        = "/v1.Backrest/UnpinSnapshot"
 	Backrest_Restore_FullMethodName              = "/v1.Backrest/Restore"
 	Backrest_Cancel_FullMethodName               = "/v1.Backrest/Cancel"
 	Backrest_GetLogs_FullMethodName              = "/v1.Backrest/GetLogs"
 	Backrest_RunCommand_FullMethodName           = "/v1.Backrest/RunCommand"
+	Backrest_TestHook_FullMethodName             = "/v1.Backrest/TestHook"
 	Backrest_GetDownloadURL_FullMethodName       = "/v1.Backrest/GetDownloadURL"
 	Backrest_ClearHistory_FullMethodName         = "/v1.Backrest/ClearHistory"
 	Backrest_PathAutocomplete_FullMethodName     = "/v1.Backrest/PathAutocomplete"
@@ -56,15 +67,38 @@ type BackrestClient interface {
 	AddRepo(ctx context.Context, in *AddRepoRequest, opts ...grpc.CallOption) (*Config, error)
 	RemoveRepo(ctx context.Context, in *RemoveRepoRequest, opts ...grpc.CallOption) (*Config, error)
 	GetOperationEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationEvent], error)
+	// ListRepoKeys lists the keys (passwords) that can open a repo.
+	ListRepoKeys(ctx context.Context, in *ListRepoKeysRequest, opts ...grpc.CallOption) (*ListRepoKeysResponse, error)
+	// AddRepoKey adds a key (password) to a repo. The repo's configured password is not changed.
+	AddRepoKey(ctx context.Context, in *AddRepoKeyRequest, opts ...grpc.CallOption) (*RepoKey, error)
+	// RemoveRepoKey removes a key from a repo. The key backrest uses to open the repo can not be removed.
+	RemoveRepoKey(ctx context.Context, in *RemoveRepoKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RotateRepoPassword replaces the repo's password: a key for the new password is added and verified,
+	// the config is updated, and then the key for the old password is removed.
+	RotateRepoPassword(ctx context.Context, in *RotateRepoPasswordRequest, opts ...grpc.CallOption) (*Config, error)
 	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*OperationList, error)
 	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ResticSnapshotList, error)
 	ListSnapshotFiles(ctx context.Context, in *ListSnapshotFilesRequest, opts ...grpc.CallOption) (*ListSnapshotFilesResponse, error)
+	// FindFiles schedules a search for files across the snapshots of a repo and returns the search's operation ID.
+	// The search can be cancelled with Cancel and its results are fetched with GetFindFilesResults.
+	FindFiles(ctx context.Context, in *FindFilesRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error)
+	// GetFindFilesResults returns a page of the results of a completed FindFiles operation.
+	GetFindFilesResults(ctx context.Context, in *GetFindFilesResultsRequest, opts ...grpc.CallOption) (*GetFindFilesResultsResponse, error)
+	// DiffSnapshots compares two snapshots. The changed entries are written to a log that can be streamed with GetLogs.
+	// restic diff doesn't report sizes per entry, size deltas are only available in aggregate in the response's stats.
+	DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...grpc.CallOption) (*DiffSnapshotsResponse, error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// DoRepoTask schedules a repo task. It accepts a repo id and a task type and returns the scheduled operation's ID.
 	DoRepoTask(ctx context.Context, in *DoRepoTaskRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error)
 	// Forget schedules a forget operation. It accepts a plan id and returns the scheduled operation's ID.
 	Forget(ctx context.Context, in *ForgetRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error)
+	// PreviewForget simulates applying a retention policy with `restic forget --dry-run`, no snapshots are removed.
+	PreviewForget(ctx context.Context, in *PreviewForgetRequest, opts ...grpc.CallOption) (*PreviewForgetResponse, error)
+	// PinSnapshot tags a snapshot so that it is always kept by retention policies.
+	PinSnapshot(ctx context.Context, in *PinSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UnpinSnapshot removes the pin from a snapshot, it is then subject to retention policies again.
+	UnpinSnapshot(ctx context.Context, in *UnpinSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Restore schedules a restore operation.
 	Restore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -73,6 +107,8 @@ type BackrestClient interface {
 	GetLogs(ctx context.Context, in *LogDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[types.BytesValue], error)
 	// RunCommand executes a generic restic command on the repository.
 	RunCommand(ctx context.Context, in *RunCommandRequest, opts ...grpc.CallOption) (*RunCommandResponse, error)
+	// TestHook fires a hook once, without applying its notify mode or error policy and without recording an operation.
+	TestHook(ctx context.Context, in *TestHookRequest, opts ...grpc.CallOption) (*TestHookResponse, error)
 	// GetDownloadURL returns a signed download URL given an operation ID and file path.
 	GetDownloadURL(ctx context.Context, in *GetDownloadURLRequest, opts ...grpc.CallOption) (*types.StringValue, error)
 	// Clears the history of operations
@@ -173,6 +209,46 @@ func (c *backrestClient) GetOperationEvents(ctx context.Context, in *emptypb.Emp
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Backrest_GetOperationEventsClient = grpc.ServerStreamingClient[OperationEvent]
 
+func (c *backrestClient) ListRepoKeys(ctx context.Context, in *ListRepoKeysRequest, opts ...grpc.CallOption) (*ListRepoKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRepoKeysResponse)
+	err := c.cc.Invoke(ctx, Backrest_ListRepoKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) AddRepoKey(ctx context.Context, in *AddRepoKeyRequest, opts ...grpc.CallOption) (*RepoKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RepoKey)
+	err := c.cc.Invoke(ctx, Backrest_AddRepoKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RemoveRepoKey(ctx context.Context, in *RemoveRepoKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_RemoveRepoKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RotateRepoPassword(ctx context.Context, in *RotateRepoPasswordRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Backrest_RotateRepoPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*OperationList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OperationList)
@@ -203,6 +279,36 @@ func (c *backrestClient) ListSnapshotFiles(ctx context.Context, in *ListSnapshot
 	return out, nil
 }
 
+func (c *backrestClient) FindFiles(ctx context.Context, in *FindFilesRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleTaskResponse)
+	err := c.cc.Invoke(ctx, Backrest_FindFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetFindFilesResults(ctx context.Context, in *GetFindFilesResultsRequest, opts ...grpc.CallOption) (*GetFindFilesResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFindFilesResultsResponse)
+	err := c.cc.Invoke(ctx, Backrest_GetFindFilesResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...grpc.CallOption) (*DiffSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffSnapshotsResponse)
+	err := c.cc.Invoke(ctx, Backrest_DiffSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -233,6 +339,36 @@ func (c *backrestClient) Forget(ctx context.Context, in *ForgetRequest, opts ...
 	return out, nil
 }
 
+func (c *backrestClient) PreviewForget(ctx context.Context, in *PreviewForgetRequest, opts ...grpc.CallOption) (*PreviewForgetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewForgetResponse)
+	err := c.cc.Invoke(ctx, Backrest_PreviewForget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) PinSnapshot(ctx context.Context, in *PinSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_PinSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) UnpinSnapshot(ctx context.Context, in *UnpinSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_UnpinSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Restore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ScheduleTaskResponse)
@@ -282,6 +418,16 @@ func (c *backrestClient) RunCommand(ctx context.Context, in *RunCommandRequest,
 	return out, nil
 }
 
+func (c *backrestClient) TestHook(ctx context.Context, in *TestHookRequest, opts ...grpc.CallOption) (*TestHookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestHookResponse)
+	err := c.cc.Invoke(ctx, Backrest_TestHook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) GetDownloadURL(ctx context.Context, in *GetDownloadURLRequest, opts ...grpc.CallOption) (*types.StringValue, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(types.StringValue)
@@ -343,15 +489,38 @@ type BackrestServer interface {
 	AddRepo(context.Context, *AddRepoRequest) (*Config, error)
 	RemoveRepo(context.Context, *RemoveRepoRequest) (*Config, error)
 	GetOperationEvents(*emptypb.Empty, grpc.ServerStreamingServer[OperationEvent]) error
+	// ListRepoKeys lists the keys (passwords) that can open a repo.
+	ListRepoKeys(context.Context, *ListRepoKeysRequest) (*ListRepoKeysResponse, error)
+	// AddRepoKey adds a key (password) to a repo. The repo's configured password is not changed.
+	AddRepoKey(context.Context, *AddRepoKeyRequest) (*RepoKey, error)
+	// RemoveRepoKey removes a key from a repo. The key backrest uses to open the repo can not be removed.
+	RemoveRepoKey(context.Context, *RemoveRepoKeyRequest) (*emptypb.Empty, error)
+	// RotateRepoPassword replaces the repo's password: a key for the new password is added and verified,
+	// the config is updated, and then the key for the old password is removed.
+	RotateRepoPassword(context.Context, *RotateRepoPasswordRequest) (*Config, error)
 	GetOperations(context.Context, *GetOperationsRequest) (*OperationList, error)
 	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ResticSnapshotList, error)
 	ListSnapshotFiles(context.Context, *ListSnapshotFilesRequest) (*ListSnapshotFilesResponse, error)
+	// FindFiles schedules a search for files across the snapshots of a repo and returns the search's operation ID.
+	// The search can be cancelled with Cancel and its results are fetched with GetFindFilesResults.
+	FindFiles(context.Context, *FindFilesRequest) (*ScheduleTaskResponse, error)
+	// GetFindFilesResults returns a page of the results of a completed FindFiles operation.
+	GetFindFilesResults(context.Context, *GetFindFilesResultsRequest) (*GetFindFilesResultsResponse, error)
+	// DiffSnapshots compares two snapshots. The changed entries are written to a log that can be streamed with GetLogs.
+	// restic diff doesn't report sizes per entry, size deltas are only available in aggregate in the response's stats.
+	DiffSnapshots(context.Context, *DiffSnapshotsRequest) (*DiffSnapshotsResponse, error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(context.Context, *BackupRequest) (*emptypb.Empty, error)
 	// DoRepoTask schedules a repo task. It accepts a repo id and a task type and returns the scheduled operation's ID.
 	DoRepoTask(context.Context, *DoRepoTaskRequest) (*ScheduleTaskResponse, error)
 	// Forget schedules a forget operation. It accepts a plan id and returns the scheduled operation's ID.
 	Forget(context.Context, *ForgetRequest) (*ScheduleTaskResponse, error)
+	// PreviewForget simulates applying a retention policy with `restic forget --dry-run`, no snapshots are removed.
+	PreviewForget(context.Context, *PreviewForgetRequest) (*PreviewForgetResponse, error)
+	// PinSnapshot tags a snapshot so that it is always kept by retention policies.
+	PinSnapshot(context.Context, *PinSnapshotRequest) (*emptypb.Empty, error)
+	// UnpinSnapshot removes the pin from a snapshot, it is then subject to retention policies again.
+	UnpinSnapshot(context.Context, *UnpinSnapshotRequest) (*emptypb.Empty, error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *RestoreSnapshotRequest) (*ScheduleTaskResponse, error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -360,6 +529,8 @@ type BackrestServer interface {
 	GetLogs(*LogDataRequest, grpc.ServerStreamingServer[types.BytesValue]) error
 	// RunCommand executes a generic restic command on the repository.
 	RunCommand(context.Context, *RunCommandRequest) (*RunCommandResponse, error)
+	// TestHook fires a hook once, without applying its notify mode or error policy and without recording an operation.
+	TestHook(context.Context, *TestHookRequest) (*TestHookResponse, error)
 	// GetDownloadURL returns a signed download URL given an operation ID and file path.
 	GetDownloadURL(context.Context, *GetDownloadURLRequest) (*types.StringValue, error)
 	// Clears the history of operations
@@ -402,6 +573,18 @@ func (UnimplementedBackrestServer) RemoveRepo(context.Context, *RemoveRepoReques
 func (UnimplementedBackrestServer) GetOperationEvents(*emptypb.Empty, grpc.ServerStreamingServer[OperationEvent]) error {
 	return status.Error(codes.Unimplemented, "method GetOperationEvents not implemented")
 }
+func (UnimplementedBackrestServer) ListRepoKeys(context.Context, *ListRepoKeysRequest) (*ListRepoKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRepoKeys not implemented")
+}
+func (UnimplementedBackrestServer) AddRepoKey(context.Context, *AddRepoKeyRequest) (*RepoKey, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddRepoKey not implemented")
+}
+func (UnimplementedBackrestServer) RemoveRepoKey(context.Context, *RemoveRepoKeyRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveRepoKey not implemented")
+}
+func (UnimplementedBackrestServer) RotateRepoPassword(context.Context, *RotateRepoPasswordRequest) (*Config, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateRepoPassword not implemented")
+}
 func (UnimplementedBackrestServer) GetOperations(context.Context, *GetOperationsRequest) (*OperationList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOperations not implemented")
 }
@@ -411,6 +594,15 @@ func (UnimplementedBackrestServer) ListSnapshots(context.Context, *ListSnapshots
 func (UnimplementedBackrestServer) ListSnapshotFiles(context.Context, *ListSnapshotFilesRequest) (*ListSnapshotFilesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListSnapshotFiles not implemented")
 }
+func (UnimplementedBackrestServer) FindFiles(context.Context, *FindFilesRequest) (*ScheduleTaskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindFiles not implemented")
+}
+func (UnimplementedBackrestServer) GetFindFilesResults(context.Context, *GetFindFilesResultsRequest) (*GetFindFilesResultsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFindFilesResults not implemented")
+}
+func (UnimplementedBackrestServer) DiffSnapshots(context.Context, *DiffSnapshotsRequest) (*DiffSnapshotsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffSnapshots not implemented")
+}
 func (UnimplementedBackrestServer) Backup(context.Context, *BackupRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method Backup not implemented")
 }
@@ -420,6 +612,15 @@ func (UnimplementedBackrestServer) DoRepoTask(context.Context, *DoRepoTaskReques
 func (UnimplementedBackrestServer) Forget(context.Context, *ForgetRequest) (*ScheduleTaskResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Forget not implemented")
 }
+func (UnimplementedBackrestServer) PreviewForget(context.Context, *PreviewForgetRequest) (*PreviewForgetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewForget not implemented")
+}
+func (UnimplementedBackrestServer) PinSnapshot(context.Context, *PinSnapshotRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinSnapshot not implemented")
+}
+func (UnimplementedBackrestServer) UnpinSnapshot(context.Context, *UnpinSnapshotRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnpinSnapshot not implemented")
+}
 func (UnimplementedBackrestServer) Restore(context.Context, *RestoreSnapshotRequest) (*ScheduleTaskResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Restore not implemented")
 }
@@ -432,6 +633,9 @@ func (UnimplementedBackrestServer) GetLogs(*LogDataRequest, grpc.ServerStreaming
 func (UnimplementedBackrestServer) RunCommand(context.Context, *RunCommandRequest) (*RunCommandResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RunCommand not implemented")
 }
+func (UnimplementedBackrestServer) TestHook(context.Context, *TestHookRequest) (*TestHookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TestHook not implemented")
+}
 func (UnimplementedBackrestServer) GetDownloadURL(context.Context, *GetDownloadURLRequest) (*types.StringValue, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDownloadURL not implemented")
 }
@@ -587,6 +791,78 @@ func _Backrest_GetOperationEvents_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Backrest_GetOperationEventsServer = grpc.ServerStreamingServer[OperationEvent]
 
+func _Backrest_ListRepoKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRepoKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ListRepoKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ListRepoKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ListRepoKeys(ctx, req.(*ListRepoKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_AddRepoKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRepoKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).AddRepoKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_AddRepoKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).AddRepoKey(ctx, req.(*AddRepoKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_RemoveRepoKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRepoKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).RemoveRepoKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_RemoveRepoKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).RemoveRepoKey(ctx, req.(*RemoveRepoKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_RotateRepoPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateRepoPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).RotateRepoPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_RotateRepoPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).RotateRepoPassword(ctx, req.(*RotateRepoPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backrest_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOperationsRequest)
 	if err := dec(in); err != nil {
@@ -641,6 +917,60 @@ func _Backrest_ListSnapshotFiles_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backrest_FindFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).FindFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_FindFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).FindFiles(ctx, req.(*FindFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetFindFilesResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFindFilesResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetFindFilesResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetFindFilesResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetFindFilesResults(ctx, req.(*GetFindFilesResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_DiffSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).DiffSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_DiffSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).DiffSnapshots(ctx, req.(*DiffSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backrest_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BackupRequest)
 	if err := dec(in); err != nil {
@@ -695,6 +1025,60 @@ func _Backrest_Forget_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backrest_PreviewForget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewForgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).PreviewForget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_PreviewForget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).PreviewForget(ctx, req.(*PreviewForgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_PinSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).PinSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_PinSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).PinSnapshot(ctx, req.(*PinSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_UnpinSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).UnpinSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_UnpinSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).UnpinSnapshot(ctx, req.(*UnpinSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backrest_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RestoreSnapshotRequest)
 	if err := dec(in); err != nil {
@@ -760,6 +1144,24 @@ func _Backrest_RunCommand_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backrest_TestHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).TestHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_TestHook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).TestHook(ctx, req.(*TestHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backrest_GetDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDownloadURLRequest)
 	if err := dec(in); err != nil {
@@ -881,6 +1283,22 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRepo",
 			Handler:    _Backrest_RemoveRepo_Handler,
 		},
+		{
+			MethodName: "ListRepoKeys",
+			Handler:    _Backrest_ListRepoKeys_Handler,
+		},
+		{
+			MethodName: "AddRepoKey",
+			Handler:    _Backrest_AddRepoKey_Handler,
+		},
+		{
+			MethodName: "RemoveRepoKey",
+			Handler:    _Backrest_RemoveRepoKey_Handler,
+		},
+		{
+			MethodName: "RotateRepoPassword",
+			Handler:    _Backrest_RotateRepoPassword_Handler,
+		},
 		{
 			MethodName: "GetOperations",
 			Handler:    _Backrest_GetOperations_Handler,
@@ -893,6 +1311,18 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSnapshotFiles",
 			Handler:    _Backrest_ListSnapshotFiles_Handler,
 		},
+		{
+			MethodName: "FindFiles",
+			Handler:    _Backrest_FindFiles_Handler,
+		},
+		{
+			MethodName: "GetFindFilesResults",
+			Handler:    _Backrest_GetFindFilesResults_Handler,
+		},
+		{
+			MethodName: "DiffSnapshots",
+			Handler:    _Backrest_DiffSnapshots_Handler,
+		},
 		{
 			MethodName: "Backup",
 			Handler:    _Backrest_Backup_Handler,
@@ -905,6 +1335,18 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Forget",
 			Handler:    _Backrest_Forget_Handler,
 		},
+		{
+			MethodName: "PreviewForget",
+			Handler:    _Backrest_PreviewForget_Handler,
+		},
+		{
+			MethodName: "PinSnapshot",
+			Handler:    _Backrest_PinSnapshot_Handler,
+		},
+		{
+			MethodName: "UnpinSnapshot",
+			Handler:    _Backrest_UnpinSnapshot_Handler,
+		},
 		{
 			MethodName: "Restore",
 			Handler:    _Backrest_Restore_Handler,
@@ -917,6 +1359,10 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RunCommand",
 			Handler:    _Backrest_RunCommand_Handler,
 		},
+		{
+			MethodName: "TestHook",
+			Handler:    _Backrest_TestHook_Handler,
+		},
 		{
 			MethodName: "GetDownloadURL",
 			Handler:    _Backrest_GetDownloadURL_Handler,
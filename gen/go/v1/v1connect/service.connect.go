@@ -51,6 +51,15 @@ const (
 	// BackrestGetOperationEventsProcedure is the fully-qualified name of the Backrest's
 	// GetOperationEvents RPC.
 	BackrestGetOperationEventsProcedure = "/v1.Backrest/GetOperationEvents"
+	// BackrestListRepoKeysProcedure is the fully-qualified name of the Backrest's ListRepoKeys RPC.
+	BackrestListRepoKeysProcedure = "/v1.Backrest/ListRepoKeys"
+	// BackrestAddRepoKeyProcedure is the fully-qualified name of the Backrest's AddRepoKey RPC.
+	BackrestAddRepoKeyProcedure = "/v1.Backrest/AddRepoKey"
+	// BackrestRemoveRepoKeyProcedure is the fully-qualified name of the Backrest's RemoveRepoKey RPC.
+	BackrestRemoveRepoKeyProcedure = "/v1.Backrest/RemoveRepoKey"
+	// BackrestRotateRepoPasswordProcedure is the fully-qualified name of the Backrest's
+	// RotateRepoPassword RPC.
+	BackrestRotateRepoPasswordProcedure = "/v1.Backrest/RotateRepoPassword"
 	// BackrestGetOperationsProcedure is the fully-qualified name of the Backrest's GetOperations RPC.
 	BackrestGetOperationsProcedure = "/v1.Backrest/GetOperations"
 	// BackrestListSnapshotsProcedure is the fully-qualified name of the Backrest's ListSnapshots RPC.
@@ -58,12 +67,25 @@ const (
 	// BackrestListSnapshotFilesProcedure is the fully-qualified name of the Backrest's
 	// ListSnapshotFiles RPC.
 	BackrestListSnapshotFilesProcedure = "/v1.Backrest/ListSnapshotFiles"
+	// BackrestFindFilesProcedure is the fully-qualified name of the Backrest's FindFiles RPC.
+	BackrestFindFilesProcedure = "/v1.Backrest/FindFiles"
+	// BackrestGetFindFilesResultsProcedure is the fully-qualified name of the Backrest's
+	// GetFindFilesResults RPC.
+	BackrestGetFindFilesResultsProcedure = "/v1.Backrest/GetFindFilesResults"
+	// BackrestDiffSnapshotsProcedure is the fully-qualified name of the Backrest's DiffSnapshots RPC.
+	BackrestDiffSnapshotsProcedure = "/v1.Backrest/DiffSnapshots"
 	// BackrestBackupProcedure is the fully-qualified name of the Backrest's Backup RPC.
 	BackrestBackupProcedure = "/v1.Backrest/Backup"
 	// BackrestDoRepoTaskProcedure is the fully-qualified name of the Backrest's DoRepoTask RPC.
 	BackrestDoRepoTaskProcedure = "/v1.Backrest/DoRepoTask"
 	// BackrestForgetProcedure is the fully-qualified name of the Backrest's Forget RPC.
 	BackrestForgetProcedure = "/v1.Backrest/Forget"
+	// BackrestPreviewForgetProcedure is the fully-qualified name of the Backrest's PreviewForget RPC.
+	BackrestPreviewForgetProcedure = "/v1.Backrest/PreviewForget"
+	// BackrestPinSnapshotProcedure is the fully-qualified name of the Backrest's PinSnapshot RPC.
+	BackrestPinSnapshotProcedure = "/v1.Backrest/PinSnapshot"
+	// BackrestUnpinSnapshotProcedure is the fully-qualified name of the Backrest's UnpinSnapshot RPC.
+	BackrestUnpinSnapshotProcedure = "/v1.Backrest/UnpinSnapshot"
 	// BackrestRestoreProcedure is the fully-qualified name of the Backrest's Restore RPC.
 	BackrestRestoreProcedure = "/v1.Backrest/Restore"
 	// BackrestCancelProcedure is the fully-qualified name of the Backrest's Cancel RPC.
@@ -72,6 +94,8 @@ const (
 	BackrestGetLogsProcedure = "/v1.Backrest/GetLogs"
 	// BackrestRunCommandProcedure is the fully-qualified name of the Backrest's RunCommand RPC.
 	BackrestRunCommandProcedure = "/v1.Backrest/RunCommand"
+	// BackrestTestHookProcedure is the fully-qualified name of the Backrest's TestHook RPC.
+	BackrestTestHookProcedure = "/v1.Backrest/TestHook"
 	// BackrestGetDownloadURLProcedure is the fully-qualified name of the Backrest's GetDownloadURL RPC.
 	BackrestGetDownloadURLProcedure = "/v1.Backrest/GetDownloadURL"
 	// BackrestClearHistoryProcedure is the fully-qualified name of the Backrest's ClearHistory RPC.
@@ -96,15 +120,38 @@ type BackrestClient interface {
 	AddRepo(context.Context, *connect.Request[v1.AddRepoRequest]) (*connect.Response[v1.Config], error)
 	RemoveRepo(context.Context, *connect.Request[v1.RemoveRepoRequest]) (*connect.Response[v1.Config], error)
 	GetOperationEvents(context.Context, *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[v1.OperationEvent], error)
+	// ListRepoKeys lists the keys (passwords) that can open a repo.
+	ListRepoKeys(context.Context, *connect.Request[v1.ListRepoKeysRequest]) (*connect.Response[v1.ListRepoKeysResponse], error)
+	// AddRepoKey adds a key (password) to a repo. The repo's configured password is not changed.
+	AddRepoKey(context.Context, *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error)
+	// RemoveRepoKey removes a key from a repo. The key backrest uses to open the repo can not be removed.
+	RemoveRepoKey(context.Context, *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error)
+	// RotateRepoPassword replaces the repo's password: a key for the new password is added and verified,
+	// the config is updated, and then the key for the old password is removed.
+	RotateRepoPassword(context.Context, *connect.Request[v1.RotateRepoPasswordRequest]) (*connect.Response[v1.Config], error)
 	GetOperations(context.Context, *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error)
 	ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ResticSnapshotList], error)
 	ListSnapshotFiles(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[v1.ListSnapshotFilesResponse], error)
+	// FindFiles schedules a search for files across the snapshots of a repo and returns the search's operation ID.
+	// The search can be cancelled with Cancel and its results are fetched with GetFindFilesResults.
+	FindFiles(context.Context, *connect.Request[v1.FindFilesRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
+	// GetFindFilesResults returns a page of the results of a completed FindFiles operation.
+	GetFindFilesResults(context.Context, *connect.Request[v1.GetFindFilesResultsRequest]) (*connect.Response[v1.GetFindFilesResultsResponse], error)
+	// DiffSnapshots compares two snapshots. The changed entries are written to a log that can be streamed with GetLogs.
+	// restic diff doesn't report sizes per entry, size deltas are only available in aggregate in the response's stats.
+	DiffSnapshots(context.Context, *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.DiffSnapshotsResponse], error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(context.Context, *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error)
 	// DoRepoTask schedules a repo task. It accepts a repo id and a task type and returns the scheduled operation's ID.
 	DoRepoTask(context.Context, *connect.Request[v1.DoRepoTaskRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
 	// Forget schedules a forget operation. It accepts a plan id and returns the scheduled operation's ID.
 	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
+	// PreviewForget simulates applying a retention policy with `restic forget --dry-run`, no snapshots are removed.
+	PreviewForget(context.Context, *connect.Request[v1.PreviewForgetRequest]) (*connect.Response[v1.PreviewForgetResponse], error)
+	// PinSnapshot tags a snapshot so that it is always kept by retention policies.
+	PinSnapshot(context.Context, *connect.Request[v1.PinSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
+	// UnpinSnapshot removes the pin from a snapshot, it is then subject to retention policies again.
+	UnpinSnapshot(context.Context, *connect.Request[v1.UnpinSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -113,6 +160,8 @@ type BackrestClient interface {
 	GetLogs(context.Context, *connect.Request[v1.LogDataRequest]) (*connect.ServerStreamForClient[types.BytesValue], error)
 	// RunCommand executes a generic restic command on the repository.
 	RunCommand(context.Context, *connect.Request[v1.RunCommandRequest]) (*connect.Response[v1.RunCommandResponse], error)
+	// TestHook fires a hook once, without applying its notify mode or error policy and without recording an operation.
+	TestHook(context.Context, *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error)
 	// GetDownloadURL returns a signed download URL given an operation ID and file path.
 	GetDownloadURL(context.Context, *connect.Request[v1.GetDownloadURLRequest]) (*connect.Response[types.StringValue], error)
 	// Clears the history of operations
@@ -179,6 +228,30 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("GetOperationEvents")),
 			connect.WithClientOptions(opts...),
 		),
+		listRepoKeys: connect.NewClient[v1.ListRepoKeysRequest, v1.ListRepoKeysResponse](
+			httpClient,
+			baseURL+BackrestListRepoKeysProcedure,
+			connect.WithSchema(backrestMethods.ByName("ListRepoKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		addRepoKey: connect.NewClient[v1.AddRepoKeyRequest, v1.RepoKey](
+			httpClient,
+			baseURL+BackrestAddRepoKeyProcedure,
+			connect.WithSchema(backrestMethods.ByName("AddRepoKey")),
+			connect.WithClientOptions(opts...),
+		),
+		removeRepoKey: connect.NewClient[v1.RemoveRepoKeyRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestRemoveRepoKeyProcedure,
+			connect.WithSchema(backrestMethods.ByName("RemoveRepoKey")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateRepoPassword: connect.NewClient[v1.RotateRepoPasswordRequest, v1.Config](
+			httpClient,
+			baseURL+BackrestRotateRepoPasswordProcedure,
+			connect.WithSchema(backrestMethods.ByName("RotateRepoPassword")),
+			connect.WithClientOptions(opts...),
+		),
 		getOperations: connect.NewClient[v1.GetOperationsRequest, v1.OperationList](
 			httpClient,
 			baseURL+BackrestGetOperationsProcedure,
@@ -197,6 +270,24 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("ListSnapshotFiles")),
 			connect.WithClientOptions(opts...),
 		),
+		findFiles: connect.NewClient[v1.FindFilesRequest, v1.ScheduleTaskResponse](
+			httpClient,
+			baseURL+BackrestFindFilesProcedure,
+			connect.WithSchema(backrestMethods.ByName("FindFiles")),
+			connect.WithClientOptions(opts...),
+		),
+		getFindFilesResults: connect.NewClient[v1.GetFindFilesResultsRequest, v1.GetFindFilesResultsResponse](
+			httpClient,
+			baseURL+BackrestGetFindFilesResultsProcedure,
+			connect.WithSchema(backrestMethods.ByName("GetFindFilesResults")),
+			connect.WithClientOptions(opts...),
+		),
+		diffSnapshots: connect.NewClient[v1.DiffSnapshotsRequest, v1.DiffSnapshotsResponse](
+			httpClient,
+			baseURL+BackrestDiffSnapshotsProcedure,
+			connect.WithSchema(backrestMethods.ByName("DiffSnapshots")),
+			connect.WithClientOptions(opts...),
+		),
 		backup: connect.NewClient[v1.BackupRequest, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestBackupProcedure,
@@ -215,6 +306,24 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("Forget")),
 			connect.WithClientOptions(opts...),
 		),
+		previewForget: connect.NewClient[v1.PreviewForgetRequest, v1.PreviewForgetResponse](
+			httpClient,
+			baseURL+BackrestPreviewForgetProcedure,
+			connect.WithSchema(backrestMethods.ByName("PreviewForget")),
+			connect.WithClientOptions(opts...),
+		),
+		pinSnapshot: connect.NewClient[v1.PinSnapshotRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestPinSnapshotProcedure,
+			connect.WithSchema(backrestMethods.ByName("PinSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		unpinSnapshot: connect.NewClient[v1.UnpinSnapshotRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestUnpinSnapshotProcedure,
+			connect.WithSchema(backrestMethods.ByName("UnpinSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
 		restore: connect.NewClient[v1.RestoreSnapshotRequest, v1.ScheduleTaskResponse](
 			httpClient,
 			baseURL+BackrestRestoreProcedure,
@@ -239,6 +348,12 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("RunCommand")),
 			connect.WithClientOptions(opts...),
 		),
+		testHook: connect.NewClient[v1.TestHookRequest, v1.TestHookResponse](
+			httpClient,
+			baseURL+BackrestTestHookProcedure,
+			connect.WithSchema(backrestMethods.ByName("TestHook")),
+			connect.WithClientOptions(opts...),
+		),
 		getDownloadURL: connect.NewClient[v1.GetDownloadURLRequest, types.StringValue](
 			httpClient,
 			baseURL+BackrestGetDownloadURLProcedure,
@@ -281,16 +396,27 @@ type backrestClient struct {
 	addRepo              *connect.Client[v1.AddRepoRequest, v1.Config]
 	removeRepo           *connect.Client[v1.RemoveRepoRequest, v1.Config]
 	getOperationEvents   *connect.Client[emptypb.Empty, v1.OperationEvent]
+	listRepoKeys         *connect.Client[v1.ListRepoKeysRequest, v1.ListRepoKeysResponse]
+	addRepoKey           *connect.Client[v1.AddRepoKeyRequest, v1.RepoKey]
+	removeRepoKey        *connect.Client[v1.RemoveRepoKeyRequest, emptypb.Empty]
+	rotateRepoPassword   *connect.Client[v1.RotateRepoPasswordRequest, v1.Config]
 	getOperations        *connect.Client[v1.GetOperationsRequest, v1.OperationList]
 	listSnapshots        *connect.Client[v1.ListSnapshotsRequest, v1.ResticSnapshotList]
 	listSnapshotFiles    *connect.Client[v1.ListSnapshotFilesRequest, v1.ListSnapshotFilesResponse]
+	findFiles            *connect.Client[v1.FindFilesRequest, v1.ScheduleTaskResponse]
+	getFindFilesResults  *connect.Client[v1.GetFindFilesResultsRequest, v1.GetFindFilesResultsResponse]
+	diffSnapshots        *connect.Client[v1.DiffSnapshotsRequest, v1.DiffSnapshotsResponse]
 	backup               *connect.Client[v1.BackupRequest, emptypb.Empty]
 	doRepoTask           *connect.Client[v1.DoRepoTaskRequest, v1.ScheduleTaskResponse]
 	forget               *connect.Client[v1.ForgetRequest, v1.ScheduleTaskResponse]
+	previewForget        *connect.Client[v1.PreviewForgetRequest, v1.PreviewForgetResponse]
+	pinSnapshot          *connect.Client[v1.PinSnapshotRequest, emptypb.Empty]
+	unpinSnapshot        *connect.Client[v1.UnpinSnapshotRequest, emptypb.Empty]
 	restore              *connect.Client[v1.RestoreSnapshotRequest, v1.ScheduleTaskResponse]
 	cancel               *connect.Client[v1.CancelOperationRequest, emptypb.Empty]
 	getLogs              *connect.Client[v1.LogDataRequest, types.BytesValue]
 	runCommand           *connect.Client[v1.RunCommandRequest, v1.RunCommandResponse]
+	testHook             *connect.Client[v1.TestHookRequest, v1.TestHookResponse]
 	getDownloadURL       *connect.Client[v1.GetDownloadURLRequest, types.StringValue]
 	clearHistory         *connect.Client[v1.ClearHistoryRequest, emptypb.Empty]
 	pathAutocomplete     *connect.Client[types.StringValue, types.StringList]
@@ -333,6 +459,26 @@ func (c *backrestClient) GetOperationEvents(ctx context.Context, req *connect.Re
 	return c.getOperationEvents.CallServerStream(ctx, req)
 }
 
+// ListRepoKeys calls v1.Backrest.ListRepoKeys.
+func (c *backrestClient) ListRepoKeys(ctx context.Context, req *connect.Request[v1.ListRepoKeysRequest]) (*connect.Response[v1.ListRepoKeysResponse], error) {
+	return c.listRepoKeys.CallUnary(ctx, req)
+}
+
+// AddRepoKey calls v1.Backrest.AddRepoKey.
+func (c *backrestClient) AddRepoKey(ctx context.Context, req *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error) {
+	return c.addRepoKey.CallUnary(ctx, req)
+}
+
+// RemoveRepoKey calls v1.Backrest.RemoveRepoKey.
+func (c *backrestClient) RemoveRepoKey(ctx context.Context, req *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.removeRepoKey.CallUnary(ctx, req)
+}
+
+// RotateRepoPassword calls v1.Backrest.RotateRepoPassword.
+func (c *backrestClient) RotateRepoPassword(ctx context.Context, req *connect.Request[v1.RotateRepoPasswordRequest]) (*connect.Response[v1.Config], error) {
+	return c.rotateRepoPassword.CallUnary(ctx, req)
+}
+
 // GetOperations calls v1.Backrest.GetOperations.
 func (c *backrestClient) GetOperations(ctx context.Context, req *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error) {
 	return c.getOperations.CallUnary(ctx, req)
@@ -348,6 +494,21 @@ func (c *backrestClient) ListSnapshotFiles(ctx context.Context, req *connect.Req
 	return c.listSnapshotFiles.CallUnary(ctx, req)
 }
 
+// FindFiles calls v1.Backrest.FindFiles.
+func (c *backrestClient) FindFiles(ctx context.Context, req *connect.Request[v1.FindFilesRequest]) (*connect.Response[v1.ScheduleTaskResponse], error) {
+	return c.findFiles.CallUnary(ctx, req)
+}
+
+// GetFindFilesResults calls v1.Backrest.GetFindFilesResults.
+func (c *backrestClient) GetFindFilesResults(ctx context.Context, req *connect.Request[v1.GetFindFilesResultsRequest]) (*connect.Response[v1.GetFindFilesResultsResponse], error) {
+	return c.getFindFilesResults.CallUnary(ctx, req)
+}
+
+// DiffSnapshots calls v1.Backrest.DiffSnapshots.
+func (c *backrestClient) DiffSnapshots(ctx context.Context, req *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.DiffSnapshotsResponse], error) {
+	return c.diffSnapshots.CallUnary(ctx, req)
+}
+
 // Backup calls v1.Backrest.Backup.
 func (c *backrestClient) Backup(ctx context.Context, req *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.backup.CallUnary(ctx, req)
@@ -363,6 +524,21 @@ func (c *backrestClient) Forget(ctx context.Context, req *connect.Request[v1.For
 	return c.forget.CallUnary(ctx, req)
 }
 
+// PreviewForget calls v1.Backrest.PreviewForget.
+func (c *backrestClient) PreviewForget(ctx context.Context, req *connect.Request[v1.PreviewForgetRequest]) (*connect.Response[v1.PreviewForgetResponse], error) {
+	return c.previewForget.CallUnary(ctx, req)
+}
+
+// PinSnapshot calls v1.Backrest.PinSnapshot.
+func (c *backrestClient) PinSnapshot(ctx context.Context, req *connect.Request[v1.PinSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.pinSnapshot.CallUnary(ctx, req)
+}
+
+// UnpinSnapshot calls v1.Backrest.UnpinSnapshot.
+func (c *backrestClient) UnpinSnapshot(ctx context.Context, req *connect.Request[v1.UnpinSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.unpinSnapshot.CallUnary(ctx, req)
+}
+
 // Restore calls v1.Backrest.Restore.
 func (c *backrestClient) Restore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.ScheduleTaskResponse], error) {
 	return c.restore.CallUnary(ctx, req)
@@ -383,6 +559,11 @@ func (c *backrestClient) RunCommand(ctx context.Context, req *connect.Request[v1
 	return c.runCommand.CallUnary(ctx, req)
 }
 
+// TestHook calls v1.Backrest.TestHook.
+func (c *backrestClient) TestHook(ctx context.Context, req *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error) {
+	return c.testHook.CallUnary(ctx, req)
+}
+
 // GetDownloadURL calls v1.Backrest.GetDownloadURL.
 func (c *backrestClient) GetDownloadURL(ctx context.Context, req *connect.Request[v1.GetDownloadURLRequest]) (*connect.Response[types.StringValue], error) {
 	return c.getDownloadURL.CallUnary(ctx, req)
@@ -417,15 +598,38 @@ type BackrestHandler interface {
 	AddRepo(context.Context, *connect.Request[v1.AddRepoRequest]) (*connect.Response[v1.Config], error)
 	RemoveRepo(context.Context, *connect.Request[v1.RemoveRepoRequest]) (*connect.Response[v1.Config], error)
 	GetOperationEvents(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.OperationEvent]) error
+	// ListRepoKeys lists the keys (passwords) that can open a repo.
+	ListRepoKeys(context.Context, *connect.Request[v1.ListRepoKeysRequest]) (*connect.Response[v1.ListRepoKeysResponse], error)
+	// AddRepoKey adds a key (password) to a repo. The repo's configured password is not changed.
+	AddRepoKey(context.Context, *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error)
+	// RemoveRepoKey removes a key from a repo. The key backrest uses to open the repo can not be removed.
+	RemoveRepoKey(context.Context, *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error)
+	// RotateRepoPassword replaces the repo's password: a key for the new password is added and verified,
+	// the config is updated, and then the key for the old password is removed.
+	RotateRepoPassword(context.Context, *connect.Request[v1.RotateRepoPasswordRequest]) (*connect.Response[v1.Config], error)
 	GetOperations(context.Context, *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error)
 	ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ResticSnapshotList], error)
 	ListSnapshotFiles(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[v1.ListSnapshotFilesResponse], error)
+	// FindFiles schedules a search for files across the snapshots of a repo and returns the search's operation ID.
+	// The search can be cancelled with Cancel and its results are fetched with GetFindFilesResults.
+	FindFiles(context.Context, *connect.Request[v1.FindFilesRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
+	// GetFindFilesResults returns a page of the results of a completed FindFiles operation.
+	GetFindFilesResults(context.Context, *connect.Request[v1.GetFindFilesResultsRequest]) (*connect.Response[v1.GetFindFilesResultsResponse], error)
+	// DiffSnapshots compares two snapshots. The changed entries are written to a log that can be streamed with GetLogs.
+	// restic diff doesn't report sizes per entry, size deltas are only available in aggregate in the response's stats.
+	DiffSnapshots(context.Context, *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.DiffSnapshotsResponse], error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(context.Context, *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error)
 	// DoRepoTask schedules a repo task. It accepts a repo id and a task type and returns the scheduled operation's ID.
 	DoRepoTask(context.Context, *connect.Request[v1.DoRepoTaskRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
 	// Forget schedules a forget operation. It accepts a plan id and returns the scheduled operation's ID.
 	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
+	// PreviewForget simulates applying a retention policy with `restic forget --dry-run`, no snapshots are removed.
+	PreviewForget(context.Context, *connect.Request[v1.PreviewForgetRequest]) (*connect.Response[v1.PreviewForgetResponse], error)
+	// PinSnapshot tags a snapshot so that it is always kept by retention policies.
+	PinSnapshot(context.Context, *connect.Request[v1.PinSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
+	// UnpinSnapshot removes the pin from a snapshot, it is then subject to retention policies again.
+	UnpinSnapshot(context.Context, *connect.Request[v1.UnpinSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.ScheduleTaskResponse], error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -434,6 +638,8 @@ type BackrestHandler interface {
 	GetLogs(context.Context, *connect.Request[v1.LogDataRequest], *connect.ServerStream[types.BytesValue]) error
 	// RunCommand executes a generic restic command on the repository.
 	RunCommand(context.Context, *connect.Request[v1.RunCommandRequest]) (*connect.Response[v1.RunCommandResponse], error)
+	// TestHook fires a hook once, without applying its notify mode or error policy and without recording an operation.
+	TestHook(context.Context, *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error)
 	// GetDownloadURL returns a signed download URL given an operation ID and file path.
 	GetDownloadURL(context.Context, *connect.Request[v1.GetDownloadURLRequest]) (*connect.Response[types.StringValue], error)
 	// Clears the history of operations
@@ -496,6 +702,30 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("GetOperationEvents")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestListRepoKeysHandler := connect.NewUnaryHandler(
+		BackrestListRepoKeysProcedure,
+		svc.ListRepoKeys,
+		connect.WithSchema(backrestMethods.ByName("ListRepoKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestAddRepoKeyHandler := connect.NewUnaryHandler(
+		BackrestAddRepoKeyProcedure,
+		svc.AddRepoKey,
+		connect.WithSchema(backrestMethods.ByName("AddRepoKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRemoveRepoKeyHandler := connect.NewUnaryHandler(
+		BackrestRemoveRepoKeyProcedure,
+		svc.RemoveRepoKey,
+		connect.WithSchema(backrestMethods.ByName("RemoveRepoKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRotateRepoPasswordHandler := connect.NewUnaryHandler(
+		BackrestRotateRepoPasswordProcedure,
+		svc.RotateRepoPassword,
+		connect.WithSchema(backrestMethods.ByName("RotateRepoPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestGetOperationsHandler := connect.NewUnaryHandler(
 		BackrestGetOperationsProcedure,
 		svc.GetOperations,
@@ -514,6 +744,24 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("ListSnapshotFiles")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestFindFilesHandler := connect.NewUnaryHandler(
+		BackrestFindFilesProcedure,
+		svc.FindFiles,
+		connect.WithSchema(backrestMethods.ByName("FindFiles")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetFindFilesResultsHandler := connect.NewUnaryHandler(
+		BackrestGetFindFilesResultsProcedure,
+		svc.GetFindFilesResults,
+		connect.WithSchema(backrestMethods.ByName("GetFindFilesResults")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestDiffSnapshotsHandler := connect.NewUnaryHandler(
+		BackrestDiffSnapshotsProcedure,
+		svc.DiffSnapshots,
+		connect.WithSchema(backrestMethods.ByName("DiffSnapshots")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestBackupHandler := connect.NewUnaryHandler(
 		BackrestBackupProcedure,
 		svc.Backup,
@@ -532,6 +780,24 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("Forget")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestPreviewForgetHandler := connect.NewUnaryHandler(
+		BackrestPreviewForgetProcedure,
+		svc.PreviewForget,
+		connect.WithSchema(backrestMethods.ByName("PreviewForget")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestPinSnapshotHandler := connect.NewUnaryHandler(
+		BackrestPinSnapshotProcedure,
+		svc.PinSnapshot,
+		connect.WithSchema(backrestMethods.ByName("PinSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestUnpinSnapshotHandler := connect.NewUnaryHandler(
+		BackrestUnpinSnapshotProcedure,
+		svc.UnpinSnapshot,
+		connect.WithSchema(backrestMethods.ByName("UnpinSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestRestoreHandler := connect.NewUnaryHandler(
 		BackrestRestoreProcedure,
 		svc.Restore,
@@ -556,6 +822,12 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("RunCommand")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestTestHookHandler := connect.NewUnaryHandler(
+		BackrestTestHookProcedure,
+		svc.TestHook,
+		connect.WithSchema(backrestMethods.ByName("TestHook")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestGetDownloadURLHandler := connect.NewUnaryHandler(
 		BackrestGetDownloadURLProcedure,
 		svc.GetDownloadURL,
@@ -602,18 +874,38 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 			backrestRemoveRepoHandler.ServeHTTP(w, r)
 		case BackrestGetOperationEventsProcedure:
 			backrestGetOperationEventsHandler.ServeHTTP(w, r)
+		case BackrestListRepoKeysProcedure:
+			backrestListRepoKeysHandler.ServeHTTP(w, r)
+		case BackrestAddRepoKeyProcedure:
+			backrestAddRepoKeyHandler.ServeHTTP(w, r)
+		case BackrestRemoveRepoKeyProcedure:
+			backrestRemoveRepoKeyHandler.ServeHTTP(w, r)
+		case BackrestRotateRepoPasswordProcedure:
+			backrestRotateRepoPasswordHandler.ServeHTTP(w, r)
 		case BackrestGetOperationsProcedure:
 			backrestGetOperationsHandler.ServeHTTP(w, r)
 		case BackrestListSnapshotsProcedure:
 			backrestListSnapshotsHandler.ServeHTTP(w, r)
 		case BackrestListSnapshotFilesProcedure:
 			backrestListSnapshotFilesHandler.ServeHTTP(w, r)
+		case BackrestFindFilesProcedure:
+			backrestFindFilesHandler.ServeHTTP(w, r)
+		case BackrestGetFindFilesResultsProcedure:
+			backrestGetFindFilesResultsHandler.ServeHTTP(w, r)
+		case BackrestDiffSnapshotsProcedure:
+			backrestDiffSnapshotsHandler.ServeHTTP(w, r)
 		case BackrestBackupProcedure:
 			backrestBackupHandler.ServeHTTP(w, r)
 		case BackrestDoRepoTaskProcedure:
 			backrestDoRepoTaskHandler.ServeHTTP(w, r)
 		case BackrestForgetProcedure:
 			backrestForgetHandler.ServeHTTP(w, r)
+		case BackrestPreviewForgetProcedure:
+			backrestPreviewForgetHandler.ServeHTTP(w, r)
+		case BackrestPinSnapshotProcedure:
+			backrestPinSnapshotHandler.ServeHTTP(w, r)
+		case BackrestUnpinSnapshotProcedure:
+			backrestUnpinSnapshotHandler.ServeHTTP(w, r)
 		case BackrestRestoreProcedure:
 			backrestRestoreHandler.ServeHTTP(w, r)
 		case BackrestCancelProcedure:
@@ -622,6 +914,8 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 			backrestGetLogsHandler.ServeHTTP(w, r)
 		case BackrestRunCommandProcedure:
 			backrestRunCommandHandler.ServeHTTP(w, r)
+		case BackrestTestHookProcedure:
+			backrestTestHookHandler.ServeHTTP(w, r)
 		case BackrestGetDownloadURLProcedure:
 			backrestGetDownloadURLHandler.ServeHTTP(w, r)
 		case BackrestClearHistoryProcedure:
@@ -669,6 +963,22 @@ func (UnimplementedBackrestHandler) GetOperationEvents(context.Context, *connect
 	return connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetOperationEvents is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) ListRepoKeys(context.Context, *connect.Request[v1.ListRepoKeysRequest]) (*connect.Response[v1.ListRepoKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ListRepoKeys is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) AddRepoKey(context.Context, *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.AddRepoKey is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RemoveRepoKey(context.Context, *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RemoveRepoKey is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RotateRepoPassword(context.Context, *connect.Request[v1.RotateRepoPasswordRequest]) (*connect.Response[v1.Config], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RotateRepoPassword is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) GetOperations(context.Context, *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetOperations is not implemented"))
 }
@@ -681,6 +991,18 @@ func (UnimplementedBackrestHandler) ListSnapshotFiles(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ListSnapshotFiles is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) FindFiles(context.Context, *connect.Request[v1.FindFilesRequest]) (*connect.Response[v1.ScheduleTaskResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.FindFiles is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetFindFilesResults(context.Context, *connect.Request[v1.GetFindFilesResultsRequest]) (*connect.Response[v1.GetFindFilesResultsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetFindFilesResults is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) DiffSnapshots(context.Context, *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.DiffSnapshotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.DiffSnapshots is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Backup(context.Context, *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Backup is not implemented"))
 }
@@ -693,6 +1015,18 @@ func (UnimplementedBackrestHandler) Forget(context.Context, *connect.Request[v1.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Forget is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) PreviewForget(context.Context, *connect.Request[v1.PreviewForgetRequest]) (*connect.Response[v1.PreviewForgetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PreviewForget is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) PinSnapshot(context.Context, *connect.Request[v1.PinSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PinSnapshot is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) UnpinSnapshot(context.Context, *connect.Request[v1.UnpinSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.UnpinSnapshot is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.ScheduleTaskResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Restore is not implemented"))
 }
@@ -709,6 +1043,10 @@ func (UnimplementedBackrestHandler) RunCommand(context.Context, *connect.Request
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RunCommand is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) TestHook(context.Context, *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.TestHook is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) GetDownloadURL(context.Context, *connect.Request[v1.GetDownloadURLRequest]) (*connect.Response[types.StringValue], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetDownloadURL is not implemented"))
 }
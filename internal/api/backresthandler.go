@@ -1,8 +1,10 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -32,7 +34,9 @@ import (
 	"github.com/garethgeorge/backrest/internal/protoutil"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
 	"github.com/garethgeorge/backrest/pkg/restic"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -69,13 +73,26 @@ func (s *BackrestHandler) GetConfig(ctx context.Context, req *connect.Request[em
 	return connect.NewResponse(config.SanitizeForNetwork(c)), nil
 }
 
+// ConfirmRetentionChangeHeader confirms a SetConfig request that changes a retention policy in a way that would remove
+// more than env.RetentionGuardPercent of the snapshots it applies to.
+const ConfirmRetentionChangeHeader = "Backrest-Confirm-Retention-Change"
+
 // SetConfig implements POST /v1/config
 func (s *BackrestHandler) SetConfig(ctx context.Context, req *connect.Request[v1.Config]) (*connect.Response[v1.Config], error) {
+	if guardPercent := env.RetentionGuardPercent(); guardPercent > 0 && req.Header().Get(ConfirmRetentionChangeHeader) != "true" {
+		if err := s.checkRetentionChanges(ctx, req.Msg, guardPercent); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.config.Transform(func(cfg *v1.Config) (*v1.Config, error) {
 		if cfg.Modno != req.Msg.Modno {
 			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("config modno mismatch, reload and try again"))
 		}
 		rehydrated := config.RehydrateNetworkSanitizedConfig(req.Msg, cfg)
+		if err := config.ValidateRehydratedConfig(rehydrated); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("validation error: %w", err))
+		}
 		if err := config.ValidateConfig(rehydrated); err != nil {
 			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("validation error: %w", err))
 		}
@@ -92,6 +109,54 @@ func (s *BackrestHandler) SetConfig(ctx context.Context, req *connect.Request[v1
 	return connect.NewResponse(config.SanitizeForNetwork(newConfig)), nil
 }
 
+// checkRetentionChanges previews the retention policies that newConfig changes on existing plans and repos, and returns
+// a FailedPrecondition error if any would remove more than guardPercent of the snapshots it applies to.
+func (s *BackrestHandler) checkRetentionChanges(ctx context.Context, newConfig *v1.Config, guardPercent float64) error {
+	cfg, err := s.config.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	check := func(what, repoID, planID string, policy *v1.RetentionPolicy, groupBy string) error {
+		if policy.GetPolicy() == nil || policy.GetPolicyKeepAll() {
+			return nil
+		}
+		preview, err := s.previewForget(ctx, cfg.Instance, repoID, planID, policy, groupBy)
+		if err != nil {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("couldn't preview the retention change for %s, set the %s header to save anyway: %w", what, ConfirmRetentionChangeHeader, err))
+		}
+		total := len(preview.Keep) + len(preview.Remove)
+		if total == 0 {
+			return nil
+		}
+		if percent := float64(len(preview.Remove)) * 100 / float64(total); percent > guardPercent {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("retention change for %s would remove %d of %d snapshots (%.0f%%), set the %s header to confirm", what, len(preview.Remove), total, percent, ConfirmRetentionChangeHeader))
+		}
+		return nil
+	}
+
+	for _, plan := range newConfig.Plans {
+		oldPlan := config.FindPlan(cfg, plan.Id)
+		if oldPlan == nil || oldPlan.Repo != plan.Repo || proto.Equal(oldPlan.Retention, plan.Retention) {
+			continue
+		}
+		if err := check(fmt.Sprintf("plan %q", plan.Id), plan.Repo, plan.Id, plan.Retention, ""); err != nil {
+			return err
+		}
+	}
+	for _, r := range newConfig.Repos {
+		oldRepo := config.FindRepo(cfg, r.Id)
+		if oldRepo == nil || (proto.Equal(oldRepo.GetForgetPolicy().GetRetention(), r.GetForgetPolicy().GetRetention()) &&
+			protoutil.ForgetGroupBy(oldRepo.GetForgetPolicy()) == protoutil.ForgetGroupBy(r.GetForgetPolicy())) {
+			continue
+		}
+		if err := check(fmt.Sprintf("repo %q", r.Id), r.Id, "", r.GetForgetPolicy().GetRetention(), protoutil.ForgetGroupBy(r.GetForgetPolicy())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // cloneConfigWithRepo fetches the current config, sanitizes and upserts r into a clone of it,
 // then locates the restic binary and builds a RepoOrchestrator for r against the cloned config.
 // oldRepo is the previously-existing repo with the same ID, or nil if r is new.
@@ -309,6 +374,151 @@ func (s *BackrestHandler) RemoveRepo(ctx context.Context, req *connect.Request[v
 	return connect.NewResponse(config.SanitizeForNetwork(newConfig)), nil
 }
 
+func (s *BackrestHandler) ListRepoKeys(ctx context.Context, req *connect.Request[v1.ListRepoKeysRequest]) (*connect.Response[v1.ListRepoKeysResponse], error) {
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, withLookupCode(fmt.Errorf("failed to get repo: %w", err))
+	}
+
+	keys, err := repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	return connect.NewResponse(&v1.ListRepoKeysResponse{Keys: keys}), nil
+}
+
+func (s *BackrestHandler) AddRepoKey(ctx context.Context, req *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error) {
+	if req.Msg.Password == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password must not be empty"))
+	}
+
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, withLookupCode(fmt.Errorf("failed to get repo: %w", err))
+	}
+
+	key, err := repo.AddKey(ctx, req.Msg.Password, req.Msg.Username, req.Msg.Hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add key: %w", err)
+	}
+	return connect.NewResponse(key), nil
+}
+
+func (s *BackrestHandler) RemoveRepoKey(ctx context.Context, req *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.KeyId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("must specify keyId"))
+	}
+
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, withLookupCode(fmt.Errorf("failed to get repo: %w", err))
+	}
+
+	keys, err := repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	idx := slices.IndexFunc(keys, func(k *v1.RepoKey) bool { return strings.HasPrefix(k.Id, req.Msg.KeyId) })
+	if idx == -1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("key %q not found in repo %q", req.Msg.KeyId, req.Msg.RepoId))
+	} else if keys[idx].Current {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("key %q is the key backrest uses to open repo %q, rotate the repo's password instead", req.Msg.KeyId, req.Msg.RepoId))
+	}
+
+	if err := repo.RemoveKey(ctx, keys[idx].Id); err != nil {
+		return nil, fmt.Errorf("failed to remove key: %w", err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *BackrestHandler) RotateRepoPassword(ctx context.Context, req *connect.Request[v1.RotateRepoPasswordRequest]) (*connect.Response[v1.Config], error) {
+	if req.Msg.NewPassword == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password must not be empty"))
+	}
+
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	repoCfg := config.FindRepo(cfg, req.Msg.RepoId)
+	if repoCfg == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", req.Msg.RepoId))
+	}
+	// Only the password field is managed by backrest, a password provided by the environment can't be rotated here.
+	oldPassword := repoCfg.GetPassword()
+	if oldPassword == "" || slices.ContainsFunc(repoCfg.GetEnv(), func(e string) bool { return strings.HasPrefix(e, "RESTIC_PASSWORD") }) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("repo %q does not use a password set in backrest's config", req.Msg.RepoId))
+	}
+	if req.Msg.NewPassword == oldPassword {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password must differ from the current password"))
+	}
+
+	oldRepo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, withLookupCode(fmt.Errorf("failed to get repo: %w", err))
+	}
+	keys, err := oldRepo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	oldKeyIdx := slices.IndexFunc(keys, func(k *v1.RepoKey) bool { return k.Current })
+	if oldKeyIdx == -1 {
+		return nil, errors.New("failed to identify the key for the current password")
+	}
+	oldKeyID := keys[oldKeyIdx].Id
+
+	newKey, err := oldRepo.AddKey(ctx, req.Msg.NewPassword, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to add key for new password: %w", err)
+	}
+	// removeNewKey undoes the rotation if it fails before the config is updated.
+	removeNewKey := func() {
+		if err := oldRepo.RemoveKey(context.WithoutCancel(ctx), newKey.Id); err != nil {
+			zap.L().Error("failed to remove key added for password rotation", zap.String("repo", req.Msg.RepoId), zap.String("key", newKey.Id), zap.Error(err))
+		}
+	}
+
+	// Verify that the repo opens with the new password before committing to it.
+	rotatedRepo := proto.Clone(repoCfg).(*v1.Repo)
+	rotatedRepo.Password = req.Msg.NewPassword
+	_, _, newRepo, err := s.cloneConfigWithRepo(rotatedRepo)
+	if err != nil {
+		removeNewKey()
+		return nil, err
+	}
+	if err := newRepo.Exists(ctx); err != nil {
+		removeNewKey()
+		return nil, fmt.Errorf("failed to open repo with new password: %w", err)
+	}
+
+	if err := s.config.Transform(func(cfg *v1.Config) (*v1.Config, error) {
+		repoCfg := config.FindRepo(cfg, req.Msg.RepoId)
+		if repoCfg == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", req.Msg.RepoId))
+		}
+		if repoCfg.Password != oldPassword {
+			return nil, connect.NewError(connect.CodeAborted, errors.New("repo password was changed concurrently"))
+		}
+		repoCfg.Password = req.Msg.NewPassword
+		cfg.Modno++
+		return cfg, nil
+	}); err != nil {
+		removeNewKey()
+		return nil, fmt.Errorf("failed to update config: %w", err)
+	}
+
+	// The old key is removed with the new password, restic refuses to remove the key that opened the repo.
+	if err := newRepo.RemoveKey(ctx, oldKeyID); err != nil {
+		return nil, fmt.Errorf("password rotated but failed to remove the key %q for the old password, remove it with RemoveRepoKey: %w", oldKeyID, err)
+	}
+
+	newConfig, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	return connect.NewResponse(config.SanitizeForNetwork(newConfig)), nil
+}
+
 // SetupSftp implements SetupSftp RPC
 func (s *BackrestHandler) SetupSftp(ctx context.Context, req *connect.Request[v1.SetupSftpRequest]) (*connect.Response[v1.SetupSftpResponse], error) {
 	host := req.Msg.Host
@@ -396,6 +606,154 @@ func (s *BackrestHandler) ListSnapshotFiles(ctx context.Context, req *connect.Re
 	}), nil
 }
 
+func (s *BackrestHandler) FindFiles(ctx context.Context, req *connect.Request[v1.FindFilesRequest]) (*connect.Response[v1.ScheduleTaskResponse], error) {
+	query := req.Msg.Query
+	if strings.TrimSpace(query.GetPattern()) == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("must specify a pattern to search for"))
+	}
+
+	repo, err := s.orchestrator.GetRepo(req.Msg.RepoId)
+	if err != nil {
+		return nil, withLookupCode(err)
+	}
+
+	planID := query.GetPlanId()
+	if planID == "" {
+		planID = tasks.PlanForSystemTasks
+	}
+
+	id, err := s.orchestrator.ScheduleTask(tasks.NewOneoffFindFilesTask(repo, planID, time.Now(), query), tasks.TaskPriorityInteractive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule find files task: %w", err)
+	}
+	return connect.NewResponse(&v1.ScheduleTaskResponse{OperationId: id}), nil
+}
+
+const (
+	defaultFindFilesResultsLimit = 100
+	maxFindFilesResultsLimit     = 1000
+)
+
+func (s *BackrestHandler) GetFindFilesResults(ctx context.Context, req *connect.Request[v1.GetFindFilesResultsRequest]) (*connect.Response[v1.GetFindFilesResultsResponse], error) {
+	op, err := s.oplog.Get(req.Msg.OperationId)
+	if err != nil {
+		if errors.Is(err, oplog.ErrNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("failed to get operation %v: %w", req.Msg.OperationId, err))
+		}
+		return nil, fmt.Errorf("failed to get operation %v: %w", req.Msg.OperationId, err)
+	}
+
+	findOp := op.GetOperationFindFiles()
+	if findOp == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("operation %v is not a find files operation", req.Msg.OperationId))
+	}
+	if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_INPROGRESS {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("operation %v has not finished", req.Msg.OperationId))
+	}
+
+	offset := max(req.Msg.Offset, 0)
+	limit := int64(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultFindFilesResultsLimit
+	}
+	limit = min(limit, maxFindFilesResultsLimit)
+
+	resp := &v1.GetFindFilesResultsResponse{
+		Total: findOp.MatchCount,
+	}
+	if findOp.ResultsLogref == "" || offset >= findOp.MatchCount {
+		return connect.NewResponse(resp), nil
+	}
+
+	r, err := s.logStore.Open(findOp.ResultsLogref)
+	if err != nil {
+		if errors.Is(err, logstore.ErrLogNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("results for operation %v not found: %w", req.Msg.OperationId, err))
+		}
+		return nil, fmt.Errorf("failed to open results for operation %v: %w", req.Msg.OperationId, err)
+	}
+	defer r.Close()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var line int64
+	for scanner.Scan() && int64(len(resp.Matches)) < limit {
+		line++
+		if line <= offset {
+			continue
+		}
+		match := &v1.FileMatch{}
+		if err := protojson.Unmarshal(scanner.Bytes(), match); err != nil {
+			return nil, fmt.Errorf("failed to parse result %d of operation %v: %w", line, req.Msg.OperationId, err)
+		}
+		resp.Matches = append(resp.Matches, match)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read results for operation %v: %w", req.Msg.OperationId, err)
+	}
+
+	if next := offset + int64(len(resp.Matches)); next < findOp.MatchCount {
+		resp.NextOffset = next
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// diffLogTTL is how long the logs holding DiffSnapshots results are retained.
+const diffLogTTL = 24 * time.Hour
+
+func (s *BackrestHandler) DiffSnapshots(ctx context.Context, req *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.DiffSnapshotsResponse], error) {
+	query := req.Msg
+	if query.SnapshotId == "" || query.OtherSnapshotId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("must specify snapshotId and otherSnapshotId"))
+	}
+
+	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
+	if err != nil {
+		return nil, withLookupCode(fmt.Errorf("failed to get repo: %w", err))
+	}
+
+	// Entries are streamed to the logstore rather than buffered, diffs between large snapshots can be huge.
+	logID := "diff-" + uuid.New().String()
+	w, err := s.logStore.Create(logID, 0, diffLogTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create diff log: %w", err)
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	// restic diff has no per entry sizes, the size delta is only reported in aggregate in stats.
+	var writeErr error
+	stats, err := repo.Diff(ctx, query.SnapshotId, query.OtherSnapshotId, query.Path, func(entry *restic.DiffEntry) {
+		if writeErr != nil {
+			return
+		}
+		writeErr = enc.Encode(struct {
+			Path     string `json:"path"`
+			Modifier string `json:"modifier"`
+		}{entry.Path, entry.Modifier})
+	})
+	if err == nil {
+		err = writeErr
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if e := w.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		if e := s.logStore.Delete(logID); e != nil {
+			zap.L().Warn("failed to delete diff log", zap.String("logref", logID), zap.Error(e))
+		}
+		return nil, fmt.Errorf("failed to diff snapshots: %w", err)
+	}
+
+	return connect.NewResponse(&v1.DiffSnapshotsResponse{
+		Logref: logID,
+		Stats:  stats,
+	}), nil
+}
+
 // GetOperationEvents implements GET /v1/events/operations
 func (s *BackrestHandler) GetOperationEvents(ctx context.Context, req *connect.Request[emptypb.Empty], resp *connect.ServerStream[v1.OperationEvent]) error {
 	errChan := make(chan error, 1)
@@ -561,6 +919,90 @@ func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.Fo
 	return connect.NewResponse(&v1.ScheduleTaskResponse{OperationId: id}), nil
 }
 
+// PinSnapshot implements POST /v1.Backrest/PinSnapshot
+func (s *BackrestHandler) PinSnapshot(ctx context.Context, req *connect.Request[v1.PinSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.setSnapshotPinned(ctx, req.Msg.RepoId, req.Msg.SnapshotId, true); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// UnpinSnapshot implements POST /v1.Backrest/UnpinSnapshot
+func (s *BackrestHandler) UnpinSnapshot(ctx context.Context, req *connect.Request[v1.UnpinSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.setSnapshotPinned(ctx, req.Msg.RepoId, req.Msg.SnapshotId, false); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// setSnapshotPinned adds or removes the reserved pinned tag on a snapshot. The re-tag runs as a task so that it holds
+// the repo exclusively like any other operation that rewrites snapshots.
+func (s *BackrestHandler) setSnapshotPinned(ctx context.Context, repoID, snapshotID string, pinned bool) error {
+	if err := restic.ValidateSnapshotId(snapshotID); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	repoCfg, err := s.orchestrator.GetRepo(repoID)
+	if err != nil {
+		return withLookupCode(err)
+	}
+
+	if err := s.scheduleTaskAndWait(tasks.NewOneoffPinSnapshotTask(repoCfg, time.Now(), snapshotID, pinned), tasks.TaskPriorityInteractive); err != nil {
+		return withLookupCode(err)
+	}
+	return nil
+}
+
+// PreviewForget implements POST /v1.Backrest/PreviewForget
+func (s *BackrestHandler) PreviewForget(ctx context.Context, req *connect.Request[v1.PreviewForgetRequest]) (*connect.Response[v1.PreviewForgetResponse], error) {
+	if req.Msg.Retention == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("retention is required"))
+	}
+	if err := protoutil.ValidateRetentionPolicy(req.Msg.Retention); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("retention: %w", err))
+	}
+	if req.Msg.Retention.GetPolicyKeepAll() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("retention policy keeps all snapshots, nothing would be removed"))
+	}
+
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	groupBy := req.Msg.GroupBy
+	if groupBy == "" {
+		groupBy = protoutil.ForgetGroupBy(config.FindRepo(cfg, req.Msg.RepoId).GetForgetPolicy())
+	} else if err := protoutil.ValidateGroupBy(groupBy); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	resp, err := s.previewForget(ctx, cfg.Instance, req.Msg.RepoId, req.Msg.PlanId, req.Msg.Retention, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// previewForget simulates applying the policy to the plan's snapshots, or to all of the repo's snapshots grouped by
+// groupBy if planID is empty, with the same snapshot selection as the forget tasks.
+func (s *BackrestHandler) previewForget(ctx context.Context, instance, repoID, planID string, policy *v1.RetentionPolicy, groupBy string) (*v1.PreviewForgetResponse, error) {
+	r, err := s.orchestrator.GetRepoOrchestrator(repoID)
+	if err != nil {
+		return nil, withLookupCode(err)
+	}
+
+	var opts []restic.GenericOption
+	if planID != "" {
+		if opts, err = tasks.PlanForgetOptions(zap.L(), s.oplog.Query, r.Config().GetGuid(), planID, instance); err != nil {
+			return nil, err
+		}
+	} else {
+		opts = append(opts, restic.WithFlags("--group-by", groupBy))
+	}
+	return r.PreviewForget(ctx, policy, opts...)
+}
+
 func (s BackrestHandler) DoRepoTask(ctx context.Context, req *connect.Request[v1.DoRepoTaskRequest]) (*connect.Response[v1.ScheduleTaskResponse], error) {
 	var task tasks.Task
 
@@ -582,6 +1024,11 @@ func (s BackrestHandler) DoRepoTask(ctx context.Context, req *connect.Request[v1
 		}
 		task = tasks.NewScheduledForgetTask(repo, tasks.PlanForSystemTasks, true)
 		priority |= tasks.TaskPriorityForget
+	case v1.DoRepoTaskRequest_TASK_COPY:
+		if repo.GetCopyPolicy().GetTargetRepo() == "" {
+			return nil, fmt.Errorf("repo %q has no copy policy configured", req.Msg.RepoId)
+		}
+		task = tasks.NewCopyTask(repo, tasks.PlanForSystemTasks, true)
 	case v1.DoRepoTaskRequest_TASK_STATS:
 		task = tasks.NewStatsTask(repo, tasks.PlanForSystemTasks, true)
 		priority |= tasks.TaskPriorityStats
@@ -618,8 +1065,11 @@ func (s *BackrestHandler) Restore(ctx context.Context, req *connect.Request[v1.R
 	if req.Msg.Path == "" {
 		req.Msg.Path = "/"
 	}
-	// prevent restoring to a directory that already exists
-	if _, err := os.Stat(req.Msg.Target); err == nil {
+	if len(req.Msg.Options.GetIncludes()) > 0 && len(req.Msg.Options.GetExcludes()) > 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("restore includes and excludes are mutually exclusive"))
+	}
+	// prevent restoring to a directory that already exists unless the request says how to treat existing files.
+	if _, err := os.Stat(req.Msg.Target); err == nil && req.Msg.Options.GetOverwrite() == v1.RestoreOptions_OVERWRITE_DEFAULT {
 		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("target directory %q already exists", req.Msg.Target))
 	}
 
@@ -629,7 +1079,7 @@ func (s *BackrestHandler) Restore(ctx context.Context, req *connect.Request[v1.R
 	}
 
 	at := time.Now()
-	id, err := s.orchestrator.ScheduleTask(tasks.NewOneoffRestoreTask(repo, req.Msg.PlanId, 0 /* flowID */, at, req.Msg.SnapshotId, req.Msg.Path, req.Msg.Target), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	id, err := s.orchestrator.ScheduleTask(tasks.NewOneoffRestoreTask(repo, req.Msg.PlanId, 0 /* flowID */, at, req.Msg.SnapshotId, req.Msg.Path, req.Msg.Target, req.Msg.Options), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
 	if err != nil {
 		return nil, fmt.Errorf("failed to schedule restore task: %w", err)
 	}
@@ -674,6 +1124,38 @@ func (s *BackrestHandler) RunCommand(ctx context.Context, req *connect.Request[v
 	return connect.NewResponse(&v1.RunCommandResponse{OperationId: st.Op.GetId()}), nil
 }
 
+// TestHook implements POST /v1.Backrest/TestHook
+func (s *BackrestHandler) TestHook(ctx context.Context, req *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error) {
+	if req.Msg.Condition == v1.Hook_CONDITION_UNKNOWN {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("condition is required"))
+	}
+
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	hook, err := config.ResolveHook(cfg, req.Msg.Hook)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if hook.GetAction() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hook action is required"))
+	}
+	// hook definitions are sent to the UI with their credentials redacted.
+	if def := config.FindHook(cfg, hook.GetId()); hook.GetId() != "" && def != nil {
+		config.RehydrateHookSecrets(hook, def)
+	}
+	if err := config.ValidateRehydratedHook(hook); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	resp, err := s.orchestrator.TestHook(ctx, hook, req.Msg.Condition, req.Msg.RepoId, req.Msg.PlanId)
+	if err != nil {
+		return nil, withLookupCode(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *BackrestHandler) Cancel(ctx context.Context, req *connect.Request[v1.CancelOperationRequest]) (*connect.Response[emptypb.Empty], error) {
 	if err := s.orchestrator.CancelOperation(req.Msg.OperationId, v1.OperationStatus_STATUS_USER_CANCELLED); err != nil {
 		return nil, err
@@ -890,7 +1372,7 @@ func withLookupCode(err error) error {
 	if err == nil {
 		return nil
 	}
-	if errors.Is(err, orchestrator.ErrRepoNotFound) || errors.Is(err, orchestrator.ErrPlanNotFound) {
+	if errors.Is(err, orchestrator.ErrRepoNotFound) || errors.Is(err, orchestrator.ErrPlanNotFound) || errors.Is(err, tasks.ErrSnapshotNotFound) {
 		return connect.NewError(connect.CodeNotFound, err)
 	}
 	return err
@@ -21,6 +21,7 @@ import (
 	"github.com/garethgeorge/backrest/internal/config"
 	"github.com/garethgeorge/backrest/internal/config/migrations"
 	"github.com/garethgeorge/backrest/internal/cryptoutil"
+	"github.com/garethgeorge/backrest/internal/env"
 	"github.com/garethgeorge/backrest/internal/logstore"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
@@ -151,6 +152,107 @@ func TestRemoveRepo(t *testing.T) {
 	}
 }
 
+func TestRepoKeys(t *testing.T) {
+	t.Parallel()
+
+	mgr := createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+	})
+	sut := createSystemUnderTest(t, mgr)
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	listKeys := func() []*v1.RepoKey {
+		res, err := sut.handler.ListRepoKeys(ctx, connect.NewRequest(&v1.ListRepoKeysRequest{RepoId: "local"}))
+		if err != nil {
+			t.Fatalf("ListRepoKeys() error = %v", err)
+		}
+		return res.Msg.Keys
+	}
+
+	keys := listKeys()
+	if len(keys) != 1 || !keys[0].Current {
+		t.Fatalf("Expected a single current key, got %v", keys)
+	}
+	currentKeyID := keys[0].Id
+
+	added, err := sut.handler.AddRepoKey(ctx, connect.NewRequest(&v1.AddRepoKeyRequest{
+		RepoId:   "local",
+		Password: "other",
+		Username: "tester",
+	}))
+	if err != nil {
+		t.Fatalf("AddRepoKey() error = %v", err)
+	}
+	if added.Msg.Current || added.Msg.Username != "tester" {
+		t.Errorf("Expected a non-current key for user tester, got %v", added.Msg)
+	}
+	if keys := listKeys(); len(keys) != 2 {
+		t.Fatalf("Expected 2 keys after AddRepoKey, got %v", keys)
+	}
+
+	if _, err := sut.handler.RemoveRepoKey(ctx, connect.NewRequest(&v1.RemoveRepoKeyRequest{
+		RepoId: "local",
+		KeyId:  currentKeyID,
+	})); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("Expected FailedPrecondition removing the current key, got %v", err)
+	}
+
+	if _, err := sut.handler.RemoveRepoKey(ctx, connect.NewRequest(&v1.RemoveRepoKeyRequest{
+		RepoId: "local",
+		KeyId:  added.Msg.Id,
+	})); err != nil {
+		t.Fatalf("RemoveRepoKey() error = %v", err)
+	}
+	if keys := listKeys(); len(keys) != 1 || keys[0].Id != currentKeyID {
+		t.Fatalf("Expected only the original key after RemoveRepoKey, got %v", keys)
+	}
+
+	if _, err := sut.handler.RotateRepoPassword(ctx, connect.NewRequest(&v1.RotateRepoPasswordRequest{
+		RepoId:      "local",
+		NewPassword: "rotated",
+	})); err != nil {
+		t.Fatalf("RotateRepoPassword() error = %v", err)
+	}
+
+	cfg, err := mgr.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := config.FindRepo(cfg, "local").GetPassword(); got != "rotated" {
+		t.Errorf("Expected the config to hold the rotated password, got %q", got)
+	}
+
+	// The orchestrator picks up the new password asynchronously.
+	if err := testutil.Retry(t, ctx, func() error {
+		res, err := sut.handler.ListRepoKeys(ctx, connect.NewRequest(&v1.ListRepoKeysRequest{RepoId: "local"}))
+		if err != nil {
+			return err
+		}
+		if len(res.Msg.Keys) != 1 || res.Msg.Keys[0].Id == currentKeyID {
+			return fmt.Errorf("expected only a new key after rotation, got %v", res.Msg.Keys)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Couldn't list keys after rotation: %v", err)
+	}
+}
+
 func TestBackup(t *testing.T) {
 	t.Parallel()
 
@@ -605,6 +707,218 @@ func TestMultipleBackup(t *testing.T) {
 	}
 }
 
+func TestPreviewForget(t *testing.T) {
+	// not parallel: sets the retention guard environment variable.
+	t.Setenv(env.EnvVarRetentionGuardPercent, "50")
+
+	mgr := createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:   "test",
+				Repo: "local",
+				Paths: []string{
+					t.TempDir(),
+				},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+				Retention: &v1.RetentionPolicy{
+					Policy: &v1.RetentionPolicy_PolicyKeepLastN{
+						PolicyKeepLastN: 10,
+					},
+				},
+			},
+		},
+	})
+	sut := createSystemUnderTest(t, mgr)
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	for i := 0; i < 3; i++ {
+		if _, err := sut.handler.Backup(ctx, connect.NewRequest(&v1.BackupRequest{Value: "test"})); err != nil {
+			t.Fatalf("Backup() error = %v", err)
+		}
+	}
+
+	if err := testutil.Retry(t, ctx, func() error {
+		ops := getOperations(t, sut.oplog)
+		var backups int
+		for _, op := range ops {
+			if _, ok := op.GetOp().(*v1.Operation_OperationBackup); ok && op.Status == v1.OperationStatus_STATUS_SUCCESS {
+				backups++
+			}
+		}
+		if backups != 3 {
+			return fmt.Errorf("expected 3 backups, got %d", backups)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Couldn't find backups in oplog: %v", err)
+	}
+
+	keepLast1 := &v1.RetentionPolicy{
+		Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 1},
+	}
+	res, err := sut.handler.PreviewForget(ctx, connect.NewRequest(&v1.PreviewForgetRequest{
+		RepoId:    "local",
+		PlanId:    "test",
+		Retention: keepLast1,
+	}))
+	if err != nil {
+		t.Fatalf("PreviewForget() error = %v", err)
+	}
+	if len(res.Msg.Keep) != 1 || len(res.Msg.Remove) != 2 {
+		t.Fatalf("Expected 1 kept and 2 removed snapshots, got %d kept and %d removed", len(res.Msg.Keep), len(res.Msg.Remove))
+	}
+	if !slices.Contains(res.Msg.Keep[0].Reasons, "last snapshot") {
+		t.Errorf("Expected the kept snapshot to match \"last snapshot\", got reasons %v", res.Msg.Keep[0].Reasons)
+	}
+
+	// The preview must not have removed anything.
+	snapshots, err := sut.handler.ListSnapshots(ctx, connect.NewRequest(&v1.ListSnapshotsRequest{RepoId: "local"}))
+	if err != nil {
+		t.Fatalf("ListSnapshots() error = %v", err)
+	}
+	if len(snapshots.Msg.Snapshots) != 3 {
+		t.Fatalf("Expected 3 snapshots after preview, got %d", len(snapshots.Msg.Snapshots))
+	}
+
+	// Removing 2 of 3 snapshots exceeds the 50% guard unless confirmed.
+	cfg, err := mgr.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	cfg = proto.Clone(cfg).(*v1.Config)
+	cfg.Plans[0].Retention = keepLast1
+	if _, err := sut.handler.SetConfig(ctx, connect.NewRequest(cfg)); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition from SetConfig, got %v", err)
+	}
+
+	req := connect.NewRequest(cfg)
+	req.Header().Set(ConfirmRetentionChangeHeader, "true")
+	if _, err := sut.handler.SetConfig(ctx, req); err != nil {
+		t.Fatalf("SetConfig() with confirmation error = %v", err)
+	}
+}
+
+func TestPinSnapshot(t *testing.T) {
+	t.Parallel()
+
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:   "test",
+				Repo: "local",
+				Paths: []string{
+					t.TempDir(),
+				},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+			},
+		},
+	}))
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	if _, err := sut.handler.Backup(ctx, connect.NewRequest(&v1.BackupRequest{Value: "test"})); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	findIndexOp := func() (*v1.Operation, error) {
+		operations := getOperations(t, sut.oplog)
+		if index := slices.IndexFunc(operations, func(op *v1.Operation) bool {
+			return op.GetOperationIndexSnapshot() != nil
+		}); index != -1 {
+			return operations[index], nil
+		}
+		return nil, errors.New("snapshot not indexed")
+	}
+
+	var snapshotOp *v1.Operation
+	if err := testutil.Retry(t, ctx, func() error {
+		var err error
+		snapshotOp, err = findIndexOp()
+		return err
+	}); err != nil {
+		t.Fatalf("Couldn't find snapshot in oplog")
+	}
+
+	if _, err := sut.handler.PinSnapshot(ctx, connect.NewRequest(&v1.PinSnapshotRequest{
+		RepoId:     "local",
+		SnapshotId: snapshotOp.SnapshotId,
+	})); err != nil {
+		t.Fatalf("PinSnapshot() error = %v", err)
+	}
+
+	pinnedOp, err := findIndexOp()
+	if err != nil {
+		t.Fatalf("Couldn't find snapshot in oplog after pinning: %v", err)
+	}
+	if !pinnedOp.GetOperationIndexSnapshot().Pinned || pinnedOp.GetOperationIndexSnapshot().Forgot {
+		t.Errorf("Expected the snapshot to be pinned and not forgotten, got %v", pinnedOp.GetOperationIndexSnapshot())
+	}
+	if pinnedOp.Id != snapshotOp.Id || pinnedOp.SnapshotId == snapshotOp.SnapshotId {
+		t.Errorf("Expected operation %d to move to the re-tagged snapshot, got operation %d with snapshot %q", snapshotOp.Id, pinnedOp.Id, pinnedOp.SnapshotId)
+	}
+
+	if _, err := sut.handler.UnpinSnapshot(ctx, connect.NewRequest(&v1.UnpinSnapshotRequest{
+		RepoId:     "local",
+		SnapshotId: pinnedOp.SnapshotId,
+	})); err != nil {
+		t.Fatalf("UnpinSnapshot() error = %v", err)
+	}
+
+	unpinnedOp, err := findIndexOp()
+	if err != nil {
+		t.Fatalf("Couldn't find snapshot in oplog after unpinning: %v", err)
+	}
+	if unpinnedOp.GetOperationIndexSnapshot().Pinned {
+		t.Errorf("Expected the snapshot to be unpinned, got %v", unpinnedOp.GetOperationIndexSnapshot())
+	}
+
+	if _, err := sut.handler.PinSnapshot(ctx, connect.NewRequest(&v1.PinSnapshotRequest{
+		RepoId:     "local",
+		SnapshotId: snapshotOp.SnapshotId,
+	})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("Expected NotFound pinning the rewritten snapshot, got %v", err)
+	}
+}
+
 func TestHookExecution(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -1214,6 +1528,21 @@ func TestRestore(t *testing.T) {
 
 	restoreTarget := t.TempDir() + "/restore"
 
+	// restic can't combine includes with excludes.
+	_, err = sut.handler.Restore(ctx, connect.NewRequest(&v1.RestoreSnapshotRequest{
+		SnapshotId: snapshotOp.SnapshotId,
+		PlanId:     "test",
+		RepoId:     "local",
+		Target:     restoreTarget,
+		Options: &v1.RestoreOptions{
+			Includes: []string{"/findme.txt"},
+			Excludes: []string{"*.log"},
+		},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("Restore() with includes and excludes: want invalid argument, got %v", err)
+	}
+
 	_, err = sut.handler.Restore(ctx, connect.NewRequest(&v1.RestoreSnapshotRequest{
 		SnapshotId: snapshotOp.SnapshotId,
 		PlanId:     "test",
@@ -1259,6 +1588,217 @@ func TestRestore(t *testing.T) {
 	}
 }
 
+func TestDiffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	backupDataDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(backupDataDir, "unchanged.txt"), []byte("test data"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:   "test",
+				Repo: "local",
+				Paths: []string{
+					backupDataDir,
+				},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+				Retention: &v1.RetentionPolicy{
+					Policy: &v1.RetentionPolicy_PolicyKeepAll{PolicyKeepAll: true},
+				},
+			},
+		},
+	}))
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	// Take two backups, adding a file in between.
+	var snapshotIDs []string
+	for i := 0; i < 2; i++ {
+		if i == 1 {
+			if err := os.WriteFile(filepath.Join(backupDataDir, "findme.txt"), []byte("new data"), 0644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+		}
+		if _, err := sut.handler.Backup(ctx, connect.NewRequest(&v1.BackupRequest{Value: "test"})); err != nil {
+			t.Fatalf("Backup() error = %v", err)
+		}
+		if err := testutil.Retry(t, ctx, func() error {
+			snapshotIDs = nil
+			for _, op := range getOperations(t, sut.oplog) {
+				if _, ok := op.GetOp().(*v1.Operation_OperationIndexSnapshot); ok {
+					snapshotIDs = append(snapshotIDs, op.SnapshotId)
+				}
+			}
+			if len(snapshotIDs) != i+1 {
+				return fmt.Errorf("expected %d indexed snapshots, got %d", i+1, len(snapshotIDs))
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("Couldn't find snapshot in oplog: %v", err)
+		}
+	}
+
+	res, err := sut.handler.DiffSnapshots(ctx, connect.NewRequest(&v1.DiffSnapshotsRequest{
+		RepoId:          "local",
+		SnapshotId:      snapshotIDs[0],
+		OtherSnapshotId: snapshotIDs[1],
+		Path:            backupDataDir,
+	}))
+	if err != nil {
+		t.Fatalf("DiffSnapshots() error = %v", err)
+	}
+
+	if res.Msg.Stats.GetAdded().GetFiles() != 1 {
+		t.Errorf("Expected 1 file added, got %d", res.Msg.Stats.GetAdded().GetFiles())
+	}
+	if res.Msg.Stats.GetBytesDelta() <= 0 {
+		t.Errorf("Expected a positive bytes delta, got %d", res.Msg.Stats.GetBytesDelta())
+	}
+
+	log, err := sut.logStore.Open(res.Msg.Logref)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	defer log.Close()
+
+	data, err := io.ReadAll(log)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	wantEntry := fmt.Sprintf(`{"path":%q,"modifier":"+"}`, filepath.ToSlash(filepath.Join(backupDataDir, "findme.txt")))
+	if !strings.Contains(string(data), wantEntry) {
+		t.Fatalf("Expected diff log to contain %s, got: %s", wantEntry, data)
+	}
+	if strings.Contains(string(data), "unchanged.txt") {
+		t.Fatalf("Expected diff log not to contain unchanged files, got: %s", data)
+	}
+}
+
+func TestFindFiles(t *testing.T) {
+	t.Parallel()
+
+	backupDataDir := t.TempDir()
+	for _, name := range []string{"findme-1.txt", "findme-2.txt", "other.txt"} {
+		if err := os.WriteFile(filepath.Join(backupDataDir, name), []byte("test data"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:   "test",
+				Repo: "local",
+				Paths: []string{
+					backupDataDir,
+				},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+				Retention: &v1.RetentionPolicy{
+					Policy: &v1.RetentionPolicy_PolicyKeepAll{PolicyKeepAll: true},
+				},
+			},
+		},
+	}))
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	if _, err := sut.handler.Backup(ctx, connect.NewRequest(&v1.BackupRequest{Value: "test"})); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	if _, err := sut.handler.FindFiles(ctx, connect.NewRequest(&v1.FindFilesRequest{
+		RepoId: "local",
+		Query:  &v1.FindFilesQuery{},
+	})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty pattern, got %v", err)
+	}
+
+	res, err := sut.handler.FindFiles(ctx, connect.NewRequest(&v1.FindFilesRequest{
+		RepoId: "local",
+		Query:  &v1.FindFilesQuery{Pattern: "FINDME-*", IgnoreCase: true, PlanId: "test"},
+	}))
+	if err != nil {
+		t.Fatalf("FindFiles() error = %v", err)
+	}
+
+	if err := testutil.Retry(t, ctx, func() error {
+		op, err := sut.oplog.Get(res.Msg.OperationId)
+		if err != nil {
+			return err
+		}
+		if op.Status != v1.OperationStatus_STATUS_SUCCESS {
+			return fmt.Errorf("expected find files operation to succeed, got status %v", op.Status)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Couldn't find completed find files operation: %v", err)
+	}
+
+	page, err := sut.handler.GetFindFilesResults(ctx, connect.NewRequest(&v1.GetFindFilesResultsRequest{
+		OperationId: res.Msg.OperationId,
+		Limit:       1,
+	}))
+	if err != nil {
+		t.Fatalf("GetFindFilesResults() error = %v", err)
+	}
+	if page.Msg.Total != 2 || len(page.Msg.Matches) != 1 || page.Msg.NextOffset != 1 {
+		t.Fatalf("Expected first page with 1 of 2 matches, got %v", page.Msg)
+	}
+
+	page, err = sut.handler.GetFindFilesResults(ctx, connect.NewRequest(&v1.GetFindFilesResultsRequest{
+		OperationId: res.Msg.OperationId,
+		Offset:      page.Msg.NextOffset,
+	}))
+	if err != nil {
+		t.Fatalf("GetFindFilesResults() error = %v", err)
+	}
+	if len(page.Msg.Matches) != 1 || page.Msg.NextOffset != 0 {
+		t.Fatalf("Expected last page with 1 match, got %v", page.Msg)
+	}
+	if !strings.HasPrefix(path.Base(page.Msg.Matches[0].Path), "findme-") {
+		t.Errorf("Expected a match for a findme file, got %v", page.Msg.Matches[0].Path)
+	}
+}
+
 func TestRunCommand(t *testing.T) {
 	testutil.InstallZapLogger(t)
 	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
@@ -1485,7 +2025,7 @@ func createSystemUnderTest(t *testing.T, config *config.ConfigManager) systemUnd
 	}
 	t.Cleanup(func() { logStore.Close() })
 	orch, err := orchestrator.NewOrchestrator(
-		resticBin, config, oplog, logStore,
+		resticBin, config, oplog, logStore, nil,
 	)
 	if err != nil {
 		t.Fatalf("Failed to create orchestrator: %v", err)
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
+
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func FindPlan(cfg *v1.Config, planID string) *v1.Plan {
@@ -30,3 +33,29 @@ func FindRepoByGUID(cfg *v1.Config, guid string) *v1.Repo {
 	}
 	return nil
 }
+
+func FindHook(cfg *v1.Config, hookID string) *v1.Hook {
+	for _, hook := range cfg.Hooks {
+		if hook.Id == hookID {
+			return hook
+		}
+	}
+	return nil
+}
+
+// ResolveHook returns the hook to run for a plan's, repo's or digest's hook. Hooks that reference a definition in
+// cfg.Hooks resolve to a copy of the definition, with the referencing hook's conditions if it sets any.
+func ResolveHook(cfg *v1.Config, hook *v1.Hook) (*v1.Hook, error) {
+	if hook.GetRef() == "" {
+		return hook, nil
+	}
+	def := FindHook(cfg, hook.Ref)
+	if def == nil {
+		return nil, fmt.Errorf("hook %q not found", hook.Ref)
+	}
+	resolved := proto.Clone(def).(*v1.Hook)
+	if len(hook.Conditions) > 0 {
+		resolved.Conditions = hook.Conditions
+	}
+	return resolved, nil
+}
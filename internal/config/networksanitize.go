@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"reflect"
 	"slices"
 	"sort"
 	"strings"
@@ -9,6 +11,10 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// redactedSecret replaces secrets in configs sent over the network, it's replaced by the stored secret when the config
+// is sent back.
+const redactedSecret = "********"
+
 func SanitizeForNetwork(config *v1.Config) *v1.Config {
 	clone := proto.Clone(config).(*v1.Config)
 
@@ -17,11 +23,20 @@ func SanitizeForNetwork(config *v1.Config) *v1.Config {
 		clone.Multihost.Identity = sanitizePrivateKey(clone.Multihost.Identity)
 	}
 
+	// Sanitize the credentials of the shared hook definitions
+	for _, hook := range clone.Hooks {
+		for _, secret := range hookSecrets(hook) {
+			if *secret != "" {
+				*secret = redactedSecret
+			}
+		}
+	}
+
 	// Sanitize the users password hashes
 	for _, user := range clone.GetAuth().GetUsers() {
 		if user.GetPassword() != nil {
 			user.Password = &v1.User_PasswordBcrypt{
-				PasswordBcrypt: "********",
+				PasswordBcrypt: redactedSecret,
 			}
 		}
 	}
@@ -38,6 +53,15 @@ func RehydrateNetworkSanitizedConfig(sanitized *v1.Config, full *v1.Config) *v1.
 		proto.Merge(clone.Multihost.Identity, sanitized.Multihost.Identity)
 	}
 
+	// Rehydrate the credentials of the shared hook definitions by id
+	for _, hook := range clone.Hooks {
+		fullHook := FindHook(full, hook.Id)
+		if fullHook == nil {
+			continue
+		}
+		RehydrateHookSecrets(hook, fullHook)
+	}
+
 	// Loop over the full config users and rehydrate the clone users
 	fullUsers := slices.Clone(full.GetAuth().GetUsers())
 	sanitizedUsers := clone.GetAuth().GetUsers()
@@ -45,7 +69,7 @@ func RehydrateNetworkSanitizedConfig(sanitized *v1.Config, full *v1.Config) *v1.
 		return strings.Compare(a.GetName(), b.GetName())
 	})
 	for i, user := range sanitizedUsers {
-		if user.GetPasswordBcrypt() == "********" {
+		if user.GetPasswordBcrypt() == redactedSecret {
 			index := sort.Search(len(fullUsers), func(j int) bool {
 				return fullUsers[j].GetName() >= user.GetName()
 			})
@@ -58,6 +82,70 @@ func RehydrateNetworkSanitizedConfig(sanitized *v1.Config, full *v1.Config) *v1.
 	return clone
 }
 
+// ValidateRehydratedConfig returns an error if a hook credential redacted by SanitizeForNetwork couldn't be restored
+// by RehydrateNetworkSanitizedConfig, e.g. because the hook definition was renamed.
+func ValidateRehydratedConfig(config *v1.Config) error {
+	for _, hook := range config.Hooks {
+		if err := ValidateRehydratedHook(hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateRehydratedHook returns an error if the hook still has a credential redacted by SanitizeForNetwork.
+func ValidateRehydratedHook(hook *v1.Hook) error {
+	for _, secret := range hookSecrets(hook) {
+		if *secret == redactedSecret {
+			return fmt.Errorf("hook %q: redacted credential can't be restored, e.g. after renaming the hook, enter it again", hook.Id)
+		}
+	}
+	return nil
+}
+
+// RehydrateHookSecrets restores the credentials of a hook that were redacted by SanitizeForNetwork from the full hook.
+// Credentials are only restored if the hook's action type didn't change.
+func RehydrateHookSecrets(sanitized *v1.Hook, full *v1.Hook) {
+	sanitizedSecrets := hookSecrets(sanitized)
+	fullSecrets := hookSecrets(full)
+	if reflect.TypeOf(sanitized.Action) != reflect.TypeOf(full.Action) || len(sanitizedSecrets) != len(fullSecrets) {
+		return
+	}
+	for i, secret := range sanitizedSecrets {
+		if *secret == redactedSecret {
+			*secret = *fullSecrets[i]
+		}
+	}
+}
+
+// hookSecrets returns pointers to the credential fields of the hook's action, including URLs that embed a token.
+func hookSecrets(hook *v1.Hook) []*string {
+	switch action := hook.Action.(type) {
+	case *v1.Hook_ActionWebhook:
+		return []*string{&action.ActionWebhook.BasicAuthPassword, &action.ActionWebhook.BearerToken, &action.ActionWebhook.HmacSecret}
+	case *v1.Hook_ActionDiscord:
+		return []*string{&action.ActionDiscord.WebhookUrl}
+	case *v1.Hook_ActionGotify:
+		return []*string{&action.ActionGotify.Token}
+	case *v1.Hook_ActionSlack:
+		return []*string{&action.ActionSlack.WebhookUrl}
+	case *v1.Hook_ActionShoutrrr:
+		return []*string{&action.ActionShoutrrr.ShoutrrrUrl}
+	case *v1.Hook_ActionHealthchecks:
+		return []*string{&action.ActionHealthchecks.WebhookUrl}
+	case *v1.Hook_ActionTelegram:
+		return []*string{&action.ActionTelegram.BotToken}
+	case *v1.Hook_ActionEmail:
+		return []*string{&action.ActionEmail.Password}
+	case *v1.Hook_ActionMqtt:
+		return []*string{&action.ActionMqtt.Password}
+	case *v1.Hook_ActionNtfy:
+		return []*string{&action.ActionNtfy.Token}
+	default:
+		return nil
+	}
+}
+
 func sanitizePrivateKey(proto *v1.PrivateKey) *v1.PrivateKey {
 	return &v1.PrivateKey{
 		Keyid: proto.Keyid,
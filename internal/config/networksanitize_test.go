@@ -120,6 +120,41 @@ func TestSanitizeForNetwork(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "config with hook definitions",
+			config: &v1.Config{
+				Hooks: []*v1.Hook{
+					{
+						Id: "slack",
+						Action: &v1.Hook_ActionSlack{
+							ActionSlack: &v1.Hook_Slack{WebhookUrl: "https://hooks.slack.com/services/secret"},
+						},
+					},
+					{
+						Id: "webhook",
+						Action: &v1.Hook_ActionWebhook{
+							ActionWebhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com", BearerToken: "token"},
+						},
+					},
+				},
+			},
+			sanitized: &v1.Config{
+				Hooks: []*v1.Hook{
+					{
+						Id: "slack",
+						Action: &v1.Hook_ActionSlack{
+							ActionSlack: &v1.Hook_Slack{WebhookUrl: "********"},
+						},
+					},
+					{
+						Id: "webhook",
+						Action: &v1.Hook_ActionWebhook{
+							ActionWebhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com", BearerToken: "********"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "config with nil identity",
 			config: &v1.Config{
@@ -189,6 +224,75 @@ func TestRehydrateNetworkSanitizedConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "config with hook definitions",
+			sanitized: &v1.Config{
+				Hooks: []*v1.Hook{
+					{
+						Id: "ntfy",
+						Action: &v1.Hook_ActionNtfy{
+							ActionNtfy: &v1.Hook_Ntfy{Topic: "new-topic", Token: "********"},
+						},
+					},
+					{
+						Id: "email",
+						Action: &v1.Hook_ActionEmail{
+							ActionEmail: &v1.Hook_Email{Host: "smtp.example.com", Password: "new-password"},
+						},
+					},
+					{
+						Id: "changed-type",
+						Action: &v1.Hook_ActionGotify{
+							ActionGotify: &v1.Hook_Gotify{Token: "********"},
+						},
+					},
+				},
+			},
+			original: &v1.Config{
+				Hooks: []*v1.Hook{
+					{
+						Id: "ntfy",
+						Action: &v1.Hook_ActionNtfy{
+							ActionNtfy: &v1.Hook_Ntfy{Topic: "topic", Token: "ntfy-token"},
+						},
+					},
+					{
+						Id: "email",
+						Action: &v1.Hook_ActionEmail{
+							ActionEmail: &v1.Hook_Email{Host: "smtp.example.com", Password: "password"},
+						},
+					},
+					{
+						Id: "changed-type",
+						Action: &v1.Hook_ActionNtfy{
+							ActionNtfy: &v1.Hook_Ntfy{Token: "ntfy-token"},
+						},
+					},
+				},
+			},
+			want: &v1.Config{
+				Hooks: []*v1.Hook{
+					{
+						Id: "ntfy",
+						Action: &v1.Hook_ActionNtfy{
+							ActionNtfy: &v1.Hook_Ntfy{Topic: "new-topic", Token: "ntfy-token"},
+						},
+					},
+					{
+						Id: "email",
+						Action: &v1.Hook_ActionEmail{
+							ActionEmail: &v1.Hook_Email{Host: "smtp.example.com", Password: "new-password"},
+						},
+					},
+					{
+						Id: "changed-type",
+						Action: &v1.Hook_ActionGotify{
+							ActionGotify: &v1.Hook_Gotify{Token: "********"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "config with same set of users before and after",
 			sanitized: &v1.Config{
@@ -374,3 +478,30 @@ func TestRehydrateNetworkSanitizedConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRehydratedConfig(t *testing.T) {
+	original := &v1.Config{
+		Hooks: []*v1.Hook{{
+			Id:     "slack",
+			Action: &v1.Hook_ActionSlack{ActionSlack: &v1.Hook_Slack{WebhookUrl: "https://hooks.slack.com/services/secret"}},
+		}},
+	}
+	sanitized := SanitizeForNetwork(original)
+
+	if err := ValidateRehydratedConfig(RehydrateNetworkSanitizedConfig(sanitized, original)); err != nil {
+		t.Errorf("unexpected error for a rehydrated config: %v", err)
+	}
+
+	// renaming the hook loses the link to its stored credential.
+	sanitized.Hooks[0].Id = "slack-renamed"
+	rehydrated := RehydrateNetworkSanitizedConfig(sanitized, original)
+	if err := ValidateRehydratedConfig(rehydrated); err == nil {
+		t.Errorf("expected an error for a renamed hook with a redacted credential")
+	}
+
+	// entering the credential again is accepted.
+	rehydrated.Hooks[0].GetActionSlack().WebhookUrl = "https://hooks.slack.com/services/other"
+	if err := ValidateRehydratedConfig(rehydrated); err != nil {
+		t.Errorf("unexpected error after entering the credential again: %v", err)
+	}
+}
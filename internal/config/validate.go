@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/mail"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/garethgeorge/backrest/internal/config/validationutil"
 	"github.com/garethgeorge/backrest/internal/cryptoutil"
 	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/google/shlex"
 	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
 )
@@ -44,6 +47,11 @@ func ValidateConfig(c *v1.Config) error {
 			}
 			repos[repo.Id] = repo
 		}
+		for _, repo := range c.Repos {
+			if e := validateCopyPolicy(repo, repos); e != nil {
+				err = multierror.Append(err, fmt.Errorf("repo %s: copy policy: %w", repo.GetId(), e))
+			}
+		}
 		slices.SortFunc(c.Repos, func(a, b *v1.Repo) int {
 			if a.Id < b.Id {
 				return -1
@@ -75,6 +83,29 @@ func ValidateConfig(c *v1.Config) error {
 		err = multierror.Append(err, fmt.Errorf("multihost: %w", e))
 	}
 
+	if c.Digest != nil {
+		if e := validateDigestPolicy(c, c.Digest); e != nil {
+			err = multierror.Append(err, fmt.Errorf("digest: %w", e))
+		}
+	}
+
+	if e := validateHookDefinitions(c.Hooks); e != nil {
+		err = multierror.Append(err, fmt.Errorf("hooks: %w", e))
+	}
+	for _, repo := range c.Repos {
+		if e := validateHookRefs(c, repo.Hooks); e != nil {
+			err = multierror.Append(err, fmt.Errorf("repo %s: %w", repo.GetId(), e))
+		}
+	}
+	for _, plan := range c.Plans {
+		if e := validateHookRefs(c, plan.Hooks); e != nil {
+			err = multierror.Append(err, fmt.Errorf("plan %s: %w", plan.GetId(), e))
+		}
+	}
+	if e := validateHookRefs(c, c.GetDigest().GetHooks()); e != nil {
+		err = multierror.Append(err, fmt.Errorf("digest: %w", e))
+	}
+
 	return err
 }
 
@@ -128,6 +159,13 @@ func validateRepo(repo *v1.Repo) error {
 				err = multierror.Append(err, fmt.Errorf("forget policy: %w", e))
 			}
 		}
+		if e := protoutil.ValidateGroupBy(repo.ForgetPolicy.GetGroupBy()); e != nil {
+			err = multierror.Append(err, fmt.Errorf("forget policy: %w", e))
+		}
+	}
+
+	if e := validateHooks(repo.Hooks); e != nil {
+		err = multierror.Append(err, e)
 	}
 
 	for _, env := range repo.Env {
@@ -141,6 +179,33 @@ func validateRepo(repo *v1.Repo) error {
 	return err
 }
 
+func validateCopyPolicy(repo *v1.Repo, repos map[string]*v1.Repo) error {
+	policy := repo.GetCopyPolicy()
+	if policy == nil {
+		return nil
+	}
+
+	var err error
+	if policy.GetSchedule() != nil {
+		if e := protoutil.ValidateSchedule(policy.GetSchedule()); e != nil {
+			err = multierror.Append(err, fmt.Errorf("schedule: %w", e))
+		}
+	}
+
+	// Only require a target when the schedule is actually active.
+	if policy.GetTargetRepo() == "" {
+		if protoutil.ScheduleEnabled(policy.GetSchedule()) {
+			err = multierror.Append(err, errors.New("target repo is required"))
+		}
+	} else if policy.GetTargetRepo() == repo.GetId() {
+		err = multierror.Append(err, errors.New("target repo must be different from the source repo"))
+	} else if _, ok := repos[policy.GetTargetRepo()]; !ok {
+		err = multierror.Append(err, fmt.Errorf("target repo %q not found", policy.GetTargetRepo()))
+	}
+
+	return err
+}
+
 func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 	var err error
 	if e := validationutil.ValidateID(plan.Id, 0); e != nil {
@@ -153,7 +218,14 @@ func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 		}
 	}
 
-	if len(plan.Paths) == 0 && len(plan.BackupFlags) == 0 {
+	if stdinCmd := plan.GetStdinCommand(); stdinCmd != nil {
+		if e := validateStdinCommand(stdinCmd); e != nil {
+			err = multierror.Append(err, fmt.Errorf("stdin command: %w", e))
+		}
+		if len(plan.Paths) != 0 {
+			err = multierror.Append(err, errors.New("paths can not be used with a stdin command"))
+		}
+	} else if len(plan.Paths) == 0 && len(plan.BackupFlags) == 0 {
 		err = multierror.Append(err, fmt.Errorf("at least one path is required (unless backup_flags supplies paths, e.g. --files-from or --stdin-from-command)"))
 	}
 	for idx, p := range plan.Paths {
@@ -176,11 +248,216 @@ func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 		}
 	}
 
+	if policy := plan.GetRestoreTestPolicy(); policy != nil {
+		if e := validateRestoreTestPolicy(policy); e != nil {
+			err = multierror.Append(err, fmt.Errorf("restore test policy: %w", e))
+		}
+	}
+
+	if e := validateHooks(plan.Hooks); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if m := plan.GetOverdueGraceMultiplier(); m != 0 && m < 1 {
+		err = multierror.Append(err, fmt.Errorf("overdue grace multiplier %v must be at least 1", m))
+	}
+
 	slices.Sort(plan.Paths)
 
 	return err
 }
 
+func validateDigestPolicy(c *v1.Config, policy *v1.DigestPolicy) error {
+	var err error
+	if policy.Schedule != nil {
+		if e := protoutil.ValidateSchedule(policy.Schedule); e != nil {
+			err = multierror.Append(err, fmt.Errorf("schedule: %w", e))
+		}
+	}
+	if e := validateHooks(policy.Hooks); e != nil {
+		err = multierror.Append(err, e)
+	}
+	for idx, hook := range policy.Hooks {
+		hook, e := ResolveHook(c, hook)
+		if e != nil {
+			continue // dangling references are reported by validateHookRefs.
+		}
+		if !slices.Contains(hook.Conditions, v1.Hook_CONDITION_DIGEST) {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: digest hooks must include condition %v", idx, v1.Hook_CONDITION_DIGEST))
+		}
+		if hook.NotifyMode != v1.Hook_NOTIFY_ALWAYS {
+			// every digest has the same outcome, other modes would suppress all but the first digest.
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: digest hooks must use notify mode %v", idx, v1.Hook_NOTIFY_ALWAYS))
+		}
+	}
+	return err
+}
+
+// validateHookDefinitions validates the named hook definitions in the config that other hooks reference.
+func validateHookDefinitions(hooks []*v1.Hook) error {
+	err := validateHooks(hooks)
+	ids := make(map[string]bool)
+	for idx, hook := range hooks {
+		if e := validationutil.ValidateID(hook.Id, 0); e != nil {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: id %q invalid: %w", idx, hook.Id, e))
+		} else if ids[hook.Id] {
+			err = multierror.Append(err, fmt.Errorf("hook %s: duplicate id", hook.Id))
+		}
+		ids[hook.Id] = true
+		if hook.Action == nil {
+			err = multierror.Append(err, fmt.Errorf("hook %s: action is required", hook.Id))
+		}
+		if hook.Ref != "" {
+			err = multierror.Append(err, fmt.Errorf("hook %s: a hook definition can't reference another hook", hook.Id))
+		}
+	}
+	return err
+}
+
+// validateHookRefs checks that the plan's, repo's or digest's hooks only reference hooks defined in the config.
+func validateHookRefs(c *v1.Config, hooks []*v1.Hook) error {
+	var err error
+	for idx, hook := range hooks {
+		if hook.Id != "" {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: id is only valid on hooks defined in the config's hooks", idx))
+		}
+		if hook.Ref != "" && FindHook(c, hook.Ref) == nil {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: references undefined hook %q", idx, hook.Ref))
+		}
+	}
+	return err
+}
+
+func validateHooks(hooks []*v1.Hook) error {
+	var err error
+	for idx, hook := range hooks {
+		if hook.GetRef() != "" && (hook.Action != nil || hook.OnError != v1.Hook_ON_ERROR_IGNORE || hook.NotifyMode != v1.Hook_NOTIFY_ALWAYS || hook.RateLimitMinutes != 0 || hook.TimeoutSeconds != 0) {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: a hook referencing %q may only set conditions", idx, hook.Ref))
+		}
+		if hook.GetTimeoutSeconds() < 0 {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: timeout seconds must not be negative", idx))
+		}
+		if hook.GetNotifyMode() == v1.Hook_NOTIFY_RATE_LIMITED && hook.GetRateLimitMinutes() < 1 {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: rate limit minutes must be at least 1 for a rate limited hook", idx))
+		}
+		if email := hook.GetActionEmail(); email != nil {
+			if e := validateEmailHook(email); e != nil {
+				err = multierror.Append(err, fmt.Errorf("hook[%d]: email: %w", idx, e))
+			}
+		}
+		if mqtt := hook.GetActionMqtt(); mqtt != nil {
+			if e := validateMqttHook(mqtt); e != nil {
+				err = multierror.Append(err, fmt.Errorf("hook[%d]: mqtt: %w", idx, e))
+			}
+		}
+		if webhook := hook.GetActionWebhook(); webhook != nil {
+			if e := validateWebhookHook(webhook); e != nil {
+				err = multierror.Append(err, fmt.Errorf("hook[%d]: webhook: %w", idx, e))
+			}
+		}
+		if ntfy := hook.GetActionNtfy(); ntfy != nil {
+			if ntfy.GetTopic() == "" {
+				err = multierror.Append(err, fmt.Errorf("hook[%d]: ntfy: topic is required", idx))
+			}
+			if ntfy.GetPriority() < 0 || ntfy.GetPriority() > 5 {
+				err = multierror.Append(err, fmt.Errorf("hook[%d]: ntfy: priority %d must be between 1 and 5, or 0 to pick it from the event", idx, ntfy.GetPriority()))
+			}
+		}
+	}
+	return err
+}
+
+func validateEmailHook(email *v1.Hook_Email) error {
+	var err error
+	if email.GetHost() == "" {
+		err = multierror.Append(err, errors.New("host is required"))
+	}
+	if email.GetPort() < 0 || email.GetPort() > 65535 {
+		err = multierror.Append(err, fmt.Errorf("port %d is out of range", email.GetPort()))
+	}
+	if _, e := mail.ParseAddress(email.GetFrom()); e != nil {
+		err = multierror.Append(err, fmt.Errorf("from address %q invalid: %w", email.GetFrom(), e))
+	}
+	if len(email.GetTo()) == 0 {
+		err = multierror.Append(err, errors.New("at least one to address is required"))
+	}
+	for _, addr := range append(append([]string{}, email.GetTo()...), email.GetCc()...) {
+		if _, e := mail.ParseAddress(addr); e != nil {
+			err = multierror.Append(err, fmt.Errorf("recipient address %q invalid: %w", addr, e))
+		}
+	}
+	return err
+}
+
+func validateWebhookHook(webhook *v1.Hook_Webhook) error {
+	var err error
+	if u, e := url.Parse(webhook.GetWebhookUrl()); e != nil {
+		err = multierror.Append(err, fmt.Errorf("url %q invalid: %w", webhook.GetWebhookUrl(), e))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		err = multierror.Append(err, fmt.Errorf("url %q must be an http:// or https:// url", webhook.GetWebhookUrl()))
+	}
+	for _, header := range webhook.GetHeaders() {
+		if name, _, ok := strings.Cut(header, ":"); !ok || strings.TrimSpace(name) == "" {
+			err = multierror.Append(err, fmt.Errorf("header %q must be formatted as \"Name: value\"", header))
+		}
+	}
+	if webhook.GetBasicAuthUsername() != "" && webhook.GetBearerToken() != "" {
+		err = multierror.Append(err, errors.New("only one of basic auth and bearer token can be set"))
+	}
+	if webhook.GetTimeoutSeconds() < 0 {
+		err = multierror.Append(err, fmt.Errorf("timeout seconds %d must not be negative", webhook.GetTimeoutSeconds()))
+	}
+	if webhook.GetRetries() < 0 || webhook.GetRetries() > 10 {
+		err = multierror.Append(err, fmt.Errorf("retries %d must be between 0 and 10", webhook.GetRetries()))
+	}
+	return err
+}
+
+func validateMqttHook(mqtt *v1.Hook_Mqtt) error {
+	var err error
+	if u, e := url.Parse(mqtt.GetBrokerUrl()); e != nil {
+		err = multierror.Append(err, fmt.Errorf("broker url %q invalid: %w", mqtt.GetBrokerUrl(), e))
+	} else if !slices.Contains([]string{"tcp", "mqtt", "ssl", "tls", "mqtts"}, u.Scheme) || u.Host == "" {
+		err = multierror.Append(err, fmt.Errorf("broker url %q must be a tcp://, mqtt://, ssl://, tls:// or mqtts:// url with a host", mqtt.GetBrokerUrl()))
+	}
+	if mqtt.GetQos() < 0 || mqtt.GetQos() > 2 {
+		err = multierror.Append(err, fmt.Errorf("qos %d must be 0, 1 or 2", mqtt.GetQos()))
+	}
+	if strings.ContainsAny(mqtt.GetTopic(), "+#") {
+		err = multierror.Append(err, fmt.Errorf("topic %q must not contain wildcards", mqtt.GetTopic()))
+	}
+	return err
+}
+
+func validateStdinCommand(stdinCmd *v1.StdinCommand) error {
+	var err error
+	if args, e := shlex.Split(stdinCmd.GetCommand()); e != nil {
+		err = multierror.Append(err, fmt.Errorf("command %q invalid: %w", stdinCmd.GetCommand(), e))
+	} else if len(args) == 0 {
+		err = multierror.Append(err, errors.New("command is required"))
+	}
+	if strings.TrimSpace(stdinCmd.GetFilename()) == "" {
+		err = multierror.Append(err, errors.New("filename is required"))
+	}
+	return err
+}
+
+func validateRestoreTestPolicy(policy *v1.RestoreTestPolicy) error {
+	var err error
+	if policy.GetSchedule() != nil {
+		if e := protoutil.ValidateSchedule(policy.GetSchedule()); e != nil {
+			err = multierror.Append(err, fmt.Errorf("schedule: %w", e))
+		}
+	}
+	if policy.GetSampleSize() < 0 {
+		err = multierror.Append(err, fmt.Errorf("sample size %d must not be negative", policy.GetSampleSize()))
+	}
+	if policy.GetPath() != "" && !strings.HasPrefix(policy.GetPath(), "/") {
+		err = multierror.Append(err, fmt.Errorf("path %q must be an absolute path in the snapshot", policy.GetPath()))
+	}
+	return err
+}
+
 func validateAuth(auth *v1.Auth) error {
 	if auth == nil || auth.Disabled {
 		return nil
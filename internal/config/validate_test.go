@@ -208,6 +208,54 @@ func TestValidateRepoForgetPolicy(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "forget policy with keep within durations, keep tags and group by",
+			repo: &v1.Repo{
+				Id: "repo1", Uri: "file:///tmp/repo", Guid: validGUID,
+				ForgetPolicy: &v1.ForgetPolicy{
+					Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}},
+					Retention: &v1.RetentionPolicy{
+						Policy:   &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{KeepWithin: "7d", KeepWithinDaily: "3m"}},
+						KeepTags: []string{"important", "legal,hold"},
+					},
+					GroupBy: "host,tags",
+				},
+			},
+		},
+		{
+			name: "forget policy with invalid keep within duration",
+			repo: &v1.Repo{
+				Id: "repo1", Uri: "file:///tmp/repo", Guid: validGUID,
+				ForgetPolicy: &v1.ForgetPolicy{
+					Schedule:  &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}},
+					Retention: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{KeepWithin: "7 days"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "forget policy with empty keep tag",
+			repo: &v1.Repo{
+				Id: "repo1", Uri: "file:///tmp/repo", Guid: validGUID,
+				ForgetPolicy: &v1.ForgetPolicy{
+					Schedule:  &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}},
+					Retention: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5}, KeepTags: []string{"a,"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "forget policy with invalid group by",
+			repo: &v1.Repo{
+				Id: "repo1", Uri: "file:///tmp/repo", Guid: validGUID,
+				ForgetPolicy: &v1.ForgetPolicy{
+					Schedule:  &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}},
+					Retention: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5}},
+					GroupBy:   "hostname",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -222,6 +270,177 @@ func TestValidateRepoForgetPolicy(t *testing.T) {
 	}
 }
 
+func TestValidateRepoCopyPolicy(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	schedule := &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}}
+	baseConfig := func(policy *v1.CopyPolicy) *v1.Config {
+		return &v1.Config{Instance: "test", Repos: []*v1.Repo{
+			{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, CopyPolicy: policy},
+			{Id: "repo2", Uri: "file:///tmp/repo2", Guid: validGUID},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		policy  *v1.CopyPolicy
+		wantErr bool
+	}{
+		{
+			name: "no copy policy is valid",
+		},
+		{
+			name:   "valid copy policy",
+			policy: &v1.CopyPolicy{Schedule: schedule, TargetRepo: "repo2"},
+		},
+		{
+			name:   "disabled copy policy without target",
+			policy: &v1.CopyPolicy{Schedule: &v1.Schedule{Schedule: &v1.Schedule_Disabled{Disabled: true}}},
+		},
+		{
+			name:    "copy policy without target",
+			policy:  &v1.CopyPolicy{Schedule: schedule},
+			wantErr: true,
+		},
+		{
+			name:    "copy policy targeting itself",
+			policy:  &v1.CopyPolicy{Schedule: schedule, TargetRepo: "repo1"},
+			wantErr: true,
+		},
+		{
+			name:    "copy policy with unknown target",
+			policy:  &v1.CopyPolicy{Schedule: schedule, TargetRepo: "missing"},
+			wantErr: true,
+		},
+		{
+			name:    "copy policy with invalid schedule",
+			policy:  &v1.CopyPolicy{Schedule: &v1.Schedule{Schedule: &v1.Schedule_Cron{Cron: "bad cron"}}, TargetRepo: "repo2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(baseConfig(tc.policy))
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePlanStdinCommand(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	baseConfig := func(paths []string, stdinCmd *v1.StdinCommand) *v1.Config {
+		return &v1.Config{
+			Instance: "test",
+			Repos:    []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID}},
+			Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1", Paths: paths, StdinCommand: stdinCmd}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		stdinCmd *v1.StdinCommand
+		wantErr  bool
+	}{
+		{
+			name:     "valid stdin command",
+			stdinCmd: &v1.StdinCommand{Command: "pg_dump --dbname 'my db'", Filename: "db.sql"},
+		},
+		{
+			name:     "stdin command without command",
+			stdinCmd: &v1.StdinCommand{Filename: "db.sql"},
+			wantErr:  true,
+		},
+		{
+			name:     "stdin command without filename",
+			stdinCmd: &v1.StdinCommand{Command: "pg_dump"},
+			wantErr:  true,
+		},
+		{
+			name:     "stdin command with unterminated quote",
+			stdinCmd: &v1.StdinCommand{Command: "pg_dump 'db", Filename: "db.sql"},
+			wantErr:  true,
+		},
+		{
+			name:     "stdin command with paths",
+			paths:    []string{"/tmp"},
+			stdinCmd: &v1.StdinCommand{Command: "pg_dump", Filename: "db.sql"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(baseConfig(tc.paths, tc.stdinCmd))
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePlanRestoreTestPolicy(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		policy  *v1.RestoreTestPolicy
+		wantErr bool
+	}{
+		{
+			name: "valid sampled restore test",
+			policy: &v1.RestoreTestPolicy{
+				Schedule:   &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 7}},
+				SampleSize: 5,
+			},
+		},
+		{
+			name: "valid restore test of a path",
+			policy: &v1.RestoreTestPolicy{
+				Schedule: &v1.Schedule{Schedule: &v1.Schedule_Cron{Cron: "0 0 * * 0"}},
+				Path:     "/home/user/documents",
+			},
+		},
+		{
+			name: "invalid schedule",
+			policy: &v1.RestoreTestPolicy{
+				Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 0}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "negative sample size",
+			policy:  &v1.RestoreTestPolicy{SampleSize: -1},
+			wantErr: true,
+		},
+		{
+			name:    "relative path",
+			policy:  &v1.RestoreTestPolicy{Path: "documents"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos:    []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID}},
+				Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1", Paths: []string{"/tmp"}, RestoreTestPolicy: tc.policy}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func sliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -233,3 +452,348 @@ func sliceEqual(a, b []string) bool {
 	}
 	return true
 }
+
+func TestValidatePlanOverdueGraceMultiplier(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name       string
+		multiplier float64
+		wantErr    bool
+	}{
+		{name: "default", multiplier: 0},
+		{name: "custom", multiplier: 3},
+		{name: "less than one", multiplier: 0.5, wantErr: true},
+		{name: "negative", multiplier: -1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos:    []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID}},
+				Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1", Paths: []string{"/tmp"}, OverdueGraceMultiplier: tc.multiplier}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookNotifyMode(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		hook    *v1.Hook
+		wantErr bool
+	}{
+		{name: "always", hook: &v1.Hook{}},
+		{name: "on change", hook: &v1.Hook{NotifyMode: v1.Hook_NOTIFY_ON_CHANGE}},
+		{name: "rate limited", hook: &v1.Hook{NotifyMode: v1.Hook_NOTIFY_RATE_LIMITED, RateLimitMinutes: 60}},
+		{name: "rate limited without interval", hook: &v1.Hook{NotifyMode: v1.Hook_NOTIFY_RATE_LIMITED}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos:    []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: []*v1.Hook{tc.hook}}},
+				Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1", Paths: []string{"/tmp"}, Hooks: []*v1.Hook{tc.hook}}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateDigestPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *v1.DigestPolicy
+		wantErr bool
+	}{
+		{
+			name: "valid digest",
+			policy: &v1.DigestPolicy{
+				Schedule: &v1.Schedule{Schedule: &v1.Schedule_Cron{Cron: "0 8 * * 1"}},
+				Hooks:    []*v1.Hook{{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST}}},
+			},
+		},
+		{
+			name: "invalid schedule",
+			policy: &v1.DigestPolicy{
+				Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 0}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hook without digest condition",
+			policy: &v1.DigestPolicy{
+				Hooks: []*v1.Hook{{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}}},
+			},
+			wantErr: true,
+		},		{
+			name: "hook notifying on change",
+			policy: &v1.DigestPolicy{
+				Hooks: []*v1.Hook{{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST}, NotifyMode: v1.Hook_NOTIFY_ON_CHANGE}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Digest:   tc.policy,
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookEmail(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	validEmail := func() *v1.Hook_Email {
+		return &v1.Hook_Email{
+			Host: "smtp.example.com",
+			From: "Backrest <backrest@example.com>",
+			To:   []string{"alice@example.com"},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(e *v1.Hook_Email)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(e *v1.Hook_Email) {}},
+		{name: "valid with cc", mutate: func(e *v1.Hook_Email) { e.Cc = []string{"Bob <bob@example.com>"} }},
+		{name: "missing host", mutate: func(e *v1.Hook_Email) { e.Host = "" }, wantErr: true},
+		{name: "port out of range", mutate: func(e *v1.Hook_Email) { e.Port = 70000 }, wantErr: true},
+		{name: "invalid from", mutate: func(e *v1.Hook_Email) { e.From = "not an address" }, wantErr: true},
+		{name: "no recipients", mutate: func(e *v1.Hook_Email) { e.To = nil }, wantErr: true},
+		{name: "invalid cc", mutate: func(e *v1.Hook_Email) { e.Cc = []string{"bob"} }, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			email := validEmail()
+			tc.mutate(email)
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos: []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: []*v1.Hook{{
+					Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_ERROR},
+					Action:     &v1.Hook_ActionEmail{ActionEmail: email},
+				}}}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookMqtt(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		mqtt    *v1.Hook_Mqtt
+		wantErr bool
+	}{
+		{name: "valid", mqtt: &v1.Hook_Mqtt{BrokerUrl: "tcp://localhost:1883"}},
+		{name: "valid tls with topic", mqtt: &v1.Hook_Mqtt{BrokerUrl: "mqtts://broker.example.com", Topic: "home/backrest", Qos: 1}},
+		{name: "missing broker", mqtt: &v1.Hook_Mqtt{}, wantErr: true},
+		{name: "unsupported scheme", mqtt: &v1.Hook_Mqtt{BrokerUrl: "http://localhost:1883"}, wantErr: true},
+		{name: "invalid qos", mqtt: &v1.Hook_Mqtt{BrokerUrl: "tcp://localhost:1883", Qos: 3}, wantErr: true},
+		{name: "wildcard topic", mqtt: &v1.Hook_Mqtt{BrokerUrl: "tcp://localhost:1883", Topic: "backrest/#"}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos: []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: []*v1.Hook{{
+					Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+					Action:     &v1.Hook_ActionMqtt{ActionMqtt: tc.mqtt},
+				}}}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookNtfy(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		ntfy    *v1.Hook_Ntfy
+		wantErr bool
+	}{
+		{name: "valid", ntfy: &v1.Hook_Ntfy{Topic: "backups"}},
+		{name: "valid with priority", ntfy: &v1.Hook_Ntfy{Topic: "backups", Priority: 5}},
+		{name: "missing topic", ntfy: &v1.Hook_Ntfy{}, wantErr: true},
+		{name: "priority out of range", ntfy: &v1.Hook_Ntfy{Topic: "backups", Priority: 6}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos: []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: []*v1.Hook{{
+					Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+					Action:     &v1.Hook_ActionNtfy{ActionNtfy: tc.ntfy},
+				}}}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookWebhook(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		webhook *v1.Hook_Webhook
+		wantErr bool
+	}{
+		{name: "valid", webhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com/hook"}},
+		{name: "valid with options", webhook: &v1.Hook_Webhook{
+			WebhookUrl:     "http://localhost:8080/hook",
+			Method:         v1.Hook_Webhook_PATCH,
+			Headers:        []string{"X-Token: abc"},
+			BearerToken:    "token",
+			HmacSecret:     "secret",
+			TimeoutSeconds: 10,
+			Retries:        3,
+		}},
+		{name: "missing url", webhook: &v1.Hook_Webhook{}, wantErr: true},
+		{name: "not http", webhook: &v1.Hook_Webhook{WebhookUrl: "ftp://example.com"}, wantErr: true},
+		{name: "invalid header", webhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com", Headers: []string{"X-Token"}}, wantErr: true},
+		{name: "basic and bearer auth", webhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com", BasicAuthUsername: "user", BearerToken: "token"}, wantErr: true},
+		{name: "negative timeout", webhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com", TimeoutSeconds: -1}, wantErr: true},
+		{name: "too many retries", webhook: &v1.Hook_Webhook{WebhookUrl: "https://example.com", Retries: 11}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos: []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: []*v1.Hook{{
+					Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+					Action:     &v1.Hook_ActionWebhook{ActionWebhook: tc.webhook},
+				}}}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookRefs(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	slack := func(id string) *v1.Hook {
+		return &v1.Hook{
+			Id:         id,
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR},
+			Action:     &v1.Hook_ActionSlack{ActionSlack: &v1.Hook_Slack{WebhookUrl: "https://hooks.slack.com/services/abc"}},
+		}
+	}
+	tests := []struct {
+		name      string
+		hooks     []*v1.Hook
+		repoHooks []*v1.Hook
+		digest    *v1.DigestPolicy
+		wantErr   bool
+	}{
+		{name: "valid reference", hooks: []*v1.Hook{slack("slack")}, repoHooks: []*v1.Hook{{Ref: "slack"}}},
+		{name: "reference with conditions", hooks: []*v1.Hook{slack("slack")}, repoHooks: []*v1.Hook{{Ref: "slack", Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}}}},
+		{name: "dangling reference", hooks: []*v1.Hook{slack("slack")}, repoHooks: []*v1.Hook{{Ref: "discord"}}, wantErr: true},
+		{name: "reference with an action", hooks: []*v1.Hook{slack("slack")}, repoHooks: []*v1.Hook{func() *v1.Hook { h := slack(""); h.Ref = "slack"; return h }()}, wantErr: true},
+		{name: "reference with notify mode", hooks: []*v1.Hook{slack("slack")}, repoHooks: []*v1.Hook{{Ref: "slack", NotifyMode: v1.Hook_NOTIFY_ON_CHANGE}}, wantErr: true},
+		{name: "id outside definitions", repoHooks: []*v1.Hook{slack("slack")}, wantErr: true},
+		{name: "definition without id", hooks: []*v1.Hook{slack("")}, wantErr: true},
+		{name: "duplicate definitions", hooks: []*v1.Hook{slack("slack"), slack("slack")}, wantErr: true},
+		{name: "definition without action", hooks: []*v1.Hook{{Id: "slack"}}, wantErr: true},
+		{name: "definition referencing a definition", hooks: []*v1.Hook{slack("slack"), {Id: "alias", Ref: "slack"}}, wantErr: true},
+		{
+			name:    "digest reference without digest condition",
+			hooks:   []*v1.Hook{slack("slack")},
+			digest:  &v1.DigestPolicy{Hooks: []*v1.Hook{{Ref: "slack"}}},
+			wantErr: true,
+		},
+		{
+			name:   "digest reference with digest condition",
+			hooks:  []*v1.Hook{slack("slack")},
+			digest: &v1.DigestPolicy{Hooks: []*v1.Hook{{Ref: "slack", Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST}}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Hooks:    tc.hooks,
+				Repos:    []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: tc.repoHooks}},
+				Digest:   tc.digest,
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHookTimeout(t *testing.T) {
+	validGUID := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		timeout int32
+		wantErr bool
+	}{
+		{name: "no timeout", timeout: 0},
+		{name: "timeout", timeout: 300},
+		{name: "negative timeout", timeout: -1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfig(&v1.Config{
+				Instance: "test",
+				Repos: []*v1.Repo{{Id: "repo1", Uri: "file:///tmp/repo1", Guid: validGUID, Hooks: []*v1.Hook{{
+					Conditions:     []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START},
+					TimeoutSeconds: tc.timeout,
+					Action:         &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "pg_dump mydb > /backups/mydb.sql"}},
+				}}}},
+			})
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,12 +19,18 @@ var (
 	EnvVarBindAddress                = "BACKREST_PORT"                         // port to bind to (default 9898)
 	EnvVarBinPath                    = "BACKREST_RESTIC_COMMAND"               // path to restic binary (default restic)
 	EnvVarMultihostHeartbeatInterval = "BACKREST_MULTIHOST_HEARTBEAT_INTERVAL" // interval for multihost heartbeat messages
+	EnvVarMaxConcurrentTasks         = "BACKREST_MAX_CONCURRENT_TASKS"         // max number of tasks the orchestrator runs at once (default 1)
+	EnvVarRetentionGuardPercent      = "BACKREST_RETENTION_GUARD_PERCENT"      // max percent of snapshots a retention change may remove without confirmation (default 0, disabled)
 )
 
+const defaultMaxConcurrentTasks = 1
+
 var flagDataDir = flag.String("data-dir", "", "path to data directory, defaults to XDG_DATA_HOME/.local/backrest. Overrides BACKREST_DATA environment variable.")
 var flagConfigPath = flag.String("config-file", "", "path to config file, defaults to XDG_CONFIG_HOME/backrest/config.json. Overrides BACKREST_CONFIG environment variable.")
 var flagBindAddress = flag.String("bind-address", "", "address to bind to, defaults to 127.0.0.1:9898. Use :9898 to listen on all interfaces. Overrides BACKREST_PORT environment variable.")
 var flagResticBinPath = flag.String("restic-cmd", "", "path to restic binary, defaults to a backrest managed version of restic. Overrides BACKREST_RESTIC_COMMAND environment variable.")
+var flagMaxConcurrentTasks = flag.Int("max-concurrent-tasks", 0, "maximum number of tasks (e.g. backups, prunes, checks) to run at once across all repos, defaults to 1 which runs tasks one at a time. A task that modifies a repo doesn't run alongside other tasks against that repo. Overrides BACKREST_MAX_CONCURRENT_TASKS environment variable.")
+var flagRetentionGuardPercent = flag.Float64("retention-guard-percent", 0, "reject config changes to a retention policy that would remove more than this percent of the snapshots it applies to, unless the change is confirmed. Defaults to 0 (disabled). Overrides BACKREST_RETENTION_GUARD_PERCENT environment variable.")
 var flagMultihostHeartbeatInterval = flag.Duration("multihost-heartbeat-interval", 600*time.Second, "interval in seconds to send heartbeat messages to other hosts in a multihost setup. Defaults to 600 seconds, but can be set lower to keep connections alive with reverse proxies that aggressively timeout idle connections.")
 
 // ConfigFilePath
@@ -93,6 +100,35 @@ func MultihostHeartbeatInterval() time.Duration {
 	return 600 * time.Second // Default to 10 minutes.
 }
 
+func MaxConcurrentTasks() int {
+	if *flagMaxConcurrentTasks > 0 {
+		return *flagMaxConcurrentTasks
+	}
+	if val := os.Getenv(EnvVarMaxConcurrentTasks); val != "" {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			return n
+		} else {
+			zap.S().Warnf("Invalid value for %s: %s, using default %d", EnvVarMaxConcurrentTasks, val, defaultMaxConcurrentTasks)
+		}
+	}
+	return defaultMaxConcurrentTasks
+}
+
+// RetentionGuardPercent returns the max percent of snapshots a retention policy change may remove without confirmation, 0 if the guard is disabled.
+func RetentionGuardPercent() float64 {
+	if *flagRetentionGuardPercent > 0 {
+		return *flagRetentionGuardPercent
+	}
+	if val := os.Getenv(EnvVarRetentionGuardPercent); val != "" {
+		if p, err := strconv.ParseFloat(val, 64); err == nil && p >= 0 {
+			return p
+		} else {
+			zap.S().Warnf("Invalid value for %s: %s, retention guard is disabled", EnvVarRetentionGuardPercent, val)
+		}
+	}
+	return 0
+}
+
 func LogsPath() string {
 	dataDir := DataDir()
 	return filepath.Join(dataDir, "processlogs")
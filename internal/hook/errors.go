@@ -42,3 +42,18 @@ type HookErrorRetry struct {
 func (e HookErrorRetry) Error() string {
 	return fmt.Sprintf("retry: %v", e.Err.Error())
 }
+
+// HookErrorTimeout is returned when a hook fails because it ran longer than its timeout. It's handled by the hook's
+// error policy like any other error.
+type HookErrorTimeout struct {
+	Err     error
+	Timeout time.Duration
+}
+
+func (e HookErrorTimeout) Error() string {
+	return fmt.Sprintf("timed out after %v: %v", e.Timeout, e.Err.Error())
+}
+
+func (e HookErrorTimeout) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,153 @@
+package hook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// historyMu serializes updates to the hook firing history.
+var historyMu sync.Mutex
+
+// historyKey returns the key of the resolved hook's firing history among the hooks of scope e.g. "plan/<id>". ref is
+// the definition the hook was resolved from, if any. The key doesn't depend on the hook's position so that reordering
+// hooks keeps their history. Hooks referencing a definition are identified by the reference, others by their action,
+// editing the action starts a new history. The conditions are part of the key, hooks sharing a reference or an action
+// but not their conditions keep separate histories.
+func historyKey(scope string, ref string, hook *v1.Hook) string {
+	conditions := slices.Clone(hook.GetConditions())
+	slices.Sort(conditions)
+	names := make([]string, 0, len(conditions))
+	for _, c := range slices.Compact(conditions) {
+		names = append(names, c.String())
+	}
+	suffix := "/" + strings.Join(names, ",")
+
+	if ref != "" {
+		return scope + "/ref/" + ref + suffix
+	}
+	action := proto.Clone(hook).(*v1.Hook)
+	action.Conditions = nil
+	action.OnError = 0
+	action.NotifyMode = 0
+	action.RateLimitMinutes = 0
+	action.TimeoutSeconds = 0
+	action.Id = ""
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(action) // only fails for invalid messages.
+	sum := sha256.Sum256(b)
+	return scope + "/action/" + hex.EncodeToString(sum[:8]) + suffix
+}
+
+// shouldNotify applies the hook's notify mode, recording the firing history needed to apply it to later events in
+// history under key. event is the hook's first condition matching events, or CONDITION_UNKNOWN if it matches none of
+// them.
+// Errors reading or writing the history are logged and the hook runs as if its notify mode were NOTIFY_ALWAYS.
+func shouldNotify(history kvstore.KvStore, key string, planID string, hook *v1.Hook, events []v1.Hook_Condition, event v1.Hook_Condition, now time.Time) bool {
+	matched := event != v1.Hook_CONDITION_UNKNOWN
+	if history == nil {
+		return matched
+	}
+
+	var notify bool
+	var err error
+	switch hook.GetNotifyMode() {
+	case v1.Hook_NOTIFY_ON_CHANGE:
+		notify, err = notifyOnChange(history, key, planID, events, matched)
+	case v1.Hook_NOTIFY_RATE_LIMITED:
+		if !matched {
+			return false
+		}
+		notify, err = notifyRateLimited(history, key, time.Duration(hook.GetRateLimitMinutes())*time.Minute, now)
+	default:
+		return matched
+	}
+	if err != nil {
+		zap.S().Warnf("hook %v: notify mode %v: %v", key, hook.GetNotifyMode(), err)
+		return matched
+	}
+	return notify
+}
+
+// notifyOnChange records the outcome of events for the plan and returns true if the hook matched and the outcome
+// differs from the previous outcome recorded for the same condition group. Every event is recorded whether or not
+// the hook matched, so that a hook e.g. only running on success still runs on the first success following an error.
+func notifyOnChange(history kvstore.KvStore, name string, planID string, events []v1.Hook_Condition, matched bool) (bool, error) {
+	group, outcome := eventOutcome(events)
+	if outcome == "start" {
+		return matched, nil
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	key := fmt.Sprintf("hook_history/%v/outcome/%v/%d", name, planID, group)
+	prev, err := history.Get(key)
+	if err != nil && !errors.Is(err, kvstore.ErrNotExist) {
+		return false, fmt.Errorf("get previous outcome: %w", err)
+	}
+	if string(prev) == outcome {
+		return false, nil
+	}
+	if err := history.Set(key, []byte(outcome)); err != nil {
+		return false, fmt.Errorf("set outcome: %w", err)
+	}
+	return matched, nil
+}
+
+// notifyRateLimited returns true and records the hook's run if the hook has not run within the last interval.
+func notifyRateLimited(history kvstore.KvStore, name string, interval time.Duration, now time.Time) (bool, error) {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	key := fmt.Sprintf("hook_history/%v/last_run", name)
+	prev, err := history.Get(key)
+	if err != nil && !errors.Is(err, kvstore.ErrNotExist) {
+		return false, fmt.Errorf("get last run: %w", err)
+	}
+	if lastRunMs, err := strconv.ParseInt(string(prev), 10, 64); err == nil && now.Sub(time.UnixMilli(lastRunMs)) < interval {
+		return false, nil
+	}
+	if err := history.Set(key, []byte(strconv.FormatInt(now.UnixMilli(), 10))); err != nil {
+		return false, fmt.Errorf("set last run: %w", err)
+	}
+	return true, nil
+}
+
+// eventOutcome summarizes the events fired together by an operation as the condition group they belong to, conditions
+// are numbered in ranges of 100 per operation type, and the operation's outcome.
+func eventOutcome(events []v1.Hook_Condition) (int, string) {
+	var group int
+	for _, event := range events {
+		if event != v1.Hook_CONDITION_ANY_ERROR {
+			group = int(event) / 100
+			break
+		}
+	}
+
+	switch {
+	case len(events) == 0:
+		return group, ""
+	case slices.ContainsFunc(events, protoutil.IsErrorCondition):
+		return group, "error"
+	case slices.Contains(events, v1.Hook_CONDITION_SNAPSHOT_WARNING):
+		return group, "warning"
+	case slices.ContainsFunc(events, protoutil.IsStartCondition):
+		return group, "start"
+	case slices.ContainsFunc(events, protoutil.IsSuccessCondition):
+		return group, "success"
+	default:
+		return group, events[0].String()
+	}
+}
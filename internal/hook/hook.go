@@ -12,11 +12,39 @@ import (
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	cfg "github.com/garethgeorge/backrest/internal/config"
 	"github.com/garethgeorge/backrest/internal/hook/types"
+	"github.com/garethgeorge/backrest/internal/kvstore"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 )
 
-func TasksTriggeredByEvent(config *v1.Config, repoID string, planID string, parentOp *v1.Operation, events []v1.Hook_Condition, vars interface{}) ([]tasks.Task, error) {
+// TasksTriggeredByEvent returns the tasks for the repo's and plan's hooks that should run for the events, or for the
+// digest policy's hooks if repoID is empty. history records hook firings to apply each hook's notify mode, if nil every
+// hook matching the events runs.
+func TasksTriggeredByEvent(config *v1.Config, history kvstore.KvStore, repoID string, planID string, parentOp *v1.Operation, events []v1.Hook_Condition, vars interface{}) ([]tasks.Task, error) {
 	var taskSet []tasks.Task
+	now := time.Now()
+
+	if repoID == "" {
+		// Events not associated with a repo are instance wide, e.g. the digest, and run the digest policy's hooks.
+		for idx, hook := range config.GetDigest().GetHooks() {
+			name := fmt.Sprintf("digest/hook/%v", idx)
+			ref := hook.GetRef()
+			hook, err := cfg.ResolveHook(config, hook)
+			if err != nil {
+				return nil, fmt.Errorf("digest hook %d: %w", idx, err)
+			}
+			key := historyKey("digest", ref, hook)
+			event := firstMatchingCondition(hook, events)
+			if !shouldNotify(history, key, planID, hook, events, event, now) {
+				continue
+			}
+			task, err := newOneoffRunHookTask(name, config.Instance, nil, planID, parentOp, now, hook, event, vars)
+			if err != nil {
+				return nil, err
+			}
+			taskSet = append(taskSet, task)
+		}
+		return taskSet, nil
+	}
 
 	repo := cfg.FindRepo(config, repoID)
 	if repo == nil {
@@ -25,13 +53,18 @@ func TasksTriggeredByEvent(config *v1.Config, repoID string, planID string, pare
 	plan := cfg.FindPlan(config, planID)
 
 	for idx, hook := range repo.GetHooks() {
+		name := fmt.Sprintf("repo/%v/hook/%v", repo.Id, idx)
+		ref := hook.GetRef()
+		hook, err := cfg.ResolveHook(config, hook)
+		if err != nil {
+			return nil, fmt.Errorf("repo %v hook %d: %w", repo.Id, idx, err)
+		}
+		key := historyKey("repo/"+repo.Id, ref, hook)
 		event := firstMatchingCondition(hook, events)
-		if event == v1.Hook_CONDITION_UNKNOWN {
+		if !shouldNotify(history, key, planID, hook, events, event, now) {
 			continue
 		}
-
-		name := fmt.Sprintf("repo/%v/hook/%v", repo.Id, idx)
-		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, time.Now(), hook, event, vars)
+		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, now, hook, event, vars)
 		if err != nil {
 			return nil, err
 		}
@@ -39,13 +72,18 @@ func TasksTriggeredByEvent(config *v1.Config, repoID string, planID string, pare
 	}
 
 	for idx, hook := range plan.GetHooks() {
+		name := fmt.Sprintf("plan/%v/hook/%v", plan.Id, idx)
+		ref := hook.GetRef()
+		hook, err := cfg.ResolveHook(config, hook)
+		if err != nil {
+			return nil, fmt.Errorf("plan %v hook %d: %w", plan.Id, idx, err)
+		}
+		key := historyKey("plan/"+plan.Id, ref, hook)
 		event := firstMatchingCondition(hook, events)
-		if event == v1.Hook_CONDITION_UNKNOWN {
+		if !shouldNotify(history, key, planID, hook, events, event, now) {
 			continue
 		}
-
-		name := fmt.Sprintf("plan/%v/hook/%v", plan.Id, idx)
-		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, time.Now(), hook, event, vars)
+		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, now, hook, event, vars)
 		if err != nil {
 			return nil, err
 		}
@@ -63,7 +101,7 @@ func newOneoffRunHookTask(title, instanceID string, repo *v1.Repo, planID string
 
 	title = h.Name() + " hook " + title
 
-	return &tasks.GenericOneoffTask{
+	task := &tasks.GenericOneoffTask{
 		BaseTask: tasks.BaseTask{
 			TaskType:   "hook",
 			TaskName:   fmt.Sprintf("run hook %v", title),
@@ -91,13 +129,42 @@ func newOneoffRunHookTask(title, instanceID string, repo *v1.Repo, planID string
 				clone.FieldByName("Event").Set(reflect.ValueOf(event))
 			}
 
-			if err := h.Execute(ctx, hook, clone, taskRunner, event); err != nil {
+			// handlers receive the vars' own type, e.g. tasks.HookVars, not the reflect.Value.
+			if err := ExecuteWithTimeout(ctx, h, hook, clone.Interface(), taskRunner, event); err != nil {
+				var timeoutErr *HookErrorTimeout
+				if errors.As(err, &timeoutErr) {
+					if runHook := st.Op.GetOperationRunHook(); runHook != nil {
+						runHook.TimedOut = true
+					}
+				}
 				err = applyHookErrorPolicy(hook.OnError, err)
 				return err
 			}
 			return nil
 		},
-	}, nil
+	}
+	if repo == nil {
+		task.ProtoOp = nil // operations belong to a repo, hooks for instance wide events aren't recorded.
+	}
+	return task, nil
+}
+
+// ExecuteWithTimeout runs the hook's handler, cancelling it after the hook's timeout if it has one. Running out of time
+// is reported as a HookErrorTimeout.
+func ExecuteWithTimeout(ctx context.Context, h types.Handler, hook *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	if hook.GetTimeoutSeconds() <= 0 {
+		return h.Execute(ctx, hook, vars, runner, event)
+	}
+
+	timeout := time.Duration(hook.GetTimeoutSeconds()) * time.Second
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := h.Execute(hookCtx, hook, vars, runner, event)
+	if err != nil && ctx.Err() == nil && errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+		return &HookErrorTimeout{Err: err, Timeout: timeout}
+	}
+	return err
 }
 
 func firstMatchingCondition(hook *v1.Hook, events []v1.Hook_Condition) v1.Hook_Condition {
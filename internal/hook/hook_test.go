@@ -1,10 +1,21 @@
 package hook
 
 import (
+	"context"
 	"errors"
+	"io"
+	"net"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/eclipse/paho.golang/packets"
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
 )
 
 // TestApplyHookErrorPolicy tests that applyHookErrorPolicy is defined for all values of Hook_OnError.
@@ -14,3 +25,368 @@ func TestApplyHookErrorPolicy(t *testing.T) {
 		applyHookErrorPolicy(v1.Hook_OnError(values.Get(i).Number()), errors.New("an error"))
 	}
 }
+
+func TestTasksTriggeredByEventNotifyMode(t *testing.T) {
+	newConfig := func(hook *v1.Hook) *v1.Config {
+		hook.Action = &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}}
+		return &v1.Config{
+			Instance: "test",
+			Repos:    []*v1.Repo{{Id: "repo1"}},
+			Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1", Hooks: []*v1.Hook{hook}}},
+		}
+	}
+	success := []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS, v1.Hook_CONDITION_SNAPSHOT_END}
+	failure := []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_ERROR, v1.Hook_CONDITION_ANY_ERROR, v1.Hook_CONDITION_SNAPSHOT_END}
+	pruneFailure := []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR, v1.Hook_CONDITION_PRUNE_ERROR}
+
+	tests := []struct {
+		name   string
+		hook   *v1.Hook
+		events [][]v1.Hook_Condition
+		want   []bool // whether the hook runs for each set of events
+	}{
+		{
+			name:   "always",
+			hook:   &v1.Hook{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS}},
+			events: [][]v1.Hook_Condition{success, success, failure, success},
+			want:   []bool{true, true, false, true},
+		},
+		{
+			name:   "on change",
+			hook:   &v1.Hook{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}, NotifyMode: v1.Hook_NOTIFY_ON_CHANGE},
+			events: [][]v1.Hook_Condition{success, success, failure, failure, success},
+			want:   []bool{true, false, true, false, true},
+		},
+		{
+			name:   "on change records outcomes the hook doesn't match",
+			hook:   &v1.Hook{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS}, NotifyMode: v1.Hook_NOTIFY_ON_CHANGE},
+			events: [][]v1.Hook_Condition{success, failure, success, success},
+			want:   []bool{true, false, true, false},
+		},
+		{
+			name:   "on change tracks condition groups separately",
+			hook:   &v1.Hook{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR}, NotifyMode: v1.Hook_NOTIFY_ON_CHANGE},
+			events: [][]v1.Hook_Condition{failure, pruneFailure, failure, pruneFailure},
+			want:   []bool{true, true, false, false},
+		},
+		{
+			name:   "on change always runs start conditions",
+			hook:   &v1.Hook{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START}, NotifyMode: v1.Hook_NOTIFY_ON_CHANGE},
+			events: [][]v1.Hook_Condition{{v1.Hook_CONDITION_SNAPSHOT_START}, {v1.Hook_CONDITION_SNAPSHOT_START}},
+			want:   []bool{true, true},
+		},
+		{
+			name:   "rate limited",
+			hook:   &v1.Hook{Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}, NotifyMode: v1.Hook_NOTIFY_RATE_LIMITED, RateLimitMinutes: 60},
+			events: [][]v1.Hook_Condition{success, failure, success},
+			want:   []bool{true, false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			history, err := kvstore.NewSqliteKVStore(kvstore.NewInMemorySqliteDbForKvStore(t), "hook_history")
+			if err != nil {
+				t.Fatalf("failed to create kvstore: %v", err)
+			}
+			config := newConfig(tc.hook)
+			for i, events := range tc.events {
+				hookTasks, err := TasksTriggeredByEvent(config, history, "repo1", "plan1", nil, events, tasks.HookVars{})
+				if err != nil {
+					t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+				}
+				if got := len(hookTasks) == 1; got != tc.want[i] {
+					t.Errorf("events[%d] %v: hook ran = %v, want %v", i, events, got, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTasksTriggeredByEventHistoryKeptWhenReordered(t *testing.T) {
+	history, err := kvstore.NewSqliteKVStore(kvstore.NewInMemorySqliteDbForKvStore(t), "hook_history")
+	if err != nil {
+		t.Fatalf("failed to create kvstore: %v", err)
+	}
+	newHook := func(command string) *v1.Hook {
+		return &v1.Hook{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+			NotifyMode: v1.Hook_NOTIFY_ON_CHANGE,
+			Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: command}},
+		}
+	}
+	plan := &v1.Plan{Id: "plan1", Repo: "repo1", Hooks: []*v1.Hook{newHook("echo a")}}
+	config := &v1.Config{
+		Instance: "test",
+		Repos:    []*v1.Repo{{Id: "repo1"}},
+		Plans:    []*v1.Plan{plan},
+	}
+	events := []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS, v1.Hook_CONDITION_SNAPSHOT_END}
+
+	if hookTasks, err := TasksTriggeredByEvent(config, history, "repo1", "plan1", nil, events, tasks.HookVars{}); err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+	} else if len(hookTasks) != 1 {
+		t.Fatalf("got %d hook tasks for the first success, want 1", len(hookTasks))
+	}
+
+	// inserting a hook before the first one moves it, only the new hook hasn't seen the success yet.
+	plan.Hooks = []*v1.Hook{newHook("echo b"), plan.Hooks[0]}
+	hookTasks, err := TasksTriggeredByEvent(config, history, "repo1", "plan1", nil, events, tasks.HookVars{})
+	if err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+	}
+	if len(hookTasks) != 1 || !strings.Contains(hookTasks[0].Name(), "plan/plan1/hook/0") {
+		t.Errorf("got hook tasks %v, want only the inserted hook", hookTasks)
+	}
+}
+
+func TestTasksTriggeredByEventHistoryPerReferencingHook(t *testing.T) {
+	history, err := kvstore.NewSqliteKVStore(kvstore.NewInMemorySqliteDbForKvStore(t), "hook_history")
+	if err != nil {
+		t.Fatalf("failed to create kvstore: %v", err)
+	}
+	config := &v1.Config{
+		Instance: "test",
+		Hooks: []*v1.Hook{{
+			Id:         "notify",
+			NotifyMode: v1.Hook_NOTIFY_ON_CHANGE,
+			Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+		}},
+		Repos: []*v1.Repo{{Id: "repo1"}},
+		Plans: []*v1.Plan{{Id: "plan1", Repo: "repo1", Hooks: []*v1.Hook{
+			{Ref: "notify", Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_ERROR}},
+			{Ref: "notify", Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS}},
+		}}},
+	}
+	success := []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS, v1.Hook_CONDITION_SNAPSHOT_END}
+	failure := []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_ERROR, v1.Hook_CONDITION_ANY_ERROR, v1.Hook_CONDITION_SNAPSHOT_END}
+
+	for i, step := range []struct {
+		events []v1.Hook_Condition
+		want   string // the only hook expected to run.
+	}{
+		{events: success, want: "plan/plan1/hook/1"},
+		{events: failure, want: "plan/plan1/hook/0"},
+		{events: success, want: "plan/plan1/hook/1"},
+	} {
+		hookTasks, err := TasksTriggeredByEvent(config, history, "repo1", "plan1", nil, step.events, tasks.HookVars{})
+		if err != nil {
+			t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+		}
+		if len(hookTasks) != 1 || !strings.Contains(hookTasks[0].Name(), step.want) {
+			t.Errorf("events[%d] %v: got hook tasks %v, want only %v", i, step.events, hookTasks, step.want)
+		}
+	}
+}
+
+func TestTasksTriggeredByEventNilHistory(t *testing.T) {
+	config := &v1.Config{
+		Instance: "test",
+		Repos: []*v1.Repo{{Id: "repo1", Hooks: []*v1.Hook{{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+			NotifyMode: v1.Hook_NOTIFY_ON_CHANGE,
+			Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+		}}}},
+	}
+	for i := 0; i < 2; i++ {
+		hookTasks, err := TasksTriggeredByEvent(config, nil, "repo1", "plan1", nil, []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}, tasks.HookVars{})
+		if err != nil {
+			t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+		}
+		if len(hookTasks) != 1 {
+			t.Errorf("run %d: got %d hook tasks, want 1 without history", i, len(hookTasks))
+		}
+	}
+}
+
+func TestTasksTriggeredByEventDigest(t *testing.T) {
+	command := &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}}
+	config := &v1.Config{
+		Instance: "test",
+		Repos: []*v1.Repo{{Id: "repo1", Hooks: []*v1.Hook{{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST},
+			Action:     command,
+		}}}},
+		Digest: &v1.DigestPolicy{Hooks: []*v1.Hook{{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST},
+			Action:     command,
+		}}},
+	}
+
+	hookTasks, err := TasksTriggeredByEvent(config, nil, "", "", nil, []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST}, tasks.HookVars{})
+	if err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+	}
+	if len(hookTasks) != 1 {
+		t.Fatalf("got %d hook tasks, want only the digest policy's hook", len(hookTasks))
+	}
+	if task := hookTasks[0].(*tasks.GenericOneoffTask); task.ProtoOp != nil || task.Repo() != nil {
+		t.Errorf("digest hook task should not be associated with a repo or operation")
+	}
+}
+
+func TestTasksTriggeredByEventHookRefs(t *testing.T) {
+	config := &v1.Config{
+		Instance: "test",
+		Hooks: []*v1.Hook{{
+			Id:         "slack",
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR},
+			Action:     &v1.Hook_ActionSlack{ActionSlack: &v1.Hook_Slack{WebhookUrl: "https://hooks.slack.com/services/abc"}},
+		}},
+		Repos: []*v1.Repo{{Id: "repo1", Hooks: []*v1.Hook{{Ref: "slack"}}}},
+		Plans: []*v1.Plan{{Id: "plan1", Repo: "repo1", Hooks: []*v1.Hook{{
+			Ref:        "slack",
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS},
+		}}}},
+	}
+
+	tests := []struct {
+		name   string
+		events []v1.Hook_Condition
+		want   int
+	}{
+		{name: "definition's conditions", events: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR}, want: 1},
+		{name: "referencing hook's conditions", events: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS}, want: 1},
+		{name: "no match", events: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START}, want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hookTasks, err := TasksTriggeredByEvent(config, nil, "repo1", "plan1", nil, tc.events, tasks.HookVars{})
+			if err != nil {
+				t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+			}
+			if len(hookTasks) != tc.want {
+				t.Errorf("got %d hook tasks, want %d", len(hookTasks), tc.want)
+			}
+			for _, task := range hookTasks {
+				if name := task.Name(); !strings.Contains(name, "slack hook") {
+					t.Errorf("task name %q, want a slack hook", name)
+				}
+			}
+		})
+	}
+
+	config.Repos[0].Hooks[0].Ref = "missing"
+	if _, err := TasksTriggeredByEvent(config, nil, "repo1", "plan1", nil, []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR}, tasks.HookVars{}); err == nil {
+		t.Error("expected an error for a reference to an undefined hook")
+	}
+}
+
+func TestCommandHookTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a posix shell")
+	}
+
+	config := &v1.Config{
+		Instance: "test",
+		Repos: []*v1.Repo{{Id: "repo1", Hooks: []*v1.Hook{{
+			Conditions:     []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START},
+			OnError:        v1.Hook_ON_ERROR_FATAL,
+			TimeoutSeconds: 1,
+			// the backgrounded sleep holds the output open, the hook only returns promptly if it's killed too.
+			Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "sleep 30 &\nsleep 30"}},
+		}}}},
+	}
+	hookTasks, err := TasksTriggeredByEvent(config, nil, "repo1", "", nil, []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START}, tasks.HookVars{})
+	if err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+	}
+	if len(hookTasks) != 1 {
+		t.Fatalf("got %d hook tasks, want 1", len(hookTasks))
+	}
+	task := hookTasks[0].(*tasks.GenericOneoffTask)
+
+	ctx := logging.ContextWithWriter(context.Background(), io.Discard)
+	start := time.Now()
+	err = task.Do(ctx, tasks.ScheduledTask{Task: task, Op: task.ProtoOp}, nil)
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("hook took %v, want it killed after its 1s timeout", elapsed)
+	}
+
+	var timeoutErr *HookErrorTimeout
+	if !errors.As(err, &timeoutErr) {
+		t.Errorf("error %v, want a timeout error", err)
+	}
+	var fatalErr *HookErrorFatal
+	if !errors.As(err, &fatalErr) {
+		t.Errorf("error %v, want the hook's fatal error policy applied", err)
+	}
+	if !task.ProtoOp.GetOperationRunHook().GetTimedOut() {
+		t.Errorf("expected the hook operation to be marked as timed out")
+	}
+}
+
+type instanceTaskRunner struct {
+	tasks.TaskRunner
+}
+
+func (instanceTaskRunner) Logger(ctx context.Context) *zap.Logger {
+	return zap.NewNop()
+}
+
+func (instanceTaskRunner) InstanceID() string {
+	return "test"
+}
+
+// TestMqttHookPublishes runs an mqtt hook the way the orchestrator does, with the vars TasksTriggeredByEvent hands
+// to the hook's task.
+func TestMqttHookPublishes(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	topics := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			cp, err := packets.ReadPacket(conn)
+			if err != nil {
+				return
+			}
+			switch p := cp.Content.(type) {
+			case *packets.Connect:
+				packets.NewControlPacket(packets.CONNACK).WriteTo(conn)
+			case *packets.Publish:
+				topics <- p.Topic
+			case *packets.Disconnect:
+				return
+			}
+		}
+	}()
+
+	config := &v1.Config{
+		Instance: "test",
+		Repos:    []*v1.Repo{{Id: "repo1"}},
+		Plans: []*v1.Plan{{Id: "plan1", Repo: "repo1", Hooks: []*v1.Hook{{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+			Action:     &v1.Hook_ActionMqtt{ActionMqtt: &v1.Hook_Mqtt{BrokerUrl: "tcp://" + ln.Addr().String()}},
+		}}}},
+	}
+	vars := tasks.HookVars{Repo: config.Repos[0], Plan: config.Plans[0]}
+	hookTasks, err := TasksTriggeredByEvent(config, nil, "repo1", "plan1", nil, []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}, vars)
+	if err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error: %v", err)
+	}
+	if len(hookTasks) != 1 {
+		t.Fatalf("got %d hook tasks, want 1", len(hookTasks))
+	}
+	task := hookTasks[0].(*tasks.GenericOneoffTask)
+
+	if err := task.Do(context.Background(), tasks.ScheduledTask{Task: task, Op: task.ProtoOp}, instanceTaskRunner{}); err != nil {
+		t.Fatalf("hook task error: %v", err)
+	}
+	select {
+	case topic := <-topics:
+		if topic != "backrest/test/plan1/state" {
+			t.Errorf("published to %q, want the plan's state topic", topic)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("no message published")
+	}
+}
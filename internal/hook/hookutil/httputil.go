@@ -1,25 +1,139 @@
 package hookutil
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
-func PostRequest(url string, contentType string, body io.Reader) (string, error) {
-	r, err := http.Post(url, contentType, body)
+const (
+	DefaultRequestTimeout = 30 * time.Second
+	DefaultHMACHeader     = "X-Backrest-Signature-256"
+	maxRetryBackoff       = 30 * time.Second
+)
+
+// retryBackoff is the delay before the first retry of a request, it doubles with each further retry.
+var retryBackoff = 1 * time.Second
+
+// Request is an HTTP request sent by a hook.
+type Request struct {
+	Method      string // defaults to POST.
+	URL         string
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+
+	BasicAuthUsername string // basic auth credentials are sent if a username is set.
+	BasicAuthPassword string
+	BearerToken       string
+
+	// HMACSecret signs the body with HMAC-SHA256 if set, the signature is sent hex encoded as sha256=<signature>
+	// in HMACHeader, DefaultHMACHeader if empty.
+	HMACSecret string
+	HMACHeader string
+
+	Timeout time.Duration // timeout of each attempt, DefaultRequestTimeout if zero.
+	Retries int           // retries after connection errors and 429 or 5xx responses.
+}
+
+// PostRequest posts body to url with the default timeout and no retries, it is canceled with ctx e.g. when the hook
+// times out.
+func PostRequest(ctx context.Context, url string, contentType string, body io.Reader) (string, error) {
+	b, err := io.ReadAll(body)
 	if err != nil {
-		return "", fmt.Errorf("send request %v: %w", url, err)
+		return "", fmt.Errorf("read request body: %w", err)
 	}
-	if r.StatusCode == 204 {
-		return "", nil
-	} else if r.StatusCode != 200 {
-		return "", fmt.Errorf("unexpected status %v: %s", r.StatusCode, r.Status)
+	return SendRequest(ctx, Request{
+		URL:         url,
+		ContentType: contentType,
+		Body:        b,
+	})
+}
+
+// SendRequest sends the request, retrying as configured, and returns the body of the successful response.
+func SendRequest(ctx context.Context, r Request) (string, error) {
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		body, retryable, err := sendRequestOnce(ctx, r)
+		if err == nil || !retryable || attempt >= r.Retries {
+			return body, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// sendRequestOnce sends the request and returns the response body, or an error and whether it may succeed on retry.
+func sendRequestOnce(ctx context.Context, r Request) (string, bool, error) {
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := r.Method
+	if method == "" {
+		method = http.MethodPost
 	}
-	defer r.Body.Close()
-	bodyBytes, err := io.ReadAll(r.Body)
+	var body io.Reader
+	if r.Body != nil {
+		body = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.URL, body)
+	if err != nil {
+		return "", false, fmt.Errorf("create request %v: %w", r.URL, err)
+	}
+	if r.ContentType != "" && r.Body != nil {
+		req.Header.Set("Content-Type", r.ContentType)
+	}
+	for key, value := range r.Headers {
+		req.Header.Set(key, value)
+	}
+	if r.BasicAuthUsername != "" {
+		req.SetBasicAuth(r.BasicAuthUsername, r.BasicAuthPassword)
+	}
+	if r.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.BearerToken)
+	}
+	if r.HMACSecret != "" {
+		header := r.HMACHeader
+		if header == "" {
+			header = DefaultHMACHeader
+		}
+		req.Header.Set(header, "sha256="+SignHMACSHA256(r.HMACSecret, r.Body))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("read response: %w", err)
+		return "", true, fmt.Errorf("send request %v: %w", r.URL, err)
 	}
-	return string(bodyBytes), nil
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return "", retryable, fmt.Errorf("unexpected status %v: %s", resp.StatusCode, resp.Status)
+	}
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", true, fmt.Errorf("read response: %w", err)
+	}
+	return string(bodyBytes), false, nil
+}
+
+// SignHMACSHA256 returns the hex encoded HMAC-SHA256 of body keyed with secret.
+func SignHMACSHA256(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
 }
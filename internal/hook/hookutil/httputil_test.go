@@ -0,0 +1,136 @@
+package hookutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendRequestHeaders(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	}))
+	defer server.Close()
+
+	body, err := SendRequest(context.Background(), Request{
+		Method:            http.MethodPut,
+		URL:               server.URL,
+		ContentType:       "application/json",
+		Headers:           map[string]string{"X-Custom": "value"},
+		Body:              []byte(`{"hello":"world"}`),
+		BasicAuthUsername: "user",
+		BasicAuthPassword: "pass",
+		HMACSecret:        "secret",
+	})
+	if err != nil {
+		t.Fatalf("SendRequest() error: %v", err)
+	}
+	if body != "created" {
+		t.Errorf("body = %q, want %q", body, "created")
+	}
+
+	if got.Method != http.MethodPut {
+		t.Errorf("method = %v, want PUT", got.Method)
+	}
+	if ct := got.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if v := got.Header.Get("X-Custom"); v != "value" {
+		t.Errorf("X-Custom = %q, want value", v)
+	}
+	if user, pass, ok := got.BasicAuth(); !ok || user != "user" || pass != "pass" {
+		t.Errorf("basic auth = %q/%q, want user/pass", user, pass)
+	}
+	// echo -n '{"hello":"world"}' | openssl dgst -sha256 -hmac secret
+	wantSig := "sha256=2677ad3e7c090b2fa2c0fb13020d66d5420879b8316eb356a2d60fb9073bc778"
+	if sig := got.Header.Get(DefaultHMACHeader); sig != wantSig {
+		t.Errorf("signature = %q, want %q", sig, wantSig)
+	}
+}
+
+func TestSendRequestRetries(t *testing.T) {
+	defer func(backoff time.Duration) { retryBackoff = backoff }(retryBackoff)
+	retryBackoff = time.Millisecond
+
+	tests := []struct {
+		name         string
+		failures     int
+		failStatus   int
+		retries      int
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{name: "no retries", failures: 1, failStatus: http.StatusBadGateway, retries: 0, wantErr: true, wantAttempts: 1},
+		{name: "succeeds on retry", failures: 2, failStatus: http.StatusServiceUnavailable, retries: 2, wantAttempts: 3},
+		{name: "retries exhausted", failures: 5, failStatus: http.StatusTooManyRequests, retries: 2, wantErr: true, wantAttempts: 3},
+		{name: "client errors are not retried", failures: 1, failStatus: http.StatusUnauthorized, retries: 3, wantErr: true, wantAttempts: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(attempts.Add(1)) <= tc.failures {
+					w.WriteHeader(tc.failStatus)
+				}
+			}))
+			defer server.Close()
+
+			_, err := SendRequest(context.Background(), Request{URL: server.URL, Retries: tc.retries})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SendRequest() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if attempts.Load() != tc.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts.Load(), tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestSendRequestTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	start := time.Now()
+	if _, err := SendRequest(context.Background(), Request{URL: server.URL, Timeout: 50 * time.Millisecond}); err == nil {
+		t.Fatal("SendRequest() succeeded, want a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SendRequest() took %v, want it to time out after 50ms", elapsed)
+	}
+}
+
+func TestPostRequestCanceledWithContext(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := PostRequest(ctx, server.URL, "text/plain", strings.NewReader("body")); err == nil {
+		t.Fatal("PostRequest() succeeded, want an error once the context is done")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("PostRequest() took %v, want it canceled after 50ms", elapsed)
+	}
+}
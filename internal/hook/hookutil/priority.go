@@ -0,0 +1,28 @@
+package hookutil
+
+import (
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+)
+
+// Priority is the urgency of a notification, push services map it onto their own priority levels.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityDefault
+	PriorityHigh
+)
+
+// PriorityForEvent picks a notification's priority from the event that triggered it: high for errors and overdue
+// backups, low for successes and default for everything else e.g. start events.
+func PriorityForEvent(event v1.Hook_Condition) Priority {
+	switch {
+	case protoutil.IsErrorCondition(event), event == v1.Hook_CONDITION_BACKUP_OVERDUE:
+		return PriorityHigh
+	case protoutil.IsSuccessCondition(event), event == v1.Hook_CONDITION_BACKUP_OVERDUE_RECOVERED:
+		return PriorityLow
+	default:
+		return PriorityDefault
+	}
+}
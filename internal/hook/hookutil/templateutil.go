@@ -5,14 +5,39 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 )
 
 var (
 	DefaultTemplate = `{{ .Summary }}`
 )
 
+// TemplateFuncs are the helper functions available to every hook template, in addition to the methods of the
+// template's variables.
+var TemplateFuncs = template.FuncMap{
+	// shortId shortens a snapshot ID to the 8 characters restic displays.
+	"shortId": func(id string) string {
+		if len(id) > 8 {
+			return id[:8]
+		}
+		return id
+	},
+	// formatUnixMs formats a timestamp in milliseconds, e.g. a snapshot's time, as RFC3339.
+	"formatUnixMs": func(ms int64) string {
+		return time.UnixMilli(ms).Format(time.RFC3339)
+	},
+	"join": func(elems []string, sep string) string {
+		return strings.Join(elems, sep)
+	},
+	// indent prefixes every line of text with n spaces, e.g. to nest command output in a list.
+	"indent": func(n int, text string) string {
+		prefix := strings.Repeat(" ", n)
+		return prefix + strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n"+prefix)
+	},
+}
+
 func RenderTemplate(text string, vars interface{}) (string, error) {
-	template, err := template.New("template").Parse(text)
+	template, err := template.New("template").Funcs(TemplateFuncs).Parse(text)
 	if err != nil {
 		return "", fmt.Errorf("parse template: %w", err)
 	}
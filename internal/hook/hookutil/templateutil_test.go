@@ -0,0 +1,32 @@
+package hookutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		vars interface{}
+		want string
+	}{
+		{name: "shortId", tmpl: `{{ shortId . }}`, vars: "1234567890abcdef", want: "12345678"},
+		{name: "shortId of a short id", tmpl: `{{ shortId . }}`, vars: "1234", want: "1234"},
+		{name: "formatUnixMs", tmpl: `{{ formatUnixMs . }}`, vars: int64(0), want: time.UnixMilli(0).Format(time.RFC3339)},
+		{name: "join", tmpl: `{{ join . ", " }}`, vars: []string{"a", "b"}, want: "a, b"},
+		{name: "indent", tmpl: `{{ indent 2 . }}`, vars: "line 1\nline 2\n", want: "  line 1\n  line 2"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenderTemplate(tc.tmpl, tc.vars)
+			if err != nil {
+				t.Fatalf("RenderTemplate() error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("RenderTemplate() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
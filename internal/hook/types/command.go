@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/hook/hookutil"
@@ -18,6 +20,10 @@ import (
 	"github.com/google/shlex"
 )
 
+// commandWaitDelay bounds how long to wait for the command's output after it's killed, e.g. if an orphaned process
+// still holds its stdout open.
+const commandWaitDelay = 5 * time.Second
+
 type commandHandler struct{}
 
 func (commandHandler) Name() string {
@@ -58,16 +64,48 @@ func (commandHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{},
 	outputWriter := &ioutil.LinePrefixer{W: writer, Prefix: []byte("[output] ")}
 	defer outputWriter.Close()
 
-	// Run the command in the specified shell
-	execCmd := exec.Command(shell[0], shell[1:]...)
+	// Run the command in the specified shell, when the context is done e.g. because the hook timed out the command is
+	// killed along with any processes it started.
+	execCmd := exec.CommandContext(ctx, shell[0], shell[1:]...)
 	platformutil.SetPlatformOptions(execCmd)
+	platformutil.SetProcessGroup(execCmd)
+	execCmd.Cancel = func() error {
+		return platformutil.KillProcessGroup(execCmd)
+	}
+	execCmd.WaitDelay = commandWaitDelay
 	execCmd.Stdin = strings.NewReader(command)
 
 	stdout := &ioutil.SynchronizedWriter{W: outputWriter}
 	execCmd.Stderr = stdout
 	execCmd.Stdout = stdout
 
-	return execCmd.Run()
+	// Hooks that can modify the running backup are given a file to write their result to.
+	result := tasks.BackupHookResultFromContext(ctx)
+	if result == nil {
+		return execCmd.Run()
+	}
+
+	resultFile, err := os.CreateTemp("", "backrest-hook-result-*")
+	if err != nil {
+		return fmt.Errorf("create result file: %w", err)
+	}
+	resultFile.Close()
+	defer os.Remove(resultFile.Name())
+	execCmd.Env = append(os.Environ(), tasks.BackupHookResultEnv+"="+resultFile.Name())
+
+	if err := execCmd.Run(); err != nil {
+		return err
+	}
+
+	f, err := os.Open(resultFile.Name())
+	if err != nil {
+		return fmt.Errorf("open result file: %w", err)
+	}
+	defer f.Close()
+	if err := result.Parse(f); err != nil {
+		return fmt.Errorf("parse result file: %w", err)
+	}
+	return nil
 }
 
 func (commandHandler) ActionType() reflect.Type {
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"runtime"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+func commandHook(command string) *v1.Hook {
+	return &v1.Hook{
+		Action: &v1.Hook_ActionCommand{
+			ActionCommand: &v1.Hook_Command{Command: command},
+		},
+	}
+}
+
+func TestCommandHandlerBackupHookResult(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a posix shell")
+	}
+
+	result := &tasks.BackupHookResult{}
+	ctx := tasks.ContextWithBackupHookResult(logging.ContextWithWriter(context.Background(), io.Discard), result)
+	hook := commandHook(`printf 'PATH=/mnt/usb\nTAG=usb\n' > "$BACKREST_RESULT_FILE"`)
+	if err := (commandHandler{}).Execute(ctx, hook, tasks.HookVars{}, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_START); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	want := &tasks.BackupHookResult{Paths: []string{"/mnt/usb"}, Tags: []string{"usb"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+
+	hook = commandHook(`echo "NOT A RESULT" > "$BACKREST_RESULT_FILE"`)
+	if err := (commandHandler{}).Execute(ctx, hook, tasks.HookVars{}, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_START); err == nil {
+		t.Error("expected an error for an invalid result")
+	}
+}
+
+func TestCommandHandlerNoBackupHookResult(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a posix shell")
+	}
+
+	ctx := logging.ContextWithWriter(context.Background(), io.Discard)
+	hook := commandHook(`test -z "$BACKREST_RESULT_FILE"`)
+	if err := (commandHandler{}).Execute(ctx, hook, tasks.HookVars{}, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_END); err != nil {
+		t.Errorf("expected no result file outside of snapshot start hooks, got error: %v", err)
+	}
+}
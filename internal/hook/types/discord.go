@@ -38,7 +38,7 @@ func (discordHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{},
 	}
 
 	requestBytes, _ := json.Marshal(request)
-	body, err := hookutil.PostRequest(h.GetActionDiscord().GetWebhookUrl(), "application/json", bytes.NewReader(requestBytes))
+	body, err := hookutil.PostRequest(ctx, h.GetActionDiscord().GetWebhookUrl(), "application/json", bytes.NewReader(requestBytes))
 	if err != nil {
 		return fmt.Errorf("sending discord message to %q: %w", h.GetActionDiscord().GetWebhookUrl(), err)
 	}
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+const (
+	defaultEmailSubjectTemplate = "Backrest {{ .EventName .Event }}"
+	emailTimeout                = 1 * time.Minute
+)
+
+type emailHandler struct{}
+
+func (emailHandler) Name() string {
+	return "email"
+}
+
+func (emailHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	e := h.GetActionEmail()
+
+	body, err := hookutil.RenderTemplateOrDefault(e.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	subject, err := hookutil.RenderTemplateOrDefault(e.GetSubjectTemplate(), defaultEmailSubjectTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("subject template rendering: %w", err)
+	}
+
+	from, err := mail.ParseAddress(e.GetFrom())
+	if err != nil {
+		return fmt.Errorf("parse from address %q: %w", e.GetFrom(), err)
+	}
+
+	var recipients []string
+	for _, addr := range append(append([]string{}, e.GetTo()...), e.GetCc()...) {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return fmt.Errorf("parse recipient address %q: %w", addr, err)
+		}
+		recipients = append(recipients, parsed.Address)
+	}
+	if len(recipients) == 0 {
+		return errors.New("no recipients")
+	}
+
+	msg, err := buildEmailMessage(e, subject, body, time.Now())
+	if err != nil {
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending email to %v via %s", recipients, e.GetHost())
+
+	if err := sendEmail(ctx, e, from.Address, recipients, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+func (emailHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionEmail{})
+}
+
+// buildEmailMessage formats a plain text message with the headers for the configured sender and recipients. The body
+// is quoted-printable encoded so that long lines and non-ASCII text survive any relay.
+func buildEmailMessage(e *v1.Hook_Email, subject string, body string, now time.Time) ([]byte, error) {
+	// templates may render newlines into the subject, they must not start new headers.
+	subject = strings.Join(strings.Fields(subject), " ")
+
+	var buf bytes.Buffer
+	writeHeader := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+	writeHeader("From", e.GetFrom())
+	writeHeader("To", strings.Join(e.GetTo(), ", "))
+	if len(e.GetCc()) > 0 {
+		writeHeader("Cc", strings.Join(e.GetCc(), ", "))
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+	writeHeader("Content-Type", "text/plain; charset=utf-8")
+	writeHeader("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sendEmail delivers msg to the recipients through the configured SMTP server, the whole exchange is bounded by ctx's
+// deadline or emailTimeout if ctx has none.
+func sendEmail(ctx context.Context, e *v1.Hook_Email, from string, recipients []string, msg []byte) error {
+	port := int(e.GetPort())
+	if port == 0 {
+		switch e.GetSecurity() {
+		case v1.Hook_Email_SECURITY_TLS:
+			port = 465
+		case v1.Hook_Email_SECURITY_NONE:
+			port = 25
+		default:
+			port = 587
+		}
+	}
+	addr := net.JoinHostPort(e.GetHost(), strconv.Itoa(port))
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(emailTimeout)
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	tlsConfig := &tls.Config{ServerName: e.GetHost()}
+
+	var conn net.Conn
+	var err error
+	if e.GetSecurity() == v1.Hook_Email_SECURITY_TLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("dial %v: %w", addr, err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, e.GetHost())
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if e.GetSecurity() == v1.Hook_Email_SECURITY_STARTTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server %v does not support STARTTLS", addr)
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+
+	if e.GetUsername() != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.GetUsername(), e.GetPassword(), e.GetHost())); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("recipient %v: %w", rcpt, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&emailHandler{})
+}
@@ -0,0 +1,202 @@
+package types
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
+)
+
+// fakeSMTPMessage is a message received by fakeSMTPServer.
+type fakeSMTPMessage struct {
+	auth string // decoded AUTH PLAIN response.
+	from string
+	rcpt []string
+	data string
+}
+
+// fakeSMTPServer accepts a single SMTP session on a local port and sends the message it receives on the returned
+// channel. extensions are advertised in response to EHLO.
+func fakeSMTPServer(t *testing.T, extensions ...string) (string, <-chan fakeSMTPMessage) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	msgs := make(chan fakeSMTPMessage, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tc := textproto.NewConn(conn)
+
+		var msg fakeSMTPMessage
+		tc.PrintfLine("220 localhost fake ESMTP")
+		for {
+			line, err := tc.ReadLine()
+			if err != nil {
+				return
+			}
+			verb, arg, _ := strings.Cut(line, " ")
+			switch strings.ToUpper(verb) {
+			case "EHLO", "HELO":
+				lines := append([]string{"localhost"}, extensions...)
+				for i, l := range lines {
+					sep := "-"
+					if i == len(lines)-1 {
+						sep = " "
+					}
+					tc.PrintfLine("250%s%s", sep, l)
+				}
+			case "AUTH":
+				_, resp, _ := strings.Cut(arg, " ")
+				decoded, _ := base64.StdEncoding.DecodeString(resp)
+				msg.auth = string(decoded)
+				tc.PrintfLine("235 authenticated")
+			case "MAIL":
+				msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+				tc.PrintfLine("250 ok")
+			case "RCPT":
+				msg.rcpt = append(msg.rcpt, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+				tc.PrintfLine("250 ok")
+			case "DATA":
+				tc.PrintfLine("354 send data")
+				data, err := tc.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				tc.PrintfLine("250 queued")
+			case "QUIT":
+				tc.PrintfLine("221 bye")
+				msgs <- msg
+				return
+			default:
+				tc.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), msgs
+}
+
+type loggerTaskRunner struct {
+	tasks.TaskRunner
+}
+
+func (loggerTaskRunner) Logger(ctx context.Context) *zap.Logger {
+	return zap.NewNop()
+}
+
+func emailHook(t *testing.T, addr string, email *v1.Hook_Email) *v1.Hook {
+	t.Helper()
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("split addr: %v", err)
+	}
+	portNum, _ := strconv.Atoi(port)
+	email.Host = host
+	email.Port = int32(portNum)
+	return &v1.Hook{
+		Action: &v1.Hook_ActionEmail{
+			ActionEmail: email,
+		},
+	}
+}
+
+func TestEmailHandlerRegistration(t *testing.T) {
+	handler, err := DefaultRegistry().GetHandler(&v1.Hook{
+		Action: &v1.Hook_ActionEmail{
+			ActionEmail: &v1.Hook_Email{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get email handler: %v", err)
+	}
+	if handler.Name() != "email" {
+		t.Errorf("Expected handler name to be 'email', got '%s'", handler.Name())
+	}
+}
+
+func TestEmailHandlerSend(t *testing.T) {
+	addr, msgs := fakeSMTPServer(t, "AUTH PLAIN")
+	hook := emailHook(t, addr, &v1.Hook_Email{
+		Security:        v1.Hook_Email_SECURITY_NONE,
+		Username:        "user",
+		Password:        "pass",
+		From:            "Backrest <backrest@example.com>",
+		To:              []string{"alice@example.com"},
+		Cc:              []string{"Bob <bob@example.com>"},
+		SubjectTemplate: "Backup of {{ .Plan.Id }}\n{{ .EventName .Event }}",
+		Template:        "snapshot {{ .SnapshotId }} failed: {{ .Error }}",
+	})
+
+	vars := tasks.HookVars{
+		Event:      v1.Hook_CONDITION_SNAPSHOT_ERROR,
+		Plan:       &v1.Plan{Id: "plan1"},
+		SnapshotId: "abc123",
+		Error:      "disk full",
+	}
+	if err := (emailHandler{}).Execute(context.Background(), hook, vars, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_ERROR); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	msg := <-msgs
+	if msg.auth != "\x00user\x00pass" {
+		t.Errorf("auth = %q, want %q", msg.auth, "\x00user\x00pass")
+	}
+	if msg.from != "backrest@example.com" {
+		t.Errorf("envelope from = %q, want %q", msg.from, "backrest@example.com")
+	}
+	if got, want := strings.Join(msg.rcpt, ","), "alice@example.com,bob@example.com"; got != want {
+		t.Errorf("envelope recipients = %q, want %q", got, want)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msg.data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	for key, want := range map[string]string{
+		"From":    "Backrest <backrest@example.com>",
+		"To":      "alice@example.com",
+		"Cc":      "Bob <bob@example.com>",
+		"Subject": "Backup of plan1 snapshot error",
+	} {
+		if got := parsed.Header.Get(key); got != want {
+			t.Errorf("header %v = %q, want %q", key, got, want)
+		}
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(body)), "snapshot abc123 failed: disk full"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestEmailHandlerRequiresStartTLS(t *testing.T) {
+	addr, _ := fakeSMTPServer(t)
+	hook := emailHook(t, addr, &v1.Hook_Email{
+		From: "backrest@example.com",
+		To:   []string{"alice@example.com"},
+	})
+
+	err := (emailHandler{}).Execute(context.Background(), hook, tasks.HookVars{}, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_ERROR)
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("Execute() error = %v, want an error about STARTTLS", err)
+	}
+}
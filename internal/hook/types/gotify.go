@@ -63,7 +63,7 @@ func (gotifyHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{},
 		baseUrl,
 		url.QueryEscape(g.GetToken()))
 
-	body, err := hookutil.PostRequest(postUrl, "application/json", bytes.NewReader(b))
+	body, err := hookutil.PostRequest(ctx, postUrl, "application/json", bytes.NewReader(b))
 
 	if err != nil {
 		return fmt.Errorf("send gotify message: %w", err)
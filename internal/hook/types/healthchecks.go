@@ -47,7 +47,7 @@ func (healthchecksHandler) Execute(ctx context.Context, cmd *v1.Hook, vars inter
 
 	pingUrl := u.String()
 
-	body, err := hookutil.PostRequest(pingUrl, "text/plain", bytes.NewBufferString(payload))
+	body, err := hookutil.PostRequest(ctx, pingUrl, "text/plain", bytes.NewBufferString(payload))
 	if err != nil {
 		return fmt.Errorf("sending healthchecks message to %q: %w", pingUrl, err)
 	}
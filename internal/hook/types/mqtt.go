@@ -0,0 +1,269 @@
+package types
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/eclipse/paho.golang/paho"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"go.uber.org/zap"
+)
+
+const (
+	mqttTimeout                = 30 * time.Second
+	defaultMqttDiscoveryPrefix = "homeassistant"
+)
+
+var mqttInvalidIDChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// mqttPlanState is the retained state message published for a plan.
+type mqttPlanState struct {
+	Plan            string  `json:"plan"`
+	Repo            string  `json:"repo"`
+	Status          string  `json:"status"` // one of "running", "success", "warning", "error" or the event's name.
+	Event           string  `json:"event"`
+	LastRun         string  `json:"last_run"`
+	BytesAdded      int64   `json:"bytes_added"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	SnapshotID      string  `json:"snapshot_id,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+type mqttHandler struct{}
+
+func (mqttHandler) Name() string {
+	return "mqtt"
+}
+
+func (mqttHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	m := h.GetActionMqtt()
+	l := runner.Logger(ctx)
+
+	hookVars, ok := vars.(tasks.HookVars)
+	if !ok {
+		return fmt.Errorf("unexpected hook vars type %T", vars)
+	}
+	if planID := hookVars.Plan.GetId(); planID == "" || planID == tasks.PlanForSystemTasks || planID == tasks.PlanForUnassociatedOperations {
+		l.Sugar().Infof("Skipping mqtt publish for event %v, it is not associated with a plan", hookVars.EventName(event))
+		return nil
+	}
+
+	instanceID := runner.InstanceID()
+	baseTopic := strings.TrimSuffix(m.GetTopic(), "/")
+	if baseTopic == "" {
+		baseTopic = "backrest/" + instanceID
+	}
+	stateTopic := fmt.Sprintf("%s/%s/state", baseTopic, hookVars.Plan.Id)
+
+	state, err := json.Marshal(mqttStateForEvent(hookVars, event))
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+
+	messages := []*paho.Publish{{Topic: stateTopic, Payload: state}}
+	if m.GetHomeAssistantDiscovery() {
+		discovery, err := mqttDiscoveryMessages(m, instanceID, hookVars.Plan.Id, stateTopic)
+		if err != nil {
+			return fmt.Errorf("home assistant discovery: %w", err)
+		}
+		// discovery configs are published first so that Home Assistant has the sensors when the state arrives.
+		messages = append(discovery, messages...)
+	}
+
+	l.Sugar().Infof("Publishing mqtt state for plan %q to %s", hookVars.Plan.Id, stateTopic)
+	l.Debug("Publishing mqtt state", zap.ByteString("state", state))
+
+	if err := mqttPublish(ctx, m, "backrest-"+instanceID, messages); err != nil {
+		return fmt.Errorf("mqtt publish: %w", err)
+	}
+	return nil
+}
+
+func (mqttHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionMqtt{})
+}
+
+func mqttStateForEvent(vars tasks.HookVars, event v1.Hook_Condition) mqttPlanState {
+	state := mqttPlanState{
+		Plan:            vars.Plan.Id,
+		Status:          vars.EventName(event),
+		Event:           vars.EventName(event),
+		LastRun:         vars.CurTime.Format(time.RFC3339),
+		DurationSeconds: vars.Duration.Seconds(),
+		SnapshotID:      vars.SnapshotId,
+		Error:           vars.Error,
+	}
+	if vars.Repo != nil {
+		state.Repo = vars.Repo.Id
+	}
+	if vars.SnapshotStats != nil {
+		state.BytesAdded = vars.SnapshotStats.DataAdded
+	}
+	switch {
+	case protoutil.IsErrorCondition(event):
+		state.Status = "error"
+	case event == v1.Hook_CONDITION_SNAPSHOT_WARNING:
+		state.Status = "warning"
+	case protoutil.IsStartCondition(event):
+		state.Status = "running"
+	case protoutil.IsSuccessCondition(event):
+		state.Status = "success"
+	}
+	return state
+}
+
+// mqttDiscoveryMessages returns the Home Assistant MQTT discovery configs for the sensors reading a plan's state.
+// The sensors of a plan are grouped into one device per plan and instance.
+func mqttDiscoveryMessages(m *v1.Hook_Mqtt, instanceID, planID, stateTopic string) ([]*paho.Publish, error) {
+	prefix := strings.TrimSuffix(m.GetDiscoveryPrefix(), "/")
+	if prefix == "" {
+		prefix = defaultMqttDiscoveryPrefix
+	}
+	nodeID := mqttInvalidIDChars.ReplaceAllString("backrest_"+instanceID+"_"+planID, "_")
+
+	device := map[string]any{
+		"identifiers":  []string{nodeID},
+		"name":         fmt.Sprintf("Backrest %s (%s)", planID, instanceID),
+		"manufacturer": "Backrest",
+		"model":        "Backup plan",
+	}
+	sensors := []struct {
+		object string
+		config map[string]any
+	}{
+		{"status", map[string]any{
+			"name":           "Status",
+			"value_template": "{{ value_json.status }}",
+			"icon":           "mdi:backup-restore",
+		}},
+		{"last_run", map[string]any{
+			"name":           "Last run",
+			"value_template": "{{ value_json.last_run }}",
+			"device_class":   "timestamp",
+		}},
+		{"bytes_added", map[string]any{
+			"name":                "Bytes added",
+			"value_template":      "{{ value_json.bytes_added }}",
+			"device_class":        "data_size",
+			"unit_of_measurement": "B",
+		}},
+		{"duration", map[string]any{
+			"name":                "Duration",
+			"value_template":      "{{ value_json.duration_seconds }}",
+			"device_class":        "duration",
+			"unit_of_measurement": "s",
+		}},
+	}
+
+	var messages []*paho.Publish
+	for _, sensor := range sensors {
+		sensor.config["unique_id"] = nodeID + "_" + sensor.object
+		sensor.config["state_topic"] = stateTopic
+		sensor.config["json_attributes_topic"] = stateTopic
+		sensor.config["device"] = device
+		payload, err := json.Marshal(sensor.config)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &paho.Publish{
+			Topic:   fmt.Sprintf("%s/sensor/%s/%s/config", prefix, nodeID, sensor.object),
+			Payload: payload,
+		})
+	}
+	return messages, nil
+}
+
+// mqttPublish connects to the configured broker and publishes the messages retained with the configured QoS, the
+// connection is closed once they're published.
+func mqttPublish(ctx context.Context, m *v1.Hook_Mqtt, defaultClientID string, messages []*paho.Publish) error {
+	ctx, cancel := context.WithTimeout(ctx, mqttTimeout)
+	defer cancel()
+
+	conn, err := mqttDial(ctx, m.GetBrokerUrl())
+	if err != nil {
+		return err
+	}
+
+	clientID := m.GetClientId()
+	if clientID == "" {
+		clientID = defaultClientID
+	}
+	client := paho.NewClient(paho.ClientConfig{
+		ClientID: clientID,
+		Conn:     packets.NewThreadSafeConn(conn),
+	})
+	if _, err := client.Connect(ctx, &paho.Connect{
+		ClientID:     clientID,
+		KeepAlive:    uint16(mqttTimeout.Seconds()),
+		CleanStart:   true,
+		Username:     m.GetUsername(),
+		UsernameFlag: m.GetUsername() != "",
+		Password:     []byte(m.GetPassword()),
+		PasswordFlag: m.GetPassword() != "",
+	}); err != nil {
+		conn.Close()
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer client.Disconnect(&paho.Disconnect{ReasonCode: 0})
+
+	for _, msg := range messages {
+		msg.QoS = byte(m.GetQos())
+		msg.Retain = true
+		if _, err := client.Publish(ctx, msg); err != nil {
+			return fmt.Errorf("publish to %v: %w", msg.Topic, err)
+		}
+	}
+	return nil
+}
+
+func mqttDial(ctx context.Context, brokerURL string) (net.Conn, error) {
+	u, err := url.Parse(brokerURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse broker url: %w", err)
+	} else if u.Host == "" {
+		return nil, errors.New("broker url must include a host")
+	}
+
+	var useTLS bool
+	var defaultPort string
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		defaultPort = "1883"
+	case "ssl", "tls", "mqtts":
+		useTLS = true
+		defaultPort = "8883"
+	default:
+		return nil, fmt.Errorf("unsupported broker url scheme %q", u.Scheme)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+
+	var conn net.Conn
+	if useTLS {
+		conn, err = (&tls.Dialer{Config: &tls.Config{ServerName: u.Hostname()}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dial %v: %w", addr, err)
+	}
+	return conn, nil
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&mqttHandler{})
+}
@@ -0,0 +1,218 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+// fakeMQTTSession is what fakeMQTTBroker received from a client.
+type fakeMQTTSession struct {
+	connect  *packets.Connect
+	messages map[string]*packets.Publish // retained messages by topic.
+}
+
+// fakeMQTTBroker accepts a single MQTT v5 session on a local port, acknowledging its packets, and sends what it
+// received on the returned channel once the client disconnects.
+func fakeMQTTBroker(t *testing.T) (string, <-chan fakeMQTTSession) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	sessions := make(chan fakeMQTTSession, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		session := fakeMQTTSession{messages: make(map[string]*packets.Publish)}
+		for {
+			cp, err := packets.ReadPacket(conn)
+			if err != nil {
+				return
+			}
+			switch p := cp.Content.(type) {
+			case *packets.Connect:
+				session.connect = p
+				packets.NewControlPacket(packets.CONNACK).WriteTo(conn)
+			case *packets.Publish:
+				if p.Retain {
+					session.messages[p.Topic] = p
+				}
+				if p.QoS == 1 {
+					ack := packets.NewControlPacket(packets.PUBACK)
+					ack.Content.(*packets.Puback).PacketID = p.PacketID
+					ack.WriteTo(conn)
+				}
+			case *packets.Pingreq:
+				packets.NewControlPacket(packets.PINGRESP).WriteTo(conn)
+			case *packets.Disconnect:
+				sessions <- session
+				return
+			}
+		}
+	}()
+	return ln.Addr().String(), sessions
+}
+
+type instanceTaskRunner struct {
+	loggerTaskRunner
+	instanceID string
+}
+
+func (r instanceTaskRunner) InstanceID() string {
+	return r.instanceID
+}
+
+func TestMqttHandlerRegistration(t *testing.T) {
+	handler, err := DefaultRegistry().GetHandler(&v1.Hook{
+		Action: &v1.Hook_ActionMqtt{
+			ActionMqtt: &v1.Hook_Mqtt{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get mqtt handler: %v", err)
+	}
+	if handler.Name() != "mqtt" {
+		t.Errorf("Expected handler name to be 'mqtt', got '%s'", handler.Name())
+	}
+}
+
+func TestMqttHandlerPublishesState(t *testing.T) {
+	addr, sessions := fakeMQTTBroker(t)
+	hook := &v1.Hook{
+		Action: &v1.Hook_ActionMqtt{
+			ActionMqtt: &v1.Hook_Mqtt{
+				BrokerUrl:              "tcp://" + addr,
+				Username:               "user",
+				Password:               "pass",
+				Qos:                    1,
+				HomeAssistantDiscovery: true,
+			},
+		},
+	}
+	curTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	vars := tasks.HookVars{
+		Event:         v1.Hook_CONDITION_SNAPSHOT_SUCCESS,
+		Repo:          &v1.Repo{Id: "repo1"},
+		Plan:          &v1.Plan{Id: "plan1"},
+		SnapshotId:    "abc123",
+		SnapshotStats: &restic.BackupProgressEntry{DataAdded: 1024},
+		CurTime:       curTime,
+		Duration:      90 * time.Second,
+	}
+
+	runner := instanceTaskRunner{instanceID: "my-instance"}
+	if err := (mqttHandler{}).Execute(context.Background(), hook, vars, runner, v1.Hook_CONDITION_SNAPSHOT_SUCCESS); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	session := <-sessions
+	if session.connect.ClientID != "backrest-my-instance" {
+		t.Errorf("client ID = %q, want %q", session.connect.ClientID, "backrest-my-instance")
+	}
+	if session.connect.Username != "user" || string(session.connect.Password) != "pass" {
+		t.Errorf("credentials = %q/%q, want user/pass", session.connect.Username, session.connect.Password)
+	}
+
+	stateMsg, ok := session.messages["backrest/my-instance/plan1/state"]
+	if !ok {
+		t.Fatalf("no retained state message, got topics %v", topics(session.messages))
+	}
+	var state mqttPlanState
+	if err := json.Unmarshal(stateMsg.Payload, &state); err != nil {
+		t.Fatalf("unmarshal state: %v", err)
+	}
+	want := mqttPlanState{
+		Plan:            "plan1",
+		Repo:            "repo1",
+		Status:          "success",
+		Event:           "snapshot success",
+		LastRun:         "2024-01-02T03:04:05Z",
+		BytesAdded:      1024,
+		DurationSeconds: 90,
+		SnapshotID:      "abc123",
+	}
+	if state != want {
+		t.Errorf("state = %+v, want %+v", state, want)
+	}
+
+	for _, sensor := range []string{"status", "last_run", "bytes_added", "duration"} {
+		topic := "homeassistant/sensor/backrest_my-instance_plan1/" + sensor + "/config"
+		msg, ok := session.messages[topic]
+		if !ok {
+			t.Errorf("no discovery config published to %v, got topics %v", topic, topics(session.messages))
+			continue
+		}
+		var config map[string]any
+		if err := json.Unmarshal(msg.Payload, &config); err != nil {
+			t.Fatalf("unmarshal discovery config: %v", err)
+		}
+		if config["state_topic"] != "backrest/my-instance/plan1/state" {
+			t.Errorf("%v state_topic = %v, want the plan's state topic", sensor, config["state_topic"])
+		}
+		if config["unique_id"] != "backrest_my-instance_plan1_"+sensor {
+			t.Errorf("%v unique_id = %v", sensor, config["unique_id"])
+		}
+	}
+}
+
+func TestMqttHandlerSkipsEventsWithoutPlan(t *testing.T) {
+	hook := &v1.Hook{
+		Action: &v1.Hook_ActionMqtt{
+			ActionMqtt: &v1.Hook_Mqtt{
+				BrokerUrl: "tcp://127.0.0.1:1", // nothing should be dialed.
+			},
+		},
+	}
+	// events outside of a plan get a stand-in plan conveying only the task's plan ID.
+	for _, planID := range []string{"", tasks.PlanForSystemTasks, tasks.PlanForUnassociatedOperations} {
+		vars := tasks.HookVars{Repo: &v1.Repo{Id: "repo1"}, Plan: &v1.Plan{Id: planID}}
+		if err := (mqttHandler{}).Execute(context.Background(), hook, vars, instanceTaskRunner{}, v1.Hook_CONDITION_PRUNE_SUCCESS); err != nil {
+			t.Errorf("plan %q: Execute() error: %v", planID, err)
+		}
+	}
+}
+
+func TestMqttStateStatus(t *testing.T) {
+	tests := []struct {
+		event v1.Hook_Condition
+		want  string
+	}{
+		{v1.Hook_CONDITION_SNAPSHOT_START, "running"},
+		{v1.Hook_CONDITION_SNAPSHOT_SUCCESS, "success"},
+		{v1.Hook_CONDITION_SNAPSHOT_WARNING, "warning"},
+		{v1.Hook_CONDITION_SNAPSHOT_ERROR, "error"},
+		{v1.Hook_CONDITION_PRUNE_ERROR, "error"},
+		{v1.Hook_CONDITION_SNAPSHOT_END, "snapshot end"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.event.String(), func(t *testing.T) {
+			state := mqttStateForEvent(tasks.HookVars{Plan: &v1.Plan{Id: "plan1"}}, tc.event)
+			if state.Status != tc.want {
+				t.Errorf("status = %q, want %q", state.Status, tc.want)
+			}
+		})
+	}
+}
+
+func topics(messages map[string]*packets.Publish) string {
+	var topics []string
+	for topic := range messages {
+		topics = append(topics, topic)
+	}
+	return strings.Join(topics, ", ")
+}
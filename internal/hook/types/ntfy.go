@@ -0,0 +1,101 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
+)
+
+const defaultNtfyServerURL = "https://ntfy.sh"
+
+// ntfyPriorities maps notification priorities onto ntfy's priority levels, 1 (min) to 5 (urgent).
+var ntfyPriorities = map[hookutil.Priority]int{
+	hookutil.PriorityLow:     2,
+	hookutil.PriorityDefault: 3,
+	hookutil.PriorityHigh:    4,
+}
+
+type ntfyHandler struct{}
+
+func (ntfyHandler) Name() string {
+	return "ntfy"
+}
+
+func (ntfyHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	n := h.GetActionNtfy()
+
+	payload, err := hookutil.RenderTemplateOrDefault(n.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	title, err := hookutil.RenderTemplateOrDefault(n.GetTitleTemplate(), "Backrest Event", vars)
+	if err != nil {
+		return fmt.Errorf("title template rendering: %w", err)
+	}
+
+	priority := int(n.GetPriority())
+	if priority == 0 {
+		priority = ntfyPriorities[hookutil.PriorityForEvent(event)]
+	}
+
+	message := struct {
+		Topic    string   `json:"topic"`
+		Message  string   `json:"message"`
+		Title    string   `json:"title"`
+		Tags     []string `json:"tags,omitempty"`
+		Priority int      `json:"priority"`
+		Click    string   `json:"click,omitempty"`
+	}{
+		Topic:    n.GetTopic(),
+		Message:  payload,
+		Title:    title,
+		Tags:     n.GetTags(),
+		Priority: priority,
+		Click:    n.GetClickUrl(),
+	}
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending ntfy message to topic %q", n.GetTopic())
+	l.Debug("Sending ntfy message", zap.Any("message", message))
+
+	b, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+
+	serverURL := strings.TrimSuffix(n.GetServerUrl(), "/")
+	if serverURL == "" {
+		serverURL = defaultNtfyServerURL
+	}
+
+	// messages are published as JSON to the server's root URL, the topic is part of the message.
+	body, err := hookutil.SendRequest(ctx, hookutil.Request{
+		URL:         serverURL + "/",
+		ContentType: "application/json",
+		Body:        b,
+		BearerToken: n.GetToken(),
+	})
+	if err != nil {
+		return fmt.Errorf("send ntfy message: %w", err)
+	}
+
+	l.Sugar().Debugf("Ntfy response: %s", body)
+
+	return nil
+}
+
+func (ntfyHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionNtfy{})
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&ntfyHandler{})
+}
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+type ntfyRequest struct {
+	auth    string
+	message struct {
+		Topic    string   `json:"topic"`
+		Message  string   `json:"message"`
+		Title    string   `json:"title"`
+		Tags     []string `json:"tags"`
+		Priority int      `json:"priority"`
+		Click    string   `json:"click"`
+	}
+}
+
+func fakeNtfyServer(t *testing.T) (string, <-chan ntfyRequest) {
+	t.Helper()
+	requests := make(chan ntfyRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ntfyRequest
+		req.auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&req.message); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests <- req
+		w.Write([]byte(`{"id":"abc"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, requests
+}
+
+func TestNtfyHandlerRegistration(t *testing.T) {
+	handler, err := DefaultRegistry().GetHandler(&v1.Hook{
+		Action: &v1.Hook_ActionNtfy{
+			ActionNtfy: &v1.Hook_Ntfy{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get ntfy handler: %v", err)
+	}
+	if handler.Name() != "ntfy" {
+		t.Errorf("Expected handler name to be 'ntfy', got '%s'", handler.Name())
+	}
+}
+
+func TestNtfyHandlerSend(t *testing.T) {
+	serverURL, requests := fakeNtfyServer(t)
+	hook := &v1.Hook{
+		Action: &v1.Hook_ActionNtfy{
+			ActionNtfy: &v1.Hook_Ntfy{
+				ServerUrl:     serverURL + "/",
+				Topic:         "backups",
+				Token:         "tk_secret",
+				Tags:          []string{"floppy_disk", "backrest"},
+				ClickUrl:      "https://backrest.example.com",
+				Template:      "{{ .EventName .Event }}: {{ .Error }}",
+				TitleTemplate: "Backrest {{ .Plan.Id }}",
+			},
+		},
+	}
+	vars := tasks.HookVars{
+		Event: v1.Hook_CONDITION_SNAPSHOT_ERROR,
+		Plan:  &v1.Plan{Id: "plan1"},
+		Error: "disk full",
+	}
+	if err := (ntfyHandler{}).Execute(context.Background(), hook, vars, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_ERROR); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	req := <-requests
+	if req.auth != "Bearer tk_secret" {
+		t.Errorf("Authorization = %q, want %q", req.auth, "Bearer tk_secret")
+	}
+	msg := req.message
+	if msg.Topic != "backups" || msg.Title != "Backrest plan1" || msg.Message != "snapshot error: disk full" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if !slices.Equal(msg.Tags, []string{"floppy_disk", "backrest"}) || msg.Click != "https://backrest.example.com" {
+		t.Errorf("unexpected tags or click url: %+v", msg)
+	}
+	if msg.Priority != 4 {
+		t.Errorf("priority = %d, want 4 for an error", msg.Priority)
+	}
+}
+
+func TestNtfyHandlerPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority int32
+		event    v1.Hook_Condition
+		want     int
+	}{
+		{name: "error", event: v1.Hook_CONDITION_PRUNE_ERROR, want: 4},
+		{name: "overdue", event: v1.Hook_CONDITION_BACKUP_OVERDUE, want: 4},
+		{name: "success", event: v1.Hook_CONDITION_SNAPSHOT_SUCCESS, want: 2},
+		{name: "start", event: v1.Hook_CONDITION_SNAPSHOT_START, want: 3},
+		{name: "explicit priority", priority: 5, event: v1.Hook_CONDITION_SNAPSHOT_SUCCESS, want: 5},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			serverURL, requests := fakeNtfyServer(t)
+			hook := &v1.Hook{
+				Action: &v1.Hook_ActionNtfy{
+					ActionNtfy: &v1.Hook_Ntfy{
+						ServerUrl: serverURL,
+						Topic:     "backups",
+						Priority:  tc.priority,
+						Template:  "test",
+					},
+				},
+			}
+			if err := (ntfyHandler{}).Execute(context.Background(), hook, tasks.HookVars{Event: tc.event}, loggerTaskRunner{}, tc.event); err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			req := <-requests
+			if req.auth != "" {
+				t.Errorf("Authorization = %q, want none without a token", req.auth)
+			}
+			if req.message.Priority != tc.want {
+				t.Errorf("priority = %d, want %d", req.message.Priority, tc.want)
+			}
+		})
+	}
+}
@@ -44,7 +44,7 @@ func (slackHandler) Execute(ctx context.Context, cmd *v1.Hook, vars interface{},
 		requestBytes, _ = json.Marshal(request)
 	}
 
-	body, err := hookutil.PostRequest(cmd.GetActionSlack().GetWebhookUrl(), "application/json", bytes.NewReader(requestBytes))
+	body, err := hookutil.PostRequest(ctx, cmd.GetActionSlack().GetWebhookUrl(), "application/json", bytes.NewReader(requestBytes))
 	if err != nil {
 		return fmt.Errorf("sending slack message to %q: %w", cmd.GetActionSlack().GetWebhookUrl(), err)
 	}
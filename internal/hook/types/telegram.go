@@ -49,7 +49,7 @@ func (telegramHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}
 
 	postUrl := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.GetBotToken())
 
-	body, err := hookutil.PostRequest(postUrl, "application/json", bytes.NewReader(b))
+	body, err := hookutil.PostRequest(ctx, postUrl, "application/json", bytes.NewReader(b))
 	if err != nil {
 		return fmt.Errorf("send telegram message: %w", err)
 	}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+)
+
+// RenderMessage renders the message template of the hook's action, or the command of a command hook, as its handler
+// would. It returns an empty string for actions without a message template.
+func RenderMessage(h *v1.Hook, vars interface{}) (string, error) {
+	var tmpl string
+	switch action := h.GetAction().(type) {
+	case *v1.Hook_ActionCommand:
+		return hookutil.RenderTemplate(action.ActionCommand.GetCommand(), vars)
+	case *v1.Hook_ActionWebhook:
+		tmpl = action.ActionWebhook.GetTemplate()
+	case *v1.Hook_ActionDiscord:
+		tmpl = action.ActionDiscord.GetTemplate()
+	case *v1.Hook_ActionGotify:
+		tmpl = action.ActionGotify.GetTemplate()
+	case *v1.Hook_ActionSlack:
+		tmpl = action.ActionSlack.GetTemplate()
+	case *v1.Hook_ActionShoutrrr:
+		tmpl = action.ActionShoutrrr.GetTemplate()
+	case *v1.Hook_ActionHealthchecks:
+		tmpl = action.ActionHealthchecks.GetTemplate()
+	case *v1.Hook_ActionTelegram:
+		tmpl = action.ActionTelegram.GetTemplate()
+	case *v1.Hook_ActionEmail:
+		tmpl = action.ActionEmail.GetTemplate()
+	case *v1.Hook_ActionNtfy:
+		tmpl = action.ActionNtfy.GetTemplate()
+	default:
+		return "", nil
+	}
+	return hookutil.RenderTemplateOrDefault(tmpl, hookutil.DefaultTemplate, vars)
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
+)
+
+var webhookMethods = map[v1.Hook_Webhook_Method]string{
+	v1.Hook_Webhook_UNKNOWN: http.MethodPost,
+	v1.Hook_Webhook_GET:     http.MethodGet,
+	v1.Hook_Webhook_POST:    http.MethodPost,
+	v1.Hook_Webhook_PUT:     http.MethodPut,
+	v1.Hook_Webhook_PATCH:   http.MethodPatch,
+	v1.Hook_Webhook_DELETE:  http.MethodDelete,
+}
+
+type webhookHandler struct{}
+
+func (webhookHandler) Name() string {
+	return "webhook"
+}
+
+func (webhookHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	w := h.GetActionWebhook()
+
+	method, ok := webhookMethods[w.GetMethod()]
+	if !ok {
+		return fmt.Errorf("unsupported method %v", w.GetMethod())
+	}
+
+	headers, err := parseWebhookHeaders(w.GetHeaders())
+	if err != nil {
+		return err
+	}
+
+	req := hookutil.Request{
+		Method:            method,
+		URL:               w.GetWebhookUrl(),
+		ContentType:       w.GetContentType(),
+		Headers:           headers,
+		BasicAuthUsername: w.GetBasicAuthUsername(),
+		BasicAuthPassword: w.GetBasicAuthPassword(),
+		BearerToken:       w.GetBearerToken(),
+		HMACSecret:        w.GetHmacSecret(),
+		HMACHeader:        w.GetHmacHeader(),
+		Timeout:           time.Duration(w.GetTimeoutSeconds()) * time.Second,
+		Retries:           int(w.GetRetries()),
+	}
+	if req.ContentType == "" {
+		req.ContentType = "text/plain"
+	}
+	// GET requests carry no body, the webhook is only notified that the event happened.
+	if method != http.MethodGet {
+		payload, err := hookutil.RenderTemplateOrDefault(w.GetTemplate(), hookutil.DefaultTemplate, vars)
+		if err != nil {
+			return fmt.Errorf("template rendering: %w", err)
+		}
+		req.Body = []byte(payload)
+	}
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending webhook %s %s", method, w.GetWebhookUrl())
+	l.Debug("Sending webhook", zap.ByteString("body", req.Body))
+
+	body, err := hookutil.SendRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+
+	l.Sugar().Debugf("Webhook response: %s", body)
+	return nil
+}
+
+func (webhookHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionWebhook{})
+}
+
+// parseWebhookHeaders parses headers formatted as "Name: value".
+func parseWebhookHeaders(headers []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("header %q must be formatted as \"Name: value\"", header)
+		}
+		parsed[name] = strings.TrimSpace(value)
+	}
+	return parsed, nil
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&webhookHandler{})
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+type webhookRequest struct {
+	method string
+	header http.Header
+	body   string
+}
+
+func fakeWebhookServer(t *testing.T) (string, <-chan webhookRequest) {
+	t.Helper()
+	requests := make(chan webhookRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- webhookRequest{method: r.Method, header: r.Header, body: string(body)}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, requests
+}
+
+func TestWebhookHandlerRegistration(t *testing.T) {
+	handler, err := DefaultRegistry().GetHandler(&v1.Hook{
+		Action: &v1.Hook_ActionWebhook{
+			ActionWebhook: &v1.Hook_Webhook{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get webhook handler: %v", err)
+	}
+	if handler.Name() != "webhook" {
+		t.Errorf("Expected handler name to be 'webhook', got '%s'", handler.Name())
+	}
+}
+
+func TestWebhookHandlerSend(t *testing.T) {
+	serverURL, requests := fakeWebhookServer(t)
+	hook := &v1.Hook{
+		Action: &v1.Hook_ActionWebhook{
+			ActionWebhook: &v1.Hook_Webhook{
+				WebhookUrl:  serverURL,
+				Method:      v1.Hook_Webhook_PUT,
+				Headers:     []string{"X-Source: backrest", "X-Empty:"},
+				ContentType: "application/json",
+				BearerToken: "token",
+				HmacSecret:  "secret",
+				HmacHeader:  "X-Signature",
+				Template:    `{"event": "{{ .EventName .Event }}"}`,
+			},
+		},
+	}
+	vars := tasks.HookVars{Event: v1.Hook_CONDITION_SNAPSHOT_SUCCESS}
+	if err := (webhookHandler{}).Execute(context.Background(), hook, vars, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_SUCCESS); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	req := <-requests
+	wantBody := `{"event": "snapshot success"}`
+	if req.method != http.MethodPut || req.body != wantBody {
+		t.Errorf("request = %v %q, want PUT %q", req.method, req.body, wantBody)
+	}
+	for name, want := range map[string]string{
+		"X-Source":      "backrest",
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer token",
+		"X-Signature":   "sha256=" + hookutil.SignHMACSHA256("secret", []byte(wantBody)),
+	} {
+		if got := req.header.Get(name); got != want {
+			t.Errorf("header %v = %q, want %q", name, got, want)
+		}
+	}
+	if _, ok := req.header["X-Empty"]; !ok {
+		t.Errorf("header X-Empty not sent")
+	}
+}
+
+func TestWebhookHandlerGetHasNoBody(t *testing.T) {
+	serverURL, requests := fakeWebhookServer(t)
+	hook := &v1.Hook{
+		Action: &v1.Hook_ActionWebhook{
+			ActionWebhook: &v1.Hook_Webhook{
+				WebhookUrl: serverURL,
+				Method:     v1.Hook_Webhook_GET,
+			},
+		},
+	}
+	if err := (webhookHandler{}).Execute(context.Background(), hook, tasks.HookVars{}, loggerTaskRunner{}, v1.Hook_CONDITION_SNAPSHOT_SUCCESS); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	req := <-requests
+	if req.method != http.MethodGet || req.body != "" {
+		t.Errorf("request = %v %q, want GET without a body", req.method, req.body)
+	}
+}
+
+func TestParseWebhookHeaders(t *testing.T) {
+	if _, err := parseWebhookHeaders([]string{"no colon"}); err == nil {
+		t.Error("expected an error for a header without a colon")
+	}
+	if _, err := parseWebhookHeaders([]string{": value"}); err == nil {
+		t.Error("expected an error for a header without a name")
+	}
+	headers, err := parseWebhookHeaders([]string{"Authorization: Basic a:b"})
+	if err != nil {
+		t.Fatalf("parseWebhookHeaders() error: %v", err)
+	}
+	if headers["Authorization"] != "Basic a:b" {
+		t.Errorf("Authorization = %q, want %q", headers["Authorization"], "Basic a:b")
+	}
+}
@@ -124,3 +124,29 @@ func (w *SizeLimitedWriter) Write(p []byte) (n int, err error) {
 	}
 	return
 }
+
+// TailWriter is a writer that keeps the last N bytes written to it.
+type TailWriter struct {
+	N   int
+	buf []byte
+}
+
+var _ io.Writer = &TailWriter{}
+
+func (w *TailWriter) Write(p []byte) (n int, err error) {
+	n = len(p)
+	if len(p) >= w.N {
+		w.buf = append(w.buf[:0], p[len(p)-w.N:]...)
+		return
+	}
+	if drop := len(w.buf) + len(p) - w.N; drop > 0 {
+		w.buf = append(w.buf[:0], w.buf[drop:]...)
+	}
+	w.buf = append(w.buf, p...)
+	return
+}
+
+// Bytes returns the last N bytes written to the writer.
+func (w *TailWriter) Bytes() []byte {
+	return w.buf
+}
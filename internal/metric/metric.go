@@ -30,6 +30,26 @@ func initRegistry() *Registry {
 			Name: "backrest_backup_file_warnings",
 			Help: "The total number of file warnings during a backup",
 		}, commonDims),
+		pruneBytesFreed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_prune_bytes_freed",
+			Help: "The number of bytes freed by the last prune",
+		}, commonDims),
+		pruneBytesRepacked: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_prune_bytes_repacked",
+			Help: "The number of bytes repacked by the last prune",
+		}, commonDims),
+		prunePacksDeleted: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_prune_packs_deleted",
+			Help: "The number of packs deleted by the last prune",
+		}, commonDims),
+		prunePacksRepacked: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_prune_packs_repacked",
+			Help: "The number of packs repacked by the last prune",
+		}, commonDims),
+		pruneUnusedBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_prune_unused_bytes",
+			Help: "The number of unused bytes left in the repo after the last prune",
+		}, commonDims),
 		tasksDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Name: "backrest_tasks_duration_secs",
 			Help: "The duration of a task in seconds",
@@ -47,6 +67,11 @@ func initRegistry() *Registry {
 	registry.reg.MustRegister(registry.backupBytesProcessed)
 	registry.reg.MustRegister(registry.backupBytesAdded)
 	registry.reg.MustRegister(registry.backupFileWarnings)
+	registry.reg.MustRegister(registry.pruneBytesFreed)
+	registry.reg.MustRegister(registry.pruneBytesRepacked)
+	registry.reg.MustRegister(registry.prunePacksDeleted)
+	registry.reg.MustRegister(registry.prunePacksRepacked)
+	registry.reg.MustRegister(registry.pruneUnusedBytes)
 	registry.reg.MustRegister(registry.tasksDuration)
 	registry.reg.MustRegister(registry.tasksRun)
 	registry.reg.MustRegister(registry.lastTaskStatus)
@@ -63,6 +88,11 @@ type Registry struct {
 	backupBytesProcessed *prometheus.GaugeVec
 	backupBytesAdded     *prometheus.GaugeVec
 	backupFileWarnings   *prometheus.GaugeVec
+	pruneBytesFreed      *prometheus.GaugeVec
+	pruneBytesRepacked   *prometheus.GaugeVec
+	prunePacksDeleted    *prometheus.GaugeVec
+	prunePacksRepacked   *prometheus.GaugeVec
+	pruneUnusedBytes     *prometheus.GaugeVec
 	tasksDuration        *prometheus.GaugeVec
 	tasksRun             *prometheus.CounterVec
 	lastTaskStatus       *prometheus.GaugeVec
@@ -96,3 +126,11 @@ func (r *Registry) RecordBackupSummary(repoID, planID string, bytesProcessed, by
 	r.backupBytesAdded.WithLabelValues(repoID, planID).Set(float64(bytesAdded))
 	r.backupFileWarnings.WithLabelValues(repoID, planID).Set(float64(fileWarnings))
 }
+
+func (r *Registry) RecordPruneSummary(repoID, planID string, bytesFreed, bytesRepacked, packsDeleted, packsRepacked, unusedBytes int64) {
+	r.pruneBytesFreed.WithLabelValues(repoID, planID).Set(float64(bytesFreed))
+	r.pruneBytesRepacked.WithLabelValues(repoID, planID).Set(float64(bytesRepacked))
+	r.prunePacksDeleted.WithLabelValues(repoID, planID).Set(float64(packsDeleted))
+	r.prunePacksRepacked.WithLabelValues(repoID, planID).Set(float64(packsRepacked))
+	r.pruneUnusedBytes.WithLabelValues(repoID, planID).Set(float64(unusedBytes))
+}
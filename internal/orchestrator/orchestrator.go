@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -11,6 +12,11 @@ import (
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/env"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"github.com/garethgeorge/backrest/internal/hook/types"
+	"github.com/garethgeorge/backrest/internal/ioutil"
+	"github.com/garethgeorge/backrest/internal/kvstore"
 	"github.com/garethgeorge/backrest/internal/logstore"
 	"github.com/garethgeorge/backrest/internal/metric"
 	"github.com/garethgeorge/backrest/internal/oplog"
@@ -44,7 +50,18 @@ type Orchestrator struct {
 	taskQueue          *queue.TimePriorityQueue[stContainer]
 	lastQueueResetTime time.Time
 	logStore           *logstore.LogStore
+	kvStore            kvstore.KvStore // persistent state for tasks, may be nil for testing.
 	resticBin          string
+	maxConcurrentTasks int // max number of tasks that Run executes at once.
+
+	repoTasksMu sync.Mutex
+	// sharedRepoTasks counts the tasks running against each repo with a shared claim.
+	sharedRepoTasks map[string]int
+	// exclusiveRepoTasks tracks the repos that have a task with an exclusive claim running against them.
+	exclusiveRepoTasks map[string]bool
+	// blockedTasks holds tasks, keyed by repo ID, that were dequeued while the repo was claimed by a conflicting
+	// task. They are requeued once no task runs against the repo.
+	blockedTasks map[string][]stContainer
 
 	taskCancelMu sync.Mutex
 	taskCancel   map[int64]context.CancelFunc
@@ -63,6 +80,7 @@ var _ tasks.TaskExecutor = &Orchestrator{}
 type stContainer struct {
 	tasks.ScheduledTask
 	retryCount  int // number of times this task has been retried.
+	priority    int // priority the task was enqueued with.
 	createdTime time.Time
 	callbacks   []func(error)
 }
@@ -75,16 +93,21 @@ func (st stContainer) Less(other stContainer) bool {
 	return st.ScheduledTask.Less(other.ScheduledTask)
 }
 
-func NewOrchestrator(resticBin string, cfgMgr *config.ConfigManager, log *oplog.OpLog, logStore *logstore.LogStore) (*Orchestrator, error) {
+func NewOrchestrator(resticBin string, cfgMgr *config.ConfigManager, log *oplog.OpLog, logStore *logstore.LogStore, kvStore kvstore.KvStore) (*Orchestrator, error) {
 	// create the orchestrator.
 	o := &Orchestrator{
-		OpLog:            log,
-		configMgr:        cfgMgr,
-		taskQueue:        queue.NewTimePriorityQueue[stContainer](),
-		logStore:         logStore,
-		taskCancel:       make(map[int64]context.CancelFunc),
-		taskCancelStatus: make(map[int64]v1.OperationStatus),
-		resticBin:        resticBin,
+		OpLog:              log,
+		configMgr:          cfgMgr,
+		taskQueue:          queue.NewTimePriorityQueue[stContainer](),
+		logStore:           logStore,
+		kvStore:            kvStore,
+		taskCancel:         make(map[int64]context.CancelFunc),
+		taskCancelStatus:   make(map[int64]v1.OperationStatus),
+		resticBin:          resticBin,
+		maxConcurrentTasks: env.MaxConcurrentTasks(),
+		sharedRepoTasks:    make(map[string]int),
+		exclusiveRepoTasks: make(map[string]bool),
+		blockedTasks:       make(map[string][]stContainer),
 	}
 
 	// verify the operation log and mark any incomplete operations as failed.
@@ -255,6 +278,7 @@ func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 	zap.L().Info("scheduling default tasks, waiting for task queue reset.")
 	o.mu.Lock()
 	removedTasks := o.taskQueue.Reset()
+	removedTasks = append(removedTasks, o.takeBlockedTasks()...)
 	o.lastQueueResetTime = o.curTime()
 	o.mu.Unlock()
 
@@ -275,6 +299,12 @@ func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 		return fmt.Errorf("schedule collect garbage task: %w", err)
 	}
 
+	if o.kvStore != nil {
+		if _, err := o.ScheduleTask(tasks.NewDigestTask(o.kvStore), tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule digest task: %w", err)
+		}
+	}
+
 	var repoByID = map[string]*v1.Repo{}
 	for _, repo := range config.Repos {
 		repoByID[repo.GetId()] = repo
@@ -291,6 +321,18 @@ func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 		if _, err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
 			return fmt.Errorf("schedule backup task for plan %q: %w", plan.Id, err)
 		}
+
+		// Schedule a restore test task for the plan
+		if _, err := o.ScheduleTask(tasks.NewRestoreTestTask(repo, plan.Id, false), tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule restore test task for plan %q: %w", plan.Id, err)
+		}
+
+		// Schedule a backup overdue check for the plan
+		if o.kvStore != nil {
+			if _, err := o.ScheduleTask(tasks.NewBackupOverdueTask(repo, plan.Id, o.kvStore), tasks.TaskPriorityDefault); err != nil {
+				return fmt.Errorf("schedule backup overdue task for plan %q: %w", plan.Id, err)
+			}
+		}
 	}
 
 	for _, repo := range config.Repos {
@@ -317,6 +359,12 @@ func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 		if _, err := o.ScheduleTask(t, tasks.TaskPriorityForget); err != nil {
 			return fmt.Errorf("schedule scheduled forget task for repo %q: %w", repo.GetId(), err)
 		}
+
+		// Schedule a copy task for the repo
+		t = tasks.NewCopyTask(repo, tasks.PlanForSystemTasks, false)
+		if _, err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule copy task for repo %q: %w", repo.GetId(), err)
+		}
 	}
 
 	return nil
@@ -377,8 +425,74 @@ func (o *Orchestrator) GetPlan(planID string) (*v1.Plan, error) {
 	return plan, nil
 }
 
+// TestHook runs the hook once for event with variables built by tasks.HookVarsForTest. The hook's notify mode and
+// error policy aren't applied and no operation is recorded, errors returned by the hook are reported in the response.
+// repoID defaults to the plan's repo, both may be empty.
+func (o *Orchestrator) TestHook(ctx context.Context, h *v1.Hook, event v1.Hook_Condition, repoID, planID string) (*v1.TestHookResponse, error) {
+	handler, err := types.DefaultRegistry().GetHandler(h)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *v1.Plan
+	if planID != "" {
+		if plan, err = o.GetPlan(planID); err != nil {
+			return nil, err
+		}
+		if repoID == "" {
+			repoID = plan.Repo
+		}
+	}
+	var repo *v1.Repo
+	if repoID != "" {
+		if repo, err = o.GetRepo(repoID); err != nil {
+			return nil, err
+		}
+	}
+
+	task := &tasks.GenericOneoffTask{
+		BaseTask: tasks.BaseTask{
+			TaskType:   "test_hook",
+			TaskName:   fmt.Sprintf("test %v hook", handler.Name()),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+	}
+	runner := newTaskRunnerImpl(o, task, nil)
+
+	vars, err := tasks.HookVarsForTest(runner, event, repo, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.TestHookResponse{}
+	if resp.RenderedTemplate, err = types.RenderMessage(h, vars); err != nil {
+		resp.Error = fmt.Sprintf("template rendering: %v", err)
+		return resp, nil
+	}
+
+	var output bytes.Buffer
+	writer := &ioutil.SynchronizedWriter{W: &output}
+	ctx = logging.ContextWithWriter(ctx, writer)
+	// snapshot start hooks may write a result that modifies the backup, it's reported rather than applied.
+	var backupResult *tasks.BackupHookResult
+	if event == v1.Hook_CONDITION_SNAPSHOT_START {
+		backupResult = &tasks.BackupHookResult{}
+		ctx = tasks.ContextWithBackupHookResult(ctx, backupResult)
+	}
+	if err := hook.ExecuteWithTimeout(ctx, handler, h, vars, runner, event); err != nil {
+		resp.Error = err.Error()
+	} else if backupResult != nil && !backupResult.Empty() {
+		fmt.Fprintf(writer, "backup modifications: paths %v, excludes %v, tags %v, skip %v %v\n",
+			backupResult.Paths, backupResult.Excludes, backupResult.Tags, backupResult.Skip, backupResult.SkipReason)
+	}
+	resp.Output = output.String()
+	return resp, nil
+}
+
 func (o *Orchestrator) CancelOperation(operationId int64, status v1.OperationStatus) error {
 	allTasks := o.taskQueue.GetAll()
+	allTasks = append(allTasks, o.getBlockedTasks()...)
 	idx := slices.IndexFunc(allTasks, func(t stContainer) bool {
 		return t.Op != nil && t.Op.GetId() == operationId
 	})
@@ -403,12 +517,14 @@ func (o *Orchestrator) CancelOperation(operationId int64, status v1.OperationSta
 		return fmt.Errorf("cancel operation: update cancelled operation: %w", err)
 	}
 	o.taskQueue.Remove(t)
+	o.removeBlockedTask(t)
 
 	if st, err := o.CreateUnscheduledTask(t.Task, tasks.TaskPriorityDefault, t.RunAt); err != nil {
 		return fmt.Errorf("reschedule cancelled task: %w", err)
 	} else if !st.Eq(tasks.NeverScheduledTask) {
 		o.taskQueue.Enqueue(st.RunAt, tasks.TaskPriorityDefault, stContainer{
 			ScheduledTask: st,
+			priority:      tasks.TaskPriorityDefault,
 			createdTime:   o.curTime(),
 		})
 	}
@@ -417,6 +533,8 @@ func (o *Orchestrator) CancelOperation(operationId int64, status v1.OperationSta
 }
 
 // Run is the main orchestration loop. Cancel the context to stop the loop.
+// Tasks are dispatched in priority order to up to maxConcurrentTasks workers. A task that modifies a repo
+// doesn't run alongside any other task against that repo. Run waits for in-flight tasks to finish before returning.
 func (o *Orchestrator) Run(ctx context.Context) {
 	zap.L().Info("starting orchestrator loop")
 
@@ -430,6 +548,14 @@ func (o *Orchestrator) Run(ctx context.Context) {
 	// Start the clock jump detector goroutine
 	go o.watchForClockJumps(ctx)
 
+	maxConcurrentTasks := o.maxConcurrentTasks
+	if maxConcurrentTasks < 1 {
+		maxConcurrentTasks = 1
+	}
+	workers := make(chan struct{}, maxConcurrentTasks)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
 	// Main task processing loop
 	for {
 		if ctx.Err() != nil {
@@ -437,26 +563,150 @@ func (o *Orchestrator) Run(ctx context.Context) {
 			break
 		}
 
+		// Wait for a free worker before dequeuing so that the highest priority task
+		// that is ready at the time a worker frees up is the one that runs.
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+
 		t := o.taskQueue.Dequeue(ctx)
-		if t.Task == nil {
+		if t.Task != nil && ctx.Err() != nil {
+			// Don't start new work once shutdown has begun, return the task to the queue.
+			o.taskQueue.Enqueue(t.RunAt, t.priority, t)
+			t.Task = nil
+		}
+		if t.Task == nil || !o.acquireRepo(t) {
+			<-workers
 			continue
 		}
 
-		// Clone the operation in case we need to reset changes and reschedule the task for a retry
-		originalOp := proto.Clone(t.Op).(*v1.Operation)
-		o.prepareOperationForRetry(&t)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-workers }()
+			o.runQueuedTask(ctx, t)
+		}()
+	}
+}
+
+// runQueuedTask executes a task dequeued by Run and handles its completion.
+func (o *Orchestrator) runQueuedTask(ctx context.Context, t stContainer) {
+	// Clone the operation in case we need to reset changes and reschedule the task for a retry
+	originalOp := proto.Clone(t.Op).(*v1.Operation)
+	o.prepareOperationForRetry(&t)
 
-		// Execute the task
-		err := o.RunTask(ctx, t.ScheduledTask)
+	// Execute the task
+	err := o.RunTask(ctx, t.ScheduledTask)
+	o.releaseRepo(t)
+
+	// Handle task completion, including potential retry
+	if o.handleTaskCompletion(&t, err, originalOp) {
+		return // Skip callbacks for retried tasks
+	}
+
+	// Execute callbacks
+	for _, cb := range t.callbacks {
+		go cb(err)
+	}
+}
 
-		// Handle task completion, including potential retry
-		if o.handleTaskCompletion(&t, err, originalOp) {
-			continue // Skip callbacks for retried tasks
+// acquireRepo claims the repos the task runs against. If a repo is claimed by a conflicting task, or other tasks are
+// already waiting for it, the task is held back until the repo is released and false is returned. Waiting behind
+// earlier tasks keeps a stream of shared claims from starving a task that needs the repo exclusively.
+func (o *Orchestrator) acquireRepo(t stContainer) bool {
+	claims := tasks.RepoClaims(t.Task)
+	if len(claims) == 0 {
+		return true
+	}
+
+	o.repoTasksMu.Lock()
+	defer o.repoTasksMu.Unlock()
+	for _, claim := range claims {
+		repoID := claim.RepoID
+		if o.exclusiveRepoTasks[repoID] || (claim.Exclusive && o.sharedRepoTasks[repoID] > 0) || len(o.blockedTasks[repoID]) > 0 {
+			zap.L().Debug("repo is busy, deferring task", zap.String("task", t.Task.Name()), zap.String("repo", repoID))
+			o.blockedTasks[repoID] = append(o.blockedTasks[repoID], t)
+			return false
+		}
+	}
+	for _, claim := range claims {
+		if claim.Exclusive {
+			o.exclusiveRepoTasks[claim.RepoID] = true
+		} else {
+			o.sharedRepoTasks[claim.RepoID]++
 		}
+	}
+	return true
+}
 
-		// Execute callbacks
-		for _, cb := range t.callbacks {
-			go cb(err)
+// releaseRepo releases the repos claimed by acquireRepo and requeues the tasks waiting for repos no task runs against.
+func (o *Orchestrator) releaseRepo(t stContainer) {
+	claims := tasks.RepoClaims(t.Task)
+	if len(claims) == 0 {
+		return
+	}
+
+	var blocked []stContainer
+	o.repoTasksMu.Lock()
+	for _, claim := range claims {
+		repoID := claim.RepoID
+		if claim.Exclusive {
+			delete(o.exclusiveRepoTasks, repoID)
+		} else if o.sharedRepoTasks[repoID]--; o.sharedRepoTasks[repoID] <= 0 {
+			delete(o.sharedRepoTasks, repoID)
+		}
+		if !o.exclusiveRepoTasks[repoID] && o.sharedRepoTasks[repoID] == 0 {
+			blocked = append(blocked, o.blockedTasks[repoID]...)
+			delete(o.blockedTasks, repoID)
+		}
+	}
+	o.repoTasksMu.Unlock()
+
+	for _, bt := range blocked {
+		o.taskQueue.Enqueue(bt.RunAt, bt.priority, bt)
+	}
+}
+
+// getBlockedTasks returns all tasks that are waiting for their repo to be released.
+func (o *Orchestrator) getBlockedTasks() []stContainer {
+	o.repoTasksMu.Lock()
+	defer o.repoTasksMu.Unlock()
+	var res []stContainer
+	for _, blocked := range o.blockedTasks {
+		res = append(res, blocked...)
+	}
+	return res
+}
+
+// takeBlockedTasks removes and returns all tasks that are waiting for their repo to be released.
+func (o *Orchestrator) takeBlockedTasks() []stContainer {
+	o.repoTasksMu.Lock()
+	defer o.repoTasksMu.Unlock()
+	var res []stContainer
+	for _, blocked := range o.blockedTasks {
+		res = append(res, blocked...)
+	}
+	o.blockedTasks = make(map[string][]stContainer)
+	return res
+}
+
+// removeBlockedTask removes a task that is waiting for its repo to be released, if present.
+func (o *Orchestrator) removeBlockedTask(t stContainer) {
+	if t.Task == nil {
+		return
+	}
+
+	o.repoTasksMu.Lock()
+	defer o.repoTasksMu.Unlock()
+	for _, claim := range tasks.RepoClaims(t.Task) {
+		repoID := claim.RepoID
+		o.blockedTasks[repoID] = slices.DeleteFunc(o.blockedTasks[repoID], func(bt stContainer) bool {
+			return bt.Eq(t)
+		})
+		if len(o.blockedTasks[repoID]) == 0 {
+			delete(o.blockedTasks, repoID)
 		}
 	}
 }
@@ -585,6 +835,7 @@ func (o *Orchestrator) retryTask(t *stContainer, retryErr *tasks.TaskRetryError,
 
 	// Enqueue the task for retry
 	t.RunAt = time.Now().Add(delay)
+	t.priority = tasks.TaskPriorityDefault
 	o.taskQueue.Enqueue(t.RunAt, tasks.TaskPriorityDefault, *t)
 
 	zap.L().Info("retrying task",
@@ -753,6 +1004,7 @@ func (o *Orchestrator) ScheduleTask(t tasks.Task, priority int, callbacks ...fun
 	stc := stContainer{
 		ScheduledTask: nextRun,
 		callbacks:     callbacks,
+		priority:      priority,
 		createdTime:   o.curTime(),
 	}
 
@@ -1,10 +1,20 @@
 package orchestrator
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/logstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
 )
 
@@ -36,7 +46,7 @@ func TestAutoInitializeRepos(t *testing.T) {
 		t.Fatalf("failed to find or install restic binary: %v", err)
 	}
 
-	_, err = NewOrchestrator(resticBin, configMgr, nil, nil)
+	_, err = NewOrchestrator(resticBin, configMgr, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create orchestrator: %v", err)
 	}
@@ -54,3 +64,246 @@ func TestAutoInitializeRepos(t *testing.T) {
 		t.Fatalf("expected repo auto-initialize to be false")
 	}
 }
+
+func TestRepoTasksDontOverlapExclusiveLock(t *testing.T) {
+	t.Parallel()
+
+	configMgr := &config.ConfigManager{
+		Store: &config.MemoryStore{
+			Config: &v1.Config{
+				Version:  4,
+				Instance: "test-instance",
+				Repos: []*v1.Repo{
+					{
+						Id:  "test",
+						Uri: t.TempDir(),
+						Flags: []string{
+							"--no-cache",
+							"--insecure-no-password",
+						},
+						AutoInitialize: true,
+					},
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:    "plan",
+						Repo:  "test",
+						Paths: []string{t.TempDir()},
+						Retention: &v1.RetentionPolicy{
+							Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resticBin, err := resticinstaller.FindOrInstallResticBinary()
+	if err != nil {
+		t.Fatalf("failed to find or install restic binary: %v", err)
+	}
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	logStore, err := logstore.NewLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create log store: %v", err)
+	}
+	t.Cleanup(func() { logStore.Close() })
+	orch, err := NewOrchestrator(resticBin, configMgr, log, logStore, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	orch.maxConcurrentTasks = 4
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, _ := configMgr.Get()
+	repo := cfg.Repos[0]
+	repoOrch, err := orch.GetRepoOrchestrator(repo.Id)
+	if err != nil {
+		t.Fatalf("failed to get repo orchestrator: %v", err)
+	}
+	if _, err := repoOrch.Backup(ctx, cfg.Plans[0], false, nil); err != nil {
+		t.Fatalf("failed to back up plan: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		orch.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// forget takes restic's exclusive lock, it fails if it runs while index or stats hold a lock on the repo.
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for i := 0; i < 3; i++ {
+		for _, task := range []tasks.Task{
+			tasks.NewOneoffIndexSnapshotsTask(repo, time.Now()),
+			tasks.NewStatsTask(repo, tasks.PlanForSystemTasks, true),
+			tasks.NewOneoffForgetTask(repo, "plan", 0, time.Now()),
+		} {
+			wg.Add(1)
+			if _, err := orch.ScheduleTask(task, tasks.TaskPriorityDefault, func(err error) {
+				defer wg.Done()
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("%v: %w", task.Name(), err))
+					mu.Unlock()
+				}
+			}); err != nil {
+				t.Fatalf("failed to schedule %v: %v", task.Name(), err)
+			}
+		}
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		t.Errorf("expected tasks against the repo to succeed, got: %v", err)
+	}
+}
+
+func TestTestHook(t *testing.T) {
+	t.Parallel()
+
+	snapshotID := "1234567812345678123456781234567812345678123456781234567812345678"
+
+	repo := &v1.Repo{
+		Id:   "repo1",
+		Guid: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		Uri:  t.TempDir(),
+	}
+	configMgr := &config.ConfigManager{
+		Store: &config.MemoryStore{
+			Config: &v1.Config{
+				Version:  4,
+				Instance: "test-instance",
+				Repos:    []*v1.Repo{repo},
+				Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1", Paths: []string{"/data"}}},
+			},
+		},
+	}
+
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	if err := log.Add(&v1.Operation{
+		InstanceId:      "test-instance",
+		RepoId:          "repo1",
+		RepoGuid:        repo.Guid,
+		PlanId:          "plan1",
+		SnapshotId:      snapshotID,
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		UnixTimeStartMs: 1000,
+		UnixTimeEndMs:   61000,
+		Op: &v1.Operation_OperationBackup{
+			OperationBackup: &v1.OperationBackup{
+				LastStatus: &v1.BackupProgressEntry{
+					Entry: &v1.BackupProgressEntry_Summary{
+						Summary: &v1.BackupProgressSummary{DataAdded: 100, SnapshotId: snapshotID},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to add operation: %v", err)
+	}
+
+	orch, err := NewOrchestrator("", configMgr, log, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	commandHook := func(command string) *v1.Hook {
+		return &v1.Hook{
+			OnError: v1.Hook_ON_ERROR_RETRY_1MINUTE, // must not be applied by a test.
+			Action: &v1.Hook_ActionCommand{
+				ActionCommand: &v1.Hook_Command{Command: command},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		hook         *v1.Hook
+		event        v1.Hook_Condition
+		planID       string
+		wantRendered string
+		wantOutput   string
+		wantError    bool
+	}{
+		{
+			name:         "vars from the plan's last backup",
+			hook:         commandHook("echo {{ .Repo.Id }} {{ .SnapshotId }} {{ .SnapshotStats.DataAdded }} {{ .Duration }}"),
+			event:        v1.Hook_CONDITION_SNAPSHOT_SUCCESS,
+			planID:       "plan1",
+			wantRendered: "echo repo1 " + snapshotID + " 100 1m0s",
+			wantOutput:   "repo1 " + snapshotID + " 100 1m0s",
+		},
+		{
+			name:         "sample vars without a plan",
+			hook:         commandHook("echo {{ .Plan.Id }} {{ .Error }}"),
+			event:        v1.Hook_CONDITION_SNAPSHOT_ERROR,
+			wantRendered: "echo sample-plan this is a sample error message sent by a hook test",
+			wantOutput:   "sample-plan this is a sample error message sent by a hook test",
+		},
+		{
+			name:      "hook error is reported",
+			hook:      commandHook("echo failing; exit 3"),
+			event:     v1.Hook_CONDITION_SNAPSHOT_ERROR,
+			planID:    "plan1",
+			wantError: true,
+		},
+		{
+			name:       "backup modifications are reported",
+			hook:       commandHook(`echo "PATH=/mnt/usb" > "$BACKREST_RESULT_FILE"`),
+			event:      v1.Hook_CONDITION_SNAPSHOT_START,
+			planID:     "plan1",
+			wantOutput: "backup modifications: paths [/mnt/usb]",
+		},
+		{
+			name:      "template error is reported",
+			hook:      commandHook("echo {{ .NoSuchField }}"),
+			event:     v1.Hook_CONDITION_SNAPSHOT_START,
+			wantError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := orch.TestHook(context.Background(), tc.hook, tc.event, "", tc.planID)
+			if err != nil {
+				t.Fatalf("TestHook() error: %v", err)
+			}
+			if (resp.Error != "") != tc.wantError {
+				t.Errorf("response error = %q, wantError %v", resp.Error, tc.wantError)
+			}
+			if tc.wantRendered != "" && resp.RenderedTemplate != tc.wantRendered {
+				t.Errorf("rendered template = %q, want %q", resp.RenderedTemplate, tc.wantRendered)
+			}
+			if !strings.Contains(resp.Output, tc.wantOutput) {
+				t.Errorf("output = %q, want it to contain %q", resp.Output, tc.wantOutput)
+			}
+		})
+	}
+
+	if _, err := orch.TestHook(context.Background(), commandHook("true"), v1.Hook_CONDITION_SNAPSHOT_START, "", "no-such-plan"); !errors.Is(err, ErrPlanNotFound) {
+		t.Errorf("TestHook() with unknown plan error = %v, want ErrPlanNotFound", err)
+	}
+}
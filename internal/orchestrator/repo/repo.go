@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"path/filepath"
 	"runtime"
 	"slices"
 	"sort"
@@ -151,10 +152,10 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, dryRun boo
 	startTime := time.Now()
 
 	var opts []restic.GenericOption
-	opts = append(opts, restic.WithFlags(
-		"--exclude-caches",
-		"--tag", TagForPlan(plan.Id),
-	))
+	opts = append(opts, restic.WithFlags("--tag", TagForPlan(plan.Id)))
+	if plan.GetStdinCommand() == nil {
+		opts = append(opts, restic.WithFlags("--exclude-caches"))
+	}
 
 	if r.config.Instance != "" {
 		opts = append(opts, restic.WithFlags("--tag", TagForInstance(r.config.Instance)))
@@ -187,9 +188,21 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, dryRun boo
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 	l.Debug("starting backup", zap.String("plan", plan.Id))
-	summary, err := r.repo.Backup(ctx, plan.Paths, progressCallback, opts...)
-	if err != nil {
-		return summary, fmt.Errorf("failed to backup: %w", err)
+	var summary *restic.BackupProgressEntry
+	if stdinCmd := plan.GetStdinCommand(); stdinCmd != nil {
+		command, err := shlex.Split(stdinCmd.GetCommand())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse command %q for plan %q: %w", stdinCmd.GetCommand(), plan.Id, err)
+		}
+		summary, err = r.repo.BackupFromCommand(ctx, command, stdinCmd.GetFilename(), progressCallback, opts...)
+		if err != nil {
+			return summary, fmt.Errorf("failed to backup output of command %q: %w", stdinCmd.GetCommand(), err)
+		}
+	} else {
+		summary, err = r.repo.Backup(ctx, plan.Paths, progressCallback, opts...)
+		if err != nil {
+			return summary, fmt.Errorf("failed to backup: %w", err)
+		}
 	}
 
 	l.Debug("backup completed", zap.Duration("duration", time.Since(startTime)))
@@ -213,22 +226,123 @@ func (r *RepoOrchestrator) ListSnapshotFiles(ctx context.Context, snapshotId str
 	return lsEnts, nil
 }
 
+// ListFiles recursively lists the entries under path in the snapshot, the callback is invoked for each entry as it is read.
+func (r *RepoOrchestrator) ListFiles(ctx context.Context, snapshotId string, path string, callback func(entry *v1.LsEntry)) error {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	if err := r.repo.ListFiles(ctx, snapshotId, path, func(entry *restic.LsEntry) {
+		callback(entry.ToProto())
+	}, restic.WithFlags("--no-lock")); err != nil {
+		return fmt.Errorf("list files in snapshot %q for repo %v: %w", snapshotId, r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+// Diff compares two snapshots, optionally limited to the subtree at snapshotPath. The callback is
+// invoked for each changed entry, entry paths are absolute even when a snapshotPath is given.
+func (r *RepoOrchestrator) Diff(ctx context.Context, snapshotId string, otherSnapshotId string, snapshotPath string, callback func(entry *restic.DiffEntry)) (*v1.SnapshotDiffStats, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.logger(ctx).Debug("diff snapshots", zap.String("snapshot", snapshotId), zap.String("other", otherSnapshotId), zap.String("path", snapshotPath))
+
+	prefix := "/"
+	if snapshotPath != "" {
+		// restic diff accepts a subfolder in the form snapshotID:subfolder, paths in the output are relative to it.
+		prefix = path.Clean("/" + strings.ReplaceAll(snapshotPath, "\\", "/"))
+		snapshotId = snapshotId + ":" + prefix
+		otherSnapshotId = otherSnapshotId + ":" + prefix
+	}
+
+	summary, err := r.repo.Diff(ctx, snapshotId, otherSnapshotId, func(entry *restic.DiffEntry) {
+		if entry.MessageType != "change" || callback == nil {
+			return
+		}
+		entry.Path = path.Join(prefix, entry.Path)
+		callback(entry)
+	}, restic.WithFlags("--no-lock"))
+	if err != nil {
+		return nil, fmt.Errorf("diff snapshots %q and %q for repo %v: %w", snapshotId, otherSnapshotId, r.repoConfig.Id, err)
+	}
+
+	return protoutil.SnapshotDiffStatsToProto(summary), nil
+}
+
+// FindFiles searches the snapshots selected by the query's filters for files matching its pattern.
+// The callback is invoked for each match.
+func (r *RepoOrchestrator) FindFiles(ctx context.Context, query *v1.FindFilesQuery, callback func(match *v1.FileMatch)) error {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.logger(ctx).Debug("find files", zap.String("pattern", query.GetPattern()))
+
+	var filters []restic.GenericOption
+	if query.GetPlanId() != "" {
+		filters = append(filters, restic.WithFlags("--tag", TagForPlan(query.GetPlanId())))
+	}
+	if query.GetHostname() != "" {
+		filters = append(filters, restic.WithFlags("--host", query.GetHostname()))
+	}
+
+	snapshotIDs := query.GetSnapshotIds()
+	if query.GetSnapshotTimeStartMs() != 0 || query.GetSnapshotTimeEndMs() != 0 {
+		// restic find can't filter snapshots by time, resolve the snapshots in the time range instead.
+		snapshots, err := r.repo.Snapshots(ctx, append(slices.Clone(filters), restic.WithFlags("--no-lock"))...)
+		if err != nil {
+			return fmt.Errorf("list snapshots for repo %v: %w", r.repoConfig.Id, err)
+		}
+		var inRange []string
+		for _, snapshot := range snapshots {
+			if len(snapshotIDs) != 0 && !slices.ContainsFunc(snapshotIDs, func(id string) bool {
+				return strings.HasPrefix(snapshot.Id, id)
+			}) {
+				continue
+			}
+			if t := snapshot.UnixTimeMs(); (query.GetSnapshotTimeStartMs() != 0 && t < query.GetSnapshotTimeStartMs()) ||
+				(query.GetSnapshotTimeEndMs() != 0 && t > query.GetSnapshotTimeEndMs()) {
+				continue
+			}
+			inRange = append(inRange, snapshot.Id)
+		}
+		if len(inRange) == 0 {
+			return nil
+		}
+		snapshotIDs = inRange
+	}
+
+	opts := append(filters, restic.WithFlags("--no-lock"))
+	if query.GetIgnoreCase() {
+		opts = append(opts, restic.WithFlags("--ignore-case"))
+	}
+	for _, id := range snapshotIDs {
+		opts = append(opts, restic.WithFlags("--snapshot", id))
+	}
+
+	err := r.repo.Find(ctx, []string{query.GetPattern()}, func(snapshotID string, match *restic.FindMatch) {
+		callback(&v1.FileMatch{
+			SnapshotId: snapshotID,
+			Path:       match.Path,
+			Type:       match.Type,
+			Size:       match.Size,
+			Mtime:      match.Mtime,
+		})
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("find files in repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
+}
+
 func (r *RepoOrchestrator) Forget(ctx context.Context, policy *v1.RetentionPolicy, opts ...restic.GenericOption) ([]*v1.ResticSnapshot, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
-	if policy == nil {
-		return nil, fmt.Errorf("repo %q: forget called with nil retention policy", r.repoConfig.Id)
-	}
-
-	result, err := r.repo.Forget(
-		ctx, protoutil.RetentionPolicyFromProto(policy),
-		opts...,
-	)
+	result, err := r.forget(ctx, policy, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("forget for repo %v: %w", r.repoConfig.Id, err)
+		return nil, err
 	}
 
 	var forgotten []*v1.ResticSnapshot
@@ -245,6 +359,54 @@ func (r *RepoOrchestrator) Forget(ctx context.Context, policy *v1.RetentionPolic
 	return forgotten, nil
 }
 
+// PreviewForget runs forget with --dry-run to report the snapshots the policy would keep, and why, and remove.
+// opts select the snapshots the same way as for Forget.
+func (r *RepoOrchestrator) PreviewForget(ctx context.Context, policy *v1.RetentionPolicy, opts ...restic.GenericOption) (*v1.PreviewForgetResponse, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	// a dry run doesn't modify the repo, it neither needs r.mu nor a lock on the repo.
+	opts = append(opts, restic.WithFlags("--dry-run", "--no-lock"))
+	result, err := r.forget(ctx, policy, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[string][]string)
+	for _, reason := range result.Reasons {
+		reasons[reason.Snapshot.Id] = reason.Matches
+	}
+
+	resp := &v1.PreviewForgetResponse{}
+	for _, snapshot := range result.Keep {
+		resp.Keep = append(resp.Keep, &v1.PreviewForgetResponse_KeptSnapshot{
+			Snapshot: protoutil.SnapshotToProto(&snapshot),
+			Reasons:  reasons[snapshot.Id],
+		})
+	}
+	for _, snapshot := range result.Remove {
+		resp.Remove = append(resp.Remove, protoutil.SnapshotToProto(&snapshot))
+	}
+	return resp, nil
+}
+
+// forget applies the retention policy to the snapshots selected by opts.
+func (r *RepoOrchestrator) forget(ctx context.Context, policy *v1.RetentionPolicy, opts ...restic.GenericOption) (*restic.ForgetResult, error) {
+	if policy == nil {
+		return nil, fmt.Errorf("repo %q: forget called with nil retention policy", r.repoConfig.Id)
+	}
+	resticPolicy := protoutil.RetentionPolicyFromProto(policy)
+	if resticPolicy == nil {
+		return nil, fmt.Errorf("repo %q: retention policy keeps all snapshots", r.repoConfig.Id)
+	}
+
+	result, err := r.repo.Forget(ctx, resticPolicy, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("forget for repo %v: %w", r.repoConfig.Id, err)
+	}
+	return result, nil
+}
+
 func (r *RepoOrchestrator) ForgetSnapshot(ctx context.Context, snapshotId string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -274,6 +436,8 @@ func (r *RepoOrchestrator) Prune(ctx context.Context, output io.Writer) error {
 	} else {
 		opts = append(opts, restic.WithFlags("--max-unused", fmt.Sprintf("%v%%", policy.MaxUnusedPercent)))
 	}
+	// --verbose makes restic print the pack counts of its summary, they're parsed into the prune operation's stats.
+	opts = append(opts, restic.WithFlags("--verbose"))
 
 	r.logger(ctx).Debug("prune snapshots")
 	err := r.repo.Prune(ctx, output, opts...)
@@ -309,7 +473,84 @@ func (r *RepoOrchestrator) Check(ctx context.Context, output io.Writer) error {
 	return nil
 }
 
-func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, snapshotPath string, target string, progressCallback func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
+// CopyFrom copies snapshots from the source repo into this repo. Snapshots that were already copied are skipped.
+func (r *RepoOrchestrator) CopyFrom(ctx context.Context, source *v1.Repo, output io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	env, flags, err := copySourceArgs(r.repoConfig, source)
+	if err != nil {
+		return fmt.Errorf("copy snapshots from repo %v to repo %v: %w", source.GetId(), r.repoConfig.Id, err)
+	}
+
+	r.logger(ctx).Debug("copy snapshots", zap.String("source", source.GetId()))
+	err = r.repo.Copy(ctx, ExpandEnv(source.GetUri()), output, restic.WithEnv(env...), restic.WithFlags(flags...))
+	if err != nil {
+		return fmt.Errorf("copy snapshots from repo %v to repo %v: %w", source.GetId(), r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+// copySourceEnvVars are the variables restic reads under a RESTIC_FROM_ prefix for the source repo of a copy.
+var copySourceEnvVars = []string{"RESTIC_REPOSITORY_FILE", "RESTIC_PASSWORD", "RESTIC_PASSWORD_FILE", "RESTIC_PASSWORD_COMMAND", "RESTIC_KEY_HINT"}
+
+// copySourceFlags are the global flags that restic accepts with a --from- prefix for the source repo of a copy.
+var copySourceFlags = []string{"--repository-file", "--password-file", "--password-command", "--key-hint", "--insecure-no-password"}
+
+// copySourceArgs returns the env and flags that configure source as the --from-repo of a copy into target.
+// Repo credentials are renamed to their restic FROM variants. Everything else, e.g. backend credentials and
+// -o options, is shared by both repos in a single restic process, so it is passed through as is and a variable
+// that target sets to a different value is an error rather than silently replacing target's credentials.
+func copySourceArgs(target *v1.Repo, source *v1.Repo) ([]string, []string, error) {
+	targetEnv := make(map[string]string)
+	for _, e := range target.GetEnv() {
+		k, v, _ := strings.Cut(ExpandEnv(e), "=")
+		targetEnv[k] = v
+	}
+
+	var env []string
+	for _, e := range source.GetEnv() {
+		e = ExpandEnv(e)
+		k, v, _ := strings.Cut(e, "=")
+		if slices.Contains(copySourceEnvVars, k) {
+			env = append(env, "RESTIC_FROM_"+strings.TrimPrefix(e, "RESTIC_"))
+			continue
+		}
+		if tv, ok := targetEnv[k]; ok && tv != v {
+			return nil, nil, fmt.Errorf("env var %v is set differently for repo %v and repo %v but restic copy uses one value for both", k, source.GetId(), target.GetId())
+		}
+		env = append(env, e)
+	}
+	if p := source.GetPassword(); p != "" {
+		env = append(env,
+			"RESTIC_FROM_PASSWORD="+p,
+			"RESTIC_FROM_PASSWORD_FILE=",
+			"RESTIC_FROM_PASSWORD_COMMAND=",
+		)
+	}
+
+	var flags []string
+	for _, f := range source.GetFlags() {
+		args, err := shlex.Split(ExpandEnv(f))
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse flag %q for repo %q: %w", f, source.GetId(), err)
+		}
+		for _, arg := range args {
+			name, _, _ := strings.Cut(arg, "=")
+			if slices.Contains(copySourceFlags, name) {
+				arg = "--from-" + strings.TrimPrefix(arg, "--")
+			}
+			flags = append(flags, arg)
+		}
+	}
+	return env, flags, nil
+}
+
+// Restore restores snapshotPath from the snapshot into target. options is optional, its include and exclude
+// patterns are relative to snapshotPath and can't be combined.
+func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, snapshotPath string, target string, options *v1.RestoreOptions, progressCallback func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	ctx, flush := forwardResticLogs(ctx)
@@ -317,24 +558,58 @@ func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, snaps
 
 	r.logger(ctx).Debug("restore snapshot", zap.String("snapshot", snapshotId), zap.String("target", target))
 
-	var opts []restic.GenericOption
-	opts = append(opts, restic.WithFlags("--target", target))
+	if len(options.GetIncludes()) > 0 && len(options.GetExcludes()) > 0 {
+		return nil, errors.New("restore includes and excludes are mutually exclusive")
+	}
 
+	restoreOpts := &restic.RestoreOptions{
+		Overwrite: restoreOverwriteFlag(options.GetOverwrite()),
+		Delete:    options.GetDelete(),
+		Verify:    options.GetVerify(),
+	}
+
+	// basePattern matches the restored path within the restored subfolder of the snapshot.
+	var basePattern string
 	if snapshotPath != "" {
 		normalizedPath := strings.ReplaceAll(snapshotPath, "\\", "/")
 
 		dir := path.Dir(normalizedPath)
 		base := path.Base(normalizedPath)
 
-		if dir != "" {
-			snapshotId = snapshotId + ":" + dir
+		if len(options.GetExcludes()) > 0 {
+			// restic rejects excludes combined with an include of the restored path. Restore the path itself as the
+			// subfolder into a directory named after it instead, which gives the same layout. Only directories can
+			// be restored this way but excludes only make sense for directories.
+			snapshotId = snapshotId + ":" + normalizedPath
+			if base != "/" {
+				target = filepath.Join(target, base)
+			}
+		} else {
+			if dir != "" {
+				snapshotId = snapshotId + ":" + dir
+			}
+			if base != "" {
+				basePattern = "/" + EscapeGlob(base)
+			}
 		}
-		if base != "" {
-			opts = append(opts, restic.WithFlags("--include", "/"+escapeGlob(base)))
+	}
+
+	var opts []restic.GenericOption
+	opts = append(opts, restic.WithFlags("--target", target))
+
+	// Multiple includes match any of them, so the user's includes replace the include of the restored path.
+	if len(options.GetIncludes()) > 0 {
+		for _, include := range options.GetIncludes() {
+			restoreOpts.Includes = append(restoreOpts.Includes, scopeRestorePattern(basePattern, include))
 		}
+	} else if basePattern != "" {
+		restoreOpts.Includes = append(restoreOpts.Includes, basePattern)
+	}
+	for _, exclude := range options.GetExcludes() {
+		restoreOpts.Excludes = append(restoreOpts.Excludes, scopeRestorePattern(basePattern, exclude))
 	}
 
-	summary, err := r.repo.Restore(ctx, snapshotId, func(event *restic.RestoreProgressEntry) {
+	summary, err := r.repo.Restore(ctx, snapshotId, restoreOpts, func(event *restic.RestoreProgressEntry) {
 		if progressCallback != nil {
 			progressCallback(protoutil.RestoreProgressEntryToProto(event))
 		}
@@ -346,6 +621,35 @@ func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, snaps
 	return protoutil.RestoreProgressEntryToProto(summary), nil
 }
 
+// restoreOverwriteFlag returns the value of restic's --overwrite flag for the mode, empty for restic's default.
+func restoreOverwriteFlag(mode v1.RestoreOptions_OverwriteMode) string {
+	switch mode {
+	case v1.RestoreOptions_OVERWRITE_ALWAYS:
+		return "always"
+	case v1.RestoreOptions_OVERWRITE_IF_CHANGED:
+		return "if-changed"
+	case v1.RestoreOptions_OVERWRITE_IF_NEWER:
+		return "if-newer"
+	case v1.RestoreOptions_OVERWRITE_NEVER:
+		return "never"
+	default:
+		return ""
+	}
+}
+
+// scopeRestorePattern rewrites a pattern relative to the restored path as a pattern relative to the restored
+// subfolder of the snapshot. Patterns starting with a "/" are anchored at the restored path, others match at any depth.
+func scopeRestorePattern(basePattern string, pattern string) string {
+	basePattern = strings.TrimRight(basePattern, "/") // restoring the root of the snapshot.
+	if strings.HasPrefix(pattern, "/") {
+		return basePattern + pattern
+	}
+	if basePattern == "" {
+		return pattern
+	}
+	return basePattern + "/**/" + pattern
+}
+
 func (r *RepoOrchestrator) Dump(ctx context.Context, snapshotId string, snapshotPath string, output io.Writer) error {
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
@@ -353,6 +657,52 @@ func (r *RepoOrchestrator) Dump(ctx context.Context, snapshotId string, snapshot
 	return r.repo.Dump(ctx, snapshotId, snapshotPath, output)
 }
 
+// ListKeys lists the keys (passwords) that can open the repo.
+func (r *RepoOrchestrator) ListKeys(ctx context.Context) ([]*v1.RepoKey, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	keys, err := r.repo.ListKeys(ctx, restic.WithFlags("--no-lock"))
+	if err != nil {
+		return nil, fmt.Errorf("list keys for repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	var result []*v1.RepoKey
+	for _, key := range keys {
+		result = append(result, protoutil.RepoKeyToProto(key))
+	}
+	return result, nil
+}
+
+// AddKey adds a key for password to the repo. username and hostname are optional and are recorded with the key.
+func (r *RepoOrchestrator) AddKey(ctx context.Context, password string, username string, hostname string) (*v1.RepoKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.logger(ctx).Debug("adding key")
+	key, err := r.repo.AddKey(ctx, password, username, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("add key to repo %v: %w", r.repoConfig.Id, err)
+	}
+	return protoutil.RepoKeyToProto(key), nil
+}
+
+// RemoveKey removes the key with the given ID from the repo.
+func (r *RepoOrchestrator) RemoveKey(ctx context.Context, keyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.logger(ctx).Debug("removing key", zap.String("key", keyID))
+	if err := r.repo.RemoveKey(ctx, keyID); err != nil {
+		return fmt.Errorf("remove key %v from repo %v: %w", keyID, r.repoConfig.Id, err)
+	}
+	return nil
+}
+
 // UnlockIfAutoEnabled unlocks the repo if the auto unlock feature is enabled.
 func (r *RepoOrchestrator) UnlockIfAutoEnabled(ctx context.Context) error {
 	if !r.repoConfig.AutoUnlock {
@@ -409,6 +759,22 @@ func (r *RepoOrchestrator) AddTags(ctx context.Context, snapshotIDs []string, ta
 	return nil
 }
 
+func (r *RepoOrchestrator) RemoveTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	for idx, snapshotIDs := range chunkBy(snapshotIDs, 20) {
+		r.logger(ctx).Debug("removing tag from snapshots", zap.Strings("snapshots", snapshotIDs), zap.Strings("tags", tags))
+		if err := r.repo.RemoveTags(ctx, snapshotIDs, tags); err != nil {
+			return fmt.Errorf("batch %v: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
 // RunCommand runs a command in the repo's environment.
 // NOTE: this function does not lock the repo.
 func (r *RepoOrchestrator) RunCommand(ctx context.Context, command string, writer io.Writer) error {
@@ -473,7 +839,8 @@ var globEscapeReplacer = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[
 // should sweep the target after restore against the requested path list.
 var windowsBracketReplacer = strings.NewReplacer(`[`, `?`, `]`, `?`)
 
-func escapeGlob(s string) string {
+// EscapeGlob escapes s so that it can be used as a literal path in restic's --include and --exclude patterns.
+func EscapeGlob(s string) string {
 	if runtime.GOOS == "windows" {
 		return windowsBracketReplacer.Replace(s)
 	}
@@ -100,7 +100,7 @@ func TestRestore(t *testing.T) {
 	// Use a filepath that exercises a few of the glob characters to test escaping.
 	// On Windows, '*' and '?' are forbidden in filenames, so the messy name only
 	// contains the brackets — '[' and ']' are legal in NTFS and are the chars
-	// the Windows path of escapeGlob has to handle without backslash escape.
+	// the Windows path of EscapeGlob has to handle without backslash escape.
 	messyFilePathToTestGlobs := "test*?[].txt"
 	if runtime.GOOS == "windows" {
 		messyFilePathToTestGlobs = "test[brackets].txt"
@@ -141,7 +141,7 @@ func TestRestore(t *testing.T) {
 	// Restore the file
 	restoreDir := t.TempDir()
 	snapshotPath := strings.ReplaceAll(testFile, ":", "") // remove the colon from the windows path e.g. C:\test.txt -> C\test.txt
-	restoreSummary, err := orchestrator.Restore(context.Background(), summary.SnapshotId, snapshotPath, restoreDir, nil)
+	restoreSummary, err := orchestrator.Restore(context.Background(), summary.SnapshotId, snapshotPath, restoreDir, nil, nil)
 	if err != nil {
 		t.Fatalf("restore error: %v", err)
 	}
@@ -168,6 +168,124 @@ func TestRestore(t *testing.T) {
 	}
 }
 
+func TestRestoreWithOptions(t *testing.T) {
+	t.Parallel()
+
+	testData := t.TempDir()
+	for _, name := range []string{"keep.txt", "skip.log", filepath.Join("node_modules", "dep.js")} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(testData, name)), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(testData, name), []byte(name), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{testData},
+	}
+
+	orchestrator := initRepoHelper(t, configForTest, r)
+
+	summary, err := orchestrator.Backup(context.Background(), plan, false, nil)
+	if err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	restoreDir := t.TempDir()
+	snapshotPath := strings.ReplaceAll(testData, ":", "") // remove the colon from the windows path e.g. C:\test -> C\test
+	restoredData := filepath.Join(restoreDir, filepath.Base(testData))
+
+	if _, err := orchestrator.Restore(context.Background(), summary.SnapshotId, snapshotPath, restoreDir, &v1.RestoreOptions{
+		Excludes: []string{"node_modules", "*.log"},
+	}, nil); err != nil {
+		t.Fatalf("restore error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(restoredData, "keep.txt")); err != nil {
+		t.Errorf("expected keep.txt to be restored: %v", err)
+	}
+	for _, name := range []string{"skip.log", "node_modules"} {
+		if _, err := os.Stat(filepath.Join(restoredData, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected %s to be excluded, got: %v", name, err)
+		}
+	}
+
+	// Restoring again over the existing target skips unchanged files and deletes files that aren't in the snapshot.
+	if err := os.WriteFile(filepath.Join(restoredData, "extra.txt"), []byte("extra"), 0644); err != nil {
+		t.Fatalf("failed to create extra file: %v", err)
+	}
+	restoreSummary, err := orchestrator.Restore(context.Background(), summary.SnapshotId, snapshotPath, restoreDir, &v1.RestoreOptions{
+		Excludes:  []string{"node_modules", "*.log"},
+		Overwrite: v1.RestoreOptions_OVERWRITE_IF_CHANGED,
+		Delete:    true,
+		Verify:    true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("restore error: %v", err)
+	}
+	if restoreSummary.FilesSkipped != 1 {
+		t.Errorf("expected 1 skipped file, got %d", restoreSummary.FilesSkipped)
+	}
+	if _, err := os.Stat(filepath.Join(restoredData, "extra.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected extra.txt to be deleted, got: %v", err)
+	}
+
+	// Includes select what to restore.
+	restoreDir = t.TempDir()
+	restoredData = filepath.Join(restoreDir, filepath.Base(testData))
+	if _, err := orchestrator.Restore(context.Background(), summary.SnapshotId, snapshotPath, restoreDir, &v1.RestoreOptions{
+		Includes: []string{"/keep.txt"},
+	}, nil); err != nil {
+		t.Fatalf("restore error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(restoredData, "keep.txt")); err != nil {
+		t.Errorf("expected keep.txt to be restored: %v", err)
+	}
+	for _, name := range []string{"skip.log", "node_modules"} {
+		if _, err := os.Stat(filepath.Join(restoredData, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected %s not to be included, got: %v", name, err)
+		}
+	}
+
+	// restic can't combine includes with excludes.
+	if _, err := orchestrator.Restore(context.Background(), summary.SnapshotId, snapshotPath, t.TempDir(), &v1.RestoreOptions{
+		Includes: []string{"/keep.txt"},
+		Excludes: []string{"*.log"},
+	}, nil); err == nil {
+		t.Errorf("expected an error restoring with includes and excludes")
+	}
+}
+
+func TestScopeRestorePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		basePattern string
+		pattern     string
+		want        string
+	}{
+		{basePattern: "/data", pattern: "/keep.txt", want: "/data/keep.txt"},
+		{basePattern: "/data", pattern: "node_modules", want: "/data/**/node_modules"},
+		{basePattern: "//", pattern: "/keep.txt", want: "/keep.txt"},
+		{basePattern: "//", pattern: "*.log", want: "*.log"},
+	}
+
+	for _, tc := range tests {
+		if got := scopeRestorePattern(tc.basePattern, tc.pattern); got != tc.want {
+			t.Errorf("scopeRestorePattern(%q, %q) = %q, want %q", tc.basePattern, tc.pattern, got, tc.want)
+		}
+	}
+}
+
 func TestSnapshotParenting(t *testing.T) {
 	t.Parallel()
 
@@ -469,7 +587,7 @@ func TestRestoreAmbiguity(t *testing.T) {
 	}
 	restorePath = path.Join(restorePath, "target")
 
-	restoreSummary, err := orchestrator.Restore(context.Background(), summary.SnapshotId, restorePath, restoreDir, nil)
+	restoreSummary, err := orchestrator.Restore(context.Background(), summary.SnapshotId, restorePath, restoreDir, nil, nil)
 	if err != nil {
 		t.Fatalf("restore error: %v", err)
 	}
@@ -490,3 +608,115 @@ func TestRestoreAmbiguity(t *testing.T) {
 		t.Errorf("FAIL: Expected main file missing: %s", expectedFile)
 	}
 }
+
+func TestCopyFrom(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+
+	// the source reads its password from a flag, restic must receive it as --from-password-file.
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("source"), 0600); err != nil {
+		t.Fatalf("write password file: %v", err)
+	}
+	source := initRepoHelper(t, configForTest, &v1.Repo{
+		Id:    "source",
+		Uri:   t.TempDir(),
+		Flags: []string{"--no-cache", "--password-file " + passwordFile},
+	})
+	target := initRepoHelper(t, configForTest, &v1.Repo{
+		Id:       "target",
+		Uri:      t.TempDir(),
+		Password: "target",
+		Flags:    []string{"--no-cache"},
+	})
+
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "source",
+		Paths: []string{testData},
+	}
+	if _, err := source.Backup(context.Background(), plan, false, nil); err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	if err := target.CopyFrom(context.Background(), source.repoConfig, bytes.NewBuffer(nil)); err != nil {
+		t.Fatalf("copy error: %v", err)
+	}
+
+	snapshots, err := target.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("snapshots error: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot in the target, got %d", len(snapshots))
+	}
+}
+
+func TestCopySourceArgs(t *testing.T) {
+	tcs := []struct {
+		name      string
+		target    *v1.Repo
+		source    *v1.Repo
+		wantEnv   []string
+		wantFlags []string
+		wantErr   bool
+	}{
+		{
+			name:   "credentials are renamed",
+			target: &v1.Repo{Id: "target"},
+			source: &v1.Repo{
+				Id:    "source",
+				Env:   []string{"RESTIC_PASSWORD_COMMAND=pass show source", "RESTIC_KEY_HINT=abc"},
+				Flags: []string{"--password-file=/pw", "--key-hint abc"},
+			},
+			wantEnv:   []string{"RESTIC_FROM_PASSWORD_COMMAND=pass show source", "RESTIC_FROM_KEY_HINT=abc"},
+			wantFlags: []string{"--from-password-file=/pw", "--from-key-hint", "abc"},
+		},
+		{
+			name:   "backend env and options are passed through",
+			target: &v1.Repo{Id: "target", Env: []string{"AWS_ACCESS_KEY_ID=key"}},
+			source: &v1.Repo{
+				Id:       "source",
+				Password: "pw",
+				Env:      []string{"AWS_ACCESS_KEY_ID=key", "AWS_SECRET_ACCESS_KEY=secret"},
+				Flags:    []string{"-o s3.region=us-east-1"},
+			},
+			wantEnv: []string{
+				"AWS_ACCESS_KEY_ID=key",
+				"AWS_SECRET_ACCESS_KEY=secret",
+				"RESTIC_FROM_PASSWORD=pw",
+				"RESTIC_FROM_PASSWORD_FILE=",
+				"RESTIC_FROM_PASSWORD_COMMAND=",
+			},
+			wantFlags: []string{"-o", "s3.region=us-east-1"},
+		},
+		{
+			name:    "conflicting backend env",
+			target:  &v1.Repo{Id: "target", Env: []string{"AWS_ACCESS_KEY_ID=target"}},
+			source:  &v1.Repo{Id: "source", Env: []string{"AWS_ACCESS_KEY_ID=source"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			env, flags, err := copySourceArgs(tc.target, tc.source)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(env, tc.wantEnv) {
+				t.Errorf("env: got %q, want %q", env, tc.wantEnv)
+			}
+			if !slices.Equal(flags, tc.wantFlags) {
+				t.Errorf("flags: got %q, want %q", flags, tc.wantFlags)
+			}
+		})
+	}
+}
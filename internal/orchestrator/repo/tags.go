@@ -2,7 +2,10 @@ package repo
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/garethgeorge/backrest/pkg/restic"
 )
 
 // TagForPlan returns a tag for the plan.
@@ -34,3 +37,8 @@ func PlanFromTags(tags []string) string {
 	}
 	return ""
 }
+
+// IsPinned returns true if the tags mark the snapshot as pinned i.e. exempt from retention policies.
+func IsPinned(tags []string) bool {
+	return slices.Contains(tags, restic.PinnedTag)
+}
@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -54,7 +55,7 @@ func newTestOrchestrator(t *testing.T) *Orchestrator {
 			Config: config.NewDefaultConfig(),
 		},
 	}
-	orch, err := NewOrchestrator("", cfgMgr, nil, nil)
+	orch, err := NewOrchestrator("", cfgMgr, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create orchestrator: %v", err)
 	}
@@ -263,3 +264,256 @@ func TestSchedulerWait(t *testing.T) {
 	case <-ran:
 	}
 }
+
+func newTestRepoTask(taskType string, repoID string, onRun func() error) *testTask {
+	return &testTask{
+		BaseTask: tasks.BaseTask{
+			TaskType:   taskType,
+			TaskName:   taskType + " for " + repoID,
+			TaskRepo:   &v1.Repo{Id: repoID, Guid: cryptoutil.MustRandomID(cryptoutil.DefaultIDBits)},
+			TaskPlanID: "plan",
+		},
+		onRun: onRun,
+		onNext: func(t time.Time) *time.Time {
+			return &t
+		},
+	}
+}
+
+func TestConcurrentTasksAcrossRepos(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orch := newTestOrchestrator(t)
+	orch.maxConcurrentTasks = 2
+
+	var started sync.WaitGroup
+	started.Add(2)
+	release := make(chan struct{})
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	for _, repoID := range []string{"repo1", "repo2"} {
+		ran := false
+		task := newTestRepoTask("backup", repoID, func() error {
+			started.Done()
+			<-release
+			return nil
+		})
+		task.onNext = func(t time.Time) *time.Time {
+			if ran {
+				return nil
+			}
+			ran = true
+			return &t
+		}
+		orch.ScheduleTask(task, tasks.TaskPriorityDefault)
+	}
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		orch.Run(ctx)
+		close(done)
+	}()
+
+	// Assert both backups are running at the same time.
+	select {
+	case <-allStarted:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected tasks for different repos to run concurrently")
+	}
+	close(release)
+	cancel()
+	<-done
+}
+
+func TestMutatingTasksSerializedPerRepo(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orch := newTestOrchestrator(t)
+	orch.maxConcurrentTasks = 4
+
+	var mu sync.Mutex
+	running := 0
+	maxRunning := 0
+	var wg sync.WaitGroup
+	for _, taskType := range []string{"backup", "prune", "check", "forget"} {
+		wg.Add(1)
+		ran := false
+		task := newTestRepoTask(taskType, "repo", func() error {
+			defer wg.Done()
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+		task.onNext = func(t time.Time) *time.Time {
+			if ran {
+				return nil
+			}
+			ran = true
+			return &t
+		}
+		orch.ScheduleTask(task, tasks.TaskPriorityDefault)
+	}
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		orch.Run(ctx)
+		close(done)
+	}()
+	wg.Wait()
+	cancel()
+	<-done
+
+	// Assert
+	if maxRunning != 1 {
+		t.Errorf("expected at most 1 mutating task running against the repo at a time, got %d", maxRunning)
+	}
+}
+
+func TestSharedRepoTasksWaitForExclusiveTask(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orch := newTestOrchestrator(t)
+	orch.maxConcurrentTasks = 4
+
+	once := func(task *testTask) *testTask {
+		ran := false
+		task.onNext = func(t time.Time) *time.Time {
+			if ran {
+				return nil
+			}
+			ran = true
+			return &t
+		}
+		return task
+	}
+
+	pruneStarted := make(chan struct{})
+	releasePrune := make(chan struct{})
+	orch.ScheduleTask(once(newTestRepoTask("prune", "repo", func() error {
+		close(pruneStarted)
+		<-releasePrune
+		return nil
+	})), tasks.TaskPriorityDefault)
+
+	done := make(chan struct{})
+	go func() {
+		orch.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	<-pruneStarted
+
+	// Act
+	indexStarted := make(chan struct{}, 2)
+	var indexing sync.WaitGroup
+	indexing.Add(2)
+	for i := 0; i < 2; i++ {
+		orch.ScheduleTask(once(newTestRepoTask("index_snapshots", "repo", func() error {
+			indexStarted <- struct{}{}
+			indexing.Done()
+			indexing.Wait() // both index tasks must run at the same time.
+			return nil
+		})), tasks.TaskPriorityDefault)
+	}
+
+	// Assert
+	select {
+	case <-indexStarted:
+		t.Fatalf("expected index tasks to wait for the prune of their repo")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(releasePrune)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-indexStarted:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected index tasks to run alongside each other once the prune finished")
+		}
+	}
+}
+
+func TestMaxConcurrentTasks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orch := newTestOrchestrator(t)
+	orch.maxConcurrentTasks = 2
+
+	var mu sync.Mutex
+	running := 0
+	maxRunning := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		ran := false
+		task := newTestRepoTask("backup", fmt.Sprintf("repo%d", i), func() error {
+			defer wg.Done()
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+		task.onNext = func(t time.Time) *time.Time {
+			if ran {
+				return nil
+			}
+			ran = true
+			return &t
+		}
+		orch.ScheduleTask(task, tasks.TaskPriorityDefault)
+	}
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		orch.Run(ctx)
+		close(done)
+	}()
+	wg.Wait()
+	cancel()
+	<-done
+
+	// Assert
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 tasks running at a time, got %d", maxRunning)
+	}
+}
@@ -105,7 +105,7 @@ func (t *taskRunnerImpl) ExecuteHooks(ctx context.Context, events []v1.Hook_Cond
 		}
 	}
 
-	hookTasks, err := hook.TasksTriggeredByEvent(t.Config(), repoID, planID, t.op, events, vars)
+	hookTasks, err := hook.TasksTriggeredByEvent(t.Config(), t.orchestrator.kvStore, repoID, planID, t.op, events, vars)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,127 @@
+package tasks
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// BackupHookResultEnv is the environment variable that holds the path of the file CONDITION_SNAPSHOT_START command
+// hooks may write a result to, see BackupHookResult.
+const BackupHookResultEnv = "BACKREST_RESULT_FILE"
+
+type backupHookResultKey struct{}
+
+// BackupHookResult collects the changes CONDITION_SNAPSHOT_START command hooks request to the backup that triggered
+// them. Hooks write KEY=VALUE lines to the file named by BackupHookResultEnv, each hook's result is merged in order.
+//
+//	PATH=<path>          back up an additional path, may be repeated.
+//	EXCLUDE=<pattern>    exclude an additional glob pattern, may be repeated.
+//	TAG=<tag>            add a tag to the snapshot, may be repeated.
+//	SKIP=<bool>          skip the backup, it finishes with CONDITION_SNAPSHOT_SKIPPED.
+//	SKIP_REASON=<text>   reason shown for a skipped backup.
+type BackupHookResult struct {
+	Paths      []string
+	Excludes   []string
+	Tags       []string
+	Skip       bool
+	SkipReason string
+}
+
+// ContextWithBackupHookResult returns a context that collects the results of the hooks run with it into result.
+func ContextWithBackupHookResult(ctx context.Context, result *BackupHookResult) context.Context {
+	return context.WithValue(ctx, backupHookResultKey{}, result)
+}
+
+// BackupHookResultFromContext returns the result collector for the hooks run with the context, or nil if the hooks
+// can't modify a backup.
+func BackupHookResultFromContext(ctx context.Context) *BackupHookResult {
+	result, _ := ctx.Value(backupHookResultKey{}).(*BackupHookResult)
+	return result
+}
+
+// Parse merges the KEY=VALUE lines written by a hook into the result. Empty lines and lines starting with # are
+// ignored.
+func (r *BackupHookResult) Parse(reader io.Reader) error {
+	var parsed BackupHookResult
+	scanner := bufio.NewScanner(reader)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: expected KEY=VALUE, got %q", lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch key {
+		case "PATH":
+			if value == "" {
+				return fmt.Errorf("line %d: path must not be empty", lineNo)
+			}
+			parsed.Paths = append(parsed.Paths, value)
+		case "EXCLUDE":
+			if value == "" {
+				return fmt.Errorf("line %d: exclude must not be empty", lineNo)
+			}
+			parsed.Excludes = append(parsed.Excludes, value)
+		case "TAG":
+			if value == "" || strings.ContainsAny(value, ", \t\"'") {
+				return fmt.Errorf("line %d: tag %q must be non-empty and can't contain commas, quotes or whitespace", lineNo, value)
+			}
+			parsed.Tags = append(parsed.Tags, value)
+		case "SKIP":
+			skip, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("line %d: skip must be true or false, got %q", lineNo, value)
+			}
+			parsed.Skip = skip
+		case "SKIP_REASON":
+			parsed.SkipReason = value
+		default:
+			return fmt.Errorf("line %d: unknown key %q", lineNo, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	r.Paths = append(r.Paths, parsed.Paths...)
+	r.Excludes = append(r.Excludes, parsed.Excludes...)
+	r.Tags = append(r.Tags, parsed.Tags...)
+	if parsed.Skip {
+		r.Skip = true
+		if r.SkipReason == "" {
+			r.SkipReason = parsed.SkipReason
+		}
+	}
+	return nil
+}
+
+// Empty returns true if the result doesn't change the backup.
+func (r *BackupHookResult) Empty() bool {
+	return len(r.Paths) == 0 && len(r.Excludes) == 0 && len(r.Tags) == 0 && !r.Skip
+}
+
+// Apply returns a copy of the plan with the result's paths, excludes and tags added.
+func (r *BackupHookResult) Apply(plan *v1.Plan) (*v1.Plan, error) {
+	if len(r.Paths) > 0 && plan.GetStdinCommand() != nil {
+		return nil, errors.New("can't add paths to a plan that backs up the output of a command")
+	}
+	plan = proto.Clone(plan).(*v1.Plan)
+	plan.Paths = append(plan.Paths, r.Paths...)
+	plan.Excludes = append(plan.Excludes, r.Excludes...)
+	for _, tag := range r.Tags {
+		plan.BackupFlags = append(plan.BackupFlags, "--tag "+tag)
+	}
+	return plan, nil
+}
@@ -0,0 +1,65 @@
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBackupHookResultParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []string // result files written by successive hooks.
+		want    BackupHookResult
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			outputs: []string{""},
+		},
+		{
+			name:    "paths, excludes and tags",
+			outputs: []string{"# mounted volumes\nPATH=/mnt/a\n\nPATH = /mnt/b\nEXCLUDE=*.tmp\nTAG=usb\n"},
+			want:    BackupHookResult{Paths: []string{"/mnt/a", "/mnt/b"}, Excludes: []string{"*.tmp"}, Tags: []string{"usb"}},
+		},
+		{
+			name:    "merges hooks in order",
+			outputs: []string{"PATH=/mnt/a\nSKIP=true\nSKIP_REASON=first", "PATH=/mnt/b\nSKIP=true\nSKIP_REASON=second"},
+			want:    BackupHookResult{Paths: []string{"/mnt/a", "/mnt/b"}, Skip: true, SkipReason: "first"},
+		},
+		{
+			name:    "skip false",
+			outputs: []string{"SKIP=false\nSKIP_REASON=ignored"},
+		},
+		{name: "missing value", outputs: []string{"PATH"}, wantErr: true},
+		{name: "empty path", outputs: []string{"PATH="}, wantErr: true},
+		{name: "tag with whitespace", outputs: []string{"TAG=a b"}, wantErr: true},
+		{name: "tag with comma", outputs: []string{"TAG=a,b"}, wantErr: true},
+		{name: "invalid skip", outputs: []string{"SKIP=maybe"}, wantErr: true},
+		{name: "unknown key", outputs: []string{"PATHS=/mnt/a"}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var result BackupHookResult
+			var err error
+			for _, output := range tc.outputs {
+				if err = result.Parse(strings.NewReader(output)); err != nil {
+					break
+				}
+			}
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tc.want) {
+				t.Errorf("result = %+v, want %+v", result, tc.want)
+			}
+		})
+	}
+}
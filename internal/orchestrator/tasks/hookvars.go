@@ -1,14 +1,13 @@
 package tasks
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"text/template"
 	"time"
 
 	"al.essio.dev/pkg/shellescape"
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
 	"github.com/garethgeorge/backrest/pkg/restic"
 )
 
@@ -25,6 +24,15 @@ type HookVars struct {
 	CurTime       time.Time                   // the current time as time.Time
 	Duration      time.Duration               // the duration of the operation that triggered the hook.
 	Error         string                      // the error that caused the hook to run as a string.
+	Digest        *DigestReport               // the summary report for a digest.
+	RestorePath   string                      // the path in the snapshot that is restored.
+	RestoreTarget string                      // the directory the path is restored to.
+	RestoreStats  *v1.RestoreProgressEntry    // the progress of the restore, its summary once the restore completed.
+
+	ForgottenSnapshots []*v1.ResticSnapshot // the snapshots removed by a forget operation.
+	PruneStats         *restic.PruneSummary // the summary of a prune operation, nil if it couldn't be parsed.
+	CheckErrors        string               // an excerpt of the errors found by a failed check operation.
+	RepoStats          *v1.RepoStats        // the repo's latest stats for forget, prune and check hooks, nil if none were collected yet.
 }
 
 func (v HookVars) EventName(cond v1.Hook_Condition) string {
@@ -61,6 +69,30 @@ func (v HookVars) EventName(cond v1.Hook_Condition) string {
 		return "forget error"
 	case v1.Hook_CONDITION_FORGET_SUCCESS:
 		return "forget success"
+	case v1.Hook_CONDITION_COPY_START:
+		return "copy start"
+	case v1.Hook_CONDITION_COPY_ERROR:
+		return "copy error"
+	case v1.Hook_CONDITION_COPY_SUCCESS:
+		return "copy success"
+	case v1.Hook_CONDITION_RESTORE_TEST_START:
+		return "restore test start"
+	case v1.Hook_CONDITION_RESTORE_TEST_ERROR:
+		return "restore test error"
+	case v1.Hook_CONDITION_RESTORE_TEST_SUCCESS:
+		return "restore test success"
+	case v1.Hook_CONDITION_BACKUP_OVERDUE:
+		return "backup overdue"
+	case v1.Hook_CONDITION_BACKUP_OVERDUE_RECOVERED:
+		return "backup overdue recovered"
+	case v1.Hook_CONDITION_DIGEST:
+		return "digest"
+	case v1.Hook_CONDITION_RESTORE_START:
+		return "restore start"
+	case v1.Hook_CONDITION_RESTORE_ERROR:
+		return "restore error"
+	case v1.Hook_CONDITION_RESTORE_SUCCESS:
+		return "restore success"
 	default:
 		return "unknown"
 	}
@@ -120,23 +152,23 @@ func (v HookVars) Summary() (string, error) {
 		return v.renderTemplate(templateForSnapshotStart)
 	case v1.Hook_CONDITION_SNAPSHOT_END, v1.Hook_CONDITION_SNAPSHOT_WARNING, v1.Hook_CONDITION_SNAPSHOT_SUCCESS:
 		return v.renderTemplate(templateForSnapshotEnd)
+	case v1.Hook_CONDITION_DIGEST:
+		return v.renderTemplate(templateForDigest)
+	case v1.Hook_CONDITION_RESTORE_START, v1.Hook_CONDITION_RESTORE_ERROR, v1.Hook_CONDITION_RESTORE_SUCCESS:
+		return v.renderTemplate(templateForRestore)
+	case v1.Hook_CONDITION_FORGET_ERROR, v1.Hook_CONDITION_FORGET_SUCCESS:
+		return v.renderTemplate(templateForForget)
+	case v1.Hook_CONDITION_PRUNE_ERROR, v1.Hook_CONDITION_PRUNE_SUCCESS:
+		return v.renderTemplate(templateForPrune)
+	case v1.Hook_CONDITION_CHECK_ERROR, v1.Hook_CONDITION_CHECK_SUCCESS:
+		return v.renderTemplate(templateForCheck)
 	default:
 		return v.renderTemplate(templateDefault)
 	}
 }
 
 func (v HookVars) renderTemplate(templ string) (string, error) {
-	t, err := template.New("t").Parse(templ)
-	if err != nil {
-		return "", err
-	}
-
-	var buf bytes.Buffer
-	err = t.Execute(&buf, v)
-	if err != nil {
-		return "", err
-	}
-	return buf.String(), nil
+	return hookutil.RenderTemplate(templ, v)
 }
 
 var templateDefault = `
@@ -191,3 +223,84 @@ Paths:
 {{ range .Plan.Paths -}}
  - {{ . }}
 {{ end }}`
+
+var templateForDigest = `
+Backrest Digest for {{ .Digest.Instance }}
+Period: {{ .FormatTime .Digest.PeriodStart }} to {{ .FormatTime .CurTime }}
+{{ if .Digest.OverduePlans -}}
+Overdue plans: {{ range $i, $id := .Digest.OverduePlans }}{{ if $i }}, {{ end }}{{ $id }}{{ end }}
+{{ end }}
+Plans:
+{{ range .Digest.Plans -}}
+- {{ .PlanID }} (repo {{ .RepoID }}){{ if .Overdue }} OVERDUE{{ end }}
+  Last backup: {{ if .LastBackupTime.IsZero }}never{{ else }}{{ .LastBackupStatus }} at {{ $.FormatTime .LastBackupTime }}{{ end }}
+  Backups: {{ .BackupsSuccess }} succeeded, {{ .BackupsWarning }} warned, {{ .BackupsFailed }} failed
+  Data added: {{ $.FormatSizeBytes .BytesAdded }}
+{{ end }}
+Repos:
+{{ range .Digest.Repos -}}
+- {{ .RepoID }}: {{ if .StatsTime.IsZero }}no stats yet{{ else }}{{ $.FormatSizeBytes .TotalSize }} in {{ .SnapshotCount }} snapshots as of {{ $.FormatTime .StatsTime }}{{ end }}
+  Data added: {{ $.FormatSizeBytes .BytesAdded }}
+{{ end }}`
+
+var templateForRestore = `
+Backrest Restore Notification
+Task: {{ .Task }} at {{ .FormatTime .CurTime }}
+Event: {{ .EventName .Event }}
+Snapshot: {{ .SnapshotId }}
+Path: {{ .RestorePath }}
+Target: {{ .RestoreTarget }}
+{{ if .Error -}}
+Error: {{ .Error }}
+{{ else if .RestoreStats -}}
+Files restored: {{ .RestoreStats.FilesRestored }} of {{ .RestoreStats.TotalFiles }}
+Bytes restored: {{ .FormatSizeBytes .RestoreStats.BytesRestored }} of {{ .FormatSizeBytes .RestoreStats.TotalBytes }}
+{{ end }}`
+
+var templateRepoStats = `{{ if .RepoStats }}
+Repo stats:
+- Total size: {{ .FormatSizeBytes .RepoStats.TotalSize }}
+- Snapshots: {{ .RepoStats.SnapshotCount }}
+{{ end }}`
+
+var templateForForget = `
+Backrest Forget Notification
+Task: {{ .Task }} at {{ .FormatTime .CurTime }}
+Event: {{ .EventName .Event }}
+Repo: {{ .Repo.Id }}
+{{ if .Error -}}
+Error: {{ .Error }}
+{{ else -}}
+Forgot {{ len .ForgottenSnapshots }} snapshots
+{{ range .ForgottenSnapshots -}}
+- {{ shortId .Id }} from {{ formatUnixMs .UnixTimeMs }}
+{{ end }}
+{{- end }}` + templateRepoStats
+
+var templateForPrune = `
+Backrest Prune Notification
+Task: {{ .Task }} at {{ .FormatTime .CurTime }}
+Event: {{ .EventName .Event }}
+Repo: {{ .Repo.Id }}
+{{ if .Error -}}
+Error: {{ .Error }}
+{{ else if .PruneStats -}}
+Freed: {{ .FormatSizeBytes .PruneStats.TotalBytesPruned }} in {{ .PruneStats.TotalBlobsPruned }} blobs
+Repacked: {{ .FormatSizeBytes .PruneStats.BytesToRepack }} in {{ .PruneStats.BlobsToRepack }} blobs
+Remaining: {{ .FormatSizeBytes .PruneStats.RemainingBytes }} in {{ .PruneStats.RemainingBlobs }} blobs, {{ .FormatSizeBytes .PruneStats.UnusedBytesAfterPrune }} unused
+{{ end }}` + templateRepoStats
+
+var templateForCheck = `
+Backrest Check Notification
+Task: {{ .Task }} at {{ .FormatTime .CurTime }}
+Event: {{ .EventName .Event }}
+Repo: {{ .Repo.Id }}
+{{ if .Error -}}
+Error: {{ .Error }}
+{{ if .CheckErrors -}}
+Check errors:
+{{ indent 2 .CheckErrors }}
+{{ end }}
+{{- else -}}
+No errors found
+{{ end }}` + templateRepoStats
@@ -1,9 +1,11 @@
 package tasks
 
 import (
+	"strings"
 	"testing"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
 )
 
 func TestHookVarsEventName(t *testing.T) {
@@ -21,3 +23,62 @@ func TestHookVarsEventName(t *testing.T) {
 		}
 	}
 }
+
+func TestHookVarsSummary(t *testing.T) {
+	repoStats := &v1.RepoStats{TotalSize: 2000, SnapshotCount: 7}
+	tests := []struct {
+		name string
+		vars HookVars
+		want []string
+	}{
+		{
+			// snapshot times are formatted in local time, rendering them would load time.Local before TestScheduling
+			// sets TZ. formatUnixMs is covered by the hookutil tests.
+			name: "forget",
+			vars: HookVars{
+				Event:     v1.Hook_CONDITION_FORGET_SUCCESS,
+				Repo:      &v1.Repo{Id: "repo1"},
+				RepoStats: repoStats,
+			},
+			want: []string{"Forgot 0 snapshots", "Total size: 2.000 KB", "Snapshots: 7"},
+		},
+		{
+			name: "prune",
+			vars: HookVars{
+				Event: v1.Hook_CONDITION_PRUNE_SUCCESS,
+				Repo:  &v1.Repo{Id: "repo1"},
+				PruneStats: &restic.PruneSummary{
+					TotalBlobsPruned:      20,
+					TotalBytesPruned:      2000000,
+					RemainingBlobs:        100,
+					RemainingBytes:        4000,
+					UnusedBytesAfterPrune: 2000,
+				},
+			},
+			want: []string{"Freed: 2.000 MB in 20 blobs", "Remaining: 4.000 KB in 100 blobs, 2.000 KB unused"},
+		},
+		{
+			name: "check error",
+			vars: HookVars{
+				Event:       v1.Hook_CONDITION_CHECK_ERROR,
+				Repo:        &v1.Repo{Id: "repo1"},
+				Error:       "check failed",
+				CheckErrors: "error: pack a\nerror: pack b",
+			},
+			want: []string{"Error: check failed", "Check errors:\n  error: pack a\n  error: pack b"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			summary, err := tc.vars.Summary()
+			if err != nil {
+				t.Fatalf("Summary() error: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(summary, want) {
+					t.Errorf("Summary() = %q, want it to contain %q", summary, want)
+				}
+			}
+		})
+	}
+}
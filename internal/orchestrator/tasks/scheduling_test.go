@@ -45,6 +45,14 @@ func TestScheduling(t *testing.T) {
 						},
 					},
 				},
+				CopyPolicy: &v1.CopyPolicy{
+					Schedule: &v1.Schedule{
+						Schedule: &v1.Schedule_MaxFrequencyHours{
+							MaxFrequencyHours: 1,
+						},
+					},
+					TargetRepo: "repo1",
+				},
 			},
 			{
 				Id:   "repo-relative",
@@ -293,6 +301,29 @@ func TestScheduling(t *testing.T) {
 			},
 			wantTime: now.Add(time.Hour),
 		},
+		{
+			name: "copy schedule absolute",
+			task: NewCopyTask(repoAbsolute, "_system_", false),
+			ops: []*v1.Operation{
+				{
+					InstanceId: "instance1",
+					RepoId:     "repo-absolute",
+					RepoGuid:   repoAbsolute.Guid,
+					PlanId:     "_system_",
+					Op: &v1.Operation_OperationCopy{
+						OperationCopy: &v1.OperationCopy{},
+					},
+					UnixTimeStartMs: 1000,
+					UnixTimeEndMs:   farFuture.UnixMilli(),
+				},
+			},
+			wantTime: now.Add(time.Hour),
+		},
+		{
+			name:     "copy schedule not configured",
+			task:     NewCopyTask(cfg.Repos[0], "_system_", false),
+			wantTime: time.Time{},
+		},
 		{
 			name: "check schedule relative no backup yet",
 			task: NewCheckTask(repoRelative, "_system_", false),
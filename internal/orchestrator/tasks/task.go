@@ -21,10 +21,15 @@ type RepoOrchestrator interface {
 	ForgetSnapshot(ctx context.Context, snapshotId string) error
 	Prune(ctx context.Context, output io.Writer) error
 	Check(ctx context.Context, output io.Writer) error
+	CopyFrom(ctx context.Context, source *v1.Repo, output io.Writer) error
 	Stats(ctx context.Context) (*v1.RepoStats, error)
-	Restore(ctx context.Context, snapshotId string, snapshotPath string, target string, progressCallback func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error)
+	Restore(ctx context.Context, snapshotId string, snapshotPath string, target string, options *v1.RestoreOptions, progressCallback func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error)
 	Snapshots(ctx context.Context) ([]*restic.Snapshot, error)
+	ListFiles(ctx context.Context, snapshotId string, path string, callback func(entry *v1.LsEntry)) error
+	Dump(ctx context.Context, snapshotId string, snapshotPath string, output io.Writer) error
+	FindFiles(ctx context.Context, query *v1.FindFilesQuery, callback func(match *v1.FileMatch)) error
 	AddTags(ctx context.Context, snapshotIDs []string, tags []string) error
+	RemoveTags(ctx context.Context, snapshotIDs []string, tags []string) error
 	RunCommand(ctx context.Context, command string, writer io.Writer) error
 }
 
@@ -109,6 +114,42 @@ type Task interface {
 	Repo() *v1.Repo                                                     // the repo this task is associated with.
 }
 
+// repoMutatingTaskTypes are the types of tasks that modify (or exclusively lock) their repo.
+var repoMutatingTaskTypes = map[string]bool{
+	"backup":           true,
+	"forget":           true,
+	"scheduled_forget": true,
+	"forget_snapshot":  true,
+	"prune":            true,
+	"check":            true,
+	"tag_snapshot":     true,
+}
+
+// RepoClaim is a repo a task runs against. A task that claims a repo exclusively runs only while no other task runs
+// against the repo, shared claims of a repo run alongside each other.
+type RepoClaim struct {
+	RepoID    string
+	Exclusive bool
+}
+
+// RepoClaims returns the repos the task runs against. A task claims its repo exclusively if it modifies it, e.g. forget
+// and prune take restic's exclusive lock which fails while any other restic command holds a lock on the repo.
+func RepoClaims(t Task) []RepoClaim {
+	if t.RepoID() == "" {
+		return nil
+	}
+	claims := []RepoClaim{{RepoID: t.RepoID(), Exclusive: repoMutatingTaskTypes[t.Type()]}}
+	if c, ok := t.(otherRepoClaimer); ok {
+		claims = append(claims, c.otherRepoClaims()...)
+	}
+	return claims
+}
+
+// otherRepoClaimer is implemented by tasks that also run against repos other than their own.
+type otherRepoClaimer interface {
+	otherRepoClaims() []RepoClaim
+}
+
 type BaseTask struct {
 	TaskType   string
 	TaskName   string
@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoClaims(t *testing.T) {
+	repo := &v1.Repo{
+		Id:         "source",
+		CopyPolicy: &v1.CopyPolicy{TargetRepo: "target"},
+	}
+
+	tcs := []struct {
+		name string
+		task Task
+		want []RepoClaim
+	}{
+		{
+			name: "index shares its repo",
+			task: NewOneoffIndexSnapshotsTask(repo, time.Now()),
+			want: []RepoClaim{{RepoID: "source"}},
+		},
+		{
+			name: "prune claims its repo exclusively",
+			task: NewPruneTask(repo, "plan", false),
+			want: []RepoClaim{{RepoID: "source", Exclusive: true}},
+		},
+		{
+			name: "copy reads the source and claims the target exclusively",
+			task: NewCopyTask(repo, "plan", false),
+			want: []RepoClaim{{RepoID: "source"}, {RepoID: "target", Exclusive: true}},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, RepoClaims(tc.task))
+		})
+	}
+}
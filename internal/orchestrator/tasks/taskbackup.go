@@ -145,12 +145,37 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 		return notifyError(err)
 	}
 
-	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+	// start hooks may add paths, excludes and tags to the backup, or skip it.
+	hookResult := &BackupHookResult{}
+	if err := runner.ExecuteHooks(ContextWithBackupHookResult(ctx, hookResult), []v1.Hook_Condition{
 		v1.Hook_CONDITION_SNAPSHOT_START,
 	}, HookVars{}); err != nil {
 		return notifyError(fmt.Errorf("snapshot start hook: %w", err))
 	}
 
+	if hookResult.Skip {
+		l.Info("backup skipped by snapshot start hook", zap.String("plan", plan.Id), zap.String("reason", hookResult.SkipReason))
+		op.DisplayMessage = "Backup skipped by a snapshot start hook."
+		if hookResult.SkipReason != "" {
+			op.DisplayMessage = fmt.Sprintf("Backup skipped by a snapshot start hook: %v", hookResult.SkipReason)
+		}
+		if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_SNAPSHOT_SKIPPED,
+			v1.Hook_CONDITION_SNAPSHOT_END,
+		}, HookVars{Task: t.Name()}); err != nil {
+			return fmt.Errorf("snapshot end hook: %w", err)
+		}
+		return nil
+	}
+
+	if !hookResult.Empty() {
+		l.Info("snapshot start hooks modified the backup", zap.Strings("paths", hookResult.Paths), zap.Strings("excludes", hookResult.Excludes), zap.Strings("tags", hookResult.Tags))
+		plan, err = hookResult.Apply(plan)
+		if err != nil {
+			return notifyError(fmt.Errorf("snapshot start hook result: %w", err))
+		}
+	}
+
 	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
 		return notifyError(fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err))
 	}
@@ -0,0 +1,144 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+)
+
+const (
+	backupOverdueStartupDelay  = 1 * time.Minute
+	backupOverdueCheckInterval = 30 * time.Minute
+)
+
+// BackupOverdueTask periodically checks a plan's last successful backup against its schedule. It fires
+// CONDITION_BACKUP_OVERDUE once per overdue episode and CONDITION_BACKUP_OVERDUE_RECOVERED when backups resume.
+// The episode that was alerted on is persisted in the kvstore so that restarts and config changes don't repeat it.
+type BackupOverdueTask struct {
+	BaseTask
+	state    kvstore.KvStore
+	firstRun bool
+}
+
+func NewBackupOverdueTask(repo *v1.Repo, planID string, state kvstore.KvStore) *BackupOverdueTask {
+	return &BackupOverdueTask{
+		BaseTask: BaseTask{
+			TaskType:   "backup_overdue",
+			TaskName:   fmt.Sprintf("backup overdue check for plan %q", planID),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+		state: state,
+	}
+}
+
+var _ Task = &BackupOverdueTask{}
+
+func (t *BackupOverdueTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	if !t.firstRun {
+		t.firstRun = true
+		return ScheduledTask{
+			RunAt: now.Add(backupOverdueStartupDelay),
+		}, nil
+	}
+
+	return ScheduledTask{
+		RunAt: now.Add(backupOverdueCheckInterval),
+	}, nil
+}
+
+func (t *BackupOverdueTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	plan, err := runner.GetPlan(t.PlanID())
+	if err != nil {
+		return fmt.Errorf("get plan %q: %w", t.PlanID(), err)
+	}
+
+	key := "backup_overdue/" + t.PlanID()
+	alertedEpisode, err := t.state.Get(key)
+	if err != nil && !errors.Is(err, kvstore.ErrNotExist) {
+		return fmt.Errorf("get overdue state: %w", err)
+	}
+
+	now := time.Now()
+	staleness, err := protoutil.AllowedStaleness(plan, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		// there is no expectation for when the plan backs up, any prior episode is dropped without notifying.
+		if len(alertedEpisode) != 0 {
+			return t.state.Set(key, nil)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("resolve allowed staleness: %w", err)
+	}
+
+	lastBackup, err := lastBackupForOverdueCheck(runner, t.Repo(), t.PlanID())
+	if err != nil {
+		return err
+	}
+	if lastBackup.IsZero() {
+		return nil // the plan has never attempted a backup.
+	}
+
+	// An episode is identified by the backup it is measured from, a new successful backup starts a new episode.
+	episode := []byte(strconv.FormatInt(lastBackup.UnixMilli(), 10))
+	overdue := now.Sub(lastBackup) > staleness
+
+	if overdue && string(alertedEpisode) != string(episode) {
+		if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_BACKUP_OVERDUE,
+		}, HookVars{
+			Error: fmt.Sprintf("no successful backup since %v, expected at least every %v", lastBackup.Format(time.RFC3339), staleness.Truncate(time.Minute)),
+		}); err != nil {
+			return fmt.Errorf("execute backup overdue hooks: %w", err)
+		}
+		return t.state.Set(key, episode)
+	} else if !overdue && len(alertedEpisode) != 0 {
+		if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_BACKUP_OVERDUE_RECOVERED,
+		}, HookVars{}); err != nil {
+			return fmt.Errorf("execute backup overdue recovered hooks: %w", err)
+		}
+		return t.state.Set(key, nil)
+	}
+	return nil
+}
+
+// lastBackupForOverdueCheck returns the start time of the plan's most recent successful backup. If the plan has no
+// successful backups the start time of its oldest backup attempt is returned instead, a zero time if there are none.
+func lastBackupForOverdueCheck(runner TaskRunner, repo *v1.Repo, planID string) (time.Time, error) {
+	var oldestAttempt time.Time
+	var lastSuccess time.Time
+	if err := runner.QueryOperations(oplog.Query{}.
+		SetInstanceID(runner.InstanceID()).
+		SetRepoGUID(repo.GetGuid()).
+		SetPlanID(planID).
+		SetReversed(true), func(op *v1.Operation) error {
+		backupOp := op.GetOperationBackup()
+		if backupOp == nil || backupOp.DryRun || op.UnixTimeStartMs == 0 {
+			return nil
+		}
+		startTime := time.UnixMilli(op.UnixTimeStartMs)
+		switch op.Status {
+		case v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_WARNING:
+			lastSuccess = startTime
+			return oplog.ErrStopIteration
+		case v1.OperationStatus_STATUS_PENDING, v1.OperationStatus_STATUS_INPROGRESS:
+			return nil
+		}
+		oldestAttempt = startTime
+		return nil
+	}); err != nil {
+		return time.Time{}, fmt.Errorf("finding last successful backup: %w", err)
+	}
+	if !lastSuccess.IsZero() {
+		return lastSuccess, nil
+	}
+	return oldestAttempt, nil
+}
@@ -4,11 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/ioutil"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
+	"go.uber.org/zap"
+)
+
+const (
+	checkOutputTailBytes   = 64 * 1024 // bytes of check output kept to find the errors reported to hooks.
+	checkErrorExcerptLines = 20
 )
 
 type CheckTask struct {
@@ -131,13 +141,26 @@ func (t *CheckTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 		return fmt.Errorf("update operation: %w", err)
 	}
 
-	err = repo.Check(ctx, writer)
+	repoStats, e := latestRepoStats(runner, t.Repo().GetGuid())
+	if e != nil {
+		runner.Logger(ctx).Error("find repo stats for check hooks", zap.Error(e))
+	}
+
+	tail := &ioutil.TailWriter{N: checkOutputTailBytes}
+	err = repo.Check(ctx, io.MultiWriter(writer, tail))
 	if err != nil {
+		output := string(tail.Bytes())
+		var errWithOutput *restic.ErrorWithOutput
+		if errors.As(err, &errWithOutput) {
+			output = errWithOutput.Output
+		}
 		runner.ExecuteHooks(ctx, []v1.Hook_Condition{
 			v1.Hook_CONDITION_CHECK_ERROR,
 			v1.Hook_CONDITION_ANY_ERROR,
 		}, HookVars{
-			Error: err.Error(),
+			Error:       err.Error(),
+			CheckErrors: checkErrorExcerpt(output),
+			RepoStats:   repoStats,
 		})
 
 		return fmt.Errorf("check: %w", err)
@@ -149,9 +172,33 @@ func (t *CheckTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 
 	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
 		v1.Hook_CONDITION_CHECK_SUCCESS,
-	}, HookVars{}); err != nil {
+	}, HookVars{
+		RepoStats: repoStats,
+	}); err != nil {
 		return fmt.Errorf("execute check success hooks: %w", err)
 	}
 
 	return nil
 }
+
+// checkErrorExcerpt returns the lines of check output that report errors, or the last lines of the output if none
+// do. At most checkErrorExcerptLines lines are returned.
+func checkErrorExcerpt(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var errLines []string
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "error") || strings.Contains(lower, "fatal") {
+			errLines = append(errLines, line)
+		}
+	}
+	if len(errLines) == 0 {
+		errLines = lines
+		if len(errLines) > checkErrorExcerptLines {
+			errLines = errLines[len(errLines)-checkErrorExcerptLines:]
+		}
+	} else if len(errLines) > checkErrorExcerptLines {
+		errLines = append(errLines[:checkErrorExcerptLines], fmt.Sprintf("... %d more errors", len(errLines)-checkErrorExcerptLines))
+	}
+	return strings.Join(errLines, "\n")
+}
@@ -48,6 +48,21 @@ var gcSettings = map[reflect.Type]gcSettingsForType{
 		keepMin: 1,
 		keepMax: 12,
 	},
+	reflect.TypeOf(&v1.Operation_OperationCopy{}): {
+		maxAge:  365 * 24 * time.Hour,
+		keepMin: 1,
+		keepMax: 12,
+	},
+	reflect.TypeOf(&v1.Operation_OperationRestoreTest{}): {
+		maxAge:  365 * 24 * time.Hour,
+		keepMin: 1,
+		keepMax: 12,
+	},
+	reflect.TypeOf(&v1.Operation_OperationFindFiles{}): {
+		maxAge:  7 * 24 * time.Hour,
+		keepMin: 0,
+		keepMax: 20,
+	},
 	reflect.TypeOf(&v1.Operation_OperationForget{}): {
 		maxAge:  30 * 24 * time.Hour,
 		keepMin: 1,
@@ -0,0 +1,189 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"go.uber.org/zap"
+)
+
+// CopyTask replicates the snapshots in a repo to the target repo of its copy policy using `restic copy`.
+type CopyTask struct {
+	BaseTask
+	force  bool
+	didRun bool
+}
+
+func NewCopyTask(repo *v1.Repo, planID string, force bool) Task {
+	return &CopyTask{
+		BaseTask: BaseTask{
+			TaskType:   "copy",
+			TaskName:   fmt.Sprintf("copy snapshots for repo %q", repo.Id),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+		force: force,
+	}
+}
+
+// otherRepoClaims claims the copy target exclusively, the copy adds snapshots to it. The source repo is only read.
+func (t *CopyTask) otherRepoClaims() []RepoClaim {
+	if targetID := t.Repo().GetCopyPolicy().GetTargetRepo(); targetID != "" {
+		return []RepoClaim{{RepoID: targetID, Exclusive: true}}
+	}
+	return nil
+}
+
+func (t *CopyTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	if t.force {
+		if t.didRun {
+			return NeverScheduledTask, nil
+		}
+		t.didRun = true
+		return ScheduledTask{
+			RunAt: now,
+			Op: &v1.Operation{
+				Op: &v1.Operation_OperationCopy{},
+			},
+		}, nil
+	}
+
+	repo, err := runner.GetRepo(t.RepoID())
+	if err != nil {
+		return ScheduledTask{}, fmt.Errorf("get repo %v: %w", t.RepoID(), err)
+	}
+
+	if repo.GetCopyPolicy().GetSchedule() == nil || repo.GetCopyPolicy().GetTargetRepo() == "" {
+		return NeverScheduledTask, nil
+	}
+
+	var lastRan time.Time
+	var foundBackup bool
+	if err := runner.QueryOperations(oplog.Query{}.
+		SetInstanceID(runner.InstanceID()). // note: this means that copy tasks run by remote instances are ignored.
+		SetRepoGUID(repo.GetGuid()).
+		SetReversed(true), func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_SYSTEM_CANCELLED {
+			return nil
+		}
+		if _, ok := op.Op.(*v1.Operation_OperationCopy); ok && op.UnixTimeEndMs != 0 {
+			lastRan = time.Unix(0, op.UnixTimeEndMs*int64(time.Millisecond))
+			return oplog.ErrStopIteration
+		}
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); ok {
+			foundBackup = true
+		}
+		return nil
+	}); err != nil {
+		return NeverScheduledTask, fmt.Errorf("finding last copy run time: %w", err)
+	} else if !foundBackup {
+		lastRan = now
+	}
+
+	runAt, err := protoutil.ResolveSchedule(repo.GetCopyPolicy().GetSchedule(), lastRan, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		return NeverScheduledTask, nil
+	} else if err != nil {
+		return NeverScheduledTask, fmt.Errorf("resolve schedule: %w", err)
+	}
+
+	return ScheduledTask{
+		RunAt: runAt,
+		Op: &v1.Operation{
+			Op: &v1.Operation_OperationCopy{},
+		},
+	}, nil
+}
+
+func (t *CopyTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	op := st.Op
+
+	// Helper to notify of errors during the setup phase
+	notifyError := func(err error) error {
+		return NotifyError(ctx, runner, t.Name(), err, v1.Hook_CONDITION_COPY_ERROR)
+	}
+
+	repo, err := runner.GetRepo(t.RepoID())
+	if err != nil {
+		return notifyError(fmt.Errorf("get repo %q: %w", t.RepoID(), err))
+	}
+
+	targetID := repo.GetCopyPolicy().GetTargetRepo()
+	if targetID == "" {
+		return notifyError(fmt.Errorf("repo %q has no copy target configured", t.RepoID()))
+	}
+
+	targetRepo, err := runner.GetRepo(targetID)
+	if err != nil {
+		return notifyError(fmt.Errorf("get copy target repo %q: %w", targetID, err))
+	}
+
+	target, err := runner.GetRepoOrchestrator(targetID)
+	if err != nil {
+		return notifyError(fmt.Errorf("couldn't get repo %q: %w", targetID, err))
+	}
+
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_COPY_START,
+	}, HookVars{}); err != nil {
+		return notifyError(fmt.Errorf("copy start hook: %w", err))
+	}
+
+	// restic copy writes to the target, clear stale locks there. Both repos are only locked non-exclusively.
+	if err := target.UnlockIfAutoEnabled(ctx); err != nil {
+		return notifyError(fmt.Errorf("auto unlock repo %q: %w", targetID, err))
+	}
+
+	opCopy := &v1.Operation_OperationCopy{
+		OperationCopy: &v1.OperationCopy{
+			TargetRepoId:   targetRepo.GetId(),
+			TargetRepoGuid: targetRepo.GetGuid(),
+		},
+	}
+	op.Op = opCopy
+
+	liveID, writer, err := runner.LogrefWriter()
+	if err != nil {
+		return fmt.Errorf("create logref writer: %w", err)
+	}
+	defer writer.Close()
+	opCopy.OperationCopy.OutputLogref = liveID
+
+	if err := runner.UpdateOperation(op); err != nil {
+		return fmt.Errorf("update operation: %w", err)
+	}
+
+	err = target.CopyFrom(ctx, repo, writer)
+	if err != nil {
+		runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_COPY_ERROR,
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, HookVars{
+			Error: err.Error(),
+		})
+
+		return fmt.Errorf("copy: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close logref writer: %w", err)
+	}
+
+	// Index the snapshots that were copied into the target repo.
+	if err := runner.ScheduleTask(NewOneoffIndexSnapshotsTask(targetRepo, time.Now()), TaskPriorityIndexSnapshots); err != nil {
+		zap.L().Error("schedule index snapshots task", zap.Error(err))
+	}
+
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_COPY_SUCCESS,
+	}, HookVars{}); err != nil {
+		return fmt.Errorf("execute copy success hooks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/internal/summary"
+)
+
+const digestLastRunKey = "digest/last_run"
+
+// DigestReport summarizes the backups of every plan and repo over a digest's period, it is available to digest hooks
+// as .Digest. Backups are counted, and their bytes added summed, by their start time within the period.
+type DigestReport struct {
+	Instance     string
+	PeriodStart  time.Time
+	Plans        []DigestPlanSummary
+	Repos        []DigestRepoSummary
+	OverduePlans []string // IDs of the plans that are currently overdue.
+}
+
+type DigestPlanSummary struct {
+	PlanID           string
+	RepoID           string
+	LastBackupTime   time.Time // zero if the plan has no backups in the last 30 days.
+	LastBackupStatus string    // e.g. "success", "warning" or "error".
+	BackupsSuccess   int64
+	BackupsWarning   int64
+	BackupsFailed    int64
+	BytesAdded       int64
+	Overdue          bool
+}
+
+type DigestRepoSummary struct {
+	RepoID        string
+	BytesAdded    int64
+	TotalSize     int64     // size of the repo from its latest stats operation.
+	SnapshotCount int64     // snapshot count from the repo's latest stats operation.
+	StatsTime     time.Time // time of the repo's latest stats operation, zero if stats have never been computed.
+}
+
+// DigestTask periodically sends a DigestReport to the hooks of the config's digest policy. The time of the last
+// digest is persisted in the kvstore, it starts the next digest's period.
+type DigestTask struct {
+	BaseTask
+	state kvstore.KvStore
+}
+
+func NewDigestTask(state kvstore.KvStore) *DigestTask {
+	return &DigestTask{
+		BaseTask: BaseTask{
+			TaskType: "digest",
+			TaskName: "digest report",
+		},
+		state: state,
+	}
+}
+
+var _ Task = &DigestTask{}
+
+func (t *DigestTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	schedule := runner.Config().GetDigest().GetSchedule()
+	if schedule == nil {
+		return NeverScheduledTask, nil
+	}
+
+	lastRan, err := t.lastRun()
+	if err != nil {
+		return NeverScheduledTask, err
+	} else if lastRan.IsZero() {
+		lastRan = now
+	}
+
+	runAt, err := protoutil.ResolveSchedule(schedule, lastRan, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		return NeverScheduledTask, nil
+	} else if err != nil {
+		return NeverScheduledTask, fmt.Errorf("resolve schedule: %w", err)
+	}
+
+	return ScheduledTask{
+		RunAt: runAt,
+	}, nil
+}
+
+func (t *DigestTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	cfg := runner.Config()
+	now := time.Now()
+
+	periodStart, err := t.lastRun()
+	if err != nil {
+		return err
+	} else if periodStart.IsZero() {
+		period, err := protoutil.NominalPeriod(cfg.GetDigest().GetSchedule(), now)
+		if err != nil {
+			period = 24 * time.Hour
+		}
+		periodStart = now.Add(-period)
+	}
+
+	report, err := buildDigestReport(cfg, runner, periodStart, now)
+	if err != nil {
+		return fmt.Errorf("build digest report: %w", err)
+	}
+
+	// the run is recorded even if hooks fail so that a failing hook doesn't immediately reschedule the digest.
+	hookErr := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_DIGEST,
+	}, HookVars{
+		Digest: report,
+	})
+	if err := t.state.Set(digestLastRunKey, []byte(strconv.FormatInt(now.UnixMilli(), 10))); err != nil {
+		return fmt.Errorf("record digest run: %w", err)
+	}
+	if hookErr != nil {
+		return fmt.Errorf("execute digest hooks: %w", hookErr)
+	}
+	return nil
+}
+
+func (t *DigestTask) lastRun() (time.Time, error) {
+	val, err := t.state.Get(digestLastRunKey)
+	if errors.Is(err, kvstore.ErrNotExist) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("get last digest run: %w", err)
+	}
+	ms, err := strconv.ParseInt(string(val), 10, 64)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return time.UnixMilli(ms), nil
+}
+
+// buildDigestReport renders the dashboard summaries for the period starting at periodStart into a DigestReport.
+func buildDigestReport(cfg *v1.Config, runner TaskRunner, periodStart time.Time, now time.Time) (*DigestReport, error) {
+	dashboard, err := summary.Dashboard(cfg, runner.QueryOperations, now)
+	if err != nil {
+		return nil, err
+	}
+
+	planTotals, repoTotals, err := digestPeriodTotals(cfg, runner, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DigestReport{
+		Instance:    cfg.Instance,
+		PeriodStart: periodStart,
+	}
+
+	planRepos := make(map[string]string)
+	for _, plan := range cfg.Plans {
+		planRepos[plan.Id] = plan.Repo
+	}
+	for _, s := range dashboard.PlanSummaries {
+		planSummary := DigestPlanSummary{
+			PlanID: s.Id,
+			RepoID: planRepos[s.Id],
+		}
+		if chart := s.GetRecentBackups(); len(chart.GetTimestampMs()) > 0 {
+			planSummary.LastBackupTime = time.UnixMilli(chart.TimestampMs[0])
+			planSummary.LastBackupStatus = strings.ToLower(strings.TrimPrefix(chart.Status[0].String(), "STATUS_"))
+		}
+		if totals := planTotals[s.Id]; totals != nil {
+			planSummary.BackupsSuccess, planSummary.BackupsWarning, planSummary.BackupsFailed, planSummary.BytesAdded = totals.success, totals.warning, totals.failed, totals.bytesAdded
+		}
+		if history := s.GetHistoryLast_30Days(); len(history) > 0 && history[len(history)-1].Overdue {
+			planSummary.Overdue = true
+			report.OverduePlans = append(report.OverduePlans, s.Id)
+		}
+		report.Plans = append(report.Plans, planSummary)
+	}
+
+	repoGUIDs := make(map[string]string)
+	for _, repo := range cfg.Repos {
+		repoGUIDs[repo.Id] = repo.GetGuid()
+	}
+	for _, s := range dashboard.RepoSummaries {
+		repoSummary := DigestRepoSummary{
+			RepoID: s.Id,
+		}
+		if totals := repoTotals[repoGUIDs[s.Id]]; totals != nil {
+			repoSummary.BytesAdded = totals.bytesAdded
+		}
+		statsOp, err := latestRepoStatsOperation(runner, repoGUIDs[s.Id])
+		if err != nil {
+			return nil, fmt.Errorf("repo %q: %w", s.Id, err)
+		}
+		if stats := statsOp.GetOperationStats().GetStats(); stats != nil {
+			repoSummary.TotalSize = stats.TotalSize
+			repoSummary.SnapshotCount = stats.SnapshotCount
+			repoSummary.StatsTime = time.UnixMilli(statsOp.UnixTimeEndMs)
+		}
+		report.Repos = append(report.Repos, repoSummary)
+	}
+
+	return report, nil
+}
+
+// digestTotals sums the backups of a plan or repo in the digest period.
+type digestTotals struct {
+	success, warning, failed, bytesAdded int64
+}
+
+// digestPeriodTotals sums the instance's backups that started at or after periodStart by plan ID and by repo GUID.
+func digestPeriodTotals(cfg *v1.Config, runner TaskRunner, periodStart time.Time) (plans map[string]*digestTotals, repos map[string]*digestTotals, err error) {
+	plans = make(map[string]*digestTotals)
+	repos = make(map[string]*digestTotals)
+	add := func(totals map[string]*digestTotals, key string, op *v1.Operation, backupOp *v1.OperationBackup) {
+		t := totals[key]
+		if t == nil {
+			t = &digestTotals{}
+			totals[key] = t
+		}
+		switch op.Status {
+		case v1.OperationStatus_STATUS_SUCCESS:
+			t.success++
+		case v1.OperationStatus_STATUS_WARNING:
+			t.warning++
+		case v1.OperationStatus_STATUS_ERROR:
+			t.failed++
+		}
+		t.bytesAdded += backupOp.GetLastStatus().GetSummary().GetDataAdded()
+	}
+
+	if err := runner.QueryOperations(oplog.Query{}.SetInstanceID(cfg.Instance), func(op *v1.Operation) error {
+		backupOp := op.GetOperationBackup()
+		if backupOp == nil || op.Status == v1.OperationStatus_STATUS_PENDING || op.UnixTimeStartMs < periodStart.UnixMilli() {
+			return nil
+		}
+		add(plans, op.PlanId, op, backupOp)
+		add(repos, op.RepoGuid, op, backupOp)
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("sum backups since %v: %w", periodStart, err)
+	}
+	return plans, repos, nil
+}
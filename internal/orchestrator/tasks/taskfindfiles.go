@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func NewOneoffFindFilesTask(repo *v1.Repo, planID string, at time.Time, query *v1.FindFilesQuery) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskType:   "find_files",
+			TaskName:   fmt.Sprintf("find files matching %q in repo %q", query.GetPattern(), repo.Id),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+		RunAt: at,
+		ProtoOp: &v1.Operation{
+			Op: &v1.Operation_OperationFindFiles{
+				OperationFindFiles: &v1.OperationFindFiles{
+					Query: query,
+				},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if st.Op.GetOperationFindFiles() == nil {
+				panic("find files task with non-find files operation")
+			}
+
+			return findFilesHelper(ctx, st, taskRunner)
+		},
+	}
+}
+
+// findFilesHelper runs the search and writes each match to the operation's results log as a line of JSON.
+func findFilesHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+	findOp := st.Op.GetOperationFindFiles()
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("get repo %q: %w", t.RepoID(), err)
+	}
+
+	id, writer, err := taskRunner.LogrefWriter()
+	if err != nil {
+		return fmt.Errorf("get logref writer: %w", err)
+	}
+	defer writer.Close()
+
+	findOp.ResultsLogref = id
+	if err := taskRunner.UpdateOperation(st.Op); err != nil {
+		return fmt.Errorf("update operation: %w", err)
+	}
+
+	bw := bufio.NewWriter(writer)
+	snapshots := make(map[string]struct{})
+	var writeErr error
+	err = repo.FindFiles(ctx, findOp.GetQuery(), func(match *v1.FileMatch) {
+		if writeErr != nil {
+			return
+		}
+		data, err := protojson.Marshal(match)
+		if err != nil {
+			writeErr = err
+			return
+		}
+		data = append(data, '\n')
+		if _, err := bw.Write(data); err != nil {
+			writeErr = err
+			return
+		}
+		findOp.MatchCount++
+		snapshots[match.SnapshotId] = struct{}{}
+	})
+	findOp.SnapshotsMatched = int64(len(snapshots))
+	if err != nil {
+		return fmt.Errorf("find files: %w", err)
+	} else if writeErr != nil {
+		return fmt.Errorf("write find results: %w", writeErr)
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write find results: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close logref writer: %w", err)
+	}
+
+	return nil
+}
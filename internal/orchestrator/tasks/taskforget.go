@@ -44,21 +44,11 @@ func NewOneoffForgetTask(repoProto *v1.Repo, planID string, flowID int64, at tim
 				return fmt.Errorf("get plan %q: %w", t.PlanID(), err)
 			}
 
-			tags := []string{repo.TagForPlan(t.PlanID())}
-			if compat, err := UseLegacyCompatMode(l, runner, t.Repo().GetGuid(), t.PlanID()); err != nil {
-				return fmt.Errorf("check legacy compat mode: %w", err)
-			} else if !compat {
-				tags = append(tags, repo.TagForInstance(runner.Config().Instance))
-			} else {
-				l.Warn("forgetting snapshots without instance ID, using legacy behavior (e.g. --tags not including instance ID)")
-				l.Sugar().Warnf("to avoid this warning, tag all snapshots with the instance ID e.g. by running: \r\n"+
-					"restic tag --set '%s' --set '%s' --tag '%s'", repo.TagForPlan(t.PlanID()), repo.TagForInstance(runner.Config().Instance), repo.TagForPlan(t.PlanID()))
+			opts, err := PlanForgetOptions(l, runner.QueryOperations, t.Repo().GetGuid(), t.PlanID(), runner.Config().Instance)
+			if err != nil {
+				return err
 			}
-
-			return forgetHelper(ctx, st, runner, plan.Retention,
-				restic.WithFlags("--tag", strings.Join(tags, ",")),
-				restic.WithFlags("--group-by", ""),
-			)
+			return forgetHelper(ctx, st, runner, plan.Retention, opts...)
 		},
 	}
 }
@@ -150,7 +140,7 @@ func (t *ScheduledForgetTask) Run(ctx context.Context, st ScheduledTask, runner
 	}
 
 	err = forgetHelper(ctx, st, runner, repoProto.GetForgetPolicy().GetRetention(),
-		restic.WithFlags("--group-by", "tags"),
+		restic.WithFlags("--group-by", protoutil.ForgetGroupBy(repoProto.GetForgetPolicy())),
 	)
 	if err != nil {
 		return err
@@ -227,20 +217,48 @@ func forgetHelper(ctx context.Context, st ScheduledTask, runner TaskRunner, poli
 		return notifyError(fmt.Errorf("forget: %w", err))
 	}
 
+	repoStats, e := latestRepoStats(runner, t.Repo().GetGuid())
+	if e != nil {
+		l.Error("find repo stats for forget hooks", zap.Error(e))
+	}
+
 	if e := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
 		v1.Hook_CONDITION_FORGET_SUCCESS,
-	}, HookVars{}); e != nil {
+	}, HookVars{
+		ForgottenSnapshots: forgot,
+		RepoStats:          repoStats,
+	}); e != nil {
 		return fmt.Errorf("forget end hook: %w", e)
 	}
 
 	return nil
 }
 
+// PlanForgetOptions returns the forget options that select the plan's snapshots. query looks up the plan's indexed
+// snapshots to check whether the legacy compat mode applies.
+func PlanForgetOptions(l *zap.Logger, query func(oplog.Query, func(*v1.Operation) error) error, repoGUID, planID, instance string) ([]restic.GenericOption, error) {
+	tags := []string{repo.TagForPlan(planID)}
+	if compat, err := UseLegacyCompatMode(l, query, repoGUID, planID); err != nil {
+		return nil, fmt.Errorf("check legacy compat mode: %w", err)
+	} else if !compat {
+		tags = append(tags, repo.TagForInstance(instance))
+	} else {
+		l.Warn("forgetting snapshots without instance ID, using legacy behavior (e.g. --tags not including instance ID)")
+		l.Sugar().Warnf("to avoid this warning, tag all snapshots with the instance ID e.g. by running: \r\n"+
+			"restic tag --set '%s' --set '%s' --tag '%s'", repo.TagForPlan(planID), repo.TagForInstance(instance), repo.TagForPlan(planID))
+	}
+
+	return []restic.GenericOption{
+		restic.WithFlags("--tag", strings.Join(tags, ",")),
+		restic.WithFlags("--group-by", ""),
+	}, nil
+}
+
 // UseLegacyCompatMode checks if there are any snapshots that were created without a `created-by` tag still exist in the repo.
 // The property is overridden if mixed `created-by` tag values are found.
-func UseLegacyCompatMode(l *zap.Logger, taskRunner TaskRunner, repoGUID, planID string) (bool, error) {
+func UseLegacyCompatMode(l *zap.Logger, query func(oplog.Query, func(*v1.Operation) error) error, repoGUID, planID string) (bool, error) {
 	instanceIDs := make(map[string]struct{})
-	if err := taskRunner.QueryOperations(oplog.Query{}.SetRepoGUID(repoGUID).SetPlanID(planID).SetReversed(true), func(op *v1.Operation) error {
+	if err := query(oplog.Query{}.SetRepoGUID(repoGUID).SetPlanID(planID).SetReversed(true), func(op *v1.Operation) error {
 		if snapshotOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok && !snapshotOp.OperationIndexSnapshot.GetForgot() {
 			tags := snapshotOp.OperationIndexSnapshot.GetSnapshot().GetTags()
 			instanceIDs[repo.InstanceIDFromTags(tags)] = struct{}{}
@@ -82,6 +82,7 @@ func indexSnapshotsHelper(ctx context.Context, st ScheduledTask, taskRunner Task
 			Op: &v1.Operation_OperationIndexSnapshot{
 				OperationIndexSnapshot: &v1.OperationIndexSnapshot{
 					Snapshot: snapshotProto,
+					Pinned:   isSnapshotPinned(snapshotProto),
 				},
 			},
 		})
@@ -153,6 +154,10 @@ func planForSnapshot(snapshot *v1.ResticSnapshot) string {
 	return PlanForUnassociatedOperations
 }
 
+func isSnapshotPinned(snapshot *v1.ResticSnapshot) bool {
+	return repo.IsPinned(snapshot.Tags)
+}
+
 func instanceIDForSnapshot(snapshot *v1.ResticSnapshot) string {
 	id := repo.InstanceIDFromTags(snapshot.Tags)
 	if id != "" {
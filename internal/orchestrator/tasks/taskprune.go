@@ -1,17 +1,26 @@
 package tasks
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/ioutil"
+	"github.com/garethgeorge/backrest/internal/metric"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
 	"go.uber.org/zap"
 )
 
+// pruneOutputTailBytes is the number of bytes of prune output kept to parse its summary, which restic prints before
+// deleting and repacking packs.
+const pruneOutputTailBytes = 64 * 1024
+
 type PruneTask struct {
 	BaseTask
 	force  bool
@@ -131,13 +140,20 @@ func (t *PruneTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 		return fmt.Errorf("update operation: %w", err)
 	}
 
-	err = repo.Prune(ctx, writer)
+	repoStats, e := latestRepoStats(runner, t.Repo().GetGuid())
+	if e != nil {
+		runner.Logger(ctx).Error("find repo stats for prune hooks", zap.Error(e))
+	}
+
+	tail := &ioutil.TailWriter{N: pruneOutputTailBytes}
+	err = repo.Prune(ctx, io.MultiWriter(writer, tail))
 	if err != nil {
 		runner.ExecuteHooks(ctx, []v1.Hook_Condition{
 			v1.Hook_CONDITION_PRUNE_ERROR,
 			v1.Hook_CONDITION_ANY_ERROR,
 		}, HookVars{
-			Error: err.Error(),
+			Error:     err.Error(),
+			RepoStats: repoStats,
 		})
 
 		return fmt.Errorf("prune: %w", err)
@@ -152,11 +168,39 @@ func (t *PruneTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 		zap.L().Error("schedule stats task", zap.Error(err))
 	}
 
+	pruneStats, e := restic.ParsePruneSummary(bytes.NewReader(tail.Bytes()))
+	if e != nil {
+		runner.Logger(ctx).Warn("parse prune summary", zap.Error(e))
+	} else {
+		opPrune.OperationPrune.Stats = pruneStatsToProto(pruneStats)
+		metric.GetRegistry().RecordPruneSummary(t.RepoID(), t.PlanID(), pruneStats.TotalBytesPruned, pruneStats.BytesToRepack, pruneStats.PacksToDelete, pruneStats.PacksToRepack, pruneStats.UnusedBytesAfterPrune)
+	}
+
 	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
 		v1.Hook_CONDITION_PRUNE_SUCCESS,
-	}, HookVars{}); err != nil {
+	}, HookVars{
+		PruneStats: pruneStats,
+		RepoStats:  repoStats,
+	}); err != nil {
 		return fmt.Errorf("execute prune end hooks: %w", err)
 	}
 
 	return nil
 }
+
+func pruneStatsToProto(summary *restic.PruneSummary) *v1.PruneStats {
+	return &v1.PruneStats{
+		PacksToKeep:           summary.PacksToKeep,
+		PacksToRepack:         summary.PacksToRepack,
+		PacksToDelete:         summary.PacksToDelete,
+		BlobsToRepack:         summary.BlobsToRepack,
+		BytesToRepack:         summary.BytesToRepack,
+		BlobsToDelete:         summary.BlobsToDelete,
+		BytesToDelete:         summary.BytesToDelete,
+		BlobsPruned:           summary.TotalBlobsPruned,
+		BytesPruned:           summary.TotalBytesPruned,
+		RemainingBlobs:        summary.RemainingBlobs,
+		RemainingBytes:        summary.RemainingBytes,
+		UnusedBytesAfterPrune: summary.UnusedBytesAfterPrune,
+	}
+}
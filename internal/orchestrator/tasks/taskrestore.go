@@ -11,7 +11,7 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewOneoffRestoreTask(repo *v1.Repo, planID string, flowID int64, at time.Time, snapshotID, path, target string) Task {
+func NewOneoffRestoreTask(repo *v1.Repo, planID string, flowID int64, at time.Time, snapshotID, path, target string, options *v1.RestoreOptions) Task {
 	return &GenericOneoffTask{
 		BaseTask: BaseTask{
 			TaskType:   "restore",
@@ -25,13 +25,14 @@ func NewOneoffRestoreTask(repo *v1.Repo, planID string, flowID int64, at time.Ti
 			SnapshotId: snapshotID,
 			Op: &v1.Operation_OperationRestore{
 				OperationRestore: &v1.OperationRestore{
-					Path:   path,
-					Target: target,
+					Path:    path,
+					Target:  target,
+					Options: options,
 				},
 			},
 		},
 		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
-			return NotifyError(ctx, taskRunner, st.Task.Name(), restoreHelper(ctx, st, taskRunner, snapshotID, path, target))
+			return restoreHelper(ctx, st, taskRunner, snapshotID, path, target)
 		},
 	}
 }
@@ -40,23 +41,48 @@ func restoreHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner,
 	t := st.Task
 	op := st.Op
 
+	restoreVars := func() HookVars {
+		return HookVars{
+			SnapshotId:    snapshotID,
+			RestorePath:   path,
+			RestoreTarget: target,
+			RestoreStats:  op.GetOperationRestore().GetLastStatus(),
+		}
+	}
+	notifyError := func(err error) error {
+		vars := restoreVars()
+		vars.Error = err.Error()
+		// the error from the hooks is ignored to avoid masking the restore's error.
+		_ = taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_RESTORE_ERROR,
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, vars)
+		return err
+	}
+
 	if snapshotID == "" || path == "" || target == "" {
-		return errors.New("snapshotID, path, and target are required")
+		return notifyError(errors.New("snapshotID, path, and target are required"))
 	}
 
 	restoreOp := st.Op.GetOperationRestore()
 	if restoreOp == nil {
-		return errors.New("operation is not a restore operation")
+		return notifyError(errors.New("operation is not a restore operation"))
 	}
 
 	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
 	if err != nil {
-		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+		return notifyError(fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err))
+	}
+
+	if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_RESTORE_START,
+	}, restoreVars()); err != nil {
+		return notifyError(fmt.Errorf("restore start hook: %w", err))
 	}
 
 	var sendWg sync.WaitGroup
 	lastSent := time.Now() // debounce progress updates, these can endup being very frequent.
-	summary, err := repo.Restore(ctx, snapshotID, path, target, func(entry *v1.RestoreProgressEntry) {
+	summary, err := repo.Restore(ctx, snapshotID, path, target, restoreOp.Options, func(entry *v1.RestoreProgressEntry) {
 		sendWg.Wait()
 		if time.Since(lastSent) < 1*time.Second {
 			return
@@ -73,11 +99,18 @@ func restoreHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner,
 			sendWg.Done()
 		}()
 	})
+	sendWg.Wait()
 
 	if err != nil {
-		return err
+		return notifyError(err)
 	}
 	restoreOp.LastStatus = summary
 
+	if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_RESTORE_SUCCESS,
+	}, restoreVars()); err != nil {
+		return fmt.Errorf("execute restore success hooks: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,320 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRestoreTestSampleSize = 10
+	maxRestoreTestFailures       = 20 // limits the failures recorded in the operation.
+)
+
+// RestoreTestTask restores files from the latest snapshot of a plan into a temporary directory and verifies them
+// against the snapshot's metadata and contents.
+type RestoreTestTask struct {
+	BaseTask
+	force  bool
+	didRun bool
+}
+
+func NewRestoreTestTask(repo *v1.Repo, planID string, force bool) Task {
+	return &RestoreTestTask{
+		BaseTask: BaseTask{
+			TaskType:   "restore_test",
+			TaskName:   fmt.Sprintf("restore test for plan %q in repo %q", planID, repo.Id),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+		force: force,
+	}
+}
+
+func (t *RestoreTestTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	if t.force {
+		if t.didRun {
+			return NeverScheduledTask, nil
+		}
+		t.didRun = true
+		return ScheduledTask{
+			RunAt: now,
+			Op: &v1.Operation{
+				Op: &v1.Operation_OperationRestoreTest{},
+			},
+		}, nil
+	}
+
+	plan, err := runner.GetPlan(t.PlanID())
+	if err != nil {
+		return ScheduledTask{}, fmt.Errorf("get plan %v: %w", t.PlanID(), err)
+	}
+
+	if plan.GetRestoreTestPolicy().GetSchedule() == nil {
+		return NeverScheduledTask, nil
+	}
+
+	var lastRan time.Time
+	var foundBackup bool
+	if err := runner.QueryOperations(oplog.Query{}.
+		SetInstanceID(runner.InstanceID()).
+		SetRepoGUID(t.Repo().GetGuid()).
+		SetPlanID(t.PlanID()).
+		SetReversed(true), func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_SYSTEM_CANCELLED {
+			return nil
+		}
+		if _, ok := op.Op.(*v1.Operation_OperationRestoreTest); ok && op.UnixTimeEndMs != 0 {
+			lastRan = time.Unix(0, op.UnixTimeEndMs*int64(time.Millisecond))
+			return oplog.ErrStopIteration
+		}
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); ok {
+			foundBackup = true
+		}
+		return nil
+	}); err != nil {
+		return NeverScheduledTask, fmt.Errorf("finding last restore test run time: %w", err)
+	} else if !foundBackup {
+		lastRan = now
+	}
+
+	runAt, err := protoutil.ResolveSchedule(plan.GetRestoreTestPolicy().GetSchedule(), lastRan, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		return NeverScheduledTask, nil
+	} else if err != nil {
+		return NeverScheduledTask, fmt.Errorf("resolve schedule: %w", err)
+	}
+
+	return ScheduledTask{
+		RunAt: runAt,
+		Op: &v1.Operation{
+			Op: &v1.Operation_OperationRestoreTest{},
+		},
+	}, nil
+}
+
+func (t *RestoreTestTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	op := st.Op
+
+	notifyError := func(err error) error {
+		return NotifyError(ctx, runner, t.Name(), err, v1.Hook_CONDITION_RESTORE_TEST_ERROR)
+	}
+
+	plan, err := runner.GetPlan(t.PlanID())
+	if err != nil {
+		return notifyError(fmt.Errorf("get plan %q: %w", t.PlanID(), err))
+	}
+
+	r, err := runner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return notifyError(fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err))
+	}
+
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_RESTORE_TEST_START,
+	}, HookVars{}); err != nil {
+		return notifyError(fmt.Errorf("restore test start hook: %w", err))
+	}
+
+	policy := plan.GetRestoreTestPolicy()
+	opRestoreTest := &v1.OperationRestoreTest{
+		Path: policy.GetPath(),
+	}
+	op.Op = &v1.Operation_OperationRestoreTest{
+		OperationRestoreTest: opRestoreTest,
+	}
+
+	snapshot, err := latestSnapshotForPlan(ctx, r, t.PlanID())
+	if err != nil {
+		return notifyError(err)
+	}
+	op.SnapshotId = snapshot.Id
+	if err := runner.UpdateOperation(op); err != nil {
+		return fmt.Errorf("update operation: %w", err)
+	}
+
+	if err := restoreTestHelper(ctx, r, snapshot.Id, policy, opRestoreTest); err != nil {
+		return notifyError(fmt.Errorf("restore test of snapshot %q: %w", snapshot.Id, err))
+	}
+
+	if !opRestoreTest.Passed {
+		err := fmt.Errorf("restore test of snapshot %q: %d of %d files failed verification", snapshot.Id, opRestoreTest.FilesFailed, opRestoreTest.FilesChecked)
+		runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_RESTORE_TEST_ERROR,
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, HookVars{
+			SnapshotId: snapshot.Id,
+			Error:      err.Error(),
+		})
+		return err
+	}
+
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_RESTORE_TEST_SUCCESS,
+	}, HookVars{
+		SnapshotId: snapshot.Id,
+	}); err != nil {
+		return fmt.Errorf("execute restore test success hooks: %w", err)
+	}
+
+	return nil
+}
+
+// latestSnapshotForPlan returns the most recent snapshot in the repo created by the plan.
+func latestSnapshotForPlan(ctx context.Context, r RepoOrchestrator, planID string) (*restic.Snapshot, error) {
+	snapshots, err := r.Snapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+
+	planTag := repo.TagForPlan(planID)
+	var latest *restic.Snapshot
+	for _, snapshot := range snapshots {
+		if !slices.Contains(snapshot.Tags, planTag) {
+			continue
+		}
+		if latest == nil || snapshot.UnixTimeMs() > latest.UnixTimeMs() {
+			latest = snapshot
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no snapshots found for plan %q", planID)
+	}
+	return latest, nil
+}
+
+// restoreTestHelper restores the files selected by the policy into a temporary directory and verifies each restored
+// file's size against the snapshot listing and its hash against the file dumped from the snapshot. Results are
+// recorded in opRestoreTest, the temporary directory is always removed.
+func restoreTestHelper(ctx context.Context, r RepoOrchestrator, snapshotID string, policy *v1.RestoreTestPolicy, opRestoreTest *v1.OperationRestoreTest) error {
+	files, err := selectRestoreTestFiles(ctx, r, snapshotID, policy)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("no files found to restore")
+	}
+
+	target, err := os.MkdirTemp("", "backrest-restore-test-*")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(target); err != nil {
+			zap.L().Error("remove restore test temp dir", zap.String("dir", target), zap.Error(err))
+		}
+	}()
+
+	// Restore the whole snapshot filtered by includes so that restored files are found at their snapshot path under target.
+	var includes []string
+	if policy.GetPath() != "" {
+		includes = append(includes, repo.EscapeGlob(policy.GetPath()))
+	} else {
+		for _, file := range files {
+			includes = append(includes, repo.EscapeGlob(file.Path))
+		}
+	}
+	if _, err := r.Restore(ctx, snapshotID, "/", target, &v1.RestoreOptions{
+		Includes: includes,
+		Verify:   true,
+	}, nil); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		bytesChecked, reason := verifyRestoredFile(ctx, r, snapshotID, file, filepath.Join(target, filepath.FromSlash(file.Path)))
+		opRestoreTest.FilesChecked++
+		opRestoreTest.BytesChecked += bytesChecked
+		if reason == "" {
+			continue
+		}
+		opRestoreTest.FilesFailed++
+		if len(opRestoreTest.Failures) < maxRestoreTestFailures {
+			opRestoreTest.Failures = append(opRestoreTest.Failures, &v1.RestoreTestFailure{
+				Path:   file.Path,
+				Reason: reason,
+			})
+		}
+	}
+	opRestoreTest.Passed = opRestoreTest.FilesFailed == 0
+	return nil
+}
+
+// selectRestoreTestFiles lists the files to verify, every file under the policy's path if one is set, otherwise a
+// random sample of files from the whole snapshot.
+func selectRestoreTestFiles(ctx context.Context, r RepoOrchestrator, snapshotID string, policy *v1.RestoreTestPolicy) ([]*v1.LsEntry, error) {
+	listPath := "/"
+	if policy.GetPath() != "" {
+		listPath = policy.GetPath()
+	}
+
+	sampleSize := int(policy.GetSampleSize())
+	if sampleSize == 0 {
+		sampleSize = defaultRestoreTestSampleSize
+	}
+
+	var files []*v1.LsEntry
+	var seen int
+	if err := r.ListFiles(ctx, snapshotID, listPath, func(entry *v1.LsEntry) {
+		if entry.Type != "file" {
+			return
+		}
+		if policy.GetPath() != "" {
+			files = append(files, entry)
+			return
+		}
+		// reservoir sample the files so that the snapshot's listing isn't held in memory.
+		seen++
+		if len(files) < sampleSize {
+			files = append(files, entry)
+		} else if i := rand.IntN(seen); i < sampleSize {
+			files[i] = entry
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// verifyRestoredFile compares the restored file at localPath with the file in the snapshot. It returns the number of
+// bytes verified and the reason verification failed, or an empty reason if the file matches.
+func verifyRestoredFile(ctx context.Context, r RepoOrchestrator, snapshotID string, file *v1.LsEntry, localPath string) (int64, string) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return 0, fmt.Sprintf("open restored file: %v", err)
+	}
+	defer f.Close()
+
+	restoredHash := sha256.New()
+	size, err := io.Copy(restoredHash, f)
+	if err != nil {
+		return size, fmt.Sprintf("read restored file: %v", err)
+	}
+	if size != file.Size {
+		return size, fmt.Sprintf("restored size %d does not match snapshot size %d", size, file.Size)
+	}
+
+	snapshotHash := sha256.New()
+	if err := r.Dump(ctx, snapshotID, file.Path, snapshotHash); err != nil {
+		return size, fmt.Sprintf("dump file from snapshot: %v", err)
+	}
+	if !bytes.Equal(restoredHash.Sum(nil), snapshotHash.Sum(nil)) {
+		return size, "restored contents do not match the snapshot"
+	}
+	return size, ""
+}
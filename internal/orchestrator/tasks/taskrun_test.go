@@ -3,15 +3,19 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
 	"github.com/garethgeorge/backrest/pkg/restic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 )
 
 const testSnapshotID = "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
@@ -76,6 +80,44 @@ func hookContains(calls []hookCall, cond v1.Hook_Condition) bool {
 	return false
 }
 
+// createStatsOperation records a successful stats operation for the repo, as the hooks of repo maintenance tasks
+// report the latest stats.
+func createStatsOperation(t *testing.T, runner *testTaskRunner, repo *v1.Repo, stats *v1.RepoStats) {
+	t.Helper()
+	now := time.Now().UnixMilli()
+	require.NoError(t, runner.CreateOperation(&v1.Operation{
+		RepoId:          repo.Id,
+		RepoGuid:        repo.Guid,
+		PlanId:          PlanForSystemTasks,
+		InstanceId:      runner.InstanceID(),
+		FlowId:          1,
+		UnixTimeStartMs: now,
+		UnixTimeEndMs:   now,
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		Op: &v1.Operation_OperationStats{
+			OperationStats: &v1.OperationStats{Stats: stats},
+		},
+	}))
+}
+
+const testPruneOutput = `loading indexes...
+collecting packs for deletion and repacking
+
+to repack:           169 blobs / 1.012 MiB
+this removes:          0 blobs / 0 B
+to delete:            20 blobs / 1.453 MiB
+total prune:          20 blobs / 1.453 MiB
+remaining:          5337 blobs / 1.221 GiB
+unused size after prune: 24.432 KiB (0.00% of remaining size)
+
+to keep:              38 packs
+to repack:             0 packs
+to delete:             2 packs
+
+repacking packs
+done
+`
+
 // --- PruneTask tests ---
 
 func TestPruneTaskRun(t *testing.T) {
@@ -89,7 +131,14 @@ func TestPruneTaskRun(t *testing.T) {
 	}{
 		{
 			name:          "success",
-			fake:          &fakeRepoOrchestrator{},
+			fake:          &fakeRepoOrchestrator{pruneOutput: testPruneOutput},
+			wantHooks:     []v1.Hook_Condition{v1.Hook_CONDITION_PRUNE_START, v1.Hook_CONDITION_PRUNE_SUCCESS},
+			wantScheduled: 1,
+			scheduledType: "stats",
+		},
+		{
+			name:          "success without a summary",
+			fake:          &fakeRepoOrchestrator{pruneOutput: "nothing to do\n"},
 			wantHooks:     []v1.Hook_Condition{v1.Hook_CONDITION_PRUNE_START, v1.Hook_CONDITION_PRUNE_SUCCESS},
 			wantScheduled: 1,
 			scheduledType: "stats",
@@ -114,6 +163,9 @@ func TestPruneTaskRun(t *testing.T) {
 			cfg := newTestConfig(repo)
 			runner := setupTestRunner(t, cfg, tc.fake)
 
+			repoStats := &v1.RepoStats{TotalSize: 1024, SnapshotCount: 3}
+			createStatsOperation(t, runner, repo, repoStats)
+
 			task := NewPruneTask(repo, PlanForSystemTasks, true)
 			st := nextAndCreate(t, task, runner)
 
@@ -127,6 +179,23 @@ func TestPruneTaskRun(t *testing.T) {
 			for _, cond := range tc.wantHooks {
 				assert.True(t, hookContains(runner.hookCalls, cond), "expected hook %v", cond)
 			}
+			if hookContains(runner.hookCalls, v1.Hook_CONDITION_PRUNE_SUCCESS) {
+				last := runner.hookCalls[len(runner.hookCalls)-1].Vars
+				assert.True(t, proto.Equal(repoStats, last.RepoStats), "expected the repo stats in the success hook")
+				if tc.fake.pruneOutput == testPruneOutput {
+					require.NotNil(t, last.PruneStats)
+					assert.Equal(t, int64(1523580), last.PruneStats.TotalBytesPruned)
+					assert.Equal(t, int64(25018), last.PruneStats.UnusedBytesAfterPrune)
+					stats := st.Op.GetOperationPrune().GetStats()
+					require.NotNil(t, stats, "expected the prune summary in the operation")
+					assert.Equal(t, int64(2), stats.PacksToDelete)
+					assert.Equal(t, int64(1523580), stats.BytesPruned)
+					assert.Equal(t, int64(5337), stats.RemainingBlobs)
+				} else {
+					assert.Nil(t, last.PruneStats)
+					assert.Nil(t, st.Op.GetOperationPrune().GetStats())
+				}
+			}
 
 			assert.Len(t, runner.scheduledTasks, tc.wantScheduled)
 			if tc.scheduledType != "" && len(runner.scheduledTasks) > 0 {
@@ -140,10 +209,11 @@ func TestPruneTaskRun(t *testing.T) {
 
 func TestCheckTaskRun(t *testing.T) {
 	tests := []struct {
-		name      string
-		fake      *fakeRepoOrchestrator
-		wantErr   bool
-		wantHooks []v1.Hook_Condition
+		name            string
+		fake            *fakeRepoOrchestrator
+		wantErr         bool
+		wantHooks       []v1.Hook_Condition
+		wantCheckErrors string
 	}{
 		{
 			name:      "success",
@@ -151,10 +221,26 @@ func TestCheckTaskRun(t *testing.T) {
 			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_CHECK_START, v1.Hook_CONDITION_CHECK_SUCCESS},
 		},
 		{
-			name:      "check error",
-			fake:      &fakeRepoOrchestrator{checkErr: fmt.Errorf("check failed")},
-			wantErr:   true,
-			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_CHECK_START, v1.Hook_CONDITION_CHECK_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+			name: "check error",
+			fake: &fakeRepoOrchestrator{
+				checkOutput: "load indexes\ncheck all packs\nerror: pack 1a2b3c4d: not referenced in any index\ncheck snapshots, trees and blobs\n",
+				checkErr:    fmt.Errorf("check failed"),
+			},
+			wantErr:         true,
+			wantHooks:       []v1.Hook_Condition{v1.Hook_CONDITION_CHECK_START, v1.Hook_CONDITION_CHECK_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+			wantCheckErrors: "error: pack 1a2b3c4d: not referenced in any index",
+		},
+		{
+			name: "check error with restic output",
+			fake: &fakeRepoOrchestrator{
+				checkErr: &restic.ErrorWithOutput{
+					Err:    fmt.Errorf("exit status 1"),
+					Output: "check snapshots, trees and blobs\nFatal: repository contains errors\n",
+				},
+			},
+			wantErr:         true,
+			wantHooks:       []v1.Hook_Condition{v1.Hook_CONDITION_CHECK_START, v1.Hook_CONDITION_CHECK_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+			wantCheckErrors: "Fatal: repository contains errors",
 		},
 		{
 			name:      "unlock error",
@@ -183,6 +269,128 @@ func TestCheckTaskRun(t *testing.T) {
 			for _, cond := range tc.wantHooks {
 				assert.True(t, hookContains(runner.hookCalls, cond), "expected hook %v", cond)
 			}
+			if tc.wantCheckErrors != "" {
+				last := runner.hookCalls[len(runner.hookCalls)-1].Vars
+				assert.Equal(t, tc.wantCheckErrors, last.CheckErrors)
+			}
+		})
+	}
+}
+
+// --- CopyTask tests ---
+
+func TestCopyTaskRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		fake          *fakeRepoOrchestrator
+		wantErr       bool
+		wantHooks     []v1.Hook_Condition
+		wantScheduled int
+	}{
+		{
+			name:          "success",
+			fake:          &fakeRepoOrchestrator{},
+			wantHooks:     []v1.Hook_Condition{v1.Hook_CONDITION_COPY_START, v1.Hook_CONDITION_COPY_SUCCESS},
+			wantScheduled: 1,
+		},
+		{
+			name:      "copy error",
+			fake:      &fakeRepoOrchestrator{copyErr: fmt.Errorf("copy failed")},
+			wantErr:   true,
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_COPY_START, v1.Hook_CONDITION_COPY_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+		},
+		{
+			name:      "unlock error",
+			fake:      &fakeRepoOrchestrator{unlockErr: fmt.Errorf("unlock failed")},
+			wantErr:   true,
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_COPY_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &v1.Repo{Id: "repo1", Guid: "guid1", CopyPolicy: &v1.CopyPolicy{TargetRepo: "repo2"}}
+			target := &v1.Repo{Id: "repo2", Guid: "guid2"}
+			cfg := newTestConfig(repo)
+			cfg.Repos = append(cfg.Repos, target)
+			runner := setupTestRunner(t, cfg, tc.fake)
+
+			task := NewCopyTask(repo, PlanForSystemTasks, true)
+			st := nextAndCreate(t, task, runner)
+
+			err := task.Run(context.Background(), st, runner)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "repo2", st.Op.GetOperationCopy().GetTargetRepoId())
+				assert.Equal(t, "guid2", st.Op.GetOperationCopy().GetTargetRepoGuid())
+			}
+
+			for _, cond := range tc.wantHooks {
+				assert.True(t, hookContains(runner.hookCalls, cond), "expected hook %v", cond)
+			}
+
+			assert.Len(t, runner.scheduledTasks, tc.wantScheduled)
+			if len(runner.scheduledTasks) > 0 {
+				assert.Equal(t, "index_snapshots", runner.scheduledTasks[0].Task.Type())
+				assert.Equal(t, "repo2", runner.scheduledTasks[0].Task.RepoID())
+			}
+		})
+	}
+}
+
+// --- FindFilesTask tests ---
+
+func TestFindFilesTaskRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		fake          *fakeRepoOrchestrator
+		wantErr       bool
+		wantMatches   int64
+		wantSnapshots int64
+	}{
+		{
+			name: "success",
+			fake: &fakeRepoOrchestrator{
+				findMatches: []*v1.FileMatch{
+					{SnapshotId: "snap1", Path: "/foo/bar.txt"},
+					{SnapshotId: "snap2", Path: "/foo/bar.txt"},
+					{SnapshotId: "snap2", Path: "/foo/baz/bar.txt"},
+				},
+			},
+			wantMatches:   3,
+			wantSnapshots: 2,
+		},
+		{
+			name:    "find error",
+			fake:    &fakeRepoOrchestrator{findErr: fmt.Errorf("find failed")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+			cfg := newTestConfig(repo)
+			runner := setupTestRunner(t, cfg, tc.fake)
+
+			task := NewOneoffFindFilesTask(repo, PlanForSystemTasks, time.Now(), &v1.FindFilesQuery{Pattern: "bar.txt"})
+			st := nextAndCreate(t, task, runner)
+
+			err := task.Run(context.Background(), st, runner)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			findOp := st.Op.GetOperationFindFiles()
+			assert.Equal(t, "bar.txt", findOp.GetQuery().GetPattern())
+			assert.Equal(t, "test-logref", findOp.GetResultsLogref())
+			assert.Equal(t, tc.wantMatches, findOp.GetMatchCount())
+			assert.Equal(t, tc.wantSnapshots, findOp.GetSnapshotsMatched())
+			assert.Empty(t, runner.hookCalls)
 		})
 	}
 }
@@ -391,6 +599,88 @@ func TestBackupTaskRun(t *testing.T) {
 	}
 }
 
+func TestBackupTaskRunHookResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		plan         *v1.Plan
+		result       string // written by the snapshot start hook.
+		wantErr      bool
+		wantBackup   bool
+		wantPaths    []string
+		wantExcludes []string
+		wantFlags    []string
+		wantHooks    []v1.Hook_Condition
+	}{
+		{
+			name:       "no result",
+			plan:       &v1.Plan{Id: "plan1", Repo: "repo1", Paths: []string{"/data"}},
+			wantBackup: true,
+			wantPaths:  []string{"/data"},
+		},
+		{
+			name:         "adds paths, excludes and tags",
+			plan:         &v1.Plan{Id: "plan1", Repo: "repo1", Paths: []string{"/data"}},
+			result:       "PATH=/mnt/usb\nEXCLUDE=*.tmp\nTAG=usb",
+			wantBackup:   true,
+			wantPaths:    []string{"/data", "/mnt/usb"},
+			wantExcludes: []string{"*.tmp"},
+			wantFlags:    []string{"--tag usb"},
+		},
+		{
+			name:      "skip",
+			plan:      &v1.Plan{Id: "plan1", Repo: "repo1", Paths: []string{"/data"}},
+			result:    "SKIP=true\nSKIP_REASON=no volumes mounted",
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SKIPPED, v1.Hook_CONDITION_SNAPSHOT_END},
+		},
+		{
+			name:    "paths for a command backup",
+			plan:    &v1.Plan{Id: "plan1", Repo: "repo1", StdinCommand: &v1.StdinCommand{Command: "pg_dumpall"}},
+			result:  "PATH=/mnt/usb",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+			fake := &fakeRepoOrchestrator{
+				backupResult: &restic.BackupProgressEntry{MessageType: "summary", SnapshotId: testSnapshotID},
+			}
+			runner := setupTestRunner(t, newTestConfig(repo, tc.plan), fake)
+			runner.onExecuteHooks = func(ctx context.Context, events []v1.Hook_Condition, vars HookVars) error {
+				if result := BackupHookResultFromContext(ctx); result != nil && tc.result != "" {
+					return result.Parse(strings.NewReader(tc.result))
+				}
+				return nil
+			}
+
+			task := NewOneoffBackupTask(repo, tc.plan, time.Now(), false)
+			st := nextAndCreate(t, task, runner)
+
+			err := task.Run(context.Background(), st, runner)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, cond := range tc.wantHooks {
+				assert.True(t, hookContains(runner.hookCalls, cond), "expected hook %v", cond)
+			}
+			if !tc.wantBackup {
+				assert.Nil(t, fake.backupPlan, "expected the backup to be skipped")
+				assert.Contains(t, st.Op.DisplayMessage, "no volumes mounted")
+				return
+			}
+			require.NotNil(t, fake.backupPlan)
+			assert.Equal(t, tc.wantPaths, fake.backupPlan.Paths)
+			assert.Equal(t, tc.wantExcludes, fake.backupPlan.Excludes)
+			assert.Equal(t, tc.wantFlags, fake.backupPlan.BackupFlags)
+			assert.Equal(t, []string{"/data"}, tc.plan.Paths, "expected the configured plan to be unchanged")
+		})
+	}
+}
+
 // --- ForgetSnapshot task tests ---
 
 func TestForgetSnapshotTaskRun(t *testing.T) {
@@ -448,8 +738,10 @@ func TestScheduledForgetTaskRun(t *testing.T) {
 		wantScheduled int
 	}{
 		{
-			name:          "success",
-			fake:          &fakeRepoOrchestrator{},
+			name: "success",
+			fake: &fakeRepoOrchestrator{
+				forgetResult: []*v1.ResticSnapshot{{Id: testSnapshotID, UnixTimeMs: 1000}},
+			},
 			wantHooks:     []v1.Hook_Condition{v1.Hook_CONDITION_FORGET_START, v1.Hook_CONDITION_FORGET_SUCCESS},
 			wantScheduled: 1, // stats task
 		},
@@ -487,6 +779,9 @@ func TestScheduledForgetTaskRun(t *testing.T) {
 			cfg := newTestConfig(repo)
 			runner := setupTestRunner(t, cfg, tc.fake)
 
+			repoStats := &v1.RepoStats{TotalSize: 1024, SnapshotCount: 3}
+			createStatsOperation(t, runner, repo, repoStats)
+
 			task := NewScheduledForgetTask(repo, PlanForSystemTasks, true)
 			st := nextAndCreate(t, task, runner)
 
@@ -495,6 +790,9 @@ func TestScheduledForgetTaskRun(t *testing.T) {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				last := runner.hookCalls[len(runner.hookCalls)-1].Vars
+				assert.Equal(t, tc.fake.forgetResult, last.ForgottenSnapshots)
+				assert.True(t, proto.Equal(repoStats, last.RepoStats), "expected the repo stats in the success hook")
 			}
 
 			for _, cond := range tc.wantHooks {
@@ -506,13 +804,59 @@ func TestScheduledForgetTaskRun(t *testing.T) {
 	}
 }
 
+func TestUseLegacyCompatMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance []string // the created-by instance of each indexed snapshot, empty for a legacy snapshot.
+		want     bool
+	}{
+		{name: "no snapshots", want: false},
+		{name: "tagged snapshots", instance: []string{"test-instance", "test-instance"}, want: false},
+		{name: "legacy snapshot", instance: []string{"", "test-instance"}, want: true},
+		{name: "mixed instances", instance: []string{"", "test-instance", "other-instance"}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+			runner := setupTestRunner(t, newTestConfig(repo), &fakeRepoOrchestrator{})
+
+			for i, instance := range tc.instance {
+				tags := []string{"plan:plan1"}
+				if instance != "" {
+					tags = append(tags, "created-by:"+instance)
+				}
+				require.NoError(t, runner.CreateOperation(&v1.Operation{
+					RepoId:          repo.Id,
+					RepoGuid:        repo.Guid,
+					PlanId:          "plan1",
+					InstanceId:      runner.InstanceID(),
+					FlowId:          int64(i + 1),
+					UnixTimeStartMs: int64(i + 1),
+					Status:          v1.OperationStatus_STATUS_SUCCESS,
+					Op: &v1.Operation_OperationIndexSnapshot{
+						OperationIndexSnapshot: &v1.OperationIndexSnapshot{
+							Snapshot: &v1.ResticSnapshot{Id: fmt.Sprintf("%064d", i), Tags: tags},
+						},
+					},
+				}))
+			}
+
+			got, err := UseLegacyCompatMode(zap.NewNop(), runner.QueryOperations, repo.Guid, "plan1")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 // --- RestoreTask tests ---
 
 func TestRestoreTaskRun(t *testing.T) {
 	tests := []struct {
-		name    string
-		fake    *fakeRepoOrchestrator
-		wantErr bool
+		name      string
+		fake      *fakeRepoOrchestrator
+		wantErr   bool
+		wantHooks []v1.Hook_Condition
 	}{
 		{
 			name: "success",
@@ -525,11 +869,13 @@ func TestRestoreTaskRun(t *testing.T) {
 					BytesRestored: 5000,
 				},
 			},
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_RESTORE_START, v1.Hook_CONDITION_RESTORE_SUCCESS},
 		},
 		{
-			name:    "restore error",
-			fake:    &fakeRepoOrchestrator{restoreErr: fmt.Errorf("restore failed")},
-			wantErr: true,
+			name:      "restore error",
+			fake:      &fakeRepoOrchestrator{restoreErr: fmt.Errorf("restore failed")},
+			wantErr:   true,
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_RESTORE_START, v1.Hook_CONDITION_RESTORE_ERROR, v1.Hook_CONDITION_ANY_ERROR},
 		},
 	}
 
@@ -539,7 +885,11 @@ func TestRestoreTaskRun(t *testing.T) {
 			cfg := newTestConfig(repo)
 			runner := setupTestRunner(t, cfg, tc.fake)
 
-			task := NewOneoffRestoreTask(repo, "plan1", 1, time.Now(), testSnapshotID, "/data", "/tmp/restore")
+			options := &v1.RestoreOptions{
+				Excludes:  []string{"node_modules"},
+				Overwrite: v1.RestoreOptions_OVERWRITE_IF_CHANGED,
+			}
+			task := NewOneoffRestoreTask(repo, "plan1", 1, time.Now(), testSnapshotID, "/data", "/tmp/restore", options)
 			st := nextAndCreate(t, task, runner)
 
 			err := task.Run(context.Background(), st, runner)
@@ -550,7 +900,129 @@ func TestRestoreTaskRun(t *testing.T) {
 				restoreOp := st.Op.GetOperationRestore()
 				require.NotNil(t, restoreOp)
 				assert.NotNil(t, restoreOp.LastStatus)
+				assert.True(t, proto.Equal(options, restoreOp.Options), "expected the restore options to be recorded")
+				assert.True(t, proto.Equal(options, tc.fake.restoreOptions), "expected the restore options to be passed to the repo")
+			}
+
+			for _, cond := range tc.wantHooks {
+				assert.True(t, hookContains(runner.hookCalls, cond), "expected hook %v", cond)
+			}
+			require.NotEmpty(t, runner.hookCalls)
+			last := runner.hookCalls[len(runner.hookCalls)-1].Vars
+			assert.Equal(t, testSnapshotID, last.SnapshotId)
+			assert.Equal(t, "/data", last.RestorePath)
+			assert.Equal(t, "/tmp/restore", last.RestoreTarget)
+			if tc.wantErr {
+				assert.Equal(t, "restore failed", last.Error)
+			} else {
+				assert.True(t, proto.Equal(tc.fake.restoreResult, last.RestoreStats), "expected the restore summary in the success hook")
+			}
+		})
+	}
+}
+
+// --- RestoreTestTask tests ---
+
+func TestRestoreTestTaskRun(t *testing.T) {
+	planTag := "plan:plan1"
+	snapshots := []*restic.Snapshot{
+		{Id: strings.Repeat("a", 64), Time: "2024-01-01T00:00:00Z", Tags: []string{planTag}},
+		{Id: testSnapshotID, Time: "2024-01-02T00:00:00Z", Tags: []string{planTag}},
+		{Id: strings.Repeat("b", 64), Time: "2024-01-03T00:00:00Z", Tags: []string{"plan:plan2"}},
+	}
+	lsEntries := []*v1.LsEntry{
+		{Path: "/data", Type: "dir"},
+		{Path: "/data/a.txt", Type: "file", Size: 5},
+		{Path: "/data/b.txt", Type: "file", Size: 5},
+	}
+	contents := map[string][]byte{
+		"/data/a.txt": []byte("aaaaa"),
+		"/data/b.txt": []byte("bbbbb"),
+	}
+
+	tests := []struct {
+		name       string
+		fake       *fakeRepoOrchestrator
+		wantErr    bool
+		wantHooks  []v1.Hook_Condition
+		wantFailed int64
+	}{
+		{
+			name: "success",
+			fake: &fakeRepoOrchestrator{
+				snapshots:    snapshots,
+				lsEntries:    lsEntries,
+				restoreFiles: contents,
+				dumpContents: contents,
+			},
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_RESTORE_TEST_START, v1.Hook_CONDITION_RESTORE_TEST_SUCCESS},
+		},
+		{
+			name: "corrupted and missing files",
+			fake: &fakeRepoOrchestrator{
+				snapshots:    snapshots,
+				lsEntries:    lsEntries,
+				restoreFiles: map[string][]byte{"/data/a.txt": []byte("zzzzz")},
+				dumpContents: contents,
+			},
+			wantErr:    true,
+			wantHooks:  []v1.Hook_Condition{v1.Hook_CONDITION_RESTORE_TEST_START, v1.Hook_CONDITION_RESTORE_TEST_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+			wantFailed: 2,
+		},
+		{
+			name:      "no snapshots for plan",
+			fake:      &fakeRepoOrchestrator{snapshots: snapshots[2:]},
+			wantErr:   true,
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_RESTORE_TEST_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+		},
+		{
+			name: "restore error",
+			fake: &fakeRepoOrchestrator{
+				snapshots:  snapshots,
+				lsEntries:  lsEntries,
+				restoreErr: fmt.Errorf("restore failed"),
+			},
+			wantErr:   true,
+			wantHooks: []v1.Hook_Condition{v1.Hook_CONDITION_RESTORE_TEST_ERROR, v1.Hook_CONDITION_ANY_ERROR},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+			plan := &v1.Plan{Id: "plan1", Repo: "repo1", RestoreTestPolicy: &v1.RestoreTestPolicy{SampleSize: 5}}
+			cfg := newTestConfig(repo, plan)
+			runner := setupTestRunner(t, cfg, tc.fake)
+
+			task := NewRestoreTestTask(repo, "plan1", true)
+			st := nextAndCreate(t, task, runner)
+
+			err := task.Run(context.Background(), st, runner)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, cond := range tc.wantHooks {
+				assert.True(t, hookContains(runner.hookCalls, cond), "expected hook %v", cond)
 			}
+
+			if tc.fake.restoreTarget != "" {
+				assert.NoDirExists(t, tc.fake.restoreTarget, "expected the restore target to be cleaned up")
+			}
+
+			if tc.fake.restoreFiles == nil {
+				return
+			}
+			testOp := st.Op.GetOperationRestoreTest()
+			require.NotNil(t, testOp)
+			assert.Equal(t, testSnapshotID, st.Op.SnapshotId)
+			assert.Equal(t, int64(2), testOp.FilesChecked)
+			assert.Equal(t, tc.wantFailed, testOp.FilesFailed)
+			assert.Len(t, testOp.Failures, int(tc.wantFailed))
+			assert.Equal(t, tc.wantFailed == 0, testOp.Passed)
+			assert.ElementsMatch(t, []string{`/data/a.txt`, `/data/b.txt`}, tc.fake.restoreOptions.GetIncludes())
 		})
 	}
 }
@@ -597,9 +1069,10 @@ func TestRunCommandTaskRun(t *testing.T) {
 
 func TestIndexSnapshotsTaskRun(t *testing.T) {
 	tests := []struct {
-		name    string
-		fake    *fakeRepoOrchestrator
-		wantErr bool
+		name       string
+		fake       *fakeRepoOrchestrator
+		wantErr    bool
+		wantPinned bool
 	}{
 		{
 			name: "no snapshots",
@@ -620,6 +1093,20 @@ func TestIndexSnapshotsTaskRun(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "indexes pinned snapshots",
+			fake: &fakeRepoOrchestrator{
+				snapshots: []*restic.Snapshot{
+					{
+						Id:              testSnapshotID,
+						Time:            time.Now().Format(time.RFC3339Nano),
+						Tags:            []string{"plan:plan1", "created-by:test-instance", restic.PinnedTag},
+						SnapshotSummary: restic.SnapshotSummary{},
+					},
+				},
+			},
+			wantPinned: true,
+		},
 		{
 			name:    "snapshots error",
 			fake:    &fakeRepoOrchestrator{snapshotsErr: fmt.Errorf("snapshots failed")},
@@ -640,9 +1127,237 @@ func TestIndexSnapshotsTaskRun(t *testing.T) {
 			err := task.Run(context.Background(), st, runner)
 			if tc.wantErr {
 				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var indexed []*v1.OperationIndexSnapshot
+			require.NoError(t, runner.QueryOperations(oplog.Query{}.SetRepoGUID("guid1"), func(op *v1.Operation) error {
+				if indexOp := op.GetOperationIndexSnapshot(); indexOp != nil {
+					indexed = append(indexed, indexOp)
+				}
+				return nil
+			}))
+			require.Len(t, indexed, len(tc.fake.snapshots))
+			for _, indexOp := range indexed {
+				assert.Equal(t, tc.wantPinned, indexOp.Pinned)
 			}
 		})
 	}
 }
+
+// --- PinSnapshot tests ---
+
+func TestPinSnapshotTaskRun(t *testing.T) {
+	const retaggedID = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	snapshotTime := time.Now().Format(time.RFC3339Nano)
+
+	repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+	plan := &v1.Plan{Id: "plan1", Repo: "repo1"}
+	cfg := newTestConfig(repo, plan)
+	fake := &fakeRepoOrchestrator{
+		snapshots: []*restic.Snapshot{
+			{Id: testSnapshotID, Time: snapshotTime, Tags: []string{"plan:plan1"}},
+		},
+		retagSnapshots: []*restic.Snapshot{
+			{Id: retaggedID, Original: testSnapshotID, Time: snapshotTime, Tags: []string{"plan:plan1", restic.PinnedTag}},
+		},
+	}
+	runner := setupTestRunner(t, cfg, fake)
+
+	require.NoError(t, runner.CreateOperation(&v1.Operation{
+		InstanceId:      "test-instance",
+		RepoId:          "repo1",
+		RepoGuid:        "guid1",
+		PlanId:          "plan1",
+		FlowId:          1,
+		SnapshotId:      testSnapshotID,
+		UnixTimeStartMs: time.Now().UnixMilli(),
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		Op: &v1.Operation_OperationIndexSnapshot{
+			OperationIndexSnapshot: &v1.OperationIndexSnapshot{
+				Snapshot: &v1.ResticSnapshot{Id: testSnapshotID},
+			},
+		},
+	}))
+
+	task := NewOneoffPinSnapshotTask(repo, time.Now(), testSnapshotID, true)
+	assert.Equal(t, []RepoClaim{{RepoID: "repo1", Exclusive: true}}, RepoClaims(task))
+
+	st := nextAndCreate(t, task, runner)
+	require.NoError(t, task.Run(context.Background(), st, runner))
+
+	var indexed []*v1.Operation
+	require.NoError(t, runner.QueryOperations(oplog.Query{}.SetRepoGUID("guid1"), func(op *v1.Operation) error {
+		indexed = append(indexed, op)
+		return nil
+	}))
+	require.Len(t, indexed, 1)
+	assert.Equal(t, retaggedID, indexed[0].SnapshotId)
+	assert.Equal(t, retaggedID, indexed[0].GetOperationIndexSnapshot().GetSnapshot().GetId())
+	assert.True(t, indexed[0].GetOperationIndexSnapshot().GetPinned())
+
+	err := task.Run(context.Background(), st, runner)
+	assert.ErrorIs(t, err, ErrSnapshotNotFound, "the original ID is gone once the snapshot is re-tagged")
+}
+
+func TestBackupOverdueTaskRun(t *testing.T) {
+	repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+	plan := &v1.Plan{Id: "plan1", Repo: "repo1", Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}}}
+	cfg := newTestConfig(repo, plan)
+	runner := setupTestRunner(t, cfg, &fakeRepoOrchestrator{})
+	kv, err := kvstore.NewSqliteKVStore(kvstore.NewInMemorySqliteDbForKvStore(t), "test")
+	require.NoError(t, err)
+
+	addBackup := func(startedAgo time.Duration, status v1.OperationStatus) {
+		t.Helper()
+		require.NoError(t, runner.CreateOperation(&v1.Operation{
+			RepoId:          repo.Id,
+			RepoGuid:        repo.Guid,
+			PlanId:          plan.Id,
+			FlowId:          1,
+			Status:          status,
+			UnixTimeStartMs: time.Now().Add(-startedAgo).UnixMilli(),
+			UnixTimeEndMs:   time.Now().Add(-startedAgo).Add(time.Minute).UnixMilli(),
+			Op:              &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}},
+		}))
+	}
+	// runCheck runs a fresh task, as after a restart or config change, and returns the conditions it fired.
+	runCheck := func() []v1.Hook_Condition {
+		t.Helper()
+		runner.hookCalls = nil
+		task := NewBackupOverdueTask(repo, plan.Id, kv)
+		require.NoError(t, task.Run(context.Background(), ScheduledTask{Task: task, RunAt: time.Now()}, runner))
+		var conds []v1.Hook_Condition
+		for _, call := range runner.hookCalls {
+			conds = append(conds, call.Events...)
+		}
+		return conds
+	}
+
+	// no backups have been attempted, there is nothing to measure from.
+	assert.Empty(t, runCheck())
+
+	addBackup(3*24*time.Hour, v1.OperationStatus_STATUS_SUCCESS)
+	addBackup(2*24*time.Hour, v1.OperationStatus_STATUS_ERROR)
+	assert.Equal(t, []v1.Hook_Condition{v1.Hook_CONDITION_BACKUP_OVERDUE}, runCheck())
+	assert.Contains(t, runner.hookCalls[0].Vars.Error, "no successful backup since")
+	assert.Empty(t, runCheck(), "overdue episode should only be notified once")
+
+	addBackup(time.Hour, v1.OperationStatus_STATUS_WARNING)
+	assert.Equal(t, []v1.Hook_Condition{v1.Hook_CONDITION_BACKUP_OVERDUE_RECOVERED}, runCheck())
+	assert.Empty(t, runCheck(), "recovery should only be notified once")
+}
+
+func TestBackupOverdueTaskRunOnlyFailedBackups(t *testing.T) {
+	repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+	plan := &v1.Plan{Id: "plan1", Repo: "repo1", Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyHours{MaxFrequencyHours: 1}}, OverdueGraceMultiplier: 4}
+	cfg := newTestConfig(repo, plan)
+	runner := setupTestRunner(t, cfg, &fakeRepoOrchestrator{})
+	kv, err := kvstore.NewSqliteKVStore(kvstore.NewInMemorySqliteDbForKvStore(t), "test")
+	require.NoError(t, err)
+
+	for _, startedAgo := range []time.Duration{5 * time.Hour, 3 * time.Hour} {
+		require.NoError(t, runner.CreateOperation(&v1.Operation{
+			RepoId:          repo.Id,
+			RepoGuid:        repo.Guid,
+			PlanId:          plan.Id,
+			FlowId:          1,
+			Status:          v1.OperationStatus_STATUS_ERROR,
+			UnixTimeStartMs: time.Now().Add(-startedAgo).UnixMilli(),
+			UnixTimeEndMs:   time.Now().Add(-startedAgo).Add(time.Minute).UnixMilli(),
+			Op:              &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}},
+		}))
+	}
+
+	// the plan has failed since its first attempt 5 hours ago, beyond the allowed 4 hours.
+	task := NewBackupOverdueTask(repo, plan.Id, kv)
+	require.NoError(t, task.Run(context.Background(), ScheduledTask{Task: task, RunAt: time.Now()}, runner))
+	require.Len(t, runner.hookCalls, 1)
+	assert.Equal(t, []v1.Hook_Condition{v1.Hook_CONDITION_BACKUP_OVERDUE}, runner.hookCalls[0].Events)
+}
+
+func TestDigestTaskRun(t *testing.T) {
+	repo := &v1.Repo{Id: "repo1", Guid: "guid1"}
+	plan1 := &v1.Plan{Id: "plan1", Repo: "repo1", Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}}}
+	plan2 := &v1.Plan{Id: "plan2", Repo: "repo1", Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}}}
+	cfg := newTestConfig(repo, plan1, plan2)
+	cfg.Digest = &v1.DigestPolicy{Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}}}
+	runner := setupTestRunner(t, cfg, &fakeRepoOrchestrator{})
+	kv, err := kvstore.NewSqliteKVStore(kvstore.NewInMemorySqliteDbForKvStore(t), "test")
+	require.NoError(t, err)
+
+	addOp := func(planID string, startedAgo time.Duration, status v1.OperationStatus, op *v1.Operation) {
+		t.Helper()
+		op.RepoId = repo.Id
+		op.RepoGuid = repo.Guid
+		op.PlanId = planID
+		op.FlowId = 1
+		op.Status = status
+		op.UnixTimeStartMs = time.Now().Add(-startedAgo).UnixMilli()
+		op.UnixTimeEndMs = time.Now().Add(-startedAgo).Add(time.Minute).UnixMilli()
+		require.NoError(t, runner.CreateOperation(op))
+	}
+	backup := func(dataAdded int64) *v1.Operation {
+		return &v1.Operation{Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+			LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{DataAdded: dataAdded}}},
+		}}}
+	}
+	// plan1 backs up regularly, plan2 last succeeded 5 days ago and has been failing since.
+	addOp("plan1", 2*time.Hour, v1.OperationStatus_STATUS_ERROR, backup(0))
+	addOp("plan1", time.Hour, v1.OperationStatus_STATUS_SUCCESS, backup(100))
+	addOp("plan1", 25*time.Hour, v1.OperationStatus_STATUS_SUCCESS, backup(10)) // before the period, likely on the same day as its start.
+	addOp("plan2", 5*24*time.Hour, v1.OperationStatus_STATUS_SUCCESS, backup(1000))
+	addOp("plan2", time.Hour, v1.OperationStatus_STATUS_ERROR, backup(0))
+	addOp(PlanForSystemTasks, 3*time.Hour, v1.OperationStatus_STATUS_SUCCESS, &v1.Operation{Op: &v1.Operation_OperationStats{OperationStats: &v1.OperationStats{
+		Stats: &v1.RepoStats{TotalSize: 5000, SnapshotCount: 7},
+	}}})
+
+	task := NewDigestTask(kv)
+	st, err := task.Next(time.Now(), runner)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), st.RunAt, time.Minute, "first digest should run a period after the digest is enabled")
+
+	require.NoError(t, task.Run(context.Background(), ScheduledTask{Task: task, RunAt: time.Now()}, runner))
+	require.Len(t, runner.hookCalls, 1)
+	assert.Equal(t, []v1.Hook_Condition{v1.Hook_CONDITION_DIGEST}, runner.hookCalls[0].Events)
+
+	report := runner.hookCalls[0].Vars.Digest
+	require.NotNil(t, report)
+	assert.Equal(t, "test-instance", report.Instance)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), report.PeriodStart, time.Minute)
+	require.Len(t, report.Plans, 2)
+
+	assert.Equal(t, "plan1", report.Plans[0].PlanID)
+	assert.Equal(t, "repo1", report.Plans[0].RepoID)
+	assert.Equal(t, "success", report.Plans[0].LastBackupStatus)
+	assert.Equal(t, int64(1), report.Plans[0].BackupsSuccess)
+	assert.Equal(t, int64(1), report.Plans[0].BackupsFailed)
+	assert.Equal(t, int64(100), report.Plans[0].BytesAdded)
+	assert.False(t, report.Plans[0].Overdue)
+
+	assert.Equal(t, "error", report.Plans[1].LastBackupStatus)
+	assert.Equal(t, int64(0), report.Plans[1].BackupsSuccess, "a success outside the period should not be counted")
+	assert.True(t, report.Plans[1].Overdue)
+	assert.Equal(t, []string{"plan2"}, report.OverduePlans)
+
+	require.Len(t, report.Repos, 1)
+	assert.Equal(t, int64(100), report.Repos[0].BytesAdded)
+	assert.Equal(t, int64(5000), report.Repos[0].TotalSize)
+	assert.Equal(t, int64(7), report.Repos[0].SnapshotCount)
+	assert.False(t, report.Repos[0].StatsTime.IsZero())
+
+	vars := runner.hookCalls[0].Vars
+	vars.Event = v1.Hook_CONDITION_DIGEST // set per hook when hooks run.
+	summary, err := vars.Summary()
+	require.NoError(t, err)
+	assert.Contains(t, summary, "Overdue plans: plan2")
+
+	// the next digest is scheduled a period after this one and covers the time since.
+	st, err = task.Next(time.Now(), runner)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), st.RunAt, time.Minute)
+	runner.hookCalls = nil
+	require.NoError(t, task.Run(context.Background(), ScheduledTask{Task: task, RunAt: time.Now()}, runner))
+	assert.WithinDuration(t, time.Now(), runner.hookCalls[0].Vars.Digest.PeriodStart, time.Minute)
+}
@@ -101,3 +101,29 @@ func statsHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) e
 
 	return nil
 }
+
+// latestRepoStats returns the stats of the repo's latest successful stats operation, or nil if there is none.
+func latestRepoStats(runner TaskRunner, repoGUID string) (*v1.RepoStats, error) {
+	op, err := latestRepoStatsOperation(runner, repoGUID)
+	if err != nil {
+		return nil, err
+	}
+	return op.GetOperationStats().GetStats(), nil
+}
+
+// latestRepoStatsOperation returns the repo's latest successful stats operation, or nil if there is none.
+func latestRepoStatsOperation(runner TaskRunner, repoGUID string) (*v1.Operation, error) {
+	var statsOp *v1.Operation
+	if err := runner.QueryOperations(oplog.Query{}.
+		SetRepoGUID(repoGUID).
+		SetReversed(true), func(op *v1.Operation) error {
+		if s := op.GetOperationStats().GetStats(); s != nil && op.Status == v1.OperationStatus_STATUS_SUCCESS {
+			statsOp = op
+			return oplog.ErrStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("find latest stats: %w", err)
+	}
+	return statsOp, nil
+}
@@ -0,0 +1,100 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+// ErrSnapshotNotFound is returned by tasks for a snapshot that isn't in the repo.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// NewOneoffPinSnapshotTask adds or removes the reserved pinned tag on a snapshot. restic rewrites a re-tagged snapshot
+// under a new ID, so the snapshot's operations are moved to the new ID to keep its history intact.
+func NewOneoffPinSnapshotTask(repo *v1.Repo, at time.Time, snapshotID string, pinned bool) Task {
+	verb := "pin"
+	if !pinned {
+		verb = "unpin"
+	}
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskType:   "tag_snapshot",
+			TaskName:   fmt.Sprintf("%v snapshot %q in repo %q", verb, snapshotID, repo.Id),
+			TaskRepo:   repo,
+			TaskPlanID: PlanForSystemTasks,
+		},
+		RunAt: at,
+		Do: func(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+			return pinSnapshotHelper(ctx, st, runner, snapshotID, pinned)
+		},
+	}
+}
+
+func pinSnapshotHelper(ctx context.Context, st ScheduledTask, runner TaskRunner, snapshotID string, pinned bool) error {
+	t := st.Task
+
+	r, err := runner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("get repo %q: %w", t.RepoID(), err)
+	}
+
+	snapshots, err := r.Snapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("list snapshots: %w", err)
+	}
+	idx := slices.IndexFunc(snapshots, func(sn *restic.Snapshot) bool { return sn.Id == snapshotID })
+	if idx == -1 {
+		return fmt.Errorf("snapshot %q in repo %q: %w", snapshotID, t.RepoID(), ErrSnapshotNotFound)
+	}
+	original := snapshots[idx].Original
+	if original == "" {
+		original = snapshotID
+	}
+
+	if pinned {
+		err = r.AddTags(ctx, []string{snapshotID}, []string{restic.PinnedTag})
+	} else {
+		err = r.RemoveTags(ctx, []string{snapshotID}, []string{restic.PinnedTag})
+	}
+	if err != nil {
+		return fmt.Errorf("update tags of snapshot %q: %w", snapshotID, err)
+	}
+
+	snapshots, err = r.Snapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("list snapshots: %w", err)
+	}
+	idx = slices.IndexFunc(snapshots, func(sn *restic.Snapshot) bool { return sn.Original == original && sn.Id != snapshotID })
+	if idx == -1 {
+		return nil // the snapshot's tags were unchanged e.g. it was already pinned.
+	}
+	retagged := protoutil.SnapshotToProto(snapshots[idx])
+
+	var ops []*v1.Operation
+	if err := runner.QueryOperations(oplog.Query{}.SetRepoGUID(t.Repo().GetGuid()).SetSnapshotID(snapshotID), func(op *v1.Operation) error {
+		op.SnapshotId = retagged.Id
+		if indexOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
+			indexOp.OperationIndexSnapshot.Snapshot = retagged
+			indexOp.OperationIndexSnapshot.Pinned = repo.IsPinned(retagged.Tags)
+		}
+		ops = append(ops, op)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("query operations for snapshot %q: %w", snapshotID, err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := runner.UpdateOperation(ops...); err != nil {
+		return fmt.Errorf("update operations for snapshot %q: %w", snapshotID, err)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package tasks
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/pkg/restic"
@@ -15,25 +17,42 @@ type fakeRepoOrchestrator struct {
 
 	backupResult *restic.BackupProgressEntry
 	backupErr    error
+	backupPlan   *v1.Plan // plan of the last backup.
 
 	forgetResult []*v1.ResticSnapshot
 	forgetErr    error
 
 	forgetSnapshotErr error
 
-	pruneErr error
-	checkErr error
+	pruneOutput string // written to the output of Prune.
+	pruneErr    error
+	checkOutput string // written to the output of Check.
+	checkErr    error
+	copyErr     error
 
 	statsResult *v1.RepoStats
 	statsErr    error
 
-	restoreResult *v1.RestoreProgressEntry
-	restoreErr    error
+	restoreResult  *v1.RestoreProgressEntry
+	restoreOptions *v1.RestoreOptions // options of the last restore.
+	restoreTarget  string             // target of the last restore.
+	restoreFiles   map[string][]byte  // files written relative to the target of a restore, keyed by snapshot path.
+	restoreErr     error
 
 	snapshots    []*restic.Snapshot
 	snapshotsErr error
 
-	addTagsErr error
+	lsEntries    []*v1.LsEntry
+	listFilesErr error
+
+	dumpContents map[string][]byte // file contents returned by Dump, keyed by snapshot path.
+	dumpErr      error
+
+	findMatches []*v1.FileMatch
+	findErr     error
+
+	addTagsErr     error
+	retagSnapshots []*restic.Snapshot // if set, replaces snapshots once tags are added or removed.
 
 	runCommandErr error
 }
@@ -45,6 +64,7 @@ func (f *fakeRepoOrchestrator) UnlockIfAutoEnabled(ctx context.Context) error {
 }
 
 func (f *fakeRepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, dryRun bool, cb func(event *restic.BackupProgressEntry)) (*restic.BackupProgressEntry, error) {
+	f.backupPlan = plan
 	if cb != nil && f.backupResult != nil {
 		cb(f.backupResult)
 	}
@@ -60,18 +80,35 @@ func (f *fakeRepoOrchestrator) ForgetSnapshot(ctx context.Context, snapshotId st
 }
 
 func (f *fakeRepoOrchestrator) Prune(ctx context.Context, output io.Writer) error {
+	io.WriteString(output, f.pruneOutput)
 	return f.pruneErr
 }
 
 func (f *fakeRepoOrchestrator) Check(ctx context.Context, output io.Writer) error {
+	io.WriteString(output, f.checkOutput)
 	return f.checkErr
 }
 
+func (f *fakeRepoOrchestrator) CopyFrom(ctx context.Context, source *v1.Repo, output io.Writer) error {
+	return f.copyErr
+}
+
 func (f *fakeRepoOrchestrator) Stats(ctx context.Context) (*v1.RepoStats, error) {
 	return f.statsResult, f.statsErr
 }
 
-func (f *fakeRepoOrchestrator) Restore(ctx context.Context, snapshotId string, snapshotPath string, target string, cb func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
+func (f *fakeRepoOrchestrator) Restore(ctx context.Context, snapshotId string, snapshotPath string, target string, options *v1.RestoreOptions, cb func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
+	f.restoreOptions = options
+	f.restoreTarget = target
+	for p, data := range f.restoreFiles {
+		dest := filepath.Join(target, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return nil, err
+		}
+	}
 	if cb != nil && f.restoreResult != nil {
 		cb(f.restoreResult)
 	}
@@ -82,10 +119,42 @@ func (f *fakeRepoOrchestrator) Snapshots(ctx context.Context) ([]*restic.Snapsho
 	return f.snapshots, f.snapshotsErr
 }
 
+func (f *fakeRepoOrchestrator) ListFiles(ctx context.Context, snapshotId string, path string, cb func(entry *v1.LsEntry)) error {
+	for _, e := range f.lsEntries {
+		cb(e)
+	}
+	return f.listFilesErr
+}
+
+func (f *fakeRepoOrchestrator) Dump(ctx context.Context, snapshotId string, snapshotPath string, output io.Writer) error {
+	if f.dumpErr != nil {
+		return f.dumpErr
+	}
+	_, err := output.Write(f.dumpContents[snapshotPath])
+	return err
+}
+
+func (f *fakeRepoOrchestrator) FindFiles(ctx context.Context, query *v1.FindFilesQuery, cb func(match *v1.FileMatch)) error {
+	for _, m := range f.findMatches {
+		cb(m)
+	}
+	return f.findErr
+}
+
 func (f *fakeRepoOrchestrator) AddTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	if f.addTagsErr == nil && f.retagSnapshots != nil {
+		f.snapshots = f.retagSnapshots
+	}
 	return f.addTagsErr
 }
 
+func (f *fakeRepoOrchestrator) RemoveTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	if f.retagSnapshots != nil {
+		f.snapshots = f.retagSnapshots
+	}
+	return nil
+}
+
 func (f *fakeRepoOrchestrator) RunCommand(ctx context.Context, command string, writer io.Writer) error {
 	return f.runCommandErr
 }
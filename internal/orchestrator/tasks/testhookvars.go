@@ -0,0 +1,166 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+const sampleSnapshotID = "4bd9bf2a3c6e8f0d1e2a5b7c9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f7a8b9c0"
+
+// HookVarsForTest builds the variables for test firing a hook for event. Snapshot details are filled from the plan's
+// most recent backup if it has one, otherwise with sample values. repo and plan may be nil, sample ones are used so
+// that templates referencing them render.
+func HookVarsForTest(runner TaskRunner, event v1.Hook_Condition, repo *v1.Repo, plan *v1.Plan) (HookVars, error) {
+	now := time.Now()
+	vars := HookVars{
+		Task:    "test hook",
+		Event:   event,
+		Repo:    repo,
+		Plan:    plan,
+		CurTime: now,
+	}
+	if vars.Repo == nil {
+		vars.Repo = &v1.Repo{Id: "sample-repo"}
+	}
+	if vars.Plan == nil {
+		vars.Plan = &v1.Plan{Id: "sample-plan", Repo: vars.Repo.Id, Paths: []string{"/sample/path"}}
+	}
+
+	var lastBackup *v1.Operation
+	if repo != nil && plan != nil {
+		if err := runner.QueryOperations(oplog.Query{}.
+			SetInstanceID(runner.InstanceID()).
+			SetRepoGUID(repo.GetGuid()).
+			SetPlanID(plan.Id).
+			SetReversed(true), func(op *v1.Operation) error {
+			backupOp := op.GetOperationBackup()
+			if backupOp == nil || backupOp.DryRun || op.UnixTimeEndMs == 0 {
+				return nil
+			}
+			lastBackup = op
+			return oplog.ErrStopIteration
+		}); err != nil {
+			return HookVars{}, fmt.Errorf("find last backup of plan %q: %w", plan.Id, err)
+		}
+	}
+
+	if lastBackup != nil {
+		vars.SnapshotId = lastBackup.SnapshotId
+		vars.Duration = time.Duration(lastBackup.UnixTimeEndMs-lastBackup.UnixTimeStartMs) * time.Millisecond
+		if summary := lastBackup.GetOperationBackup().GetLastStatus().GetSummary(); summary != nil {
+			vars.SnapshotStats = backupSummaryFromProto(summary)
+		}
+		if lastBackup.Status == v1.OperationStatus_STATUS_ERROR {
+			vars.Error = lastBackup.DisplayMessage
+		}
+	} else {
+		vars.SnapshotId = sampleSnapshotID
+		vars.Duration = 2*time.Minute + 30*time.Second
+		vars.SnapshotStats = &restic.BackupProgressEntry{
+			MessageType:         "summary",
+			FilesNew:            12,
+			FilesChanged:        3,
+			FilesUnmodified:     1024,
+			DirsNew:             2,
+			DirsChanged:         1,
+			DirsUnmodified:      128,
+			DataBlobs:           20,
+			TreeBlobs:           4,
+			DataAdded:           52428800,
+			TotalFilesProcessed: 1039,
+			TotalBytesProcessed: 2147483648,
+			TotalDuration:       150,
+			SnapshotId:          sampleSnapshotID,
+		}
+	}
+
+	if vars.Error == "" && (protoutil.IsErrorCondition(event) || event == v1.Hook_CONDITION_BACKUP_OVERDUE) {
+		vars.Error = "this is a sample error message sent by a hook test"
+	}
+
+	switch event {
+	case v1.Hook_CONDITION_RESTORE_START, v1.Hook_CONDITION_RESTORE_ERROR, v1.Hook_CONDITION_RESTORE_SUCCESS:
+		vars.RestorePath = "/"
+		vars.RestoreTarget = "/tmp/backrest-restore"
+		vars.RestoreStats = &v1.RestoreProgressEntry{
+			MessageType:    "summary",
+			SecondsElapsed: 42,
+			TotalFiles:     1039,
+			FilesRestored:  1039,
+			TotalBytes:     2147483648,
+			BytesRestored:  2147483648,
+			PercentDone:    1,
+		}
+	}
+
+	switch event {
+	case v1.Hook_CONDITION_FORGET_SUCCESS:
+		vars.ForgottenSnapshots = []*v1.ResticSnapshot{
+			{Id: sampleSnapshotID, UnixTimeMs: now.Add(-30 * 24 * time.Hour).UnixMilli(), Hostname: "sample-host", Paths: vars.Plan.Paths},
+		}
+	case v1.Hook_CONDITION_PRUNE_SUCCESS:
+		vars.PruneStats = &restic.PruneSummary{
+			BlobsToRepack:         120,
+			BytesToRepack:         15728640,
+			BlobsRemovedByRepack:  80,
+			BytesRemovedByRepack:  10485760,
+			BlobsToDelete:         400,
+			BytesToDelete:         524288000,
+			TotalBlobsPruned:      480,
+			TotalBytesPruned:      534773760,
+			RemainingBlobs:        20480,
+			RemainingBytes:        2147483648,
+			UnusedBytesAfterPrune: 5242880,
+		}
+	case v1.Hook_CONDITION_CHECK_ERROR:
+		vars.CheckErrors = "error: load <snapshot/" + sampleSnapshotID[:8] + ">: sample check error\nFatal: repository contains errors"
+	}
+	switch event {
+	case v1.Hook_CONDITION_FORGET_ERROR, v1.Hook_CONDITION_FORGET_SUCCESS,
+		v1.Hook_CONDITION_PRUNE_ERROR, v1.Hook_CONDITION_PRUNE_SUCCESS,
+		v1.Hook_CONDITION_CHECK_ERROR, v1.Hook_CONDITION_CHECK_SUCCESS:
+		var err error
+		if repo != nil {
+			if vars.RepoStats, err = latestRepoStats(runner, repo.GetGuid()); err != nil {
+				return HookVars{}, fmt.Errorf("find stats of repo %q: %w", repo.Id, err)
+			}
+		}
+		if vars.RepoStats == nil {
+			vars.RepoStats = &v1.RepoStats{TotalSize: 2147483648, TotalUncompressedSize: 3221225472, CompressionRatio: 1.5, TotalBlobCount: 20480, SnapshotCount: 42}
+		}
+	}
+
+	if event == v1.Hook_CONDITION_DIGEST {
+		report, err := buildDigestReport(runner.Config(), runner, now.Add(-24*time.Hour), now)
+		if err != nil {
+			return HookVars{}, fmt.Errorf("build digest report: %w", err)
+		}
+		vars.Digest = report
+	}
+
+	return vars, nil
+}
+
+func backupSummaryFromProto(summary *v1.BackupProgressSummary) *restic.BackupProgressEntry {
+	return &restic.BackupProgressEntry{
+		MessageType:         "summary",
+		FilesNew:            summary.FilesNew,
+		FilesChanged:        summary.FilesChanged,
+		FilesUnmodified:     summary.FilesUnmodified,
+		DirsNew:             summary.DirsNew,
+		DirsChanged:         summary.DirsChanged,
+		DirsUnmodified:      summary.DirsUnmodified,
+		DataBlobs:           summary.DataBlobs,
+		TreeBlobs:           summary.TreeBlobs,
+		DataAdded:           summary.DataAdded,
+		TotalFilesProcessed: summary.TotalFilesProcessed,
+		TotalBytesProcessed: summary.TotalBytesProcessed,
+		TotalDuration:       summary.TotalDuration,
+		SnapshotId:          summary.SnapshotId,
+	}
+}
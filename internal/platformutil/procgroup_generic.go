@@ -0,0 +1,25 @@
+//go:build !windows
+// +build !windows
+
+package platformutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// SetProcessGroup starts the command in a new process group so that KillProcessGroup can kill its children too.
+func SetProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// KillProcessGroup kills the started command and every process in its process group.
+func KillProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
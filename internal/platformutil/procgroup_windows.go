@@ -0,0 +1,26 @@
+//go:build windows
+// +build windows
+
+package platformutil
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+func SetProcessGroup(cmd *exec.Cmd) {
+	// No process group is needed on Windows, KillProcessGroup kills the command's process tree.
+}
+
+// KillProcessGroup kills the started command and its child processes.
+func KillProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	kill := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid))
+	SetPlatformOptions(kill)
+	if err := kill.Run(); err != nil {
+		return cmd.Process.Kill()
+	}
+	return nil
+}
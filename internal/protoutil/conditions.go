@@ -5,19 +5,25 @@ import (
 )
 
 var startConditionsMap = map[v1.Hook_Condition]bool{
-	v1.Hook_CONDITION_CHECK_START:    true,
-	v1.Hook_CONDITION_PRUNE_START:    true,
-	v1.Hook_CONDITION_SNAPSHOT_START: true,
-	v1.Hook_CONDITION_FORGET_START:   true,
+	v1.Hook_CONDITION_CHECK_START:        true,
+	v1.Hook_CONDITION_PRUNE_START:        true,
+	v1.Hook_CONDITION_SNAPSHOT_START:     true,
+	v1.Hook_CONDITION_FORGET_START:       true,
+	v1.Hook_CONDITION_COPY_START:         true,
+	v1.Hook_CONDITION_RESTORE_TEST_START: true,
+	v1.Hook_CONDITION_RESTORE_START:      true,
 }
 
 var errorConditionsMap = map[v1.Hook_Condition]bool{
-	v1.Hook_CONDITION_ANY_ERROR:      true,
-	v1.Hook_CONDITION_CHECK_ERROR:    true,
-	v1.Hook_CONDITION_PRUNE_ERROR:    true,
-	v1.Hook_CONDITION_SNAPSHOT_ERROR: true,
-	v1.Hook_CONDITION_FORGET_ERROR:   true,
-	v1.Hook_CONDITION_UNKNOWN:        true,
+	v1.Hook_CONDITION_ANY_ERROR:          true,
+	v1.Hook_CONDITION_CHECK_ERROR:        true,
+	v1.Hook_CONDITION_PRUNE_ERROR:        true,
+	v1.Hook_CONDITION_SNAPSHOT_ERROR:     true,
+	v1.Hook_CONDITION_FORGET_ERROR:       true,
+	v1.Hook_CONDITION_COPY_ERROR:         true,
+	v1.Hook_CONDITION_RESTORE_TEST_ERROR: true,
+	v1.Hook_CONDITION_RESTORE_ERROR:      true,
+	v1.Hook_CONDITION_UNKNOWN:            true,
 }
 
 var logConditionsMap = map[v1.Hook_Condition]bool{
@@ -25,10 +31,13 @@ var logConditionsMap = map[v1.Hook_Condition]bool{
 }
 
 var successConditionsMap = map[v1.Hook_Condition]bool{
-	v1.Hook_CONDITION_CHECK_SUCCESS:    true,
-	v1.Hook_CONDITION_PRUNE_SUCCESS:    true,
-	v1.Hook_CONDITION_SNAPSHOT_SUCCESS: true,
-	v1.Hook_CONDITION_FORGET_SUCCESS:   true,
+	v1.Hook_CONDITION_CHECK_SUCCESS:        true,
+	v1.Hook_CONDITION_PRUNE_SUCCESS:        true,
+	v1.Hook_CONDITION_SNAPSHOT_SUCCESS:     true,
+	v1.Hook_CONDITION_FORGET_SUCCESS:       true,
+	v1.Hook_CONDITION_COPY_SUCCESS:         true,
+	v1.Hook_CONDITION_RESTORE_TEST_SUCCESS: true,
+	v1.Hook_CONDITION_RESTORE_SUCCESS:      true,
 }
 
 // IsErrorCondition returns true if the event is an error condition.
@@ -2,6 +2,10 @@ package protoutil
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/pkg/restic"
@@ -115,29 +119,79 @@ func ValidateRetentionPolicy(p *v1.RetentionPolicy) error {
 		return errors.New("retention policy must specify a policy")
 	}
 	if policyTimeBucketed, ok := p.GetPolicy().(*v1.RetentionPolicy_PolicyTimeBucketed); ok {
-		if proto.Equal(policyTimeBucketed.PolicyTimeBucketed, &v1.RetentionPolicy_TimeBucketedCounts{}) {
+		counts := policyTimeBucketed.PolicyTimeBucketed
+		if proto.Equal(counts, &v1.RetentionPolicy_TimeBucketedCounts{}) {
 			return errors.New("time bucketed policy must specify a non-empty bucket")
 		}
+		for _, d := range []struct{ name, duration string }{
+			{"keep_within", counts.KeepWithin},
+			{"keep_within_hourly", counts.KeepWithinHourly},
+			{"keep_within_daily", counts.KeepWithinDaily},
+			{"keep_within_weekly", counts.KeepWithinWeekly},
+			{"keep_within_monthly", counts.KeepWithinMonthly},
+			{"keep_within_yearly", counts.KeepWithinYearly},
+		} {
+			if d.duration != "" && !resticDurationRegex.MatchString(d.duration) {
+				return fmt.Errorf("%s %q is not a valid duration, expected a duration like 1y6m, 7d or 12h", d.name, d.duration)
+			}
+		}
+	}
+	for _, tags := range p.KeepTags {
+		if strings.TrimSpace(tags) == "" || slices.Contains(strings.Split(tags, ","), "") {
+			return fmt.Errorf("keep tags %q must not contain empty tags", tags)
+		}
+	}
+	return nil
+}
+
+// resticDurationRegex matches the durations accepted by restic's --keep-within flags.
+var resticDurationRegex = regexp.MustCompile(`^(\d+[ymdh])+$`)
+
+// ValidateGroupBy verifies that groupBy is a valid value for restic's --group-by flag.
+func ValidateGroupBy(groupBy string) error {
+	if groupBy == "" {
+		return nil
+	}
+	for _, field := range strings.Split(groupBy, ",") {
+		if field != "host" && field != "paths" && field != "tags" {
+			return fmt.Errorf("invalid group by field %q, must be one of host, paths or tags", field)
+		}
 	}
 	return nil
 }
 
+// ForgetGroupBy returns the value of restic's --group-by flag for the repo's forget policy.
+func ForgetGroupBy(p *v1.ForgetPolicy) string {
+	if p.GetGroupBy() != "" {
+		return p.GetGroupBy()
+	}
+	return "tags"
+}
+
 func RetentionPolicyFromProto(p *v1.RetentionPolicy) *restic.RetentionPolicy {
-	switch p := p.GetPolicy().(type) {
+	switch policy := p.GetPolicy().(type) {
 	case *v1.RetentionPolicy_PolicyKeepAll:
 		return nil
 	case *v1.RetentionPolicy_PolicyTimeBucketed:
 		return &restic.RetentionPolicy{
-			KeepDaily:   int(p.PolicyTimeBucketed.Daily),
-			KeepHourly:  int(p.PolicyTimeBucketed.Hourly),
-			KeepWeekly:  int(p.PolicyTimeBucketed.Weekly),
-			KeepMonthly: int(p.PolicyTimeBucketed.Monthly),
-			KeepYearly:  int(p.PolicyTimeBucketed.Yearly),
-			KeepLastN:   int(p.PolicyTimeBucketed.KeepLastN),
+			KeepDaily:          int(policy.PolicyTimeBucketed.Daily),
+			KeepHourly:         int(policy.PolicyTimeBucketed.Hourly),
+			KeepWeekly:         int(policy.PolicyTimeBucketed.Weekly),
+			KeepMonthly:        int(policy.PolicyTimeBucketed.Monthly),
+			KeepYearly:         int(policy.PolicyTimeBucketed.Yearly),
+			KeepLastN:          int(policy.PolicyTimeBucketed.KeepLastN),
+			KeepWithinDuration: policy.PolicyTimeBucketed.KeepWithin,
+			KeepWithinHourly:   policy.PolicyTimeBucketed.KeepWithinHourly,
+			KeepWithinDaily:    policy.PolicyTimeBucketed.KeepWithinDaily,
+			KeepWithinWeekly:   policy.PolicyTimeBucketed.KeepWithinWeekly,
+			KeepWithinMonthly:  policy.PolicyTimeBucketed.KeepWithinMonthly,
+			KeepWithinYearly:   policy.PolicyTimeBucketed.KeepWithinYearly,
+			KeepTags:           p.KeepTags,
 		}
 	case *v1.RetentionPolicy_PolicyKeepLastN:
 		return &restic.RetentionPolicy{
-			KeepLastN: int(p.PolicyKeepLastN),
+			KeepLastN: int(policy.PolicyKeepLastN),
+			KeepTags:  p.KeepTags,
 		}
 	default:
 		return nil
@@ -152,6 +206,38 @@ func RestoreProgressEntryToProto(p *restic.RestoreProgressEntry) *v1.RestoreProg
 		TotalBytes:    int64(p.TotalBytes),
 		BytesRestored: int64(p.BytesRestored),
 		PercentDone:   p.PercentDone,
+		FilesSkipped:  p.FilesSkipped,
+		BytesSkipped:  p.BytesSkipped,
+		FilesDeleted:  p.FilesDeleted,
+	}
+}
+
+func SnapshotDiffStatsToProto(e *restic.DiffEntry) *v1.SnapshotDiffStats {
+	countsToProto := func(s restic.DiffStats) *v1.SnapshotDiffCounts {
+		return &v1.SnapshotDiffCounts{
+			Files:     s.Files,
+			Dirs:      s.Dirs,
+			Others:    s.Others,
+			DataBlobs: s.DataBlobs,
+			TreeBlobs: s.TreeBlobs,
+			Bytes:     s.Bytes,
+		}
+	}
+	return &v1.SnapshotDiffStats{
+		ChangedFiles: e.ChangedFiles,
+		Added:        countsToProto(e.Added),
+		Removed:      countsToProto(e.Removed),
+		BytesDelta:   e.Added.Bytes - e.Removed.Bytes,
+	}
+}
+
+func RepoKeyToProto(k *restic.Key) *v1.RepoKey {
+	return &v1.RepoKey{
+		Id:       k.ID,
+		Current:  k.Current,
+		Username: k.UserName,
+		Hostname: k.HostName,
+		Created:  k.Created,
 	}
 }
 
@@ -1,6 +1,8 @@
 package protoutil
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -187,3 +189,120 @@ func TestRepoStatsToProto(t *testing.T) {
 		})
 	}
 }
+
+func TestRetentionPolicyFromProto(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy *v1.RetentionPolicy
+		want   *restic.RetentionPolicy
+	}{
+		{
+			name:   "keep all",
+			policy: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepAll{PolicyKeepAll: true}, KeepTags: []string{"important"}},
+			want:   nil,
+		},
+		{
+			name:   "keep last n",
+			policy: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5}, KeepTags: []string{"important"}},
+			want:   &restic.RetentionPolicy{KeepLastN: 5, KeepTags: []string{"important"}},
+		},
+		{
+			name: "time bucketed with keep within durations",
+			policy: &v1.RetentionPolicy{
+				Policy: &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{
+					Daily:             7,
+					KeepWithin:        "7d",
+					KeepWithinHourly:  "2d",
+					KeepWithinDaily:   "3m",
+					KeepWithinWeekly:  "6m",
+					KeepWithinMonthly: "2y",
+					KeepWithinYearly:  "10y",
+				}},
+				KeepTags: []string{"legal,hold"},
+			},
+			want: &restic.RetentionPolicy{
+				KeepDaily:          7,
+				KeepWithinDuration: "7d",
+				KeepWithinHourly:   "2d",
+				KeepWithinDaily:    "3m",
+				KeepWithinWeekly:   "6m",
+				KeepWithinMonthly:  "2y",
+				KeepWithinYearly:   "10y",
+				KeepTags:           []string{"legal,hold"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := RetentionPolicyFromProto(c.policy)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("wanted: %+v, got: %+v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  *v1.RetentionPolicy
+		wantErr bool
+	}{
+		{
+			name:   "keep within durations",
+			policy: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{KeepWithin: "1y6m", KeepWithinHourly: "12h"}}},
+		},
+		{
+			name:    "invalid keep within duration",
+			policy:  &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{KeepWithinDaily: "3 months"}}},
+			wantErr: true,
+		},
+		{
+			name:   "keep tags",
+			policy: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5}, KeepTags: []string{"important", "legal,hold"}},
+		},
+		{
+			name:    "empty keep tag",
+			policy:  &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5}, KeepTags: []string{""}},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateRetentionPolicy(c.policy)
+			if c.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			} else if !c.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateRetentionPolicyReportsFirstInvalidDuration(t *testing.T) {
+	policy := &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{
+		KeepWithin:       "forever",
+		KeepWithinYearly: "ten years",
+	}}}
+	for i := 0; i < 10; i++ {
+		err := ValidateRetentionPolicy(policy)
+		if err == nil || !strings.HasPrefix(err.Error(), "keep_within \"forever\"") {
+			t.Fatalf("ValidateRetentionPolicy() error = %v, want the keep_within error", err)
+		}
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	for _, groupBy := range []string{"", "tags", "host,paths,tags"} {
+		if err := ValidateGroupBy(groupBy); err != nil {
+			t.Errorf("ValidateGroupBy(%q) unexpected error: %v", groupBy, err)
+		}
+	}
+	for _, groupBy := range []string{"hostname", "host,", "host tags"} {
+		if err := ValidateGroupBy(groupBy); err == nil {
+			t.Errorf("ValidateGroupBy(%q) expected error, got nil", groupBy)
+		}
+	}
+}
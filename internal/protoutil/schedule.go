@@ -102,6 +102,24 @@ func NominalPeriod(sched *v1.Schedule, from time.Time) (time.Duration, error) {
 	}
 }
 
+// DefaultOverdueGraceMultiplier pads a schedule's nominal period before a plan is
+// considered overdue, tolerating scheduler jitter and backup run time.
+const DefaultOverdueGraceMultiplier = 1.25
+
+// AllowedStaleness returns the longest the plan may go without a successful backup
+// before it is overdue: its schedule's nominal period padded by the plan's grace multiplier.
+func AllowedStaleness(plan *v1.Plan, now time.Time) (time.Duration, error) {
+	period, err := NominalPeriod(plan.GetSchedule(), now)
+	if err != nil {
+		return 0, err
+	}
+	multiplier := plan.GetOverdueGraceMultiplier()
+	if multiplier == 0 {
+		multiplier = DefaultOverdueGraceMultiplier
+	}
+	return time.Duration(float64(period) * multiplier), nil
+}
+
 func ValidateSchedule(sched *v1.Schedule) error {
 	switch s := sched.GetSchedule().(type) {
 	case *v1.Schedule_MaxFrequencyDays:
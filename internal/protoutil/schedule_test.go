@@ -192,3 +192,19 @@ func TestNominalPeriod(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowedStaleness(t *testing.T) {
+	from := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	daily := &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyDays{MaxFrequencyDays: 1}}
+
+	got, err := protoutil.AllowedStaleness(&v1.Plan{Schedule: daily}, from)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Hour, got, "default multiplier")
+
+	got, err = protoutil.AllowedStaleness(&v1.Plan{Schedule: daily, OverdueGraceMultiplier: 7}, from)
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, got, "custom multiplier")
+
+	_, err = protoutil.AllowedStaleness(&v1.Plan{Schedule: &v1.Schedule{Schedule: &v1.Schedule_Disabled{Disabled: true}}}, from)
+	assert.ErrorIs(t, err, protoutil.ErrScheduleDisabled)
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -20,6 +23,7 @@ type Snapshot struct {
 	Username        string          `json:"username"`
 	Tags            []string        `json:"tags"`
 	Parent          string          `json:"parent"`
+	Original        string          `json:"original"` // ID of the snapshot this one was rewritten from e.g. by `restic tag`.
 	SnapshotSummary SnapshotSummary `json:"summary"`
 	unixTimeMs      int64           `json:"-"`
 }
@@ -160,6 +164,9 @@ type RestoreProgressEntry struct {
 	BytesRestored  int64   `json:"bytes_restored"`
 	TotalFiles     int64   `json:"total_files"`
 	FilesRestored  int64   `json:"files_restored"`
+	FilesSkipped   int64   `json:"files_skipped"`
+	FilesDeleted   int64   `json:"files_deleted"`
+	BytesSkipped   int64   `json:"bytes_skipped"`
 	PercentDone    float64 `json:"percent_done"`
 
 	// Verbose status fields
@@ -194,6 +201,56 @@ func (r *RestoreProgressEntry) IsSummary() bool {
 	return r.MessageType == "summary"
 }
 
+type DiffEntry struct {
+	MessageType string `json:"message_type"` // "change" or "statistics" or "exit_error"
+
+	// Change fields
+	Path     string `json:"path"`
+	Modifier string `json:"modifier"` // "+" added, "-" removed, "M" content modified, "T" type changed, "U" metadata changed, "?" bitrot
+
+	// Statistics fields
+	SourceSnapshot string    `json:"source_snapshot"`
+	TargetSnapshot string    `json:"target_snapshot"`
+	ChangedFiles   int64     `json:"changed_files"`
+	Added          DiffStats `json:"added"`
+	Removed        DiffStats `json:"removed"`
+
+	// Exit error fields
+	Message string `json:"message"`
+}
+
+type DiffStats struct {
+	Files     int64 `json:"files"`
+	Dirs      int64 `json:"dirs"`
+	Others    int64 `json:"others"`
+	DataBlobs int64 `json:"data_blobs"`
+	TreeBlobs int64 `json:"tree_blobs"`
+	Bytes     int64 `json:"bytes"`
+}
+
+var validDiffMessageTypes = map[string]struct{}{
+	"change":     {},
+	"statistics": {},
+}
+
+func (e *DiffEntry) Validate() error {
+	if _, ok := validDiffMessageTypes[e.MessageType]; !ok {
+		return fmt.Errorf("invalid message type: %v", e.MessageType)
+	}
+	return nil
+}
+
+func (e *DiffEntry) IsFatalError() error {
+	if e.MessageType == "exit_error" {
+		return errors.New(e.Message)
+	}
+	return nil
+}
+
+func (e *DiffEntry) IsSummary() bool {
+	return e.MessageType == "statistics"
+}
+
 type ProgressEntryValidator interface {
 	Validate() error
 	IsFatalError() error
@@ -289,32 +346,99 @@ func (e *LsEntry) ToProto() *v1.LsEntry {
 }
 
 func readLs(output io.Reader) (*Snapshot, []*LsEntry, error) {
+	var entries []*LsEntry
+	snapshot, err := readLsEntries(output, func(entry *LsEntry) {
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return snapshot, entries, nil
+}
+
+// readLsEntries parses the output of `restic ls --json`, invoking the callback for each entry as it is read.
+func readLsEntries(output io.Reader, callback func(entry *LsEntry)) (*Snapshot, error) {
 	scanner := bufio.NewScanner(output)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
 	scanner.Split(bufio.ScanLines)
 
 	if !scanner.Scan() {
-		return nil, nil, fmt.Errorf("failed to read first line, expected snapshot info")
+		return nil, fmt.Errorf("failed to read first line, expected snapshot info")
 	}
 
 	var snapshot *Snapshot
 	if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	var entries []*LsEntry
 	for scanner.Scan() {
 		var entry *LsEntry
 		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
-			return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
 		}
-		entries = append(entries, entry)
+		callback(entry)
 	}
-	return snapshot, entries, nil
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read output: %w", err)
+	}
+	return snapshot, nil
+}
+
+type FindMatch struct {
+	Path        string `json:"path"`
+	Permissions string `json:"permissions"`
+	Type        string `json:"type"`
+	Size        int64  `json:"size"`
+	Mtime       string `json:"mtime"`
+}
+
+// readFind parses the output of `restic find --json`, a JSON array with one entry per snapshot.
+// Entries are decoded one at a time so that only a single snapshot's matches are held in memory.
+func readFind(output io.Reader, callback func(snapshotID string, match *FindMatch)) error {
+	dec := json.NewDecoder(output)
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil // no snapshots were searched.
+	} else if err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected start of JSON array, got: %v", tok)
+	}
+
+	for dec.More() {
+		var entry struct {
+			Matches  []*FindMatch `json:"matches"`
+			Hits     int64        `json:"hits"`
+			Snapshot string       `json:"snapshot"`
+		}
+		if err := dec.Decode(&entry); err != nil {
+			return fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		if err := ValidateSnapshotId(entry.Snapshot); err != nil {
+			return err
+		}
+		for _, match := range entry.Matches {
+			callback(entry.Snapshot, match)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return nil
 }
 
 type ForgetResult struct {
-	Keep   []Snapshot `json:"keep"`
-	Remove []Snapshot `json:"remove"`
+	Keep    []Snapshot   `json:"keep"`
+	Remove  []Snapshot   `json:"remove"`
+	Reasons []KeepReason `json:"reasons"`
+}
+
+// KeepReason lists the keep rules (e.g. "daily snapshot") a kept snapshot matched.
+type KeepReason struct {
+	Snapshot Snapshot `json:"snapshot"`
+	Matches  []string `json:"matches"`
 }
 
 func (r *ForgetResult) Validate() error {
@@ -348,8 +472,138 @@ type RepoStats struct {
 	SnapshotsCount         int64   `json:"snapshots_count"`
 }
 
+// Key is a key (password) entry from `restic key list --json`.
+type Key struct {
+	Current  bool   `json:"current"`
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	HostName string `json:"hostName"`
+	Created  string `json:"created"`
+}
+
 type RepoConfig struct {
 	Version           int    `json:"version"`
 	Id                string `json:"id"`
 	ChunkerPolynomial string `json:"chunker_polynomial"`
 }
+
+// PruneSummary is the summary restic prune prints of the blobs it removes or repacks, sizes are in bytes. Pack
+// counts are only printed by restic prune --verbose.
+type PruneSummary struct {
+	PacksToKeep           int64
+	PacksToRepack         int64
+	PacksToDelete         int64 // includes unreferenced packs.
+	BlobsToRepack         int64 // blobs in packs that are rewritten.
+	BytesToRepack         int64
+	BlobsRemovedByRepack  int64 // unused blobs dropped while repacking.
+	BytesRemovedByRepack  int64
+	BlobsToDelete         int64 // blobs in packs that are deleted outright.
+	BytesToDelete         int64
+	TotalBlobsPruned      int64
+	TotalBytesPruned      int64 // the space prune frees.
+	RemainingBlobs        int64
+	RemainingBytes        int64
+	UnusedBytesAfterPrune int64
+}
+
+var (
+	pruneBlobsLineRegex  = regexp.MustCompile(`^(to repack|this removes|to delete|total prune|remaining):?\s+(\d+) blobs / ([\d.]+ ?[KMGTPE]?i?B)`)
+	pruneUnusedLineRegex = regexp.MustCompile(`^unused size after prune:\s+([\d.]+ ?[KMGTPE]?i?B)`)
+	prunePacksLineRegex  = regexp.MustCompile(`^(to keep|to repack|to delete):\s+(\d+) (?:unreferenced )?packs$`)
+)
+
+// ParsePruneSummary parses the summary from restic prune's text output. restic prune ignores --json as of 0.19, it
+// prints the summary as text only. It returns an error if the output has no summary e.g. because the prune failed
+// before computing one.
+func ParsePruneSummary(output io.Reader) (*PruneSummary, error) {
+	summary := &PruneSummary{}
+	found := false
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := pruneUnusedLineRegex.FindStringSubmatch(line); m != nil {
+			bytes, err := parseResticSize(m[1])
+			if err != nil {
+				return nil, err
+			}
+			summary.UnusedBytesAfterPrune = bytes
+			found = true
+			continue
+		}
+		if m := prunePacksLineRegex.FindStringSubmatch(line); m != nil {
+			packs, err := strconv.ParseInt(m[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse pack count in %q: %w", line, err)
+			}
+			switch m[1] {
+			case "to keep":
+				summary.PacksToKeep = packs
+			case "to repack":
+				summary.PacksToRepack = packs
+			case "to delete":
+				summary.PacksToDelete += packs
+			}
+			continue
+		}
+		m := pruneBlobsLineRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		blobs, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse blob count in %q: %w", line, err)
+		}
+		bytes, err := parseResticSize(m[3])
+		if err != nil {
+			return nil, err
+		}
+		switch m[1] {
+		case "to repack":
+			summary.BlobsToRepack, summary.BytesToRepack = blobs, bytes
+		case "this removes":
+			summary.BlobsRemovedByRepack, summary.BytesRemovedByRepack = blobs, bytes
+		case "to delete":
+			summary.BlobsToDelete, summary.BytesToDelete = blobs, bytes
+		case "total prune":
+			summary.TotalBlobsPruned, summary.TotalBytesPruned = blobs, bytes
+		case "remaining":
+			summary.RemainingBlobs, summary.RemainingBytes = blobs, bytes
+		}
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("no prune summary found in output")
+	}
+	return summary, nil
+}
+
+var resticSizeUnits = map[string]float64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+	"PiB": 1 << 50,
+	"EiB": 1 << 60,
+}
+
+// parseResticSize parses a size formatted by restic e.g. "1.453 MiB" into bytes.
+func parseResticSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	idx := strings.IndexFunc(size, func(r rune) bool { return r != '.' && (r < '0' || r > '9') })
+	if idx <= 0 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	value, err := strconv.ParseFloat(size[:idx], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", size, err)
+	}
+	unit, ok := resticSizeUnits[strings.TrimSpace(size[idx:])]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit", size)
+	}
+	return int64(value * unit), nil
+}
@@ -89,3 +89,159 @@ func TestReadLs(t *testing.T) {
 		t.Errorf("wanted 3 entries, got: %d", len(entries))
 	}
 }
+
+func TestReadDiffEntries(t *testing.T) {
+	t.Parallel()
+	testInput := `{"message_type":"change","path":"/foo/added.txt","modifier":"+"}
+{"message_type":"change","path":"/foo/removed.txt","modifier":"-"}
+{"message_type":"change","path":"/foo/modified.txt","modifier":"M"}
+{"message_type":"statistics","source_snapshot":"d4558b36","target_snapshot":"db155169","changed_files":3,"added":{"files":2,"dirs":0,"others":0,"data_blobs":2,"tree_blobs":1,"bytes":2048},"removed":{"files":1,"dirs":0,"others":0,"data_blobs":1,"tree_blobs":1,"bytes":512}}`
+
+	var changes []*DiffEntry
+	summary, err := processProgressOutput[*DiffEntry](bytes.NewBuffer([]byte(testInput)), nil, func(event *DiffEntry) {
+		if event.MessageType == "change" {
+			changes = append(changes, event)
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to read diff events: %v", err)
+	}
+
+	wantChanges := []*DiffEntry{
+		{MessageType: "change", Path: "/foo/added.txt", Modifier: "+"},
+		{MessageType: "change", Path: "/foo/removed.txt", Modifier: "-"},
+		{MessageType: "change", Path: "/foo/modified.txt", Modifier: "M"},
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("wanted changes %+v, got: %+v", wantChanges, changes)
+	}
+	if summary.ChangedFiles != 3 {
+		t.Errorf("wanted 3 changed files, got: %d", summary.ChangedFiles)
+	}
+	if summary.Added.Bytes != 2048 || summary.Removed.Bytes != 512 {
+		t.Errorf("wanted 2048 bytes added and 512 bytes removed, got: %d and %d", summary.Added.Bytes, summary.Removed.Bytes)
+	}
+}
+
+func TestReadFind(t *testing.T) {
+	t.Parallel()
+	snapshotA := strings.Repeat("a", 64)
+	snapshotB := strings.Repeat("b", 64)
+	testInput := `[{"matches":[{"path":"/foo/bar.txt","permissions":"-rw-r--r--","type":"file","size":12,"mtime":"2024-01-01T00:00:00Z"}],"hits":1,"snapshot":"` + snapshotA + `"},` +
+		`{"matches":[{"path":"/foo","permissions":"drwxr-xr-x","type":"dir","size":0,"mtime":"2024-01-01T00:00:00Z"},{"path":"/foo/bar.txt","permissions":"-rw-r--r--","type":"file","size":24,"mtime":"2024-01-02T00:00:00Z"}],"hits":2,"snapshot":"` + snapshotB + `"}]`
+
+	var snapshots []string
+	var matches []*FindMatch
+	if err := readFind(bytes.NewBufferString(testInput), func(snapshotID string, match *FindMatch) {
+		snapshots = append(snapshots, snapshotID)
+		matches = append(matches, match)
+	}); err != nil {
+		t.Fatalf("failed to read find output: %v", err)
+	}
+
+	wantSnapshots := []string{snapshotA, snapshotB, snapshotB}
+	if !reflect.DeepEqual(snapshots, wantSnapshots) {
+		t.Errorf("wanted snapshots %v, got: %v", wantSnapshots, snapshots)
+	}
+	if len(matches) != 3 {
+		t.Fatalf("wanted 3 matches, got: %d", len(matches))
+	}
+	if matches[2].Path != "/foo/bar.txt" || matches[2].Type != "file" || matches[2].Size != 24 {
+		t.Errorf("wanted match for /foo/bar.txt with size 24, got: %+v", matches[2])
+	}
+
+	// restic prints nothing when there are no snapshots to search.
+	if err := readFind(bytes.NewBufferString(""), func(string, *FindMatch) {
+		t.Errorf("unexpected match")
+	}); err != nil {
+		t.Errorf("wanted no error for empty output, got: %v", err)
+	}
+}
+
+func TestParsePruneSummary(t *testing.T) {
+	t.Parallel()
+
+	output := `loading indexes...
+loading all snapshots...
+finding data that is still in use for 12 snapshots
+[0:00] 100.00%  12 / 12 snapshots
+searching used packs...
+collecting packs for deletion and repacking
+[0:00] 100.00%  40 / 40 packs processed
+
+to repack:           169 blobs / 1.012 MiB
+this removes:          0 blobs / 0 B
+to delete:            20 blobs / 1.453 MiB
+total prune:          20 blobs / 1.453 MiB
+remaining:          5337 blobs / 1.221 GiB
+unused size after prune: 24.432 KiB (0.00% of remaining size)
+
+repacking packs
+[0:00] 100.00%  2 / 2 packs repacked
+rebuilding index
+done
+`
+	summary, err := ParsePruneSummary(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParsePruneSummary() error: %v", err)
+	}
+	want := &PruneSummary{
+		BlobsToRepack:         169,
+		BytesToRepack:         1061158, // 1.012 MiB
+		BlobsToDelete:         20,
+		BytesToDelete:         1523580, // 1.453 MiB
+		TotalBlobsPruned:      20,
+		TotalBytesPruned:      1523580,
+		RemainingBlobs:        5337,
+		RemainingBytes:        1311038767, // 1.221 GiB
+		UnusedBytesAfterPrune: 25018,      // 24.432 KiB
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	verboseOutput := `
+used:            5357 blobs / 1.223 GiB
+unused:            20 blobs / 1.453 MiB
+total:           5377 blobs / 1.224 GiB
+unused size: 0.12% of total size
+
+to repack:          0 blobs / 0 B
+this removes:       0 blobs / 0 B
+to delete:         20 blobs / 1.453 MiB
+total prune:       20 blobs / 1.453 MiB
+remaining:       5357 blobs / 1.223 GiB
+unused size after prune: 0 B (0.00% of remaining size)
+
+totally used packs:         38
+partly used packs:           0
+unused packs:                2
+
+to keep:              38 packs
+to repack:             0 packs
+to delete:             2 packs
+to delete:             1 unreferenced packs
+
+`
+	summary, err = ParsePruneSummary(strings.NewReader(verboseOutput))
+	if err != nil {
+		t.Fatalf("ParsePruneSummary() error: %v", err)
+	}
+	want = &PruneSummary{
+		PacksToKeep:      38,
+		PacksToDelete:    3,
+		BlobsToDelete:    20,
+		BytesToDelete:    1523580,
+		TotalBlobsPruned: 20,
+		TotalBytesPruned: 1523580,
+		RemainingBlobs:   5357,
+		RemainingBytes:   1313186250, // 1.223 GiB
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("verbose summary = %+v, want %+v", summary, want)
+	}
+
+	if _, err := ParsePruneSummary(strings.NewReader("loading indexes...\n")); err == nil {
+		t.Error("expected an error for output without a summary")
+	}
+}
@@ -26,6 +26,7 @@ var errAlreadyInitialized = errors.New("repo already initialized")
 var ErrPartialBackup = errors.New("incomplete backup")
 var ErrBackupFailed = errors.New("backup failed")
 var ErrRestoreFailed = errors.New("restore failed")
+var ErrDiffFailed = errors.New("diff failed")
 var ErrRepoNotFound = errors.New("repo does not exist")
 
 type Repo struct {
@@ -64,6 +65,7 @@ func (r *Repo) commandWithContext(ctx context.Context, args []string, opts ...Ge
 	}
 	fullCmd = append(fullCmd, args...)
 	fullCmd = append(fullCmd, opt.extraArgs...)
+	fullCmd = append(fullCmd, opt.trailingArgs...)
 
 	cmd := exec.CommandContext(ctx, fullCmd[0], fullCmd[1:]...)
 	platformutil.SetPlatformOptions(cmd)
@@ -329,13 +331,39 @@ func (r *Repo) Backup(ctx context.Context, paths []string, progressCallback func
 	return runCommandWithProgress(ctx, r, args, progressCallback, ErrBackupFailed, opts...)
 }
 
-func (r *Repo) Restore(ctx context.Context, snapshot string, callback func(*RestoreProgressEntry), opts ...GenericOption) (*RestoreProgressEntry, error) {
+// BackupFromCommand backs up the stdout of command as a single file named filename using --stdin-from-command.
+// restic fails the backup without creating a snapshot if the command exits with a non-zero status.
+func (r *Repo) BackupFromCommand(ctx context.Context, command []string, filename string, progressCallback func(*BackupProgressEntry), opts ...GenericOption) (*BackupProgressEntry, error) {
+	if len(command) == 0 {
+		return nil, errors.New("command must not be empty")
+	}
+
+	args := []string{"backup", "--json", "--stdin-from-command", "--stdin-filename", filename}
+	// the command goes last so that flags from opts aren't passed to it.
+	opts = append(slices.Clone(opts), WithEnv("RESTIC_PROGRESS_FPS=2"), withTrailingArgs(append([]string{"--"}, command...)...))
+
+	return runCommandWithProgress(ctx, r, args, progressCallback, ErrBackupFailed, opts...)
+}
+
+// Restore restores snapshot, the target directory must be provided in opts with the --target flag. restoreOpts is optional.
+func (r *Repo) Restore(ctx context.Context, snapshot string, restoreOpts *RestoreOptions, callback func(*RestoreProgressEntry), opts ...GenericOption) (*RestoreProgressEntry, error) {
 	opts = append(slices.Clone(opts), WithEnv("RESTIC_PROGRESS_FPS=2"))
 	args := []string{"restore", "--json", snapshot}
+	if restoreOpts != nil {
+		args = append(args, restoreOpts.toRestoreFlags()...)
+	}
 
 	return runCommandWithProgress(ctx, r, args, callback, ErrRestoreFailed, opts...)
 }
 
+// Diff compares two snapshots using `restic diff`. The callback is invoked for each changed path
+// and the statistics entry is returned once the command completes.
+func (r *Repo) Diff(ctx context.Context, snapshotID string, otherSnapshotID string, callback func(*DiffEntry), opts ...GenericOption) (*DiffEntry, error) {
+	args := []string{"diff", "--json", snapshotID, otherSnapshotID}
+
+	return runCommandWithProgress(ctx, r, args, callback, ErrDiffFailed, opts...)
+}
+
 func (r *Repo) Snapshots(ctx context.Context, opts ...GenericOption) ([]*Snapshot, error) {
 
 	var snapshots []*Snapshot
@@ -374,6 +402,7 @@ func (r *Repo) Forget(ctx context.Context, policy *RetentionPolicy, opts ...Gene
 		}
 		merged.Keep = append(merged.Keep, r.Keep...)
 		merged.Remove = append(merged.Remove, r.Remove...)
+		merged.Reasons = append(merged.Reasons, r.Reasons...)
 	}
 
 	return merged, nil
@@ -391,6 +420,85 @@ func (r *Repo) ForgetSnapshot(ctx context.Context, snapshotId string, opts ...Ge
 	return nil
 }
 
+// Find searches the repository's snapshots for files matching any of the patterns using `restic find`.
+// The callback is invoked for each match along with the ID of the snapshot it was found in.
+func (r *Repo) Find(ctx context.Context, patterns []string, callback func(snapshotID string, match *FindMatch), opts ...GenericOption) error {
+	args := []string{"find", "--json"}
+	args = append(args, patterns...)
+	cmd := r.commandWithContext(ctx, args, opts...)
+	logWriter := LoggerFromContext(ctx)
+	if logWriter == nil {
+		logWriter = io.Discard
+	} else {
+		fmt.Fprintf(logWriter, "command: %q\n", cmd)
+	}
+	errorCollector := errorMessageCollector{}
+
+	// Matches are parsed as they are written, the output for large repos can be very large.
+	reader, writer := io.Pipe()
+	r.handleOutput(cmd, withStdOutTo(writer), withStdErrTo(logWriter), withStdErrTo(&errorCollector))
+
+	var readErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		readErr = readFind(reader, callback)
+		// drain any remaining output so that the command can exit.
+		io.Copy(io.Discard, reader)
+	}()
+
+	cmdErr := cmd.Run()
+	writer.Close()
+	wg.Wait()
+
+	if cmdErr != nil {
+		return errorCollector.AddCmdOutputToError(cmd, cmdErr)
+	} else if readErr != nil {
+		return errorCollector.AddCmdOutputToError(cmd, fmt.Errorf("output processing: %w", readErr))
+	}
+	return nil
+}
+
+// ListFiles recursively lists the entries under path in the snapshot. Entries are passed to the callback as they are
+// read so that large snapshots can be listed without holding every entry in memory.
+func (r *Repo) ListFiles(ctx context.Context, snapshot string, path string, callback func(entry *LsEntry), opts ...GenericOption) error {
+	if path == "" {
+		return errors.New("path must not be empty")
+	}
+
+	cmd := r.commandWithContext(ctx, []string{"ls", "--json", "--recursive", snapshot, path}, opts...)
+	logWriter := LoggerFromContext(ctx)
+	if logWriter == nil {
+		logWriter = io.Discard
+	}
+	errorCollector := errorMessageCollector{}
+
+	reader, writer := io.Pipe()
+	r.handleOutput(cmd, withStdOutTo(writer), withStdErrTo(logWriter), withStdErrTo(&errorCollector))
+
+	var readErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, readErr = readLsEntries(reader, callback)
+		// drain any remaining output so that the command can exit.
+		io.Copy(io.Discard, reader)
+	}()
+
+	cmdErr := cmd.Run()
+	writer.Close()
+	wg.Wait()
+
+	if cmdErr != nil {
+		return errorCollector.AddCmdOutputToError(cmd, cmdErr)
+	} else if readErr != nil {
+		return errorCollector.AddCmdOutputToError(cmd, fmt.Errorf("output processing: %w", readErr))
+	}
+	return nil
+}
+
 func (r *Repo) Dump(ctx context.Context, snapshotID string, file string, dumpOutput io.Writer, opts ...GenericOption) error {
 	args := []string{"dump", snapshotID, file}
 	if runtime.GOOS == "windows" {
@@ -422,6 +530,88 @@ func (r *Repo) Check(ctx context.Context, checkOutput io.Writer, opts ...Generic
 	return r.runSimpleCommand(ctx, []string{"check"}, checkOutput, opts...)
 }
 
+// Copy copies snapshots from the repository at fromURI into this repository. Snapshots that
+// already exist in this repository are skipped. Credentials for the source repository must be
+// provided in opts e.g. as RESTIC_FROM_PASSWORD.
+func (r *Repo) Copy(ctx context.Context, fromURI string, copyOutput io.Writer, opts ...GenericOption) error {
+	return r.runSimpleCommand(ctx, []string{"copy", "--from-repo", fromURI}, copyOutput, opts...)
+}
+
+// ListKeys lists the keys (passwords) that can open the repository.
+func (r *Repo) ListKeys(ctx context.Context, opts ...GenericOption) ([]*Key, error) {
+	var keys []*Key
+	if err := r.executeWithJSONOutput(ctx, []string{"key", "list", "--json"}, &keys, opts...); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// AddKey adds a key for newPassword to the repository and returns it. username and hostname
+// are optional and are recorded with the key.
+func (r *Repo) AddKey(ctx context.Context, newPassword string, username string, hostname string, opts ...GenericOption) (*Key, error) {
+	if newPassword == "" {
+		return nil, errors.New("new password must not be empty")
+	}
+
+	before, err := r.ListKeys(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+
+	// The new password is passed in a file so that it never appears in the process list.
+	f, err := os.CreateTemp("", "backrest-key-*")
+	if err != nil {
+		return nil, fmt.Errorf("create password file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(newPassword); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("write password file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("write password file: %w", err)
+	}
+
+	// --user and --host are only accepted by key add, not by the key list calls around it.
+	args := []string{"key", "add", "--new-password-file", f.Name()}
+	if username != "" {
+		args = append(args, "--user", username)
+	}
+	if hostname != "" {
+		args = append(args, "--host", hostname)
+	}
+
+	errorCollector := errorMessageCollector{}
+	cmd := r.commandWithContext(ctx, args, opts...)
+	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
+	if err := cmd.Run(); err != nil {
+		return nil, errorCollector.AddCmdOutputToError(cmd, err)
+	}
+
+	// restic doesn't report the ID of the new key in a parseable form, find it by diffing the key list.
+	after, err := r.ListKeys(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	for _, key := range after {
+		if !slices.ContainsFunc(before, func(k *Key) bool { return k.ID == key.ID }) {
+			return key, nil
+		}
+	}
+	return nil, errors.New("added key not found in key list")
+}
+
+// RemoveKey removes the key with the given ID. restic refuses to remove the key currently in use.
+func (r *Repo) RemoveKey(ctx context.Context, keyID string, opts ...GenericOption) error {
+	errorCollector := errorMessageCollector{}
+	cmd := r.commandWithContext(ctx, []string{"key", "remove", keyID}, opts...)
+	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
+	if err := cmd.Run(); err != nil {
+		return errorCollector.AddCmdOutputToError(cmd, err)
+	}
+	return nil
+}
+
 // runSimpleCommand executes a command with optional output capture
 func (r *Repo) runSimpleCommand(ctx context.Context, args []string, outputWriter io.Writer, opts ...GenericOption) error {
 	cmd := r.commandWithContext(ctx, args, opts...)
@@ -490,6 +680,21 @@ func (r *Repo) AddTags(ctx context.Context, snapshotIDs []string, tags []string,
 	return nil
 }
 
+// RemoveTags removes tags from the specified snapshots.
+func (r *Repo) RemoveTags(ctx context.Context, snapshotIDs []string, tags []string, opts ...GenericOption) error {
+	args := []string{"tag"}
+	args = append(args, "--remove", strings.Join(tags, ","))
+	args = append(args, snapshotIDs...)
+
+	errorCollector := errorMessageCollector{}
+	cmd := r.commandWithContext(ctx, args, opts...)
+	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
+	if err := cmd.Run(); err != nil {
+		return errorCollector.AddCmdOutputToError(cmd, err)
+	}
+	return nil
+}
+
 func (r *Repo) GenericCommand(ctx context.Context, args []string, opts ...GenericOption) error {
 	cmd := r.commandWithContext(ctx, args, opts...)
 	r.handleOutput(cmd, withLogWriterFromContext(ctx))
@@ -499,14 +704,51 @@ func (r *Repo) GenericCommand(ctx context.Context, args []string, opts ...Generi
 	return nil
 }
 
+type RestoreOptions struct {
+	Includes  []string // patterns of files to restore.
+	Excludes  []string // patterns of files not to restore.
+	Overwrite string   // one of "always", "if-changed", "if-newer" or "never", restic's default if empty.
+	Delete    bool     // delete files in the target that are not in the snapshot.
+	Verify    bool     // verify the restored files' content.
+}
+
+func (r *RestoreOptions) toRestoreFlags() []string {
+	flags := []string{}
+	for _, include := range r.Includes {
+		flags = append(flags, "--include", include)
+	}
+	for _, exclude := range r.Excludes {
+		flags = append(flags, "--exclude", exclude)
+	}
+	if r.Overwrite != "" {
+		flags = append(flags, "--overwrite", r.Overwrite)
+	}
+	if r.Delete {
+		flags = append(flags, "--delete")
+	}
+	if r.Verify {
+		flags = append(flags, "--verify")
+	}
+	return flags
+}
+
+// PinnedTag is the reserved tag of pinned snapshots, these are always kept by a retention policy.
+const PinnedTag = "backrest:pinned"
+
 type RetentionPolicy struct {
-	KeepLastN          int    // keep the last n snapshots.
-	KeepHourly         int    // keep the last n hourly snapshots.
-	KeepDaily          int    // keep the last n daily snapshots.
-	KeepWeekly         int    // keep the last n weekly snapshots.
-	KeepMonthly        int    // keep the last n monthly snapshots.
-	KeepYearly         int    // keep the last n yearly snapshots.
-	KeepWithinDuration string // keep snapshots within a duration e.g. 1y2m3d4h5m6s
+	KeepLastN          int      // keep the last n snapshots.
+	KeepHourly         int      // keep the last n hourly snapshots.
+	KeepDaily          int      // keep the last n daily snapshots.
+	KeepWeekly         int      // keep the last n weekly snapshots.
+	KeepMonthly        int      // keep the last n monthly snapshots.
+	KeepYearly         int      // keep the last n yearly snapshots.
+	KeepWithinDuration string   // keep snapshots within a duration e.g. 1y2m3d4h5m6s
+	KeepWithinHourly   string   // keep hourly snapshots within a duration.
+	KeepWithinDaily    string   // keep daily snapshots within a duration.
+	KeepWithinWeekly   string   // keep weekly snapshots within a duration.
+	KeepWithinMonthly  string   // keep monthly snapshots within a duration.
+	KeepWithinYearly   string   // keep yearly snapshots within a duration.
+	KeepTags           []string // keep snapshots with all of the comma separated tags of any entry.
 }
 
 func (r *RetentionPolicy) toForgetFlags() []string {
@@ -532,13 +774,36 @@ func (r *RetentionPolicy) toForgetFlags() []string {
 	if r.KeepWithinDuration != "" {
 		flags = append(flags, "--keep-within", r.KeepWithinDuration)
 	}
+	if r.KeepWithinHourly != "" {
+		flags = append(flags, "--keep-within-hourly", r.KeepWithinHourly)
+	}
+	if r.KeepWithinDaily != "" {
+		flags = append(flags, "--keep-within-daily", r.KeepWithinDaily)
+	}
+	if r.KeepWithinWeekly != "" {
+		flags = append(flags, "--keep-within-weekly", r.KeepWithinWeekly)
+	}
+	if r.KeepWithinMonthly != "" {
+		flags = append(flags, "--keep-within-monthly", r.KeepWithinMonthly)
+	}
+	if r.KeepWithinYearly != "" {
+		flags = append(flags, "--keep-within-yearly", r.KeepWithinYearly)
+	}
+	// --keep-tag is only added alongside other rules, on its own restic would remove every snapshot without the tags.
+	if len(flags) > 0 {
+		for _, tags := range r.KeepTags {
+			flags = append(flags, "--keep-tag", tags)
+		}
+		flags = append(flags, "--keep-tag", PinnedTag)
+	}
 	return flags
 }
 
 type GenericOpts struct {
-	extraArgs []string
-	extraEnv  []string
-	prefixCmd []string
+	extraArgs    []string
+	extraEnv     []string
+	prefixCmd    []string
+	trailingArgs []string // positional args that must come after all flags.
 }
 
 func resolveOpts(opt *GenericOpts, opts []GenericOption) {
@@ -597,3 +862,9 @@ func WithPrefixCommand(args ...string) GenericOption {
 		opts.prefixCmd = append(opts.prefixCmd, args...)
 	}
 }
+
+func withTrailingArgs(args ...string) GenericOption {
+	return func(opts *GenericOpts) {
+		opts.trailingArgs = append(opts.trailingArgs, args...)
+	}
+}
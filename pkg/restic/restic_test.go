@@ -240,6 +240,83 @@ func TestResticPartialBackup(t *testing.T) {
 	}
 }
 
+func TestResticBackupFromCommand(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a unix shell")
+	}
+	repo := t.TempDir()
+
+	// create a new repo with cache disabled for testing, the command needs PATH from the environment to find sh
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnviron(), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	summary, err := r.BackupFromCommand(context.Background(), []string{"sh", "-c", "echo hello world"}, "hello.txt", nil, WithFlags("--tag", "stdin"))
+	if err != nil {
+		t.Fatalf("failed to backup command output: %v", err)
+	}
+	if summary.SnapshotId == "" || summary.TotalBytesProcessed != int64(len("hello world\n")) {
+		t.Errorf("wanted a snapshot of %d bytes, got: %+v", len("hello world\n"), summary)
+	}
+
+	output := bytes.NewBuffer(nil)
+	if err := r.Dump(context.Background(), summary.SnapshotId, "/hello.txt", output); err != nil {
+		t.Fatalf("failed to dump file: %v", err)
+	}
+	if output.String() != "hello world\n" {
+		t.Errorf("wanted file content %q, got: %q", "hello world\n", output.String())
+	}
+
+	// a failing command must not create a snapshot.
+	if _, err := r.BackupFromCommand(context.Background(), []string{"sh", "-c", "echo partial; exit 1"}, "hello.txt", nil); err == nil {
+		t.Fatalf("wanted error for a failing command, got nil")
+	}
+	snapshots, err := r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Errorf("wanted 1 snapshot, got: %d", len(snapshots))
+	}
+}
+
+func TestTrailingArgsFollowFlags(t *testing.T) {
+	t.Parallel()
+	r := NewRepo("restic", "/tmp/repo", WithFlags("--no-cache"))
+	cmd := r.commandWithContext(context.Background(), []string{"backup"}, withTrailingArgs("--", "pg_dump", "db"), WithFlags("--tag", "foo"))
+
+	want := []string{"restic", "backup", "--no-cache", "--tag", "foo", "--", "pg_dump", "db"}
+	if !slices.Equal(cmd.Args, want) {
+		t.Errorf("wanted args %v, got: %v", want, cmd.Args)
+	}
+}
+
+func TestRetentionPolicyKeepsPinned(t *testing.T) {
+	t.Parallel()
+
+	flags := (&RetentionPolicy{KeepLastN: 3}).toForgetFlags()
+	want := []string{"--keep-last", "3", "--keep-tag", PinnedTag}
+	if !slices.Equal(flags, want) {
+		t.Errorf("wanted flags %v, got: %v", want, flags)
+	}
+
+	if flags := (&RetentionPolicy{}).toForgetFlags(); len(flags) != 0 {
+		t.Errorf("wanted no flags for an empty policy, got: %v", flags)
+	}
+
+	flags = (&RetentionPolicy{KeepWithinDuration: "7d", KeepWithinDaily: "3m", KeepTags: []string{"legal,hold"}}).toForgetFlags()
+	want = []string{"--keep-within", "7d", "--keep-within-daily", "3m", "--keep-tag", "legal,hold", "--keep-tag", PinnedTag}
+	if !slices.Equal(flags, want) {
+		t.Errorf("wanted flags %v, got: %v", want, flags)
+	}
+
+	if flags := (&RetentionPolicy{KeepTags: []string{"legal"}}).toForgetFlags(); len(flags) != 0 {
+		t.Errorf("wanted no flags for a policy with only keep tags, got: %v", flags)
+	}
+}
+
 func TestResticBackupLots(t *testing.T) {
 	t.Parallel()
 	t.Skip("this test takes a long time to run")
@@ -444,6 +521,66 @@ func TestResticForget(t *testing.T) {
 	if !reflect.DeepEqual(keptIds, ids[7:]) {
 		t.Errorf("wanted kept ids to be %v, got: %v", ids[7:], keptIds)
 	}
+
+	if len(res.Reasons) != 3 {
+		t.Fatalf("wanted a keep reason for each kept snapshot, got: %d", len(res.Reasons))
+	}
+	for _, reason := range res.Reasons {
+		if !slices.Contains(reason.Matches, "last snapshot") {
+			t.Errorf("wanted snapshot %v to be kept as a \"last snapshot\", got: %v", reason.Snapshot.Id, reason.Matches)
+		}
+	}
+}
+
+func TestResticForgetKeepsPinned(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	for i := 0; i < 3; i++ {
+		if _, err := r.Backup(context.Background(), []string{testData}, nil); err != nil {
+			t.Fatalf("failed to backup and create new snapshot: %v", err)
+		}
+	}
+
+	snapshots, err := r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if err := r.AddTags(context.Background(), []string{snapshots[0].Id}, []string{PinnedTag}); err != nil {
+		t.Fatalf("failed to pin snapshot: %v", err)
+	}
+
+	res, err := r.Forget(context.Background(), &RetentionPolicy{KeepLastN: 1})
+	if err != nil {
+		t.Fatalf("failed to forget snapshots: %v", err)
+	}
+	if len(res.Keep) != 2 || len(res.Remove) != 1 {
+		t.Fatalf("wanted 2 snapshots kept and 1 removed, got: %d kept and %d removed", len(res.Keep), len(res.Remove))
+	}
+	pinned := slices.IndexFunc(res.Keep, func(s Snapshot) bool { return slices.Contains(s.Tags, PinnedTag) })
+	if pinned == -1 {
+		t.Fatalf("wanted the pinned snapshot to be kept, got: %v", res.Keep)
+	}
+	if res.Keep[pinned].Original != snapshots[0].Id {
+		t.Errorf("wanted the pinned snapshot to be rewritten from %v, got original: %v", snapshots[0].Id, res.Keep[pinned].Original)
+	}
+
+	if err := r.RemoveTags(context.Background(), []string{res.Keep[pinned].Id}, []string{PinnedTag}); err != nil {
+		t.Fatalf("failed to unpin snapshot: %v", err)
+	}
+	res, err = r.Forget(context.Background(), &RetentionPolicy{KeepLastN: 1})
+	if err != nil {
+		t.Fatalf("failed to forget snapshots: %v", err)
+	}
+	if len(res.Keep) != 1 || len(res.Remove) != 1 {
+		t.Errorf("wanted the unpinned snapshot to be removed, got: %d kept and %d removed", len(res.Keep), len(res.Remove))
+	}
 }
 
 func TestResticForgetMultiGroup(t *testing.T) {
@@ -601,7 +738,7 @@ func TestResticRestore(t *testing.T) {
 	}
 
 	// restore all files
-	summary, err := r.Restore(context.Background(), snapshot.SnapshotId, func(event *RestoreProgressEntry) {
+	summary, err := r.Restore(context.Background(), snapshot.SnapshotId, nil, func(event *RestoreProgressEntry) {
 		t.Logf("restore event: %v", event)
 	}, WithFlags("--target", restorePath))
 	if err != nil {
@@ -678,6 +815,209 @@ func TestResticCheck(t *testing.T) {
 	}
 }
 
+func TestResticKeys(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	keys, err := r.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	if len(keys) != 1 || !keys[0].Current {
+		t.Fatalf("wanted a single current key, got: %+v", keys)
+	}
+
+	key, err := r.AddKey(context.Background(), "other", "", "otherhost")
+	if err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+	if key.Current || key.HostName != "otherhost" {
+		t.Errorf("wanted a non-current key for otherhost, got: %+v", key)
+	}
+
+	// the new password opens the repo.
+	other := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=other"))
+	if err := other.Exists(context.Background()); err != nil {
+		t.Fatalf("failed to open repo with the new key: %v", err)
+	}
+
+	if err := other.RemoveKey(context.Background(), keys[0].ID); err != nil {
+		t.Fatalf("failed to remove key: %v", err)
+	}
+	keys, err = other.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != key.ID {
+		t.Errorf("wanted only the new key, got: %+v", keys)
+	}
+}
+
+func TestResticCopy(t *testing.T) {
+	t.Parallel()
+
+	srcRepo := t.TempDir()
+	src := NewRepo(helpers.ResticBinary(t), srcRepo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := src.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init source repo: %v", err)
+	}
+
+	dst := NewRepo(helpers.ResticBinary(t), t.TempDir(), WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test2"))
+	if err := dst.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init target repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	for i := 0; i < 2; i++ {
+		if _, err := src.Backup(context.Background(), []string{testData}, nil, WithFlags("--tag", "copy-test")); err != nil {
+			t.Fatalf("failed to backup: %v", err)
+		}
+	}
+
+	// copy twice, the second copy should skip snapshots that were already copied.
+	for i := 0; i < 2; i++ {
+		if err := dst.Copy(context.Background(), srcRepo, bytes.NewBuffer(nil), WithEnv("RESTIC_FROM_PASSWORD=test")); err != nil {
+			t.Fatalf("failed to copy snapshots: %v", err)
+		}
+	}
+
+	snapshots, err := dst.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots in the target repo, got %d", len(snapshots))
+	}
+	for _, snapshot := range snapshots {
+		if !slices.Contains(snapshot.Tags, "copy-test") {
+			t.Errorf("expected copied snapshot %v to keep its tags, got %v", snapshot.Id, snapshot.Tags)
+		}
+	}
+}
+
+func TestResticDiff(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	first, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(testData, "new-file.txt"), []byte("new data"), 0644); err != nil {
+		t.Fatalf("failed to write new file: %v", err)
+	}
+	second, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	var changes []*DiffEntry
+	stats, err := r.Diff(context.Background(), first.SnapshotId, second.SnapshotId, func(entry *DiffEntry) {
+		if entry.MessageType == "change" {
+			changes = append(changes, entry)
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to diff snapshots: %v", err)
+	}
+
+	if !slices.ContainsFunc(changes, func(e *DiffEntry) bool {
+		return e.Modifier == "+" && strings.HasSuffix(e.Path, "new-file.txt")
+	}) {
+		t.Errorf("wanted an added entry for new-file.txt, got: %+v", changes)
+	}
+	if stats.Added.Files != 1 {
+		t.Errorf("wanted 1 file added, got: %d", stats.Added.Files)
+	}
+}
+
+func TestResticFind(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	if err := os.WriteFile(filepath.Join(testData, "findme.txt"), []byte("find me"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	snapshot, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	var matches []*FindMatch
+	if err := r.Find(context.Background(), []string{"findme.txt"}, func(snapshotID string, match *FindMatch) {
+		if snapshotID != snapshot.SnapshotId {
+			t.Errorf("wanted match in snapshot %v, got: %v", snapshot.SnapshotId, snapshotID)
+		}
+		matches = append(matches, match)
+	}); err != nil {
+		t.Fatalf("failed to find files: %v", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("wanted 1 match, got: %d", len(matches))
+	}
+	if !strings.HasSuffix(matches[0].Path, "findme.txt") || matches[0].Size != 7 {
+		t.Errorf("wanted match for findme.txt with size 7, got: %+v", matches[0])
+	}
+}
+
+func TestResticListFiles(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	if err := os.WriteFile(filepath.Join(testData, "listme.txt"), []byte("list me"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	snapshot, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	var found *LsEntry
+	var files int
+	if err := r.ListFiles(context.Background(), snapshot.SnapshotId, "/", func(entry *LsEntry) {
+		if entry.Type == "file" {
+			files++
+		}
+		if entry.Name == "listme.txt" {
+			found = entry
+		}
+	}); err != nil {
+		t.Fatalf("failed to list files: %v", err)
+	}
+
+	if files != 101 {
+		t.Errorf("wanted 101 files, got: %d", files)
+	}
+	if found == nil || found.Size != 7 {
+		t.Errorf("wanted entry for listme.txt with size 7, got: %+v", found)
+	}
+}
+
 func TestResticDump(t *testing.T) {
 	t.Parallel()
 
@@ -13,7 +13,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/config.proto.
  */
 export const file_v1_config: GenFile = /*@__PURE__*/
-  fileDesc("Cg92MS9jb25maWcucHJvdG8SAnYxIqwBCgZDb25maWcSDQoFbW9kbm8YASABKAUSDwoHdmVyc2lvbhgGIAEoBRIQCghpbnN0YW5jZRgCIAEoCRIXCgVyZXBvcxgDIAMoCzIILnYxLlJlcG8SFwoFcGxhbnMYBCADKAsyCC52MS5QbGFuEhYKBGF1dGgYBSABKAsyCC52MS5BdXRoEiYKCW11bHRpaG9zdBgHIAEoCzINLnYxLk11bHRpaG9zdFIEc3luYyL6BQoJTXVsdGlob3N0EiAKCGlkZW50aXR5GAEgASgLMg4udjEuUHJpdmF0ZUtleRInCgtrbm93bl9ob3N0cxgCIAMoCzISLnYxLk11bHRpaG9zdC5QZWVyEi4KEmF1dGhvcml6ZWRfY2xpZW50cxgDIAMoCzISLnYxLk11bHRpaG9zdC5QZWVyEjIKDnBhaXJpbmdfdG9rZW5zGAQgAygLMhoudjEuTXVsdGlob3N0LlBhaXJpbmdUb2tlbhqcAQoEUGVlchITCgtpbnN0YW5jZV9pZBgBIAEoCRIUCgVrZXlpZBgCIAEoCVIFa2V5SWQSLQoLcGVybWlzc2lvbnMYBSADKAsyGC52MS5NdWx0aWhvc3QuUGVybWlzc2lvbhIUCgxpbnN0YW5jZV91cmwYBCABKAkSHgoWaW5pdGlhbF9wYWlyaW5nX3NlY3JldBgGIAEoCUoECAMQBBquAQoMUGFpcmluZ1Rva2VuEg4KBnNlY3JldBgBIAEoCRINCgVsYWJlbBgCIAEoCRIXCg9jcmVhdGVkX2F0X3VuaXgYAyABKAMSFwoPZXhwaXJlc19hdF91bml4GAQgASgDEhAKCG1heF91c2VzGAUgASgFEgwKBHVzZXMYBiABKAUSLQoLcGVybWlzc2lvbnMYByADKAsyGC52MS5NdWx0aWhvc3QuUGVybWlzc2lvbhrtAQoKUGVybWlzc2lvbhIrCgR0eXBlGAEgASgOMh0udjEuTXVsdGlob3N0LlBlcm1pc3Npb24uVHlwZRIOCgZzY29wZXMYAiADKAkioQEKBFR5cGUSFgoSUEVSTUlTU0lPTl9VTktOT1dOEAASHgoaUEVSTUlTU0lPTl9SRUFEX09QRVJBVElPTlMQARIaChZQRVJNSVNTSU9OX1JFQURfQ09ORklHEAISIAocUEVSTUlTU0lPTl9SRUFEX1dSSVRFX0NPTkZJRxADEiMKH1BFUk1JU1NJT05fUkVDRUlWRV9TSEFSRURfUkVQT1MQBCLwAgoEUmVwbxIKCgJpZBgBIAEoCRILCgN1cmkYAiABKAkSDAoEZ3VpZBgLIAEoCRIQCghwYXNzd29yZBgDIAEoCRILCgNlbnYYBCADKAkSDQoFZmxhZ3MYBSADKAkSJQoMcHJ1bmVfcG9saWN5GAYgASgLMg8udjEuUHJ1bmVQb2xpY3kSJQoMY2hlY2tfcG9saWN5GAkgASgLMg8udjEuQ2hlY2tQb2xpY3kSFwoFaG9va3MYByADKAsyCC52MS5Ib29rEhMKC2F1dG9fdW5sb2NrGAggASgIEhcKD2F1dG9faW5pdGlhbGl6ZRgMIAEoCBIpCg5jb21tYW5kX3ByZWZpeBgKIAEoCzIRLnYxLkNvbW1hbmRQcmVmaXgSDgoGc2hhcmVkGA0gASgIEhoKEm9yaWdpbl9pbnN0YW5jZV9pZBgOIAEoCRInCg1mb3JnZXRfcG9saWN5GA8gASgLMhAudjEuRm9yZ2V0UG9saWN5IoYCCgRQbGFuEgoKAmlkGAEgASgJEgwKBHJlcG8YAiABKAkSDQoFcGF0aHMYBCADKAkSEAoIZXhjbHVkZXMYBSADKAkSEQoJaWV4Y2x1ZGVzGAkgAygJEh4KCHNjaGVkdWxlGAwgASgLMgwudjEuU2NoZWR1bGUSJgoJcmV0ZW50aW9uGAcgASgLMhMudjEuUmV0ZW50aW9uUG9saWN5EhcKBWhvb2tzGAggAygLMggudjEuSG9vaxIiCgxiYWNrdXBfZmxhZ3MYCiADKAlSDGJhY2t1cF9mbGFncxIZChFza2lwX2lmX3VuY2hhbmdlZBgNIAEoCEoECAMQBEoECAYQB0oECAsQDCKKAgoNQ29tbWFuZFByZWZpeBIuCgdpb19uaWNlGAEgASgOMh0udjEuQ29tbWFuZFByZWZpeC5JT05pY2VMZXZlbBIwCghjcHVfbmljZRgCIAEoDjIeLnYxLkNvbW1hbmRQcmVmaXguQ1BVTmljZUxldmVsIlsKC0lPTmljZUxldmVsEg4KCklPX0RFRkFVTFQQABIWChJJT19CRVNUX0VGRk9SVF9MT1cQARIXChNJT19CRVNUX0VGRk9SVF9ISUdIEAISCwoHSU9fSURMRRADIjoKDENQVU5pY2VMZXZlbBIPCgtDUFVfREVGQVVMVBAAEgwKCENQVV9ISUdIEAESCwoHQ1BVX0xPVxACIpcCCg9SZXRlbnRpb25Qb2xpY3kSHAoScG9saWN5X2tlZXBfbGFzdF9uGAogASgFSAASRgoUcG9saWN5X3RpbWVfYnVja2V0ZWQYCyABKAsyJi52MS5SZXRlbnRpb25Qb2xpY3kuVGltZUJ1Y2tldGVkQ291bnRzSAASGQoPcG9saWN5X2tlZXBfYWxsGAwgASgISAAaeQoSVGltZUJ1Y2tldGVkQ291bnRzEg4KBmhvdXJseRgBIAEoBRINCgVkYWlseRgCIAEoBRIOCgZ3ZWVrbHkYAyABKAUSDwoHbW9udGhseRgEIAEoBRIOCgZ5ZWFybHkYBSABKAUSEwoLa2VlcF9sYXN0X24YBiABKAVCCAoGcG9saWN5IlYKDEZvcmdldFBvbGljeRIeCghzY2hlZHVsZRgBIAEoCzIMLnYxLlNjaGVkdWxlEiYKCXJldGVudGlvbhgCIAEoCzITLnYxLlJldGVudGlvblBvbGljeSJjCgtQcnVuZVBvbGljeRIeCghzY2hlZHVsZRgCIAEoCzIMLnYxLlNjaGVkdWxlEhgKEG1heF91bnVzZWRfYnl0ZXMYAyABKAMSGgoSbWF4X3VudXNlZF9wZXJjZW50GAQgASgBInMKC0NoZWNrUG9saWN5Eh4KCHNjaGVkdWxlGAEgASgLMgwudjEuU2NoZWR1bGUSGAoOc3RydWN0dXJlX29ubHkYZCABKAhIABIiChhyZWFkX2RhdGFfc3Vic2V0X3BlcmNlbnQYZSABKAFIAEIGCgRtb2RlIusBCghTY2hlZHVsZRISCghkaXNhYmxlZBgBIAEoCEgAEg4KBGNyb24YAiABKAlIABIaChBtYXhGcmVxdWVuY3lEYXlzGAMgASgFSAASGwoRbWF4RnJlcXVlbmN5SG91cnMYBCABKAVIABIhCgVjbG9jaxgFIAEoDjISLnYxLlNjaGVkdWxlLkNsb2NrIlMKBUNsb2NrEhEKDUNMT0NLX0RFRkFVTFQQABIPCgtDTE9DS19MT0NBTBABEg0KCUNMT0NLX1VUQxACEhcKE0NMT0NLX0xBU1RfUlVOX1RJTUUQA0IKCghzY2hlZHVsZSKADQoESG9vaxImCgpjb25kaXRpb25zGAEgAygOMhIudjEuSG9vay5Db25kaXRpb24SIgoIb25fZXJyb3IYAiABKA4yEC52MS5Ib29rLk9uRXJyb3ISKgoOYWN0aW9uX2NvbW1hbmQYZCABKAsyEC52MS5Ib29rLkNvbW1hbmRIABIqCg5hY3Rpb25fd2ViaG9vaxhlIAEoCzIQLnYxLkhvb2suV2ViaG9va0gAEioKDmFjdGlvbl9kaXNjb3JkGGYgASgLMhAudjEuSG9vay5EaXNjb3JkSAASKAoNYWN0aW9uX2dvdGlmeRhnIAEoCzIPLnYxLkhvb2suR290aWZ5SAASJgoMYWN0aW9uX3NsYWNrGGggASgLMg4udjEuSG9vay5TbGFja0gAEiwKD2FjdGlvbl9zaG91dHJychhpIAEoCzIRLnYxLkhvb2suU2hvdXRycnJIABI0ChNhY3Rpb25faGVhbHRoY2hlY2tzGGogASgLMhUudjEuSG9vay5IZWFsdGhjaGVja3NIABIsCg9hY3Rpb25fdGVsZWdyYW0YayABKAsyES52MS5Ib29rLlRlbGVncmFtSAAaGgoHQ29tbWFuZBIPCgdjb21tYW5kGAEgASgJGoMBCgdXZWJob29rEhMKC3dlYmhvb2tfdXJsGAEgASgJEicKBm1ldGhvZBgCIAEoDjIXLnYxLkhvb2suV2ViaG9vay5NZXRob2QSEAoIdGVtcGxhdGUYZCABKAkiKAoGTWV0aG9kEgsKB1VOS05PV04QABIHCgNHRVQQARIICgRQT1NUEAIaMAoHRGlzY29yZBITCgt3ZWJob29rX3VybBgBIAEoCRIQCgh0ZW1wbGF0ZRgCIAEoCRplCgZHb3RpZnkSEAoIYmFzZV91cmwYASABKAkSDQoFdG9rZW4YAyABKAkSEAoIdGVtcGxhdGUYZCABKAkSFgoOdGl0bGVfdGVtcGxhdGUYZSABKAkSEAoIcHJpb3JpdHkYZiABKAUaLgoFU2xhY2sSEwoLd2ViaG9va191cmwYASABKAkSEAoIdGVtcGxhdGUYAiABKAkaMgoIU2hvdXRycnISFAoMc2hvdXRycnJfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGjUKDEhlYWx0aGNoZWNrcxITCgt3ZWJob29rX3VybBgBIAEoCRIQCgh0ZW1wbGF0ZRgCIAEoCRpACghUZWxlZ3JhbRIRCglib3RfdG9rZW4YASABKAkSDwoHY2hhdF9pZBgCIAEoCRIQCgh0ZW1wbGF0ZRgDIAEoCSL1AwoJQ29uZGl0aW9uEhUKEUNPTkRJVElPTl9VTktOT1dOEAASFwoTQ09ORElUSU9OX0FOWV9FUlJPUhABEhwKGENPTkRJVElPTl9TTkFQU0hPVF9TVEFSVBACEhoKFkNPTkRJVElPTl9TTkFQU0hPVF9FTkQQAxIcChhDT05ESVRJT05fU05BUFNIT1RfRVJST1IQBBIeChpDT05ESVRJT05fU05BUFNIT1RfV0FSTklORxAFEh4KGkNPTkRJVElPTl9TTkFQU0hPVF9TVUNDRVNTEAYSHgoaQ09ORElUSU9OX1NOQVBTSE9UX1NLSVBQRUQQBxIZChVDT05ESVRJT05fUFJVTkVfU1RBUlQQZBIZChVDT05ESVRJT05fUFJVTkVfRVJST1IQZRIbChdDT05ESVRJT05fUFJVTkVfU1VDQ0VTUxBmEhoKFUNPTkRJVElPTl9DSEVDS19TVEFSVBDIARIaChVDT05ESVRJT05fQ0hFQ0tfRVJST1IQyQESHAoXQ09ORElUSU9OX0NIRUNLX1NVQ0NFU1MQygESGwoWQ09ORElUSU9OX0ZPUkdFVF9TVEFSVBCsAhIbChZDT05ESVRJT05fRk9SR0VUX0VSUk9SEK0CEh0KGENPTkRJVElPTl9GT1JHRVRfU1VDQ0VTUxCuAiKpAQoHT25FcnJvchITCg9PTl9FUlJPUl9JR05PUkUQABITCg9PTl9FUlJPUl9DQU5DRUwQARISCg5PTl9FUlJPUl9GQVRBTBACEhoKFk9OX0VSUk9SX1JFVFJZXzFNSU5VVEUQZBIcChhPTl9FUlJPUl9SRVRSWV8xME1JTlVURVMQZRImCiJPTl9FUlJPUl9SRVRSWV9FWFBPTkVOVElBTF9CQUNLT0ZGEGdCCAoGYWN0aW9uIjEKBEF1dGgSEAoIZGlzYWJsZWQYASABKAgSFwoFdXNlcnMYAiADKAsyCC52MS5Vc2VyIjsKBFVzZXISDAoEbmFtZRgBIAEoCRIZCg9wYXNzd29yZF9iY3J5cHQYAiABKAlIAEIKCghwYXNzd29yZEIsWipnaXRodWIuY29tL2dhcmV0aGdlb3JnZS9iYWNrcmVzdC9nZW4vZ28vdjFiBnByb3RvMw", [file_google_protobuf_empty, file_v1_crypto]);
+  fileDesc("Cg92MS9jb25maWcucHJvdG8SAnYxIucBCgZDb25maWcSDQoFbW9kbm8YASABKAUSDwoHdmVyc2lvbhgGIAEoBRIQCghpbnN0YW5jZRgCIAEoCRIXCgVyZXBvcxgDIAMoCzIILnYxLlJlcG8SFwoFcGxhbnMYBCADKAsyCC52MS5QbGFuEhYKBGF1dGgYBSABKAsyCC52MS5BdXRoEiYKCW11bHRpaG9zdBgHIAEoCzINLnYxLk11bHRpaG9zdFIEc3luYxIgCgZkaWdlc3QYCCABKAsyEC52MS5EaWdlc3RQb2xpY3kSFwoFaG9va3MYCSADKAsyCC52MS5Ib29rIkcKDERpZ2VzdFBvbGljeRIeCghzY2hlZHVsZRgBIAEoCzIMLnYxLlNjaGVkdWxlEhcKBWhvb2tzGAIgAygLMggudjEuSG9vayL6BQoJTXVsdGlob3N0EiAKCGlkZW50aXR5GAEgASgLMg4udjEuUHJpdmF0ZUtleRInCgtrbm93bl9ob3N0cxgCIAMoCzISLnYxLk11bHRpaG9zdC5QZWVyEi4KEmF1dGhvcml6ZWRfY2xpZW50cxgDIAMoCzISLnYxLk11bHRpaG9zdC5QZWVyEjIKDnBhaXJpbmdfdG9rZW5zGAQgAygLMhoudjEuTXVsdGlob3N0LlBhaXJpbmdUb2tlbhqcAQoEUGVlchITCgtpbnN0YW5jZV9pZBgBIAEoCRIUCgVrZXlpZBgCIAEoCVIFa2V5SWQSLQoLcGVybWlzc2lvbnMYBSADKAsyGC52MS5NdWx0aWhvc3QuUGVybWlzc2lvbhIUCgxpbnN0YW5jZV91cmwYBCABKAkSHgoWaW5pdGlhbF9wYWlyaW5nX3NlY3JldBgGIAEoCUoECAMQBBquAQoMUGFpcmluZ1Rva2VuEg4KBnNlY3JldBgBIAEoCRINCgVsYWJlbBgCIAEoCRIXCg9jcmVhdGVkX2F0X3VuaXgYAyABKAMSFwoPZXhwaXJlc19hdF91bml4GAQgASgDEhAKCG1heF91c2VzGAUgASgFEgwKBHVzZXMYBiABKAUSLQoLcGVybWlzc2lvbnMYByADKAsyGC52MS5NdWx0aWhvc3QuUGVybWlzc2lvbhrtAQoKUGVybWlzc2lvbhIrCgR0eXBlGAEgASgOMh0udjEuTXVsdGlob3N0LlBlcm1pc3Npb24uVHlwZRIOCgZzY29wZXMYAiADKAkioQEKBFR5cGUSFgoSUEVSTUlTU0lPTl9VTktOT1dOEAASHgoaUEVSTUlTU0lPTl9SRUFEX09QRVJBVElPTlMQARIaChZQRVJNSVNTSU9OX1JFQURfQ09ORklHEAISIAocUEVSTUlTU0lPTl9SRUFEX1dSSVRFX0NPTkZJRxADEiMKH1BFUk1JU1NJT05fUkVDRUlWRV9TSEFSRURfUkVQT1MQBCKVAwoEUmVwbxIKCgJpZBgBIAEoCRILCgN1cmkYAiABKAkSDAoEZ3VpZBgLIAEoCRIQCghwYXNzd29yZBgDIAEoCRILCgNlbnYYBCADKAkSDQoFZmxhZ3MYBSADKAkSJQoMcHJ1bmVfcG9saWN5GAYgASgLMg8udjEuUHJ1bmVQb2xpY3kSJQoMY2hlY2tfcG9saWN5GAkgASgLMg8udjEuQ2hlY2tQb2xpY3kSFwoFaG9va3MYByADKAsyCC52MS5Ib29rEhMKC2F1dG9fdW5sb2NrGAggASgIEhcKD2F1dG9faW5pdGlhbGl6ZRgMIAEoCBIpCg5jb21tYW5kX3ByZWZpeBgKIAEoCzIRLnYxLkNvbW1hbmRQcmVmaXgSDgoGc2hhcmVkGA0gASgIEhoKEm9yaWdpbl9pbnN0YW5jZV9pZBgOIAEoCRInCg1mb3JnZXRfcG9saWN5GA8gASgLMhAudjEuRm9yZ2V0UG9saWN5EiMKC2NvcHlfcG9saWN5GBAgASgLMg4udjEuQ29weVBvbGljeSKFAwoEUGxhbhIKCgJpZBgBIAEoCRIMCgRyZXBvGAIgASgJEg0KBXBhdGhzGAQgAygJEhAKCGV4Y2x1ZGVzGAUgAygJEhEKCWlleGNsdWRlcxgJIAMoCRIeCghzY2hlZHVsZRgMIAEoCzIMLnYxLlNjaGVkdWxlEiYKCXJldGVudGlvbhgHIAEoCzITLnYxLlJldGVudGlvblBvbGljeRIXCgVob29rcxgIIAMoCzIILnYxLkhvb2sSIgoMYmFja3VwX2ZsYWdzGAogAygJUgxiYWNrdXBfZmxhZ3MSGQoRc2tpcF9pZl91bmNoYW5nZWQYDSABKAgSJwoNc3RkaW5fY29tbWFuZBgOIAEoCzIQLnYxLlN0ZGluQ29tbWFuZBIyChNyZXN0b3JlX3Rlc3RfcG9saWN5GA8gASgLMhUudjEuUmVzdG9yZVRlc3RQb2xpY3kSIAoYb3ZlcmR1ZV9ncmFjZV9tdWx0aXBsaWVyGBAgASgBSgQIAxAESgQIBhAHSgQICxAMIjEKDFN0ZGluQ29tbWFuZBIPCgdjb21tYW5kGAEgASgJEhAKCGZpbGVuYW1lGAIgASgJIooCCg1Db21tYW5kUHJlZml4Ei4KB2lvX25pY2UYASABKA4yHS52MS5Db21tYW5kUHJlZml4LklPTmljZUxldmVsEjAKCGNwdV9uaWNlGAIgASgOMh4udjEuQ29tbWFuZFByZWZpeC5DUFVOaWNlTGV2ZWwiWwoLSU9OaWNlTGV2ZWwSDgoKSU9fREVGQVVMVBAAEhYKEklPX0JFU1RfRUZGT1JUX0xPVxABEhcKE0lPX0JFU1RfRUZGT1JUX0hJR0gQAhILCgdJT19JRExFEAMiOgoMQ1BVTmljZUxldmVsEg8KC0NQVV9ERUZBVUxUEAASDAoIQ1BVX0hJR0gQARILCgdDUFVfTE9XEAIizAMKD1JldGVudGlvblBvbGljeRIcChJwb2xpY3lfa2VlcF9sYXN0X24YCiABKAVIABJGChRwb2xpY3lfdGltZV9idWNrZXRlZBgLIAEoCzImLnYxLlJldGVudGlvblBvbGljeS5UaW1lQnVja2V0ZWRDb3VudHNIABIZCg9wb2xpY3lfa2VlcF9hbGwYDCABKAhIABIRCglrZWVwX3RhZ3MYDSADKAkamgIKElRpbWVCdWNrZXRlZENvdW50cxIOCgZob3VybHkYASABKAUSDQoFZGFpbHkYAiABKAUSDgoGd2Vla2x5GAMgASgFEg8KB21vbnRobHkYBCABKAUSDgoGeWVhcmx5GAUgASgFEhMKC2tlZXBfbGFzdF9uGAYgASgFEhMKC2tlZXBfd2l0aGluGAcgASgJEhoKEmtlZXBfd2l0aGluX2hvdXJseRgIIAEoCRIZChFrZWVwX3dpdGhpbl9kYWlseRgJIAEoCRIaChJrZWVwX3dpdGhpbl93ZWVrbHkYCiABKAkSGwoTa2VlcF93aXRoaW5fbW9udGhseRgLIAEoCRIaChJrZWVwX3dpdGhpbl95ZWFybHkYDCABKAlCCAoGcG9saWN5ImgKDEZvcmdldFBvbGljeRIeCghzY2hlZHVsZRgBIAEoCzIMLnYxLlNjaGVkdWxlEiYKCXJldGVudGlvbhgCIAEoCzITLnYxLlJldGVudGlvblBvbGljeRIQCghncm91cF9ieRgDIAEoCSJjCgtQcnVuZVBvbGljeRIeCghzY2hlZHVsZRgCIAEoCzIMLnYxLlNjaGVkdWxlEhgKEG1heF91bnVzZWRfYnl0ZXMYAyABKAMSGgoSbWF4X3VudXNlZF9wZXJjZW50GAQgASgBInMKC0NoZWNrUG9saWN5Eh4KCHNjaGVkdWxlGAEgASgLMgwudjEuU2NoZWR1bGUSGAoOc3RydWN0dXJlX29ubHkYZCABKAhIABIiChhyZWFkX2RhdGFfc3Vic2V0X3BlcmNlbnQYZSABKAFIAEIGCgRtb2RlIkEKCkNvcHlQb2xpY3kSHgoIc2NoZWR1bGUYASABKAsyDC52MS5TY2hlZHVsZRITCgt0YXJnZXRfcmVwbxgCIAEoCSJWChFSZXN0b3JlVGVzdFBvbGljeRIeCghzY2hlZHVsZRgBIAEoCzIMLnYxLlNjaGVkdWxlEhMKC3NhbXBsZV9zaXplGAIgASgFEgwKBHBhdGgYAyABKAki6wEKCFNjaGVkdWxlEhIKCGRpc2FibGVkGAEgASgISAASDgoEY3JvbhgCIAEoCUgAEhoKEG1heEZyZXF1ZW5jeURheXMYAyABKAVIABIbChFtYXhGcmVxdWVuY3lIb3VycxgEIAEoBUgAEiEKBWNsb2NrGAUgASgOMhIudjEuU2NoZWR1bGUuQ2xvY2siUwoFQ2xvY2sSEQoNQ0xPQ0tfREVGQVVMVBAAEg8KC0NMT0NLX0xPQ0FMEAESDQoJQ0xPQ0tfVVRDEAISFwoTQ0xPQ0tfTEFTVF9SVU5fVElNRRADQgoKCHNjaGVkdWxlIvMYCgRIb29rEiYKCmNvbmRpdGlvbnMYASADKA4yEi52MS5Ib29rLkNvbmRpdGlvbhIiCghvbl9lcnJvchgCIAEoDjIQLnYxLkhvb2suT25FcnJvchIoCgtub3RpZnlfbW9kZRgDIAEoDjITLnYxLkhvb2suTm90aWZ5TW9kZRIaChJyYXRlX2xpbWl0X21pbnV0ZXMYBCABKAUSCgoCaWQYBSABKAkSCwoDcmVmGAYgASgJEhcKD3RpbWVvdXRfc2Vjb25kcxgHIAEoBRIqCg5hY3Rpb25fY29tbWFuZBhkIAEoCzIQLnYxLkhvb2suQ29tbWFuZEgAEioKDmFjdGlvbl93ZWJob29rGGUgASgLMhAudjEuSG9vay5XZWJob29rSAASKgoOYWN0aW9uX2Rpc2NvcmQYZiABKAsyEC52MS5Ib29rLkRpc2NvcmRIABIoCg1hY3Rpb25fZ290aWZ5GGcgASgLMg8udjEuSG9vay5Hb3RpZnlIABImCgxhY3Rpb25fc2xhY2sYaCABKAsyDi52MS5Ib29rLlNsYWNrSAASLAoPYWN0aW9uX3Nob3V0cnJyGGkgASgLMhEudjEuSG9vay5TaG91dHJyckgAEjQKE2FjdGlvbl9oZWFsdGhjaGVja3MYaiABKAsyFS52MS5Ib29rLkhlYWx0aGNoZWNrc0gAEiwKD2FjdGlvbl90ZWxlZ3JhbRhrIAEoCzIRLnYxLkhvb2suVGVsZWdyYW1IABImCgxhY3Rpb25fZW1haWwYbCABKAsyDi52MS5Ib29rLkVtYWlsSAASJAoLYWN0aW9uX21xdHQYbSABKAsyDS52MS5Ib29rLk1xdHRIABIkCgthY3Rpb25fbnRmeRhuIAEoCzINLnYxLkhvb2suTnRmeUgAGhoKB0NvbW1hbmQSDwoHY29tbWFuZBgBIAEoCRruAgoHV2ViaG9vaxITCgt3ZWJob29rX3VybBgBIAEoCRInCgZtZXRob2QYAiABKA4yFy52MS5Ib29rLldlYmhvb2suTWV0aG9kEg8KB2hlYWRlcnMYAyADKAkSFAoMY29udGVudF90eXBlGAQgASgJEhsKE2Jhc2ljX2F1dGhfdXNlcm5hbWUYBSABKAkSGwoTYmFzaWNfYXV0aF9wYXNzd29yZBgGIAEoCRIUCgxiZWFyZXJfdG9rZW4YByABKAkSEwoLaG1hY19zZWNyZXQYCCABKAkSEwoLaG1hY19oZWFkZXIYCSABKAkSFwoPdGltZW91dF9zZWNvbmRzGAogASgFEg8KB3JldHJpZXMYCyABKAUSEAoIdGVtcGxhdGUYZCABKAkiSAoGTWV0aG9kEgsKB1VOS05PV04QABIHCgNHRVQQARIICgRQT1NUEAISBwoDUFVUEAMSCQoFUEFUQ0gQBBIKCgZERUxFVEUQBRowCgdEaXNjb3JkEhMKC3dlYmhvb2tfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGmUKBkdvdGlmeRIQCghiYXNlX3VybBgBIAEoCRINCgV0b2tlbhgDIAEoCRIQCgh0ZW1wbGF0ZRhkIAEoCRIWCg50aXRsZV90ZW1wbGF0ZRhlIAEoCRIQCghwcmlvcml0eRhmIAEoBRouCgVTbGFjaxITCgt3ZWJob29rX3VybBgBIAEoCRIQCgh0ZW1wbGF0ZRgCIAEoCRoyCghTaG91dHJychIUCgxzaG91dHJycl91cmwYASABKAkSEAoIdGVtcGxhdGUYAiABKAkaNQoMSGVhbHRoY2hlY2tzEhMKC3dlYmhvb2tfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGkAKCFRlbGVncmFtEhEKCWJvdF90b2tlbhgBIAEoCRIPCgdjaGF0X2lkGAIgASgJEhAKCHRlbXBsYXRlGAMgASgJGowCCgVFbWFpbBIMCgRob3N0GAEgASgJEgwKBHBvcnQYAiABKAUSKQoIc2VjdXJpdHkYAyABKA4yFy52MS5Ib29rLkVtYWlsLlNlY3VyaXR5EhAKCHVzZXJuYW1lGAQgASgJEhAKCHBhc3N3b3JkGAUgASgJEgwKBGZyb20YBiABKAkSCgoCdG8YByADKAkSCgoCY2MYCCADKAkSGAoQc3ViamVjdF90ZW1wbGF0ZRgJIAEoCRIQCgh0ZW1wbGF0ZRgKIAEoCSJGCghTZWN1cml0eRIVChFTRUNVUklUWV9TVEFSVFRMUxAAEhAKDFNFQ1VSSVRZX1RMUxABEhEKDVNFQ1VSSVRZX05PTkUQAhqpAQoETXF0dBISCgpicm9rZXJfdXJsGAEgASgJEhAKCHVzZXJuYW1lGAIgASgJEhAKCHBhc3N3b3JkGAMgASgJEhEKCWNsaWVudF9pZBgEIAEoCRINCgV0b3BpYxgFIAEoCRILCgNxb3MYBiABKAUSIAoYaG9tZV9hc3Npc3RhbnRfZGlzY292ZXJ5GAcgASgIEhgKEGRpc2NvdmVyeV9wcmVmaXgYCCABKAkalQEKBE50ZnkSEgoKc2VydmVyX3VybBgBIAEoCRINCgV0b3BpYxgCIAEoCRINCgV0b2tlbhgDIAEoCRIMCgR0YWdzGAQgAygJEhEKCWNsaWNrX3VybBgFIAEoCRIQCghwcmlvcml0eRgGIAEoBRIQCgh0ZW1wbGF0ZRhkIAEoCRIWCg50aXRsZV90ZW1wbGF0ZRhlIAEoCSLuBgoJQ29uZGl0aW9uEhUKEUNPTkRJVElPTl9VTktOT1dOEAASFwoTQ09ORElUSU9OX0FOWV9FUlJPUhABEhwKGENPTkRJVElPTl9TTkFQU0hPVF9TVEFSVBACEhoKFkNPTkRJVElPTl9TTkFQU0hPVF9FTkQQAxIcChhDT05ESVRJT05fU05BUFNIT1RfRVJST1IQBBIeChpDT05ESVRJT05fU05BUFNIT1RfV0FSTklORxAFEh4KGkNPTkRJVElPTl9TTkFQU0hPVF9TVUNDRVNTEAYSHgoaQ09ORElUSU9OX1NOQVBTSE9UX1NLSVBQRUQQBxIZChVDT05ESVRJT05fUFJVTkVfU1RBUlQQZBIZChVDT05ESVRJT05fUFJVTkVfRVJST1IQZRIbChdDT05ESVRJT05fUFJVTkVfU1VDQ0VTUxBmEhoKFUNPTkRJVElPTl9DSEVDS19TVEFSVBDIARIaChVDT05ESVRJT05fQ0hFQ0tfRVJST1IQyQESHAoXQ09ORElUSU9OX0NIRUNLX1NVQ0NFU1MQygESGwoWQ09ORElUSU9OX0ZPUkdFVF9TVEFSVBCsAhIbChZDT05ESVRJT05fRk9SR0VUX0VSUk9SEK0CEh0KGENPTkRJVElPTl9GT1JHRVRfU1VDQ0VTUxCuAhIZChRDT05ESVRJT05fQ09QWV9TVEFSVBCQAxIZChRDT05ESVRJT05fQ09QWV9FUlJPUhCRAxIbChZDT05ESVRJT05fQ09QWV9TVUNDRVNTEJIDEiEKHENPTkRJVElPTl9SRVNUT1JFX1RFU1RfU1RBUlQQ9AMSIQocQ09ORElUSU9OX1JFU1RPUkVfVEVTVF9FUlJPUhD1AxIjCh5DT05ESVRJT05fUkVTVE9SRV9URVNUX1NVQ0NFU1MQ9gMSHQoYQ09ORElUSU9OX0JBQ0tVUF9PVkVSRFVFENgEEicKIkNPTkRJVElPTl9CQUNLVVBfT1ZFUkRVRV9SRUNPVkVSRUQQ2QQSFQoQQ09ORElUSU9OX0RJR0VTVBC8BRIcChdDT05ESVRJT05fUkVTVE9SRV9TVEFSVBCgBhIcChdDT05ESVRJT05fUkVTVE9SRV9FUlJPUhChBhIeChlDT05ESVRJT05fUkVTVE9SRV9TVUNDRVNTEKIGIqkBCgdPbkVycm9yEhMKD09OX0VSUk9SX0lHTk9SRRAAEhMKD09OX0VSUk9SX0NBTkNFTBABEhIKDk9OX0VSUk9SX0ZBVEFMEAISGgoWT05fRVJST1JfUkVUUllfMU1JTlVURRBkEhwKGE9OX0VSUk9SX1JFVFJZXzEwTUlOVVRFUxBlEiYKIk9OX0VSUk9SX1JFVFJZX0VYUE9ORU5USUFMX0JBQ0tPRkYQZyJOCgpOb3RpZnlNb2RlEhEKDU5PVElGWV9BTFdBWVMQABIUChBOT1RJRllfT05fQ0hBTkdFEAESFwoTTk9USUZZX1JBVEVfTElNSVRFRBACQggKBmFjdGlvbiIxCgRBdXRoEhAKCGRpc2FibGVkGAEgASgIEhcKBXVzZXJzGAIgAygLMggudjEuVXNlciI7CgRVc2VyEgwKBG5hbWUYASABKAkSGQoPcGFzc3dvcmRfYmNyeXB0GAIgASgJSABCCgoIcGFzc3dvcmRCLFoqZ2l0aHViLmNvbS9nYXJldGhnZW9yZ2UvYmFja3Jlc3QvZ2VuL2dvL3YxYgZwcm90bzM", [file_google_protobuf_empty, file_v1_crypto]);
 
 /**
  * Config is the top level config object for restic UI.
@@ -62,6 +62,20 @@ export type Config = Message<"v1.Config"> & {
    * @generated from field: v1.Multihost multihost = 7 [json_name = "sync"];
    */
   multihost?: Multihost;
+
+  /**
+   * optional periodic summary report of every plan and repo.
+   *
+   * @generated from field: v1.DigestPolicy digest = 8;
+   */
+  digest?: DigestPolicy;
+
+  /**
+   * named hook definitions that plans, repos and the digest reference by id, they don't run on their own.
+   *
+   * @generated from field: repeated v1.Hook hooks = 9;
+   */
+  hooks: Hook[];
 };
 
 /**
@@ -71,6 +85,34 @@ export type Config = Message<"v1.Config"> & {
 export const ConfigSchema: GenMessage<Config> = /*@__PURE__*/
   messageDesc(file_v1_config, 0);
 
+/**
+ * DigestPolicy schedules a periodic report summarizing the backups of every plan and repo on this instance.
+ *
+ * @generated from message v1.DigestPolicy
+ */
+export type DigestPolicy = Message<"v1.DigestPolicy"> & {
+  /**
+   * schedule for the digest, the report covers the time since the previous digest.
+   *
+   * @generated from field: v1.Schedule schedule = 1;
+   */
+  schedule?: Schedule;
+
+  /**
+   * hooks that deliver the digest, each must include CONDITION_DIGEST.
+   *
+   * @generated from field: repeated v1.Hook hooks = 2;
+   */
+  hooks: Hook[];
+};
+
+/**
+ * Describes the message v1.DigestPolicy.
+ * Use `create(DigestPolicySchema)` to create a new message.
+ */
+export const DigestPolicySchema: GenMessage<DigestPolicy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 1);
+
 /**
  * @generated from message v1.Multihost
  */
@@ -103,7 +145,7 @@ export type Multihost = Message<"v1.Multihost"> & {
  * Use `create(MultihostSchema)` to create a new message.
  */
 export const MultihostSchema: GenMessage<Multihost> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1);
+  messageDesc(file_v1_config, 2);
 
 /**
  * @generated from message v1.Multihost.Peer
@@ -152,7 +194,7 @@ export type Multihost_Peer = Message<"v1.Multihost.Peer"> & {
  * Use `create(Multihost_PeerSchema)` to create a new message.
  */
 export const Multihost_PeerSchema: GenMessage<Multihost_Peer> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1, 0);
+  messageDesc(file_v1_config, 2, 0);
 
 /**
  * @generated from message v1.Multihost.PairingToken
@@ -213,7 +255,7 @@ export type Multihost_PairingToken = Message<"v1.Multihost.PairingToken"> & {
  * Use `create(Multihost_PairingTokenSchema)` to create a new message.
  */
 export const Multihost_PairingTokenSchema: GenMessage<Multihost_PairingToken> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1, 1);
+  messageDesc(file_v1_config, 2, 1);
 
 /**
  * @generated from message v1.Multihost.Permission
@@ -239,7 +281,7 @@ export type Multihost_Permission = Message<"v1.Multihost.Permission"> & {
  * Use `create(Multihost_PermissionSchema)` to create a new message.
  */
 export const Multihost_PermissionSchema: GenMessage<Multihost_Permission> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1, 2);
+  messageDesc(file_v1_config, 2, 2);
 
 /**
  * @generated from enum v1.Multihost.Permission.Type
@@ -306,7 +348,7 @@ export enum Multihost_Permission_Type {
  * Describes the enum v1.Multihost.Permission.Type.
  */
 export const Multihost_Permission_TypeSchema: GenEnum<Multihost_Permission_Type> = /*@__PURE__*/
-  enumDesc(file_v1_config, 1, 2, 0);
+  enumDesc(file_v1_config, 2, 2, 0);
 
 /**
  * @generated from message v1.Repo
@@ -416,6 +458,13 @@ export type Repo = Message<"v1.Repo"> & {
    * @generated from field: v1.ForgetPolicy forget_policy = 15;
    */
   forgetPolicy?: ForgetPolicy;
+
+  /**
+   * optional policy for replicating snapshots to a secondary repo.
+   *
+   * @generated from field: v1.CopyPolicy copy_policy = 16;
+   */
+  copyPolicy?: CopyPolicy;
 };
 
 /**
@@ -423,7 +472,7 @@ export type Repo = Message<"v1.Repo"> & {
  * Use `create(RepoSchema)` to create a new message.
  */
 export const RepoSchema: GenMessage<Repo> = /*@__PURE__*/
-  messageDesc(file_v1_config, 2);
+  messageDesc(file_v1_config, 3);
 
 /**
  * @generated from message v1.Plan
@@ -498,6 +547,27 @@ export type Plan = Message<"v1.Plan"> & {
    * @generated from field: bool skip_if_unchanged = 13;
    */
   skipIfUnchanged: boolean;
+
+  /**
+   * back up the output of a command instead of paths.
+   *
+   * @generated from field: v1.StdinCommand stdin_command = 14;
+   */
+  stdinCommand?: StdinCommand;
+
+  /**
+   * optional policy for periodically test-restoring the plan's latest snapshot.
+   *
+   * @generated from field: v1.RestoreTestPolicy restore_test_policy = 15;
+   */
+  restoreTestPolicy?: RestoreTestPolicy;
+
+  /**
+   * multiple of the schedule's period the plan may go without a successful backup before it is overdue, defaults to 1.25.
+   *
+   * @generated from field: double overdue_grace_multiplier = 16;
+   */
+  overdueGraceMultiplier: number;
 };
 
 /**
@@ -505,7 +575,35 @@ export type Plan = Message<"v1.Plan"> & {
  * Use `create(PlanSchema)` to create a new message.
  */
 export const PlanSchema: GenMessage<Plan> = /*@__PURE__*/
-  messageDesc(file_v1_config, 3);
+  messageDesc(file_v1_config, 4);
+
+/**
+ * StdinCommand is a backup source that stores the stdout of a command as a single file in the snapshot, e.g. a database dump.
+ *
+ * @generated from message v1.StdinCommand
+ */
+export type StdinCommand = Message<"v1.StdinCommand"> & {
+  /**
+   * command to run, split into arguments following shell quoting rules.
+   *
+   * @generated from field: string command = 1;
+   */
+  command: string;
+
+  /**
+   * name of the file the command's output is stored as in the snapshot.
+   *
+   * @generated from field: string filename = 2;
+   */
+  filename: string;
+};
+
+/**
+ * Describes the message v1.StdinCommand.
+ * Use `create(StdinCommandSchema)` to create a new message.
+ */
+export const StdinCommandSchema: GenMessage<StdinCommand> = /*@__PURE__*/
+  messageDesc(file_v1_config, 5);
 
 /**
  * @generated from message v1.CommandPrefix
@@ -531,7 +629,7 @@ export type CommandPrefix = Message<"v1.CommandPrefix"> & {
  * Use `create(CommandPrefixSchema)` to create a new message.
  */
 export const CommandPrefixSchema: GenMessage<CommandPrefix> = /*@__PURE__*/
-  messageDesc(file_v1_config, 4);
+  messageDesc(file_v1_config, 6);
 
 /**
  * @generated from enum v1.CommandPrefix.IONiceLevel
@@ -562,7 +660,7 @@ export enum CommandPrefix_IONiceLevel {
  * Describes the enum v1.CommandPrefix.IONiceLevel.
  */
 export const CommandPrefix_IONiceLevelSchema: GenEnum<CommandPrefix_IONiceLevel> = /*@__PURE__*/
-  enumDesc(file_v1_config, 4, 0);
+  enumDesc(file_v1_config, 6, 0);
 
 /**
  * @generated from enum v1.CommandPrefix.CPUNiceLevel
@@ -588,7 +686,7 @@ export enum CommandPrefix_CPUNiceLevel {
  * Describes the enum v1.CommandPrefix.CPUNiceLevel.
  */
 export const CommandPrefix_CPUNiceLevelSchema: GenEnum<CommandPrefix_CPUNiceLevel> = /*@__PURE__*/
-  enumDesc(file_v1_config, 4, 1);
+  enumDesc(file_v1_config, 6, 1);
 
 /**
  * @generated from message v1.RetentionPolicy
@@ -616,6 +714,13 @@ export type RetentionPolicy = Message<"v1.RetentionPolicy"> & {
     value: boolean;
     case: "policyKeepAll";
   } | { case: undefined; value?: undefined };
+
+  /**
+   * also keep snapshots with these tags, an entry of comma separated tags matches snapshots with all of them. Ignored by policy_keep_all.
+   *
+   * @generated from field: repeated string keep_tags = 13;
+   */
+  keepTags: string[];
 };
 
 /**
@@ -623,9 +728,11 @@ export type RetentionPolicy = Message<"v1.RetentionPolicy"> & {
  * Use `create(RetentionPolicySchema)` to create a new message.
  */
 export const RetentionPolicySchema: GenMessage<RetentionPolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 5);
+  messageDesc(file_v1_config, 7);
 
 /**
+ * Durations are restic durations relative to the latest snapshot e.g. "1y6m", "7d" or "12h".
+ *
  * @generated from message v1.RetentionPolicy.TimeBucketedCounts
  */
 export type RetentionPolicy_TimeBucketedCounts = Message<"v1.RetentionPolicy.TimeBucketedCounts"> & {
@@ -670,6 +777,48 @@ export type RetentionPolicy_TimeBucketedCounts = Message<"v1.RetentionPolicy.Tim
    * @generated from field: int32 keep_last_n = 6;
    */
   keepLastN: number;
+
+  /**
+   * keep all snapshots within the duration.
+   *
+   * @generated from field: string keep_within = 7;
+   */
+  keepWithin: string;
+
+  /**
+   * keep the last hourly snapshots within the duration.
+   *
+   * @generated from field: string keep_within_hourly = 8;
+   */
+  keepWithinHourly: string;
+
+  /**
+   * keep the last daily snapshots within the duration.
+   *
+   * @generated from field: string keep_within_daily = 9;
+   */
+  keepWithinDaily: string;
+
+  /**
+   * keep the last weekly snapshots within the duration.
+   *
+   * @generated from field: string keep_within_weekly = 10;
+   */
+  keepWithinWeekly: string;
+
+  /**
+   * keep the last monthly snapshots within the duration.
+   *
+   * @generated from field: string keep_within_monthly = 11;
+   */
+  keepWithinMonthly: string;
+
+  /**
+   * keep the last yearly snapshots within the duration.
+   *
+   * @generated from field: string keep_within_yearly = 12;
+   */
+  keepWithinYearly: string;
 };
 
 /**
@@ -677,7 +826,7 @@ export type RetentionPolicy_TimeBucketedCounts = Message<"v1.RetentionPolicy.Tim
  * Use `create(RetentionPolicy_TimeBucketedCountsSchema)` to create a new message.
  */
 export const RetentionPolicy_TimeBucketedCountsSchema: GenMessage<RetentionPolicy_TimeBucketedCounts> = /*@__PURE__*/
-  messageDesc(file_v1_config, 5, 0);
+  messageDesc(file_v1_config, 7, 0);
 
 /**
  * @generated from message v1.ForgetPolicy
@@ -692,6 +841,13 @@ export type ForgetPolicy = Message<"v1.ForgetPolicy"> & {
    * @generated from field: v1.RetentionPolicy retention = 2;
    */
   retention?: RetentionPolicy;
+
+  /**
+   * comma separated snapshot fields the retention is applied per group of e.g. "host,tags". Any of host, paths and tags, defaults to tags.
+   *
+   * @generated from field: string group_by = 3;
+   */
+  groupBy: string;
 };
 
 /**
@@ -699,7 +855,7 @@ export type ForgetPolicy = Message<"v1.ForgetPolicy"> & {
  * Use `create(ForgetPolicySchema)` to create a new message.
  */
 export const ForgetPolicySchema: GenMessage<ForgetPolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 6);
+  messageDesc(file_v1_config, 8);
 
 /**
  * @generated from message v1.PrunePolicy
@@ -730,7 +886,7 @@ export type PrunePolicy = Message<"v1.PrunePolicy"> & {
  * Use `create(PrunePolicySchema)` to create a new message.
  */
 export const PrunePolicySchema: GenMessage<PrunePolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 7);
+  messageDesc(file_v1_config, 9);
 
 /**
  * @generated from message v1.CheckPolicy
@@ -768,7 +924,66 @@ export type CheckPolicy = Message<"v1.CheckPolicy"> & {
  * Use `create(CheckPolicySchema)` to create a new message.
  */
 export const CheckPolicySchema: GenMessage<CheckPolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 8);
+  messageDesc(file_v1_config, 10);
+
+/**
+ * CopyPolicy replicates snapshots from a repo into a secondary repo using `restic copy`.
+ *
+ * @generated from message v1.CopyPolicy
+ */
+export type CopyPolicy = Message<"v1.CopyPolicy"> & {
+  /**
+   * @generated from field: v1.Schedule schedule = 1;
+   */
+  schedule?: Schedule;
+
+  /**
+   * ID of the repo to copy snapshots into.
+   *
+   * @generated from field: string target_repo = 2;
+   */
+  targetRepo: string;
+};
+
+/**
+ * Describes the message v1.CopyPolicy.
+ * Use `create(CopyPolicySchema)` to create a new message.
+ */
+export const CopyPolicySchema: GenMessage<CopyPolicy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 11);
+
+/**
+ * RestoreTestPolicy periodically restores files from a plan's latest snapshot into a temporary directory and verifies them.
+ *
+ * @generated from message v1.RestoreTestPolicy
+ */
+export type RestoreTestPolicy = Message<"v1.RestoreTestPolicy"> & {
+  /**
+   * @generated from field: v1.Schedule schedule = 1;
+   */
+  schedule?: Schedule;
+
+  /**
+   * number of randomly chosen files to restore and verify, defaults to 10.
+   *
+   * @generated from field: int32 sample_size = 2;
+   */
+  sampleSize: number;
+
+  /**
+   * optional, restore everything under this path instead of a random sample of files.
+   *
+   * @generated from field: string path = 3;
+   */
+  path: string;
+};
+
+/**
+ * Describes the message v1.RestoreTestPolicy.
+ * Use `create(RestoreTestPolicySchema)` to create a new message.
+ */
+export const RestoreTestPolicySchema: GenMessage<RestoreTestPolicy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 12);
 
 /**
  * @generated from message v1.Schedule
@@ -824,7 +1039,7 @@ export type Schedule = Message<"v1.Schedule"> & {
  * Use `create(ScheduleSchema)` to create a new message.
  */
 export const ScheduleSchema: GenMessage<Schedule> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9);
+  messageDesc(file_v1_config, 13);
 
 /**
  * @generated from enum v1.Schedule.Clock
@@ -857,7 +1072,7 @@ export enum Schedule_Clock {
  * Describes the enum v1.Schedule.Clock.
  */
 export const Schedule_ClockSchema: GenEnum<Schedule_Clock> = /*@__PURE__*/
-  enumDesc(file_v1_config, 9, 0);
+  enumDesc(file_v1_config, 13, 0);
 
 /**
  * @generated from message v1.Hook
@@ -873,6 +1088,39 @@ export type Hook = Message<"v1.Hook"> & {
    */
   onError: Hook_OnError;
 
+  /**
+   * @generated from field: v1.Hook.NotifyMode notify_mode = 3;
+   */
+  notifyMode: Hook_NotifyMode;
+
+  /**
+   * minimum minutes between runs of the hook when notify_mode is NOTIFY_RATE_LIMITED.
+   *
+   * @generated from field: int32 rate_limit_minutes = 4;
+   */
+  rateLimitMinutes: number;
+
+  /**
+   * id of a hook defined in Config.hooks, required for and only valid on those definitions.
+   *
+   * @generated from field: string id = 5;
+   */
+  id: string;
+
+  /**
+   * id of a hook in Config.hooks to run, the hook must not set an action. Its conditions replace the definition's if set.
+   *
+   * @generated from field: string ref = 6;
+   */
+  ref: string;
+
+  /**
+   * time the hook may run before it fails, command hooks are killed with their child processes. 0 for no timeout.
+   *
+   * @generated from field: int32 timeout_seconds = 7;
+   */
+  timeoutSeconds: number;
+
   /**
    * @generated from oneof v1.Hook.action
    */
@@ -924,6 +1172,24 @@ export type Hook = Message<"v1.Hook"> & {
      */
     value: Hook_Telegram;
     case: "actionTelegram";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Email action_email = 108;
+     */
+    value: Hook_Email;
+    case: "actionEmail";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Mqtt action_mqtt = 109;
+     */
+    value: Hook_Mqtt;
+    case: "actionMqtt";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Ntfy action_ntfy = 110;
+     */
+    value: Hook_Ntfy;
+    case: "actionNtfy";
   } | { case: undefined; value?: undefined };
 };
 
@@ -932,7 +1198,7 @@ export type Hook = Message<"v1.Hook"> & {
  * Use `create(HookSchema)` to create a new message.
  */
 export const HookSchema: GenMessage<Hook> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10);
+  messageDesc(file_v1_config, 14);
 
 /**
  * @generated from message v1.Hook.Command
@@ -949,7 +1215,7 @@ export type Hook_Command = Message<"v1.Hook.Command"> & {
  * Use `create(Hook_CommandSchema)` to create a new message.
  */
 export const Hook_CommandSchema: GenMessage<Hook_Command> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 0);
+  messageDesc(file_v1_config, 14, 0);
 
 /**
  * @generated from message v1.Hook.Webhook
@@ -965,6 +1231,67 @@ export type Hook_Webhook = Message<"v1.Hook.Webhook"> & {
    */
   method: Hook_Webhook_Method;
 
+  /**
+   * extra request headers formatted as "Name: value".
+   *
+   * @generated from field: repeated string headers = 3;
+   */
+  headers: string[];
+
+  /**
+   * content type of the rendered template, defaults to text/plain.
+   *
+   * @generated from field: string content_type = 4;
+   */
+  contentType: string;
+
+  /**
+   * sends basic auth credentials if set.
+   *
+   * @generated from field: string basic_auth_username = 5;
+   */
+  basicAuthUsername: string;
+
+  /**
+   * @generated from field: string basic_auth_password = 6;
+   */
+  basicAuthPassword: string;
+
+  /**
+   * sends an Authorization: Bearer header if set.
+   *
+   * @generated from field: string bearer_token = 7;
+   */
+  bearerToken: string;
+
+  /**
+   * signs the body with HMAC-SHA256 if set, see hmac_header.
+   *
+   * @generated from field: string hmac_secret = 8;
+   */
+  hmacSecret: string;
+
+  /**
+   * header the hex encoded signature is sent in as sha256=<signature>, defaults to X-Backrest-Signature-256.
+   *
+   * @generated from field: string hmac_header = 9;
+   */
+  hmacHeader: string;
+
+  /**
+   * request timeout, defaults to 30 seconds.
+   *
+   * @generated from field: int32 timeout_seconds = 10;
+   */
+  timeoutSeconds: number;
+
+  /**
+   * retries after connection errors and 429 or 5xx responses, with exponential backoff.
+   *
+   * @generated from field: int32 retries = 11;
+   */
+  retries: number;
+
   /**
    * @generated from field: string template = 100;
    */
@@ -976,13 +1303,15 @@ export type Hook_Webhook = Message<"v1.Hook.Webhook"> & {
  * Use `create(Hook_WebhookSchema)` to create a new message.
  */
 export const Hook_WebhookSchema: GenMessage<Hook_Webhook> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 1);
+  messageDesc(file_v1_config, 14, 1);
 
 /**
  * @generated from enum v1.Hook.Webhook.Method
  */
 export enum Hook_Webhook_Method {
   /**
+   * defaults to POST.
+   *
    * @generated from enum value: UNKNOWN = 0;
    */
   UNKNOWN = 0,
@@ -996,13 +1325,28 @@ export enum Hook_Webhook_Method {
    * @generated from enum value: POST = 2;
    */
   POST = 2,
+
+  /**
+   * @generated from enum value: PUT = 3;
+   */
+  PUT = 3,
+
+  /**
+   * @generated from enum value: PATCH = 4;
+   */
+  PATCH = 4,
+
+  /**
+   * @generated from enum value: DELETE = 5;
+   */
+  DELETE = 5,
 }
 
 /**
  * Describes the enum v1.Hook.Webhook.Method.
  */
 export const Hook_Webhook_MethodSchema: GenEnum<Hook_Webhook_Method> = /*@__PURE__*/
-  enumDesc(file_v1_config, 10, 1, 0);
+  enumDesc(file_v1_config, 14, 1, 0);
 
 /**
  * @generated from message v1.Hook.Discord
@@ -1026,7 +1370,7 @@ export type Hook_Discord = Message<"v1.Hook.Discord"> & {
  * Use `create(Hook_DiscordSchema)` to create a new message.
  */
 export const Hook_DiscordSchema: GenMessage<Hook_Discord> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 2);
+  messageDesc(file_v1_config, 14, 2);
 
 /**
  * @generated from message v1.Hook.Gotify
@@ -1069,7 +1413,7 @@ export type Hook_Gotify = Message<"v1.Hook.Gotify"> & {
  * Use `create(Hook_GotifySchema)` to create a new message.
  */
 export const Hook_GotifySchema: GenMessage<Hook_Gotify> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 3);
+  messageDesc(file_v1_config, 14, 3);
 
 /**
  * @generated from message v1.Hook.Slack
@@ -1093,7 +1437,7 @@ export type Hook_Slack = Message<"v1.Hook.Slack"> & {
  * Use `create(Hook_SlackSchema)` to create a new message.
  */
 export const Hook_SlackSchema: GenMessage<Hook_Slack> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 4);
+  messageDesc(file_v1_config, 14, 4);
 
 /**
  * @generated from message v1.Hook.Shoutrrr
@@ -1115,7 +1459,7 @@ export type Hook_Shoutrrr = Message<"v1.Hook.Shoutrrr"> & {
  * Use `create(Hook_ShoutrrrSchema)` to create a new message.
  */
 export const Hook_ShoutrrrSchema: GenMessage<Hook_Shoutrrr> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 5);
+  messageDesc(file_v1_config, 14, 5);
 
 /**
  * @generated from message v1.Hook.Healthchecks
@@ -1137,7 +1481,7 @@ export type Hook_Healthchecks = Message<"v1.Hook.Healthchecks"> & {
  * Use `create(Hook_HealthchecksSchema)` to create a new message.
  */
 export const Hook_HealthchecksSchema: GenMessage<Hook_Healthchecks> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 6);
+  messageDesc(file_v1_config, 14, 6);
 
 /**
  * @generated from message v1.Hook.Telegram
@@ -1166,7 +1510,239 @@ export type Hook_Telegram = Message<"v1.Hook.Telegram"> & {
  * Use `create(Hook_TelegramSchema)` to create a new message.
  */
 export const Hook_TelegramSchema: GenMessage<Hook_Telegram> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10, 7);
+  messageDesc(file_v1_config, 14, 7);
+
+/**
+ * @generated from message v1.Hook.Email
+ */
+export type Hook_Email = Message<"v1.Hook.Email"> & {
+  /**
+   * @generated from field: string host = 1;
+   */
+  host: string;
+
+  /**
+   * defaults to 587, 465 with SECURITY_TLS or 25 with SECURITY_NONE.
+   *
+   * @generated from field: int32 port = 2;
+   */
+  port: number;
+
+  /**
+   * @generated from field: v1.Hook.Email.Security security = 3;
+   */
+  security: Hook_Email_Security;
+
+  /**
+   * authenticates with PLAIN auth if set.
+   *
+   * @generated from field: string username = 4;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string password = 5;
+   */
+  password: string;
+
+  /**
+   * @generated from field: string from = 6;
+   */
+  from: string;
+
+  /**
+   * @generated from field: repeated string to = 7;
+   */
+  to: string[];
+
+  /**
+   * @generated from field: repeated string cc = 8;
+   */
+  cc: string[];
+
+  /**
+   * template for the subject line.
+   *
+   * @generated from field: string subject_template = 9;
+   */
+  subjectTemplate: string;
+
+  /**
+   * template for the plain text body.
+   *
+   * @generated from field: string template = 10;
+   */
+  template: string;
+};
+
+/**
+ * Describes the message v1.Hook.Email.
+ * Use `create(Hook_EmailSchema)` to create a new message.
+ */
+export const Hook_EmailSchema: GenMessage<Hook_Email> = /*@__PURE__*/
+  messageDesc(file_v1_config, 14, 8);
+
+/**
+ * @generated from enum v1.Hook.Email.Security
+ */
+export enum Hook_Email_Security {
+  /**
+   * upgrade the connection with STARTTLS, fails if the server doesn't support it.
+   *
+   * @generated from enum value: SECURITY_STARTTLS = 0;
+   */
+  STARTTLS = 0,
+
+  /**
+   * connect with implicit TLS, typically on port 465.
+   *
+   * @generated from enum value: SECURITY_TLS = 1;
+   */
+  TLS = 1,
+
+  /**
+   * send in plaintext, only suitable for a trusted local relay.
+   *
+   * @generated from enum value: SECURITY_NONE = 2;
+   */
+  NONE = 2,
+}
+
+/**
+ * Describes the enum v1.Hook.Email.Security.
+ */
+export const Hook_Email_SecuritySchema: GenEnum<Hook_Email_Security> = /*@__PURE__*/
+  enumDesc(file_v1_config, 14, 8, 0);
+
+/**
+ * @generated from message v1.Hook.Mqtt
+ */
+export type Hook_Mqtt = Message<"v1.Hook.Mqtt"> & {
+  /**
+   * e.g. tcp://localhost:1883, use ssl:// or mqtts:// to connect with TLS.
+   *
+   * @generated from field: string broker_url = 1;
+   */
+  brokerUrl: string;
+
+  /**
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string password = 3;
+   */
+  password: string;
+
+  /**
+   * defaults to backrest-<instance id>.
+   *
+   * @generated from field: string client_id = 4;
+   */
+  clientId: string;
+
+  /**
+   * base topic, each plan's state is published retained to <topic>/<plan id>/state. Defaults to backrest/<instance id>.
+   *
+   * @generated from field: string topic = 5;
+   */
+  topic: string;
+
+  /**
+   * QoS of published messages, 0, 1 or 2.
+   *
+   * @generated from field: int32 qos = 6;
+   */
+  qos: number;
+
+  /**
+   * publish Home Assistant MQTT discovery configs for each plan's sensors.
+   *
+   * @generated from field: bool home_assistant_discovery = 7;
+   */
+  homeAssistantDiscovery: boolean;
+
+  /**
+   * Home Assistant discovery prefix, defaults to homeassistant.
+   *
+   * @generated from field: string discovery_prefix = 8;
+   */
+  discoveryPrefix: string;
+};
+
+/**
+ * Describes the message v1.Hook.Mqtt.
+ * Use `create(Hook_MqttSchema)` to create a new message.
+ */
+export const Hook_MqttSchema: GenMessage<Hook_Mqtt> = /*@__PURE__*/
+  messageDesc(file_v1_config, 14, 9);
+
+/**
+ * @generated from message v1.Hook.Ntfy
+ */
+export type Hook_Ntfy = Message<"v1.Hook.Ntfy"> & {
+  /**
+   * defaults to https://ntfy.sh.
+   *
+   * @generated from field: string server_url = 1;
+   */
+  serverUrl: string;
+
+  /**
+   * @generated from field: string topic = 2;
+   */
+  topic: string;
+
+  /**
+   * access token, sent as a bearer token if set.
+   *
+   * @generated from field: string token = 3;
+   */
+  token: string;
+
+  /**
+   * tags or emoji shortcodes shown with the notification.
+   *
+   * @generated from field: repeated string tags = 4;
+   */
+  tags: string[];
+
+  /**
+   * URL opened when the notification is clicked.
+   *
+   * @generated from field: string click_url = 5;
+   */
+  clickUrl: string;
+
+  /**
+   * priority 1-5, 0 picks the priority from the event: high for errors, low for successes.
+   *
+   * @generated from field: int32 priority = 6;
+   */
+  priority: number;
+
+  /**
+   * template for the message.
+   *
+   * @generated from field: string template = 100;
+   */
+  template: string;
+
+  /**
+   * template for the title.
+   *
+   * @generated from field: string title_template = 101;
+   */
+  titleTemplate: string;
+};
+
+/**
+ * Describes the message v1.Hook.Ntfy.
+ * Use `create(Hook_NtfySchema)` to create a new message.
+ */
+export const Hook_NtfySchema: GenMessage<Hook_Ntfy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 14, 10);
 
 /**
  * @generated from enum v1.Hook.Condition
@@ -1294,13 +1870,107 @@ export enum Hook_Condition {
    * @generated from enum value: CONDITION_FORGET_SUCCESS = 302;
    */
   FORGET_SUCCESS = 302,
+
+  /**
+   * copy conditions
+   *
+   * copy started.
+   *
+   * @generated from enum value: CONDITION_COPY_START = 400;
+   */
+  COPY_START = 400,
+
+  /**
+   * copy failed.
+   *
+   * @generated from enum value: CONDITION_COPY_ERROR = 401;
+   */
+  COPY_ERROR = 401,
+
+  /**
+   * copy succeeded.
+   *
+   * @generated from enum value: CONDITION_COPY_SUCCESS = 402;
+   */
+  COPY_SUCCESS = 402,
+
+  /**
+   * restore test conditions
+   *
+   * restore test started.
+   *
+   * @generated from enum value: CONDITION_RESTORE_TEST_START = 500;
+   */
+  RESTORE_TEST_START = 500,
+
+  /**
+   * restore test failed to run or found files that didn't restore correctly.
+   *
+   * @generated from enum value: CONDITION_RESTORE_TEST_ERROR = 501;
+   */
+  RESTORE_TEST_ERROR = 501,
+
+  /**
+   * restore test passed.
+   *
+   * @generated from enum value: CONDITION_RESTORE_TEST_SUCCESS = 502;
+   */
+  RESTORE_TEST_SUCCESS = 502,
+
+  /**
+   * backup overdue conditions
+   *
+   * the plan has gone longer than its schedule allows without a successful backup.
+   *
+   * @generated from enum value: CONDITION_BACKUP_OVERDUE = 600;
+   */
+  BACKUP_OVERDUE = 600,
+
+  /**
+   * the plan backed up successfully after being overdue.
+   *
+   * @generated from enum value: CONDITION_BACKUP_OVERDUE_RECOVERED = 601;
+   */
+  BACKUP_OVERDUE_RECOVERED = 601,
+
+  /**
+   * digest conditions
+   *
+   * periodic digest report, only runs hooks configured in the digest policy.
+   *
+   * @generated from enum value: CONDITION_DIGEST = 700;
+   */
+  DIGEST = 700,
+
+  /**
+   * restore conditions
+   *
+   * restore started.
+   *
+   * @generated from enum value: CONDITION_RESTORE_START = 800;
+   */
+  RESTORE_START = 800,
+
+  /**
+   * restore failed.
+   *
+   * @generated from enum value: CONDITION_RESTORE_ERROR = 801;
+   */
+  RESTORE_ERROR = 801,
+
+  /**
+   * restore succeeded.
+   *
+   * @generated from enum value: CONDITION_RESTORE_SUCCESS = 802;
+   */
+  RESTORE_SUCCESS = 802,
 }
 
 /**
  * Describes the enum v1.Hook.Condition.
  */
 export const Hook_ConditionSchema: GenEnum<Hook_Condition> = /*@__PURE__*/
-  enumDesc(file_v1_config, 10, 0);
+  enumDesc(file_v1_config, 14, 0);
 
 /**
  * @generated from enum v1.Hook.OnError
@@ -1351,7 +2021,39 @@ export enum Hook_OnError {
  * Describes the enum v1.Hook.OnError.
  */
 export const Hook_OnErrorSchema: GenEnum<Hook_OnError> = /*@__PURE__*/
-  enumDesc(file_v1_config, 10, 1);
+  enumDesc(file_v1_config, 14, 1);
+
+/**
+ * @generated from enum v1.Hook.NotifyMode
+ */
+export enum Hook_NotifyMode {
+  /**
+   * run the hook every time one of its conditions fires.
+   *
+   * @generated from enum value: NOTIFY_ALWAYS = 0;
+   */
+  NOTIFY_ALWAYS = 0,
+
+  /**
+   * run the hook only when the outcome (e.g. error or success) differs from the previous run of the same plan and condition group. Start conditions always run.
+   *
+   * @generated from enum value: NOTIFY_ON_CHANGE = 1;
+   */
+  NOTIFY_ON_CHANGE = 1,
+
+  /**
+   * run the hook at most once per rate_limit_minutes.
+   *
+   * @generated from enum value: NOTIFY_RATE_LIMITED = 2;
+   */
+  NOTIFY_RATE_LIMITED = 2,
+}
+
+/**
+ * Describes the enum v1.Hook.NotifyMode.
+ */
+export const Hook_NotifyModeSchema: GenEnum<Hook_NotifyMode> = /*@__PURE__*/
+  enumDesc(file_v1_config, 14, 2);
 
 /**
  * @generated from message v1.Auth
@@ -1377,7 +2079,7 @@ export type Auth = Message<"v1.Auth"> & {
  * Use `create(AuthSchema)` to create a new message.
  */
 export const AuthSchema: GenMessage<Auth> = /*@__PURE__*/
-  messageDesc(file_v1_config, 11);
+  messageDesc(file_v1_config, 15);
 
 /**
  * @generated from message v1.User
@@ -1405,5 +2107,5 @@ export type User = Message<"v1.User"> & {
  * Use `create(UserSchema)` to create a new message.
  */
 export const UserSchema: GenMessage<User> = /*@__PURE__*/
-  messageDesc(file_v1_config, 12);
+  messageDesc(file_v1_config, 16);
 
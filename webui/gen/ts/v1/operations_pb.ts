@@ -4,7 +4,7 @@
 
 import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
-import type { BackupProgressEntry, BackupProgressError, RepoStats, ResticSnapshot, RestoreProgressEntry } from "./restic_pb";
+import type { BackupProgressEntry, BackupProgressError, PruneStats, RepoStats, ResticSnapshot, RestoreProgressEntry } from "./restic_pb";
 import { file_v1_restic } from "./restic_pb";
 import type { Hook_Condition, RetentionPolicy } from "./config_pb";
 import { file_v1_config } from "./config_pb";
@@ -16,7 +16,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/operations.proto.
  */
 export const file_v1_operations: GenFile = /*@__PURE__*/
-  fileDesc("ChN2MS9vcGVyYXRpb25zLnByb3RvEgJ2MSIyCg1PcGVyYXRpb25MaXN0EiEKCm9wZXJhdGlvbnMYASADKAsyDS52MS5PcGVyYXRpb24iwAYKCU9wZXJhdGlvbhIKCgJpZBgBIAEoAxITCgtvcmlnaW5hbF9pZBgNIAEoAxINCgVtb2RubxgMIAEoAxIPCgdmbG93X2lkGAogASgDEhgKEG9yaWdpbmFsX2Zsb3dfaWQYDiABKAMSDwoHcmVwb19pZBgCIAEoCRIRCglyZXBvX2d1aWQYDyABKAkSDwoHcGxhbl9pZBgDIAEoCRITCgtpbnN0YW5jZV9pZBgLIAEoCRIfChdvcmlnaW5hbF9pbnN0YW5jZV9rZXlpZBgQIAEoCRITCgtzbmFwc2hvdF9pZBgIIAEoCRIjCgZzdGF0dXMYBCABKA4yEy52MS5PcGVyYXRpb25TdGF0dXMSGgoSdW5peF90aW1lX3N0YXJ0X21zGAUgASgDEhgKEHVuaXhfdGltZV9lbmRfbXMYBiABKAMSFwoPZGlzcGxheV9tZXNzYWdlGAcgASgJEg4KBmxvZ3JlZhgJIAEoCRIvChBvcGVyYXRpb25fYmFja3VwGGQgASgLMhMudjEuT3BlcmF0aW9uQmFja3VwSAASPgoYb3BlcmF0aW9uX2luZGV4X3NuYXBzaG90GGUgASgLMhoudjEuT3BlcmF0aW9uSW5kZXhTbmFwc2hvdEgAEi8KEG9wZXJhdGlvbl9mb3JnZXQYZiABKAsyEy52MS5PcGVyYXRpb25Gb3JnZXRIABItCg9vcGVyYXRpb25fcHJ1bmUYZyABKAsyEi52MS5PcGVyYXRpb25QcnVuZUgAEjEKEW9wZXJhdGlvbl9yZXN0b3JlGGggASgLMhQudjEuT3BlcmF0aW9uUmVzdG9yZUgAEi0KD29wZXJhdGlvbl9zdGF0cxhpIAEoCzISLnYxLk9wZXJhdGlvblN0YXRzSAASMgoSb3BlcmF0aW9uX3J1bl9ob29rGGogASgLMhQudjEuT3BlcmF0aW9uUnVuSG9va0gAEi0KD29wZXJhdGlvbl9jaGVjaxhrIAEoCzISLnYxLk9wZXJhdGlvbkNoZWNrSAASOAoVb3BlcmF0aW9uX3J1bl9jb21tYW5kGGwgASgLMhcudjEuT3BlcmF0aW9uUnVuQ29tbWFuZEgAQgQKAm9wIs8BCg5PcGVyYXRpb25FdmVudBIiCgprZWVwX2FsaXZlGAEgASgLMgwudHlwZXMuRW1wdHlIABIvChJjcmVhdGVkX29wZXJhdGlvbnMYAiABKAsyES52MS5PcGVyYXRpb25MaXN0SAASLwoSdXBkYXRlZF9vcGVyYXRpb25zGAMgASgLMhEudjEuT3BlcmF0aW9uTGlzdEgAEi4KEmRlbGV0ZWRfb3BlcmF0aW9ucxgEIAEoCzIQLnR5cGVzLkludDY0TGlzdEgAQgcKBWV2ZW50InkKD09wZXJhdGlvbkJhY2t1cBIsCgtsYXN0X3N0YXR1cxgDIAEoCzIXLnYxLkJhY2t1cFByb2dyZXNzRW50cnkSJwoGZXJyb3JzGAQgAygLMhcudjEuQmFja3VwUHJvZ3Jlc3NFcnJvchIPCgdkcnlfcnVuGAUgASgIIk4KFk9wZXJhdGlvbkluZGV4U25hcHNob3QSJAoIc25hcHNob3QYAiABKAsyEi52MS5SZXN0aWNTbmFwc2hvdBIOCgZmb3Jnb3QYAyABKAgiWgoPT3BlcmF0aW9uRm9yZ2V0EiIKBmZvcmdldBgBIAMoCzISLnYxLlJlc3RpY1NuYXBzaG90EiMKBnBvbGljeRgCIAEoCzITLnYxLlJldGVudGlvblBvbGljeSI7Cg5PcGVyYXRpb25QcnVuZRISCgZvdXRwdXQYASABKAlCAhgBEhUKDW91dHB1dF9sb2dyZWYYAiABKAkiOwoOT3BlcmF0aW9uQ2hlY2sSEgoGb3V0cHV0GAEgASgJQgIYARIVCg1vdXRwdXRfbG9ncmVmGAIgASgJIlgKE09wZXJhdGlvblJ1bkNvbW1hbmQSDwoHY29tbWFuZBgBIAEoCRIVCg1vdXRwdXRfbG9ncmVmGAIgASgJEhkKEW91dHB1dF9zaXplX2J5dGVzGAMgASgDIl8KEE9wZXJhdGlvblJlc3RvcmUSDAoEcGF0aBgBIAEoCRIOCgZ0YXJnZXQYAiABKAkSLQoLbGFzdF9zdGF0dXMYAyABKAsyGC52MS5SZXN0b3JlUHJvZ3Jlc3NFbnRyeSIuCg5PcGVyYXRpb25TdGF0cxIcCgVzdGF0cxgBIAEoCzINLnYxLlJlcG9TdGF0cyJxChBPcGVyYXRpb25SdW5Ib29rEhEKCXBhcmVudF9vcBgEIAEoAxIMCgRuYW1lGAEgASgJEhUKDW91dHB1dF9sb2dyZWYYAiABKAkSJQoJY29uZGl0aW9uGAMgASgOMhIudjEuSG9vay5Db25kaXRpb24qYAoST3BlcmF0aW9uRXZlbnRUeXBlEhEKDUVWRU5UX1VOS05PV04QABIRCg1FVkVOVF9DUkVBVEVEEAESEQoNRVZFTlRfVVBEQVRFRBACEhEKDUVWRU5UX0RFTEVURUQQAyrCAQoPT3BlcmF0aW9uU3RhdHVzEhIKDlNUQVRVU19VTktOT1dOEAASEgoOU1RBVFVTX1BFTkRJTkcQARIVChFTVEFUVVNfSU5QUk9HUkVTUxACEhIKDlNUQVRVU19TVUNDRVNTEAMSEgoOU1RBVFVTX1dBUk5JTkcQBxIQCgxTVEFUVVNfRVJST1IQBBIbChdTVEFUVVNfU1lTVEVNX0NBTkNFTExFRBAFEhkKFVNUQVRVU19VU0VSX0NBTkNFTExFRBAGQixaKmdpdGh1Yi5jb20vZ2FyZXRoZ2VvcmdlL2JhY2tyZXN0L2dlbi9nby92MWIGcHJvdG8z", [file_v1_restic, file_v1_config, file_types_value]);
+  fileDesc("ChN2MS9vcGVyYXRpb25zLnByb3RvEgJ2MSIyCg1PcGVyYXRpb25MaXN0EiEKCm9wZXJhdGlvbnMYASADKAsyDS52MS5PcGVyYXRpb24i4QcKCU9wZXJhdGlvbhIKCgJpZBgBIAEoAxITCgtvcmlnaW5hbF9pZBgNIAEoAxINCgVtb2RubxgMIAEoAxIPCgdmbG93X2lkGAogASgDEhgKEG9yaWdpbmFsX2Zsb3dfaWQYDiABKAMSDwoHcmVwb19pZBgCIAEoCRIRCglyZXBvX2d1aWQYDyABKAkSDwoHcGxhbl9pZBgDIAEoCRITCgtpbnN0YW5jZV9pZBgLIAEoCRIfChdvcmlnaW5hbF9pbnN0YW5jZV9rZXlpZBgQIAEoCRITCgtzbmFwc2hvdF9pZBgIIAEoCRIjCgZzdGF0dXMYBCABKA4yEy52MS5PcGVyYXRpb25TdGF0dXMSGgoSdW5peF90aW1lX3N0YXJ0X21zGAUgASgDEhgKEHVuaXhfdGltZV9lbmRfbXMYBiABKAMSFwoPZGlzcGxheV9tZXNzYWdlGAcgASgJEg4KBmxvZ3JlZhgJIAEoCRIvChBvcGVyYXRpb25fYmFja3VwGGQgASgLMhMudjEuT3BlcmF0aW9uQmFja3VwSAASPgoYb3BlcmF0aW9uX2luZGV4X3NuYXBzaG90GGUgASgLMhoudjEuT3BlcmF0aW9uSW5kZXhTbmFwc2hvdEgAEi8KEG9wZXJhdGlvbl9mb3JnZXQYZiABKAsyEy52MS5PcGVyYXRpb25Gb3JnZXRIABItCg9vcGVyYXRpb25fcHJ1bmUYZyABKAsyEi52MS5PcGVyYXRpb25QcnVuZUgAEjEKEW9wZXJhdGlvbl9yZXN0b3JlGGggASgLMhQudjEuT3BlcmF0aW9uUmVzdG9yZUgAEi0KD29wZXJhdGlvbl9zdGF0cxhpIAEoCzISLnYxLk9wZXJhdGlvblN0YXRzSAASMgoSb3BlcmF0aW9uX3J1bl9ob29rGGogASgLMhQudjEuT3BlcmF0aW9uUnVuSG9va0gAEi0KD29wZXJhdGlvbl9jaGVjaxhrIAEoCzISLnYxLk9wZXJhdGlvbkNoZWNrSAASOAoVb3BlcmF0aW9uX3J1bl9jb21tYW5kGGwgASgLMhcudjEuT3BlcmF0aW9uUnVuQ29tbWFuZEgAEisKDm9wZXJhdGlvbl9jb3B5GG0gASgLMhEudjEuT3BlcmF0aW9uQ29weUgAEjYKFG9wZXJhdGlvbl9maW5kX2ZpbGVzGG4gASgLMhYudjEuT3BlcmF0aW9uRmluZEZpbGVzSAASOgoWb3BlcmF0aW9uX3Jlc3RvcmVfdGVzdBhvIAEoCzIYLnYxLk9wZXJhdGlvblJlc3RvcmVUZXN0SABCBAoCb3AizwEKDk9wZXJhdGlvbkV2ZW50EiIKCmtlZXBfYWxpdmUYASABKAsyDC50eXBlcy5FbXB0eUgAEi8KEmNyZWF0ZWRfb3BlcmF0aW9ucxgCIAEoCzIRLnYxLk9wZXJhdGlvbkxpc3RIABIvChJ1cGRhdGVkX29wZXJhdGlvbnMYAyABKAsyES52MS5PcGVyYXRpb25MaXN0SAASLgoSZGVsZXRlZF9vcGVyYXRpb25zGAQgASgLMhAudHlwZXMuSW50NjRMaXN0SABCBwoFZXZlbnQieQoPT3BlcmF0aW9uQmFja3VwEiwKC2xhc3Rfc3RhdHVzGAMgASgLMhcudjEuQmFja3VwUHJvZ3Jlc3NFbnRyeRInCgZlcnJvcnMYBCADKAsyFy52MS5CYWNrdXBQcm9ncmVzc0Vycm9yEg8KB2RyeV9ydW4YBSABKAgiXgoWT3BlcmF0aW9uSW5kZXhTbmFwc2hvdBIkCghzbmFwc2hvdBgCIAEoCzISLnYxLlJlc3RpY1NuYXBzaG90Eg4KBmZvcmdvdBgDIAEoCBIOCgZwaW5uZWQYBCABKAgiWgoPT3BlcmF0aW9uRm9yZ2V0EiIKBmZvcmdldBgBIAMoCzISLnYxLlJlc3RpY1NuYXBzaG90EiMKBnBvbGljeRgCIAEoCzITLnYxLlJldGVudGlvblBvbGljeSJaCg5PcGVyYXRpb25QcnVuZRISCgZvdXRwdXQYASABKAlCAhgBEhUKDW91dHB1dF9sb2dyZWYYAiABKAkSHQoFc3RhdHMYAyABKAsyDi52MS5QcnVuZVN0YXRzIjsKDk9wZXJhdGlvbkNoZWNrEhIKBm91dHB1dBgBIAEoCUICGAESFQoNb3V0cHV0X2xvZ3JlZhgCIAEoCSJYCg1PcGVyYXRpb25Db3B5EhYKDnRhcmdldF9yZXBvX2lkGAEgASgJEhgKEHRhcmdldF9yZXBvX2d1aWQYAiABKAkSFQoNb3V0cHV0X2xvZ3JlZhgDIAEoCSKiAQoUT3BlcmF0aW9uUmVzdG9yZVRlc3QSDAoEcGF0aBgBIAEoCRIVCg1maWxlc19jaGVja2VkGAIgASgDEhQKDGZpbGVzX2ZhaWxlZBgDIAEoAxIVCg1ieXRlc19jaGVja2VkGAQgASgDEg4KBnBhc3NlZBgFIAEoCBIoCghmYWlsdXJlcxgGIAMoCzIWLnYxLlJlc3RvcmVUZXN0RmFpbHVyZSIyChJSZXN0b3JlVGVzdEZhaWx1cmUSDAoEcGF0aBgBIAEoCRIOCgZyZWFzb24YAiABKAkifwoST3BlcmF0aW9uRmluZEZpbGVzEiEKBXF1ZXJ5GAEgASgLMhIudjEuRmluZEZpbGVzUXVlcnkSFgoOcmVzdWx0c19sb2dyZWYYAiABKAkSEwoLbWF0Y2hfY291bnQYAyABKAMSGQoRc25hcHNob3RzX21hdGNoZWQYBCABKAMirQEKDkZpbmRGaWxlc1F1ZXJ5Eg8KB3BhdHRlcm4YASABKAkSEwoLaWdub3JlX2Nhc2UYAiABKAgSFAoMc25hcHNob3RfaWRzGAMgAygJEg8KB3BsYW5faWQYBCABKAkSEAoIaG9zdG5hbWUYBSABKAkSHgoWc25hcHNob3RfdGltZV9zdGFydF9tcxgGIAEoAxIcChRzbmFwc2hvdF90aW1lX2VuZF9tcxgHIAEoAyJYChNPcGVyYXRpb25SdW5Db21tYW5kEg8KB2NvbW1hbmQYASABKAkSFQoNb3V0cHV0X2xvZ3JlZhgCIAEoCRIZChFvdXRwdXRfc2l6ZV9ieXRlcxgDIAEoAyKEAQoQT3BlcmF0aW9uUmVzdG9yZRIMCgRwYXRoGAEgASgJEg4KBnRhcmdldBgCIAEoCRItCgtsYXN0X3N0YXR1cxgDIAEoCzIYLnYxLlJlc3RvcmVQcm9ncmVzc0VudHJ5EiMKB29wdGlvbnMYBCABKAsyEi52MS5SZXN0b3JlT3B0aW9ucyKPAgoOUmVzdG9yZU9wdGlvbnMSEAoIaW5jbHVkZXMYASADKAkSEAoIZXhjbHVkZXMYAiADKAkSMwoJb3ZlcndyaXRlGAMgASgOMiAudjEuUmVzdG9yZU9wdGlvbnMuT3ZlcndyaXRlTW9kZRIOCgZkZWxldGUYBCABKAgSDgoGdmVyaWZ5GAUgASgIIoMBCg1PdmVyd3JpdGVNb2RlEhUKEU9WRVJXUklURV9ERUZBVUxUEAASFAoQT1ZFUldSSVRFX0FMV0FZUxABEhgKFE9WRVJXUklURV9JRl9DSEFOR0VEEAISFgoST1ZFUldSSVRFX0lGX05FV0VSEAMSEwoPT1ZFUldSSVRFX05FVkVSEAQiLgoOT3BlcmF0aW9uU3RhdHMSHAoFc3RhdHMYASABKAsyDS52MS5SZXBvU3RhdHMihAEKEE9wZXJhdGlvblJ1bkhvb2sSEQoJcGFyZW50X29wGAQgASgDEgwKBG5hbWUYASABKAkSFQoNb3V0cHV0X2xvZ3JlZhgCIAEoCRIlCgljb25kaXRpb24YAyABKA4yEi52MS5Ib29rLkNvbmRpdGlvbhIRCgl0aW1lZF9vdXQYBSABKAgqYAoST3BlcmF0aW9uRXZlbnRUeXBlEhEKDUVWRU5UX1VOS05PV04QABIRCg1FVkVOVF9DUkVBVEVEEAESEQoNRVZFTlRfVVBEQVRFRBACEhEKDUVWRU5UX0RFTEVURUQQAyrCAQoPT3BlcmF0aW9uU3RhdHVzEhIKDlNUQVRVU19VTktOT1dOEAASEgoOU1RBVFVTX1BFTkRJTkcQARIVChFTVEFUVVNfSU5QUk9HUkVTUxACEhIKDlNUQVRVU19TVUNDRVNTEAMSEgoOU1RBVFVTX1dBUk5JTkcQBxIQCgxTVEFUVVNfRVJST1IQBBIbChdTVEFUVVNfU1lTVEVNX0NBTkNFTExFRBAFEhkKFVNUQVRVU19VU0VSX0NBTkNFTExFRBAGQixaKmdpdGh1Yi5jb20vZ2FyZXRoZ2VvcmdlL2JhY2tyZXN0L2dlbi9nby92MWIGcHJvdG8z", [file_v1_restic, file_v1_config, file_types_value]);
 
 /**
  * @generated from message v1.OperationList
@@ -203,6 +203,24 @@ export type Operation = Message<"v1.Operation"> & {
      */
     value: OperationRunCommand;
     case: "operationRunCommand";
+  } | {
+    /**
+     * @generated from field: v1.OperationCopy operation_copy = 109;
+     */
+    value: OperationCopy;
+    case: "operationCopy";
+  } | {
+    /**
+     * @generated from field: v1.OperationFindFiles operation_find_files = 110;
+     */
+    value: OperationFindFiles;
+    case: "operationFindFiles";
+  } | {
+    /**
+     * @generated from field: v1.OperationRestoreTest operation_restore_test = 111;
+     */
+    value: OperationRestoreTest;
+    case: "operationRestoreTest";
   } | { case: undefined; value?: undefined };
 };
 
@@ -304,6 +322,13 @@ export type OperationIndexSnapshot = Message<"v1.OperationIndexSnapshot"> & {
    * @generated from field: bool forgot = 3;
    */
   forgot: boolean;
+
+  /**
+   * true if the snapshot is pinned, pinned snapshots are never forgotten by a retention policy.
+   *
+   * @generated from field: bool pinned = 4;
+   */
+  pinned: boolean;
 };
 
 /**
@@ -357,6 +382,13 @@ export type OperationPrune = Message<"v1.OperationPrune"> & {
    * @generated from field: string output_logref = 2;
    */
   outputLogref: string;
+
+  /**
+   * summary parsed from the prune output, unset if it couldn't be parsed.
+   *
+   * @generated from field: v1.PruneStats stats = 3;
+   */
+  stats?: PruneStats;
 };
 
 /**
@@ -395,6 +427,220 @@ export type OperationCheck = Message<"v1.OperationCheck"> & {
 export const OperationCheckSchema: GenMessage<OperationCheck> = /*@__PURE__*/
   messageDesc(file_v1_operations, 7);
 
+/**
+ * OperationCopy tracks a copy of snapshots from the operation's repo into a target repo.
+ *
+ * @generated from message v1.OperationCopy
+ */
+export type OperationCopy = Message<"v1.OperationCopy"> & {
+  /**
+   * ID of the repo snapshots were copied into.
+   *
+   * @generated from field: string target_repo_id = 1;
+   */
+  targetRepoId: string;
+
+  /**
+   * GUID of the repo snapshots were copied into.
+   *
+   * @generated from field: string target_repo_guid = 2;
+   */
+  targetRepoGuid: string;
+
+  /**
+   * logref of the copy output.
+   *
+   * @generated from field: string output_logref = 3;
+   */
+  outputLogref: string;
+};
+
+/**
+ * Describes the message v1.OperationCopy.
+ * Use `create(OperationCopySchema)` to create a new message.
+ */
+export const OperationCopySchema: GenMessage<OperationCopy> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 8);
+
+/**
+ * OperationRestoreTest tracks a restore test of the snapshot identified by the operation's snapshot_id.
+ *
+ * @generated from message v1.OperationRestoreTest
+ */
+export type OperationRestoreTest = Message<"v1.OperationRestoreTest"> & {
+  /**
+   * path that was restored, empty if a random sample of files was restored.
+   *
+   * @generated from field: string path = 1;
+   */
+  path: string;
+
+  /**
+   * number of restored files that were verified.
+   *
+   * @generated from field: int64 files_checked = 2;
+   */
+  filesChecked: bigint;
+
+  /**
+   * number of restored files that failed verification.
+   *
+   * @generated from field: int64 files_failed = 3;
+   */
+  filesFailed: bigint;
+
+  /**
+   * @generated from field: int64 bytes_checked = 4;
+   */
+  bytesChecked: bigint;
+
+  /**
+   * true if every verified file matched the snapshot.
+   *
+   * @generated from field: bool passed = 5;
+   */
+  passed: boolean;
+
+  /**
+   * the first files that failed verification.
+   *
+   * @generated from field: repeated v1.RestoreTestFailure failures = 6;
+   */
+  failures: RestoreTestFailure[];
+};
+
+/**
+ * Describes the message v1.OperationRestoreTest.
+ * Use `create(OperationRestoreTestSchema)` to create a new message.
+ */
+export const OperationRestoreTestSchema: GenMessage<OperationRestoreTest> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 9);
+
+/**
+ * @generated from message v1.RestoreTestFailure
+ */
+export type RestoreTestFailure = Message<"v1.RestoreTestFailure"> & {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path: string;
+
+  /**
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message v1.RestoreTestFailure.
+ * Use `create(RestoreTestFailureSchema)` to create a new message.
+ */
+export const RestoreTestFailureSchema: GenMessage<RestoreTestFailure> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 10);
+
+/**
+ * OperationFindFiles tracks a search for files across the snapshots of a repo.
+ *
+ * @generated from message v1.OperationFindFiles
+ */
+export type OperationFindFiles = Message<"v1.OperationFindFiles"> & {
+  /**
+   * @generated from field: v1.FindFilesQuery query = 1;
+   */
+  query?: FindFilesQuery;
+
+  /**
+   * logref of the matches, one JSON encoded FileMatch per line.
+   *
+   * @generated from field: string results_logref = 2;
+   */
+  resultsLogref: string;
+
+  /**
+   * number of matches found.
+   *
+   * @generated from field: int64 match_count = 3;
+   */
+  matchCount: bigint;
+
+  /**
+   * number of snapshots containing at least one match.
+   *
+   * @generated from field: int64 snapshots_matched = 4;
+   */
+  snapshotsMatched: bigint;
+};
+
+/**
+ * Describes the message v1.OperationFindFiles.
+ * Use `create(OperationFindFilesSchema)` to create a new message.
+ */
+export const OperationFindFilesSchema: GenMessage<OperationFindFiles> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 11);
+
+/**
+ * FindFilesQuery describes a search for files across the snapshots of a repo. All filters are optional.
+ *
+ * @generated from message v1.FindFilesQuery
+ */
+export type FindFilesQuery = Message<"v1.FindFilesQuery"> & {
+  /**
+   * glob pattern matched against file names, or against the full path if it contains a '/'.
+   *
+   * @generated from field: string pattern = 1;
+   */
+  pattern: string;
+
+  /**
+   * match the pattern case insensitively.
+   *
+   * @generated from field: bool ignore_case = 2;
+   */
+  ignoreCase: boolean;
+
+  /**
+   * only search these snapshots.
+   *
+   * @generated from field: repeated string snapshot_ids = 3;
+   */
+  snapshotIds: string[];
+
+  /**
+   * only search snapshots created by this plan.
+   *
+   * @generated from field: string plan_id = 4;
+   */
+  planId: string;
+
+  /**
+   * only search snapshots taken on this host.
+   *
+   * @generated from field: string hostname = 5;
+   */
+  hostname: string;
+
+  /**
+   * only search snapshots taken at or after this time.
+   *
+   * @generated from field: int64 snapshot_time_start_ms = 6;
+   */
+  snapshotTimeStartMs: bigint;
+
+  /**
+   * only search snapshots taken at or before this time.
+   *
+   * @generated from field: int64 snapshot_time_end_ms = 7;
+   */
+  snapshotTimeEndMs: bigint;
+};
+
+/**
+ * Describes the message v1.FindFilesQuery.
+ * Use `create(FindFilesQuerySchema)` to create a new message.
+ */
+export const FindFilesQuerySchema: GenMessage<FindFilesQuery> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 12);
+
 /**
  * OperationRunCommand tracks a long running command. Commands are grouped into a flow ID for each session.
  *
@@ -424,7 +670,7 @@ export type OperationRunCommand = Message<"v1.OperationRunCommand"> & {
  * Use `create(OperationRunCommandSchema)` to create a new message.
  */
 export const OperationRunCommandSchema: GenMessage<OperationRunCommand> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 8);
+  messageDesc(file_v1_operations, 13);
 
 /**
  * OperationRestore tracks a restore operation.
@@ -452,6 +698,13 @@ export type OperationRestore = Message<"v1.OperationRestore"> & {
    * @generated from field: v1.RestoreProgressEntry last_status = 3;
    */
   lastStatus?: RestoreProgressEntry;
+
+  /**
+   * options the restore was run with.
+   *
+   * @generated from field: v1.RestoreOptions options = 4;
+   */
+  options?: RestoreOptions;
 };
 
 /**
@@ -459,7 +712,102 @@ export type OperationRestore = Message<"v1.OperationRestore"> & {
  * Use `create(OperationRestoreSchema)` to create a new message.
  */
 export const OperationRestoreSchema: GenMessage<OperationRestore> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 9);
+  messageDesc(file_v1_operations, 14);
+
+/**
+ * RestoreOptions customize which files a restore writes and how it treats existing files in the target.
+ *
+ * @generated from message v1.RestoreOptions
+ */
+export type RestoreOptions = Message<"v1.RestoreOptions"> & {
+  /**
+   * glob patterns to restore, relative to the restored path. Restores everything if empty.
+   *
+   * @generated from field: repeated string includes = 1;
+   */
+  includes: string[];
+
+  /**
+   * glob patterns to skip, relative to the restored path. Can't be combined with includes.
+   *
+   * @generated from field: repeated string excludes = 2;
+   */
+  excludes: string[];
+
+  /**
+   * how to handle files that already exist in the target.
+   *
+   * @generated from field: v1.RestoreOptions.OverwriteMode overwrite = 3;
+   */
+  overwrite: RestoreOptions_OverwriteMode;
+
+  /**
+   * delete files in the target that are not in the snapshot.
+   *
+   * @generated from field: bool delete = 4;
+   */
+  delete: boolean;
+
+  /**
+   * verify the restored files' content after the restore.
+   *
+   * @generated from field: bool verify = 5;
+   */
+  verify: boolean;
+};
+
+/**
+ * Describes the message v1.RestoreOptions.
+ * Use `create(RestoreOptionsSchema)` to create a new message.
+ */
+export const RestoreOptionsSchema: GenMessage<RestoreOptions> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 15);
+
+/**
+ * @generated from enum v1.RestoreOptions.OverwriteMode
+ */
+export enum RestoreOptions_OverwriteMode {
+  /**
+   * the target must not already exist.
+   *
+   * @generated from enum value: OVERWRITE_DEFAULT = 0;
+   */
+  OVERWRITE_DEFAULT = 0,
+
+  /**
+   * overwrite existing files.
+   *
+   * @generated from enum value: OVERWRITE_ALWAYS = 1;
+   */
+  OVERWRITE_ALWAYS = 1,
+
+  /**
+   * overwrite existing files if their content differs.
+   *
+   * @generated from enum value: OVERWRITE_IF_CHANGED = 2;
+   */
+  OVERWRITE_IF_CHANGED = 2,
+
+  /**
+   * overwrite existing files if the file in the snapshot is newer.
+   *
+   * @generated from enum value: OVERWRITE_IF_NEWER = 3;
+   */
+  OVERWRITE_IF_NEWER = 3,
+
+  /**
+   * never overwrite existing files.
+   *
+   * @generated from enum value: OVERWRITE_NEVER = 4;
+   */
+  OVERWRITE_NEVER = 4,
+}
+
+/**
+ * Describes the enum v1.RestoreOptions.OverwriteMode.
+ */
+export const RestoreOptions_OverwriteModeSchema: GenEnum<RestoreOptions_OverwriteMode> = /*@__PURE__*/
+  enumDesc(file_v1_operations, 15, 0);
 
 /**
  * OperationStats tracks a stats operation.
@@ -478,7 +826,7 @@ export type OperationStats = Message<"v1.OperationStats"> & {
  * Use `create(OperationStatsSchema)` to create a new message.
  */
 export const OperationStatsSchema: GenMessage<OperationStats> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 10);
+  messageDesc(file_v1_operations, 16);
 
 /**
  * OperationRunHook tracks a hook that was run.
@@ -513,6 +861,13 @@ export type OperationRunHook = Message<"v1.OperationRunHook"> & {
    * @generated from field: v1.Hook.Condition condition = 3;
    */
   condition: Hook_Condition;
+
+  /**
+   * true if the hook failed because it exceeded its timeout.
+   *
+   * @generated from field: bool timed_out = 5;
+   */
+  timedOut: boolean;
 };
 
 /**
@@ -520,7 +875,7 @@ export type OperationRunHook = Message<"v1.OperationRunHook"> & {
  * Use `create(OperationRunHookSchema)` to create a new message.
  */
 export const OperationRunHookSchema: GenMessage<OperationRunHook> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 11);
+  messageDesc(file_v1_operations, 17);
 
 /**
  * OperationEventType indicates whether the operation was created or updated
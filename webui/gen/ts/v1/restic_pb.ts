@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/restic.proto.
  */
 export const file_v1_restic: GenFile = /*@__PURE__*/
-  fileDesc("Cg92MS9yZXN0aWMucHJvdG8SAnYxIrcBCg5SZXN0aWNTbmFwc2hvdBIKCgJpZBgBIAEoCRIUCgx1bml4X3RpbWVfbXMYAiABKAMSEAoIaG9zdG5hbWUYAyABKAkSEAoIdXNlcm5hbWUYBCABKAkSDAoEdHJlZRgFIAEoCRIOCgZwYXJlbnQYBiABKAkSDQoFcGF0aHMYByADKAkSDAoEdGFncxgIIAMoCRIkCgdzdW1tYXJ5GAkgASgLMhMudjEuU25hcHNob3RTdW1tYXJ5IqgCCg9TbmFwc2hvdFN1bW1hcnkSEQoJZmlsZXNfbmV3GAEgASgDEhUKDWZpbGVzX2NoYW5nZWQYAiABKAMSGAoQZmlsZXNfdW5tb2RpZmllZBgDIAEoAxIQCghkaXJzX25ldxgEIAEoAxIUCgxkaXJzX2NoYW5nZWQYBSABKAMSFwoPZGlyc191bm1vZGlmaWVkGAYgASgDEhIKCmRhdGFfYmxvYnMYByABKAMSEgoKdHJlZV9ibG9icxgIIAEoAxISCgpkYXRhX2FkZGVkGAkgASgDEh0KFXRvdGFsX2ZpbGVzX3Byb2Nlc3NlZBgKIAEoAxIdChV0b3RhbF9ieXRlc19wcm9jZXNzZWQYCyABKAMSFgoOdG90YWxfZHVyYXRpb24YDCABKAEiOwoSUmVzdGljU25hcHNob3RMaXN0EiUKCXNuYXBzaG90cxgBIAMoCzISLnYxLlJlc3RpY1NuYXBzaG90In0KE0JhY2t1cFByb2dyZXNzRW50cnkSLwoGc3RhdHVzGAEgASgLMh0udjEuQmFja3VwUHJvZ3Jlc3NTdGF0dXNFbnRyeUgAEiwKB3N1bW1hcnkYAiABKAsyGS52MS5CYWNrdXBQcm9ncmVzc1N1bW1hcnlIAEIHCgVlbnRyeSKZAQoZQmFja3VwUHJvZ3Jlc3NTdGF0dXNFbnRyeRIUCgxwZXJjZW50X2RvbmUYASABKAESEwoLdG90YWxfZmlsZXMYAiABKAMSEwoLdG90YWxfYnl0ZXMYAyABKAMSEgoKZmlsZXNfZG9uZRgEIAEoAxISCgpieXRlc19kb25lGAUgASgDEhQKDGN1cnJlbnRfZmlsZRgGIAMoCSLDAgoVQmFja3VwUHJvZ3Jlc3NTdW1tYXJ5EhEKCWZpbGVzX25ldxgBIAEoAxIVCg1maWxlc19jaGFuZ2VkGAIgASgDEhgKEGZpbGVzX3VubW9kaWZpZWQYAyABKAMSEAoIZGlyc19uZXcYBCABKAMSFAoMZGlyc19jaGFuZ2VkGAUgASgDEhcKD2RpcnNfdW5tb2RpZmllZBgGIAEoAxISCgpkYXRhX2Jsb2JzGAcgASgDEhIKCnRyZWVfYmxvYnMYCCABKAMSEgoKZGF0YV9hZGRlZBgJIAEoAxIdChV0b3RhbF9maWxlc19wcm9jZXNzZWQYCiABKAMSHQoVdG90YWxfYnl0ZXNfcHJvY2Vzc2VkGAsgASgDEhYKDnRvdGFsX2R1cmF0aW9uGAwgASgBEhMKC3NuYXBzaG90X2lkGA0gASgJIkQKE0JhY2t1cFByb2dyZXNzRXJyb3ISDAoEaXRlbRgBIAEoCRIOCgZkdXJpbmcYAiABKAkSDwoHbWVzc2FnZRgDIAEoCSK1AQoUUmVzdG9yZVByb2dyZXNzRW50cnkSFAoMbWVzc2FnZV90eXBlGAEgASgJEhcKD3NlY29uZHNfZWxhcHNlZBgCIAEoARITCgt0b3RhbF9ieXRlcxgDIAEoAxIWCg5ieXRlc19yZXN0b3JlZBgEIAEoAxITCgt0b3RhbF9maWxlcxgFIAEoAxIWCg5maWxlc19yZXN0b3JlZBgGIAEoAxIUCgxwZXJjZW50X2RvbmUYByABKAEijQEKCVJlcG9TdGF0cxISCgp0b3RhbF9zaXplGAEgASgDEh8KF3RvdGFsX3VuY29tcHJlc3NlZF9zaXplGAIgASgDEhkKEWNvbXByZXNzaW9uX3JhdGlvGAMgASgBEhgKEHRvdGFsX2Jsb2JfY291bnQYBSABKAMSFgoOc25hcHNob3RfY291bnQYBiABKANCLFoqZ2l0aHViLmNvbS9nYXJldGhnZW9yZ2UvYmFja3Jlc3QvZ2VuL2dvL3YxYgZwcm90bzM");
+  fileDesc("Cg92MS9yZXN0aWMucHJvdG8SAnYxIrcBCg5SZXN0aWNTbmFwc2hvdBIKCgJpZBgBIAEoCRIUCgx1bml4X3RpbWVfbXMYAiABKAMSEAoIaG9zdG5hbWUYAyABKAkSEAoIdXNlcm5hbWUYBCABKAkSDAoEdHJlZRgFIAEoCRIOCgZwYXJlbnQYBiABKAkSDQoFcGF0aHMYByADKAkSDAoEdGFncxgIIAMoCRIkCgdzdW1tYXJ5GAkgASgLMhMudjEuU25hcHNob3RTdW1tYXJ5IqgCCg9TbmFwc2hvdFN1bW1hcnkSEQoJZmlsZXNfbmV3GAEgASgDEhUKDWZpbGVzX2NoYW5nZWQYAiABKAMSGAoQZmlsZXNfdW5tb2RpZmllZBgDIAEoAxIQCghkaXJzX25ldxgEIAEoAxIUCgxkaXJzX2NoYW5nZWQYBSABKAMSFwoPZGlyc191bm1vZGlmaWVkGAYgASgDEhIKCmRhdGFfYmxvYnMYByABKAMSEgoKdHJlZV9ibG9icxgIIAEoAxISCgpkYXRhX2FkZGVkGAkgASgDEh0KFXRvdGFsX2ZpbGVzX3Byb2Nlc3NlZBgKIAEoAxIdChV0b3RhbF9ieXRlc19wcm9jZXNzZWQYCyABKAMSFgoOdG90YWxfZHVyYXRpb24YDCABKAEiOwoSUmVzdGljU25hcHNob3RMaXN0EiUKCXNuYXBzaG90cxgBIAMoCzISLnYxLlJlc3RpY1NuYXBzaG90In0KE0JhY2t1cFByb2dyZXNzRW50cnkSLwoGc3RhdHVzGAEgASgLMh0udjEuQmFja3VwUHJvZ3Jlc3NTdGF0dXNFbnRyeUgAEiwKB3N1bW1hcnkYAiABKAsyGS52MS5CYWNrdXBQcm9ncmVzc1N1bW1hcnlIAEIHCgVlbnRyeSKZAQoZQmFja3VwUHJvZ3Jlc3NTdGF0dXNFbnRyeRIUCgxwZXJjZW50X2RvbmUYASABKAESEwoLdG90YWxfZmlsZXMYAiABKAMSEwoLdG90YWxfYnl0ZXMYAyABKAMSEgoKZmlsZXNfZG9uZRgEIAEoAxISCgpieXRlc19kb25lGAUgASgDEhQKDGN1cnJlbnRfZmlsZRgGIAMoCSLDAgoVQmFja3VwUHJvZ3Jlc3NTdW1tYXJ5EhEKCWZpbGVzX25ldxgBIAEoAxIVCg1maWxlc19jaGFuZ2VkGAIgASgDEhgKEGZpbGVzX3VubW9kaWZpZWQYAyABKAMSEAoIZGlyc19uZXcYBCABKAMSFAoMZGlyc19jaGFuZ2VkGAUgASgDEhcKD2RpcnNfdW5tb2RpZmllZBgGIAEoAxISCgpkYXRhX2Jsb2JzGAcgASgDEhIKCnRyZWVfYmxvYnMYCCABKAMSEgoKZGF0YV9hZGRlZBgJIAEoAxIdChV0b3RhbF9maWxlc19wcm9jZXNzZWQYCiABKAMSHQoVdG90YWxfYnl0ZXNfcHJvY2Vzc2VkGAsgASgDEhYKDnRvdGFsX2R1cmF0aW9uGAwgASgBEhMKC3NuYXBzaG90X2lkGA0gASgJIkQKE0JhY2t1cFByb2dyZXNzRXJyb3ISDAoEaXRlbRgBIAEoCRIOCgZkdXJpbmcYAiABKAkSDwoHbWVzc2FnZRgDIAEoCSL6AQoUUmVzdG9yZVByb2dyZXNzRW50cnkSFAoMbWVzc2FnZV90eXBlGAEgASgJEhcKD3NlY29uZHNfZWxhcHNlZBgCIAEoARITCgt0b3RhbF9ieXRlcxgDIAEoAxIWCg5ieXRlc19yZXN0b3JlZBgEIAEoAxITCgt0b3RhbF9maWxlcxgFIAEoAxIWCg5maWxlc19yZXN0b3JlZBgGIAEoAxIUCgxwZXJjZW50X2RvbmUYByABKAESFQoNZmlsZXNfc2tpcHBlZBgIIAEoAxIVCg1ieXRlc19za2lwcGVkGAkgASgDEhUKDWZpbGVzX2RlbGV0ZWQYCiABKAMijwEKEVNuYXBzaG90RGlmZlN0YXRzEhUKDWNoYW5nZWRfZmlsZXMYASABKAMSJQoFYWRkZWQYAiABKAsyFi52MS5TbmFwc2hvdERpZmZDb3VudHMSJwoHcmVtb3ZlZBgDIAEoCzIWLnYxLlNuYXBzaG90RGlmZkNvdW50cxITCgtieXRlc19kZWx0YRgEIAEoAyJ4ChJTbmFwc2hvdERpZmZDb3VudHMSDQoFZmlsZXMYASABKAMSDAoEZGlycxgCIAEoAxIOCgZvdGhlcnMYAyABKAMSEgoKZGF0YV9ibG9icxgEIAEoAxISCgp0cmVlX2Jsb2JzGAUgASgDEg0KBWJ5dGVzGAYgASgDIlkKCUZpbGVNYXRjaBITCgtzbmFwc2hvdF9pZBgBIAEoCRIMCgRwYXRoGAIgASgJEgwKBHR5cGUYAyABKAkSDAoEc2l6ZRgEIAEoAxINCgVtdGltZRgFIAEoCSKNAQoJUmVwb1N0YXRzEhIKCnRvdGFsX3NpemUYASABKAMSHwoXdG90YWxfdW5jb21wcmVzc2VkX3NpemUYAiABKAMSGQoRY29tcHJlc3Npb25fcmF0aW8YAyABKAESGAoQdG90YWxfYmxvYl9jb3VudBgFIAEoAxIWCg5zbmFwc2hvdF9jb3VudBgGIAEoAyK5AgoKUHJ1bmVTdGF0cxIVCg1wYWNrc190b19rZWVwGAEgASgDEhcKD3BhY2tzX3RvX3JlcGFjaxgCIAEoAxIXCg9wYWNrc190b19kZWxldGUYAyABKAMSFwoPYmxvYnNfdG9fcmVwYWNrGAQgASgDEhcKD2J5dGVzX3RvX3JlcGFjaxgFIAEoAxIXCg9ibG9ic190b19kZWxldGUYBiABKAMSFwoPYnl0ZXNfdG9fZGVsZXRlGAcgASgDEhQKDGJsb2JzX3BydW5lZBgIIAEoAxIUCgxieXRlc19wcnVuZWQYCSABKAMSFwoPcmVtYWluaW5nX2Jsb2JzGAogASgDEhcKD3JlbWFpbmluZ19ieXRlcxgLIAEoAxIgChh1bnVzZWRfYnl0ZXNfYWZ0ZXJfcHJ1bmUYDCABKAMiWwoHUmVwb0tleRIKCgJpZBgBIAEoCRIPCgdjdXJyZW50GAIgASgIEhAKCHVzZXJuYW1lGAMgASgJEhAKCGhvc3RuYW1lGAQgASgJEg8KB2NyZWF0ZWQYBSABKAlCLFoqZ2l0aHViLmNvbS9nYXJldGhnZW9yZ2UvYmFja3Jlc3QvZ2VuL2dvL3YxYgZwcm90bzM");
 
 /**
  * ResticSnapshot represents a restic snapshot.
@@ -401,6 +401,25 @@ export type RestoreProgressEntry = Message<"v1.RestoreProgressEntry"> & {
    * @generated from field: double percent_done = 7;
    */
   percentDone: number;
+
+  /**
+   * files left unchanged because they already existed in the target.
+   *
+   * @generated from field: int64 files_skipped = 8;
+   */
+  filesSkipped: bigint;
+
+  /**
+   * @generated from field: int64 bytes_skipped = 9;
+   */
+  bytesSkipped: bigint;
+
+  /**
+   * files deleted from the target, see RestoreOptions.delete.
+   *
+   * @generated from field: int64 files_deleted = 10;
+   */
+  filesDeleted: bigint;
 };
 
 /**
@@ -410,6 +429,126 @@ export type RestoreProgressEntry = Message<"v1.RestoreProgressEntry"> & {
 export const RestoreProgressEntrySchema: GenMessage<RestoreProgressEntry> = /*@__PURE__*/
   messageDesc(file_v1_restic, 7);
 
+/**
+ * SnapshotDiffStats summarizes the changes between two snapshots as reported by `restic diff`. Sizes are only reported
+ * in aggregate, not per changed entry.
+ *
+ * @generated from message v1.SnapshotDiffStats
+ */
+export type SnapshotDiffStats = Message<"v1.SnapshotDiffStats"> & {
+  /**
+   * @generated from field: int64 changed_files = 1;
+   */
+  changedFiles: bigint;
+
+  /**
+   * @generated from field: v1.SnapshotDiffCounts added = 2;
+   */
+  added?: SnapshotDiffCounts;
+
+  /**
+   * @generated from field: v1.SnapshotDiffCounts removed = 3;
+   */
+  removed?: SnapshotDiffCounts;
+
+  /**
+   * bytes added minus bytes removed, negative if the data shrank.
+   *
+   * @generated from field: int64 bytes_delta = 4;
+   */
+  bytesDelta: bigint;
+};
+
+/**
+ * Describes the message v1.SnapshotDiffStats.
+ * Use `create(SnapshotDiffStatsSchema)` to create a new message.
+ */
+export const SnapshotDiffStatsSchema: GenMessage<SnapshotDiffStats> = /*@__PURE__*/
+  messageDesc(file_v1_restic, 8);
+
+/**
+ * @generated from message v1.SnapshotDiffCounts
+ */
+export type SnapshotDiffCounts = Message<"v1.SnapshotDiffCounts"> & {
+  /**
+   * @generated from field: int64 files = 1;
+   */
+  files: bigint;
+
+  /**
+   * @generated from field: int64 dirs = 2;
+   */
+  dirs: bigint;
+
+  /**
+   * @generated from field: int64 others = 3;
+   */
+  others: bigint;
+
+  /**
+   * @generated from field: int64 data_blobs = 4;
+   */
+  dataBlobs: bigint;
+
+  /**
+   * @generated from field: int64 tree_blobs = 5;
+   */
+  treeBlobs: bigint;
+
+  /**
+   * @generated from field: int64 bytes = 6;
+   */
+  bytes: bigint;
+};
+
+/**
+ * Describes the message v1.SnapshotDiffCounts.
+ * Use `create(SnapshotDiffCountsSchema)` to create a new message.
+ */
+export const SnapshotDiffCountsSchema: GenMessage<SnapshotDiffCounts> = /*@__PURE__*/
+  messageDesc(file_v1_restic, 9);
+
+/**
+ * FileMatch is a file found in a snapshot by `restic find`.
+ *
+ * @generated from message v1.FileMatch
+ */
+export type FileMatch = Message<"v1.FileMatch"> & {
+  /**
+   * @generated from field: string snapshot_id = 1;
+   */
+  snapshotId: string;
+
+  /**
+   * @generated from field: string path = 2;
+   */
+  path: string;
+
+  /**
+   * "file", "dir", "symlink", etc.
+   *
+   * @generated from field: string type = 3;
+   */
+  type: string;
+
+  /**
+   * @generated from field: int64 size = 4;
+   */
+  size: bigint;
+
+  /**
+   * @generated from field: string mtime = 5;
+   */
+  mtime: string;
+};
+
+/**
+ * Describes the message v1.FileMatch.
+ * Use `create(FileMatchSchema)` to create a new message.
+ */
+export const FileMatchSchema: GenMessage<FileMatch> = /*@__PURE__*/
+  messageDesc(file_v1_restic, 10);
+
 /**
  * @generated from message v1.RepoStats
  */
@@ -445,5 +584,126 @@ export type RepoStats = Message<"v1.RepoStats"> & {
  * Use `create(RepoStatsSchema)` to create a new message.
  */
 export const RepoStatsSchema: GenMessage<RepoStats> = /*@__PURE__*/
-  messageDesc(file_v1_restic, 8);
+  messageDesc(file_v1_restic, 11);
+
+/**
+ * PruneStats is the summary of a restic prune, sizes are in bytes.
+ *
+ * @generated from message v1.PruneStats
+ */
+export type PruneStats = Message<"v1.PruneStats"> & {
+  /**
+   * @generated from field: int64 packs_to_keep = 1;
+   */
+  packsToKeep: bigint;
+
+  /**
+   * @generated from field: int64 packs_to_repack = 2;
+   */
+  packsToRepack: bigint;
+
+  /**
+   * @generated from field: int64 packs_to_delete = 3;
+   */
+  packsToDelete: bigint;
+
+  /**
+   * @generated from field: int64 blobs_to_repack = 4;
+   */
+  blobsToRepack: bigint;
+
+  /**
+   * @generated from field: int64 bytes_to_repack = 5;
+   */
+  bytesToRepack: bigint;
+
+  /**
+   * @generated from field: int64 blobs_to_delete = 6;
+   */
+  blobsToDelete: bigint;
+
+  /**
+   * @generated from field: int64 bytes_to_delete = 7;
+   */
+  bytesToDelete: bigint;
+
+  /**
+   * blobs deleted or removed by repacking.
+   *
+   * @generated from field: int64 blobs_pruned = 8;
+   */
+  blobsPruned: bigint;
+
+  /**
+   * the space freed by the prune.
+   *
+   * @generated from field: int64 bytes_pruned = 9;
+   */
+  bytesPruned: bigint;
+
+  /**
+   * @generated from field: int64 remaining_blobs = 10;
+   */
+  remainingBlobs: bigint;
+
+  /**
+   * @generated from field: int64 remaining_bytes = 11;
+   */
+  remainingBytes: bigint;
+
+  /**
+   * @generated from field: int64 unused_bytes_after_prune = 12;
+   */
+  unusedBytesAfterPrune: bigint;
+};
+
+/**
+ * Describes the message v1.PruneStats.
+ * Use `create(PruneStatsSchema)` to create a new message.
+ */
+export const PruneStatsSchema: GenMessage<PruneStats> = /*@__PURE__*/
+  messageDesc(file_v1_restic, 12);
+
+/**
+ * RepoKey is a key (password) that can open a repo, see `restic key list`.
+ *
+ * @generated from message v1.RepoKey
+ */
+export type RepoKey = Message<"v1.RepoKey"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * true if this is the key backrest uses to open the repo.
+   *
+   * @generated from field: bool current = 2;
+   */
+  current: boolean;
+
+  /**
+   * @generated from field: string username = 3;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string hostname = 4;
+   */
+  hostname: string;
+
+  /**
+   * creation time formatted by restic e.g. "2024-01-02 15:04:05".
+   *
+   * @generated from field: string created = 5;
+   */
+  created: string;
+};
+
+/**
+ * Describes the message v1.RepoKey.
+ * Use `create(RepoKeySchema)` to create a new message.
+ */
+export const RepoKeySchema: GenMessage<RepoKey> = /*@__PURE__*/
+  messageDesc(file_v1_restic, 13);
 
@@ -4,11 +4,11 @@
 
 import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { ConfigSchema, Multihost_Permission, Repo } from "./config_pb";
+import type { ConfigSchema, Hook, Hook_Condition, Multihost_Permission, Repo, RetentionPolicy } from "./config_pb";
 import { file_v1_config } from "./config_pb";
-import type { ResticSnapshotListSchema } from "./restic_pb";
+import type { FileMatch, RepoKey, RepoKeySchema, ResticSnapshot, ResticSnapshotListSchema, SnapshotDiffStats } from "./restic_pb";
 import { file_v1_restic } from "./restic_pb";
-import type { OperationEventSchema, OperationListSchema, OperationStatus } from "./operations_pb";
+import type { FindFilesQuery, OperationEventSchema, OperationListSchema, OperationStatus, RestoreOptions } from "./operations_pb";
 import { file_v1_operations } from "./operations_pb";
 import type { BytesValueSchema, StringListSchema, StringValueSchema } from "../types/value_pb";
 import { file_types_value } from "../types/value_pb";
@@ -21,7 +21,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/service.proto.
  */
 export const file_v1_service: GenFile = /*@__PURE__*/
-  fileDesc("ChB2MS9zZXJ2aWNlLnByb3RvEgJ2MSIvCg1CYWNrdXBSZXF1ZXN0Eg0KBXZhbHVlGAEgASgJEg8KB2RyeV9ydW4YAiABKAgiLAoUU2NoZWR1bGVUYXNrUmVzcG9uc2USFAoMb3BlcmF0aW9uX2lkGAEgASgDIr8CCgpPcFNlbGVjdG9yEgsKA2lkcxgBIAMoAxIYCgtpbnN0YW5jZV9pZBgGIAEoCUgAiAEBEiQKF29yaWdpbmFsX2luc3RhbmNlX2tleWlkGAggASgJSAGIAQESFgoJcmVwb19ndWlkGAcgASgJSAKIAQESFAoHcGxhbl9pZBgDIAEoCUgDiAEBEhgKC3NuYXBzaG90X2lkGAQgASgJSASIAQESFAoHZmxvd19pZBgFIAEoA0gFiAEBEhYKCW1vZG5vX2d0ZRgJIAEoA0gGiAEBQg4KDF9pbnN0YW5jZV9pZEIaChhfb3JpZ2luYWxfaW5zdGFuY2Vfa2V5aWRCDAoKX3JlcG9fZ3VpZEIKCghfcGxhbl9pZEIOCgxfc25hcHNob3RfaWRCCgoIX2Zsb3dfaWRCDAoKX21vZG5vX2d0ZSJkChBTZXR1cFNmdHBSZXF1ZXN0EgwKBGhvc3QYASABKAkSDAoEcG9ydBgCIAEoCRIQCgh1c2VybmFtZRgDIAEoCRIVCghwYXNzd29yZBgEIAEoCUgAiAEBQgsKCV9wYXNzd29yZCJiChFTZXR1cFNmdHBSZXNwb25zZRISCgpwdWJsaWNfa2V5GAEgASgJEhAKCGtleV9wYXRoGAIgASgJEhgKEGtub3duX2hvc3RzX3BhdGgYAyABKAkSDQoFZXJyb3IYBCABKAkiMAoWQ2hlY2tSZXBvRXhpc3RzUmVxdWVzdBIWCgRyZXBvGAEgASgLMggudjEuUmVwbyJUChdDaGVja1JlcG9FeGlzdHNSZXNwb25zZRIOCgZleGlzdHMYASABKAgSDQoFZXJyb3IYAiABKAkSGgoSaG9zdF9rZXlfdW50cnVzdGVkGAUgASgIIigKDkFkZFJlcG9SZXF1ZXN0EhYKBHJlcG8YASABKAsyCC52MS5SZXBvItIBChFEb1JlcG9UYXNrUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEigKBHRhc2sYAiABKA4yGi52MS5Eb1JlcG9UYXNrUmVxdWVzdC5UYXNrIoEBCgRUYXNrEg0KCVRBU0tfTk9ORRAAEhgKFFRBU0tfSU5ERVhfU05BUFNIT1RTEAESDgoKVEFTS19QUlVORRACEg4KClRBU0tfQ0hFQ0sQAxIOCgpUQVNLX1NUQVRTEAQSDwoLVEFTS19VTkxPQ0sQBRIPCgtUQVNLX0ZPUkdFVBAGIkwKE0NsZWFySGlzdG9yeVJlcXVlc3QSIAoIc2VsZWN0b3IYASABKAsyDi52MS5PcFNlbGVjdG9yEhMKC29ubHlfZmFpbGVkGAIgASgIIkYKDUZvcmdldFJlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRIPCgdwbGFuX2lkGAIgASgJEhMKC3NuYXBzaG90X2lkGAMgASgJIjgKFExpc3RTbmFwc2hvdHNSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSDwoHcGxhbl9pZBgCIAEoCSJIChRHZXRPcGVyYXRpb25zUmVxdWVzdBIgCghzZWxlY3RvchgBIAEoCzIOLnYxLk9wU2VsZWN0b3ISDgoGbGFzdF9uGAIgASgDIm0KFlJlc3RvcmVTbmFwc2hvdFJlcXVlc3QSDwoHcGxhbl9pZBgBIAEoCRIPCgdyZXBvX2lkGAUgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJEgwKBHBhdGgYAyABKAkSDgoGdGFyZ2V0GAQgASgJIk4KGExpc3RTbmFwc2hvdEZpbGVzUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJEgwKBHBhdGgYAyABKAkiRwoZTGlzdFNuYXBzaG90RmlsZXNSZXNwb25zZRIMCgRwYXRoGAEgASgJEhwKB2VudHJpZXMYAiADKAsyCy52MS5Mc0VudHJ5Ih0KDkxvZ0RhdGFSZXF1ZXN0EgsKA3JlZhgBIAEoCSI5ChVHZXREb3dubG9hZFVSTFJlcXVlc3QSDQoFb3BfaWQYASABKAMSEQoJZmlsZV9wYXRoGAIgASgJIpYBCgdMc0VudHJ5EgwKBG5hbWUYASABKAkSDAoEdHlwZRgCIAEoCRIMCgRwYXRoGAMgASgJEgsKA3VpZBgEIAEoAxILCgNnaWQYBSABKAMSDAoEc2l6ZRgGIAEoAxIMCgRtb2RlGAcgASgDEg0KBW10aW1lGAggASgJEg0KBWF0aW1lGAkgASgJEg0KBWN0aW1lGAogASgJIjUKEVJ1bkNvbW1hbmRSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSDwoHY29tbWFuZBgCIAEoCSIqChJSdW5Db21tYW5kUmVzcG9uc2USFAoMb3BlcmF0aW9uX2lkGAEgASgDIiQKEVJlbW92ZVJlcG9SZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkiLgoWQ2FuY2VsT3BlcmF0aW9uUmVxdWVzdBIUCgxvcGVyYXRpb25faWQYASABKAMiiggKGFN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZRI8Cg5yZXBvX3N1bW1hcmllcxgBIAMoCzIkLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5TdW1tYXJ5EjwKDnBsYW5fc3VtbWFyaWVzGAIgAygLMiQudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLlN1bW1hcnkSEwoLY29uZmlnX3BhdGgYCiABKAkSEQoJZGF0YV9wYXRoGAsgASgJGtIDCgdTdW1tYXJ5EgoKAmlkGAEgASgJEh0KFWJhY2t1cHNfZmFpbGVkXzMwZGF5cxgCIAEoAxIjChtiYWNrdXBzX3dhcm5pbmdfbGFzdF8zMGRheXMYAyABKAMSIwobYmFja3Vwc19zdWNjZXNzX2xhc3RfMzBkYXlzGAQgASgDEiEKGWJ5dGVzX3NjYW5uZWRfbGFzdF8zMGRheXMYBSABKAMSHwoXYnl0ZXNfYWRkZWRfbGFzdF8zMGRheXMYBiABKAMSFwoPdG90YWxfc25hcHNob3RzGAcgASgDEhkKEWJ5dGVzX3NjYW5uZWRfYXZnGAggASgDEhcKD2J5dGVzX2FkZGVkX2F2ZxgJIAEoAxIbChNuZXh0X2JhY2t1cF90aW1lX21zGAogASgDEkAKDnJlY2VudF9iYWNrdXBzGAsgASgLMigudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLkJhY2t1cENoYXJ0EhcKD3Byb3RlY3RlZF9ieXRlcxgMIAEoAxJJChNoaXN0b3J5X2xhc3RfMzBkYXlzGA0gAygLMiwudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLkRheVN0YXR1c0J1Y2tldBqDAQoLQmFja3VwQ2hhcnQSDwoHZmxvd19pZBgBIAMoAxIUCgx0aW1lc3RhbXBfbXMYAiADKAMSEwoLZHVyYXRpb25fbXMYAyADKAMSIwoGc3RhdHVzGAQgAygOMhMudjEuT3BlcmF0aW9uU3RhdHVzEhMKC2J5dGVzX2FkZGVkGAUgAygDGqgBCg9EYXlTdGF0dXNCdWNrZXQSFAoMdGltZXN0YW1wX21zGAEgASgDEhMKC2J5dGVzX2FkZGVkGAIgASgDEhUKDWJ5dGVzX3NjYW5uZWQYAyABKAMSQgoNc3RhdHVzX2NvdW50cxgEIAMoCzIrLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5TdGF0dXNBbmRDb3VudBIPCgdvdmVyZHVlGAUgASgIGkQKDlN0YXR1c0FuZENvdW50Eg0KBWNvdW50GAEgASgDEiMKBnN0YXR1cxgCIAEoDjITLnYxLk9wZXJhdGlvblN0YXR1cyKCAQobR2VuZXJhdGVQYWlyaW5nVG9rZW5SZXF1ZXN0Eg0KBWxhYmVsGAEgASgJEhMKC3R0bF9zZWNvbmRzGAIgASgDEhAKCG1heF91c2VzGAMgASgFEi0KC3Blcm1pc3Npb25zGAQgAygLMhgudjEuTXVsdGlob3N0LlBlcm1pc3Npb24iLQocR2VuZXJhdGVQYWlyaW5nVG9rZW5SZXNwb25zZRINCgV0b2tlbhgBIAEoCTKFCwoIQmFja3Jlc3QSMQoJR2V0Q29uZmlnEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GgoudjEuQ29uZmlnIgASJQoJU2V0Q29uZmlnEgoudjEuQ29uZmlnGgoudjEuQ29uZmlnIgASOgoJU2V0dXBTZnRwEhQudjEuU2V0dXBTZnRwUmVxdWVzdBoVLnYxLlNldHVwU2Z0cFJlc3BvbnNlIgASTAoPQ2hlY2tSZXBvRXhpc3RzEhoudjEuQ2hlY2tSZXBvRXhpc3RzUmVxdWVzdBobLnYxLkNoZWNrUmVwb0V4aXN0c1Jlc3BvbnNlIgASKwoHQWRkUmVwbxISLnYxLkFkZFJlcG9SZXF1ZXN0GgoudjEuQ29uZmlnIgASMQoKUmVtb3ZlUmVwbxIVLnYxLlJlbW92ZVJlcG9SZXF1ZXN0GgoudjEuQ29uZmlnIgASRAoSR2V0T3BlcmF0aW9uRXZlbnRzEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GhIudjEuT3BlcmF0aW9uRXZlbnQiADABEj4KDUdldE9wZXJhdGlvbnMSGC52MS5HZXRPcGVyYXRpb25zUmVxdWVzdBoRLnYxLk9wZXJhdGlvbkxpc3QiABJDCg1MaXN0U25hcHNob3RzEhgudjEuTGlzdFNuYXBzaG90c1JlcXVlc3QaFi52MS5SZXN0aWNTbmFwc2hvdExpc3QiABJSChFMaXN0U25hcHNob3RGaWxlcxIcLnYxLkxpc3RTbmFwc2hvdEZpbGVzUmVxdWVzdBodLnYxLkxpc3RTbmFwc2hvdEZpbGVzUmVzcG9uc2UiABI1CgZCYWNrdXASES52MS5CYWNrdXBSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASPwoKRG9SZXBvVGFzaxIVLnYxLkRvUmVwb1Rhc2tSZXF1ZXN0GhgudjEuU2NoZWR1bGVUYXNrUmVzcG9uc2UiABI3CgZGb3JnZXQSES52MS5Gb3JnZXRSZXF1ZXN0GhgudjEuU2NoZWR1bGVUYXNrUmVzcG9uc2UiABJBCgdSZXN0b3JlEhoudjEuUmVzdG9yZVNuYXBzaG90UmVxdWVzdBoYLnYxLlNjaGVkdWxlVGFza1Jlc3BvbnNlIgASPgoGQ2FuY2VsEhoudjEuQ2FuY2VsT3BlcmF0aW9uUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEjQKB0dldExvZ3MSEi52MS5Mb2dEYXRhUmVxdWVzdBoRLnR5cGVzLkJ5dGVzVmFsdWUiADABEj0KClJ1bkNvbW1hbmQSFS52MS5SdW5Db21tYW5kUmVxdWVzdBoWLnYxLlJ1bkNvbW1hbmRSZXNwb25zZSIAEkEKDkdldERvd25sb2FkVVJMEhkudjEuR2V0RG93bmxvYWRVUkxSZXF1ZXN0GhIudHlwZXMuU3RyaW5nVmFsdWUiABJBCgxDbGVhckhpc3RvcnkSFy52MS5DbGVhckhpc3RvcnlSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASOwoQUGF0aEF1dG9jb21wbGV0ZRISLnR5cGVzLlN0cmluZ1ZhbHVlGhEudHlwZXMuU3RyaW5nTGlzdCIAEk0KE0dldFN1bW1hcnlEYXNoYm9hcmQSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaHC52MS5TdW1tYXJ5RGFzaGJvYXJkUmVzcG9uc2UiABJbChRHZW5lcmF0ZVBhaXJpbmdUb2tlbhIfLnYxLkdlbmVyYXRlUGFpcmluZ1Rva2VuUmVxdWVzdBogLnYxLkdlbmVyYXRlUGFpcmluZ1Rva2VuUmVzcG9uc2UiAEIsWipnaXRodWIuY29tL2dhcmV0aGdlb3JnZS9iYWNrcmVzdC9nZW4vZ28vdjFiBnByb3RvMw", [file_v1_config, file_v1_restic, file_v1_operations, file_types_value, file_google_protobuf_empty, file_google_api_annotations]);
+  fileDesc("ChB2MS9zZXJ2aWNlLnByb3RvEgJ2MSIvCg1CYWNrdXBSZXF1ZXN0Eg0KBXZhbHVlGAEgASgJEg8KB2RyeV9ydW4YAiABKAgiLAoUU2NoZWR1bGVUYXNrUmVzcG9uc2USFAoMb3BlcmF0aW9uX2lkGAEgASgDIr8CCgpPcFNlbGVjdG9yEgsKA2lkcxgBIAMoAxIYCgtpbnN0YW5jZV9pZBgGIAEoCUgAiAEBEiQKF29yaWdpbmFsX2luc3RhbmNlX2tleWlkGAggASgJSAGIAQESFgoJcmVwb19ndWlkGAcgASgJSAKIAQESFAoHcGxhbl9pZBgDIAEoCUgDiAEBEhgKC3NuYXBzaG90X2lkGAQgASgJSASIAQESFAoHZmxvd19pZBgFIAEoA0gFiAEBEhYKCW1vZG5vX2d0ZRgJIAEoA0gGiAEBQg4KDF9pbnN0YW5jZV9pZEIaChhfb3JpZ2luYWxfaW5zdGFuY2Vfa2V5aWRCDAoKX3JlcG9fZ3VpZEIKCghfcGxhbl9pZEIOCgxfc25hcHNob3RfaWRCCgoIX2Zsb3dfaWRCDAoKX21vZG5vX2d0ZSJkChBTZXR1cFNmdHBSZXF1ZXN0EgwKBGhvc3QYASABKAkSDAoEcG9ydBgCIAEoCRIQCgh1c2VybmFtZRgDIAEoCRIVCghwYXNzd29yZBgEIAEoCUgAiAEBQgsKCV9wYXNzd29yZCJiChFTZXR1cFNmdHBSZXNwb25zZRISCgpwdWJsaWNfa2V5GAEgASgJEhAKCGtleV9wYXRoGAIgASgJEhgKEGtub3duX2hvc3RzX3BhdGgYAyABKAkSDQoFZXJyb3IYBCABKAkiMAoWQ2hlY2tSZXBvRXhpc3RzUmVxdWVzdBIWCgRyZXBvGAEgASgLMggudjEuUmVwbyJUChdDaGVja1JlcG9FeGlzdHNSZXNwb25zZRIOCgZleGlzdHMYASABKAgSDQoFZXJyb3IYAiABKAkSGgoSaG9zdF9rZXlfdW50cnVzdGVkGAUgASgIIigKDkFkZFJlcG9SZXF1ZXN0EhYKBHJlcG8YASABKAsyCC52MS5SZXBvIuEBChFEb1JlcG9UYXNrUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEigKBHRhc2sYAiABKA4yGi52MS5Eb1JlcG9UYXNrUmVxdWVzdC5UYXNrIpABCgRUYXNrEg0KCVRBU0tfTk9ORRAAEhgKFFRBU0tfSU5ERVhfU05BUFNIT1RTEAESDgoKVEFTS19QUlVORRACEg4KClRBU0tfQ0hFQ0sQAxIOCgpUQVNLX1NUQVRTEAQSDwoLVEFTS19VTkxPQ0sQBRIPCgtUQVNLX0ZPUkdFVBAGEg0KCVRBU0tfQ09QWRAHIiYKE0xpc3RSZXBvS2V5c1JlcXVlc3QSDwoHcmVwb19pZBgBIAEoCSIxChRMaXN0UmVwb0tleXNSZXNwb25zZRIZCgRrZXlzGAEgAygLMgsudjEuUmVwb0tleSJaChFBZGRSZXBvS2V5UmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEhAKCHBhc3N3b3JkGAIgASgJEhAKCHVzZXJuYW1lGAMgASgJEhAKCGhvc3RuYW1lGAQgASgJIjcKFFJlbW92ZVJlcG9LZXlSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSDgoGa2V5X2lkGAIgASgJIkIKGVJvdGF0ZVJlcG9QYXNzd29yZFJlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRIUCgxuZXdfcGFzc3dvcmQYAiABKAkiTAoTQ2xlYXJIaXN0b3J5UmVxdWVzdBIgCghzZWxlY3RvchgBIAEoCzIOLnYxLk9wU2VsZWN0b3ISEwoLb25seV9mYWlsZWQYAiABKAgiRgoNRm9yZ2V0UmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEg8KB3BsYW5faWQYAiABKAkSEwoLc25hcHNob3RfaWQYAyABKAkiOgoSUGluU25hcHNob3RSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSEwoLc25hcHNob3RfaWQYAiABKAkiPAoUVW5waW5TbmFwc2hvdFJlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRITCgtzbmFwc2hvdF9pZBgCIAEoCSJyChRQcmV2aWV3Rm9yZ2V0UmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEg8KB3BsYW5faWQYAiABKAkSJgoJcmV0ZW50aW9uGAMgASgLMhMudjEuUmV0ZW50aW9uUG9saWN5EhAKCGdyb3VwX2J5GAQgASgJIrgBChVQcmV2aWV3Rm9yZ2V0UmVzcG9uc2USNAoEa2VlcBgBIAMoCzImLnYxLlByZXZpZXdGb3JnZXRSZXNwb25zZS5LZXB0U25hcHNob3QSIgoGcmVtb3ZlGAIgAygLMhIudjEuUmVzdGljU25hcHNob3QaRQoMS2VwdFNuYXBzaG90EiQKCHNuYXBzaG90GAEgASgLMhIudjEuUmVzdGljU25hcHNob3QSDwoHcmVhc29ucxgCIAMoCSI4ChRMaXN0U25hcHNob3RzUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEg8KB3BsYW5faWQYAiABKAkiSAoUR2V0T3BlcmF0aW9uc1JlcXVlc3QSIAoIc2VsZWN0b3IYASABKAsyDi52MS5PcFNlbGVjdG9yEg4KBmxhc3RfbhgCIAEoAyKSAQoWUmVzdG9yZVNuYXBzaG90UmVxdWVzdBIPCgdwbGFuX2lkGAEgASgJEg8KB3JlcG9faWQYBSABKAkSEwoLc25hcHNob3RfaWQYAiABKAkSDAoEcGF0aBgDIAEoCRIOCgZ0YXJnZXQYBCABKAkSIwoHb3B0aW9ucxgGIAEoCzISLnYxLlJlc3RvcmVPcHRpb25zIk4KGExpc3RTbmFwc2hvdEZpbGVzUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJEgwKBHBhdGgYAyABKAkiRwoZTGlzdFNuYXBzaG90RmlsZXNSZXNwb25zZRIMCgRwYXRoGAEgASgJEhwKB2VudHJpZXMYAiADKAsyCy52MS5Mc0VudHJ5IkYKEEZpbmRGaWxlc1JlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRIhCgVxdWVyeRgCIAEoCzISLnYxLkZpbmRGaWxlc1F1ZXJ5IlEKGkdldEZpbmRGaWxlc1Jlc3VsdHNSZXF1ZXN0EhQKDG9wZXJhdGlvbl9pZBgBIAEoAxIOCgZvZmZzZXQYAiABKAMSDQoFbGltaXQYAyABKAUiYQobR2V0RmluZEZpbGVzUmVzdWx0c1Jlc3BvbnNlEh4KB21hdGNoZXMYASADKAsyDS52MS5GaWxlTWF0Y2gSDQoFdG90YWwYAiABKAMSEwoLbmV4dF9vZmZzZXQYAyABKAMiZQoURGlmZlNuYXBzaG90c1JlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRITCgtzbmFwc2hvdF9pZBgCIAEoCRIZChFvdGhlcl9zbmFwc2hvdF9pZBgDIAEoCRIMCgRwYXRoGAQgASgJIk0KFURpZmZTbmFwc2hvdHNSZXNwb25zZRIOCgZsb2dyZWYYASABKAkSJAoFc3RhdHMYAiABKAsyFS52MS5TbmFwc2hvdERpZmZTdGF0cyIdCg5Mb2dEYXRhUmVxdWVzdBILCgNyZWYYASABKAkiOQoVR2V0RG93bmxvYWRVUkxSZXF1ZXN0Eg0KBW9wX2lkGAEgASgDEhEKCWZpbGVfcGF0aBgCIAEoCSKWAQoHTHNFbnRyeRIMCgRuYW1lGAEgASgJEgwKBHR5cGUYAiABKAkSDAoEcGF0aBgDIAEoCRILCgN1aWQYBCABKAMSCwoDZ2lkGAUgASgDEgwKBHNpemUYBiABKAMSDAoEbW9kZRgHIAEoAxINCgVtdGltZRgIIAEoCRINCgVhdGltZRgJIAEoCRINCgVjdGltZRgKIAEoCSI1ChFSdW5Db21tYW5kUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEg8KB2NvbW1hbmQYAiABKAkiKgoSUnVuQ29tbWFuZFJlc3BvbnNlEhQKDG9wZXJhdGlvbl9pZBgBIAEoAyJyCg9UZXN0SG9va1JlcXVlc3QSFgoEaG9vaxgBIAEoCzIILnYxLkhvb2sSJQoJY29uZGl0aW9uGAIgASgOMhIudjEuSG9vay5Db25kaXRpb24SDwoHcmVwb19pZBgDIAEoCRIPCgdwbGFuX2lkGAQgASgJIkwKEFRlc3RIb29rUmVzcG9uc2USGQoRcmVuZGVyZWRfdGVtcGxhdGUYASABKAkSDgoGb3V0cHV0GAIgASgJEg0KBWVycm9yGAMgASgJIiQKEVJlbW92ZVJlcG9SZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkiLgoWQ2FuY2VsT3BlcmF0aW9uUmVxdWVzdBIUCgxvcGVyYXRpb25faWQYASABKAMiiggKGFN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZRI8Cg5yZXBvX3N1bW1hcmllcxgBIAMoCzIkLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5TdW1tYXJ5EjwKDnBsYW5fc3VtbWFyaWVzGAIgAygLMiQudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLlN1bW1hcnkSEwoLY29uZmlnX3BhdGgYCiABKAkSEQoJZGF0YV9wYXRoGAsgASgJGtIDCgdTdW1tYXJ5EgoKAmlkGAEgASgJEh0KFWJhY2t1cHNfZmFpbGVkXzMwZGF5cxgCIAEoAxIjChtiYWNrdXBzX3dhcm5pbmdfbGFzdF8zMGRheXMYAyABKAMSIwobYmFja3Vwc19zdWNjZXNzX2xhc3RfMzBkYXlzGAQgASgDEiEKGWJ5dGVzX3NjYW5uZWRfbGFzdF8zMGRheXMYBSABKAMSHwoXYnl0ZXNfYWRkZWRfbGFzdF8zMGRheXMYBiABKAMSFwoPdG90YWxfc25hcHNob3RzGAcgASgDEhkKEWJ5dGVzX3NjYW5uZWRfYXZnGAggASgDEhcKD2J5dGVzX2FkZGVkX2F2ZxgJIAEoAxIbChNuZXh0X2JhY2t1cF90aW1lX21zGAogASgDEkAKDnJlY2VudF9iYWNrdXBzGAsgASgLMigudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLkJhY2t1cENoYXJ0EhcKD3Byb3RlY3RlZF9ieXRlcxgMIAEoAxJJChNoaXN0b3J5X2xhc3RfMzBkYXlzGA0gAygLMiwudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLkRheVN0YXR1c0J1Y2tldBqDAQoLQmFja3VwQ2hhcnQSDwoHZmxvd19pZBgBIAMoAxIUCgx0aW1lc3RhbXBfbXMYAiADKAMSEwoLZHVyYXRpb25fbXMYAyADKAMSIwoGc3RhdHVzGAQgAygOMhMudjEuT3BlcmF0aW9uU3RhdHVzEhMKC2J5dGVzX2FkZGVkGAUgAygDGqgBCg9EYXlTdGF0dXNCdWNrZXQSFAoMdGltZXN0YW1wX21zGAEgASgDEhMKC2J5dGVzX2FkZGVkGAIgASgDEhUKDWJ5dGVzX3NjYW5uZWQYAyABKAMSQgoNc3RhdHVzX2NvdW50cxgEIAMoCzIrLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5TdGF0dXNBbmRDb3VudBIPCgdvdmVyZHVlGAUgASgIGkQKDlN0YXR1c0FuZENvdW50Eg0KBWNvdW50GAEgASgDEiMKBnN0YXR1cxgCIAEoDjITLnYxLk9wZXJhdGlvblN0YXR1cyKCAQobR2VuZXJhdGVQYWlyaW5nVG9rZW5SZXF1ZXN0Eg0KBWxhYmVsGAEgASgJEhMKC3R0bF9zZWNvbmRzGAIgASgDEhAKCG1heF91c2VzGAMgASgFEi0KC3Blcm1pc3Npb25zGAQgAygLMhgudjEuTXVsdGlob3N0LlBlcm1pc3Npb24iLQocR2VuZXJhdGVQYWlyaW5nVG9rZW5SZXNwb25zZRINCgV0b2tlbhgBIAEoCTLuEAoIQmFja3Jlc3QSMQoJR2V0Q29uZmlnEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GgoudjEuQ29uZmlnIgASJQoJU2V0Q29uZmlnEgoudjEuQ29uZmlnGgoudjEuQ29uZmlnIgASOgoJU2V0dXBTZnRwEhQudjEuU2V0dXBTZnRwUmVxdWVzdBoVLnYxLlNldHVwU2Z0cFJlc3BvbnNlIgASTAoPQ2hlY2tSZXBvRXhpc3RzEhoudjEuQ2hlY2tSZXBvRXhpc3RzUmVxdWVzdBobLnYxLkNoZWNrUmVwb0V4aXN0c1Jlc3BvbnNlIgASKwoHQWRkUmVwbxISLnYxLkFkZFJlcG9SZXF1ZXN0GgoudjEuQ29uZmlnIgASMQoKUmVtb3ZlUmVwbxIVLnYxLlJlbW92ZVJlcG9SZXF1ZXN0GgoudjEuQ29uZmlnIgASRAoSR2V0T3BlcmF0aW9uRXZlbnRzEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GhIudjEuT3BlcmF0aW9uRXZlbnQiADABEkMKDExpc3RSZXBvS2V5cxIXLnYxLkxpc3RSZXBvS2V5c1JlcXVlc3QaGC52MS5MaXN0UmVwb0tleXNSZXNwb25zZSIAEjIKCkFkZFJlcG9LZXkSFS52MS5BZGRSZXBvS2V5UmVxdWVzdBoLLnYxLlJlcG9LZXkiABJDCg1SZW1vdmVSZXBvS2V5EhgudjEuUmVtb3ZlUmVwb0tleVJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABJBChJSb3RhdGVSZXBvUGFzc3dvcmQSHS52MS5Sb3RhdGVSZXBvUGFzc3dvcmRSZXF1ZXN0GgoudjEuQ29uZmlnIgASPgoNR2V0T3BlcmF0aW9ucxIYLnYxLkdldE9wZXJhdGlvbnNSZXF1ZXN0GhEudjEuT3BlcmF0aW9uTGlzdCIAEkMKDUxpc3RTbmFwc2hvdHMSGC52MS5MaXN0U25hcHNob3RzUmVxdWVzdBoWLnYxLlJlc3RpY1NuYXBzaG90TGlzdCIAElIKEUxpc3RTbmFwc2hvdEZpbGVzEhwudjEuTGlzdFNuYXBzaG90RmlsZXNSZXF1ZXN0Gh0udjEuTGlzdFNuYXBzaG90RmlsZXNSZXNwb25zZSIAEj0KCUZpbmRGaWxlcxIULnYxLkZpbmRGaWxlc1JlcXVlc3QaGC52MS5TY2hlZHVsZVRhc2tSZXNwb25zZSIAElgKE0dldEZpbmRGaWxlc1Jlc3VsdHMSHi52MS5HZXRGaW5kRmlsZXNSZXN1bHRzUmVxdWVzdBofLnYxLkdldEZpbmRGaWxlc1Jlc3VsdHNSZXNwb25zZSIAEkYKDURpZmZTbmFwc2hvdHMSGC52MS5EaWZmU25hcHNob3RzUmVxdWVzdBoZLnYxLkRpZmZTbmFwc2hvdHNSZXNwb25zZSIAEjUKBkJhY2t1cBIRLnYxLkJhY2t1cFJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI/CgpEb1JlcG9UYXNrEhUudjEuRG9SZXBvVGFza1JlcXVlc3QaGC52MS5TY2hlZHVsZVRhc2tSZXNwb25zZSIAEjcKBkZvcmdldBIRLnYxLkZvcmdldFJlcXVlc3QaGC52MS5TY2hlZHVsZVRhc2tSZXNwb25zZSIAEkYKDVByZXZpZXdGb3JnZXQSGC52MS5QcmV2aWV3Rm9yZ2V0UmVxdWVzdBoZLnYxLlByZXZpZXdGb3JnZXRSZXNwb25zZSIAEj8KC1BpblNuYXBzaG90EhYudjEuUGluU25hcHNob3RSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASQwoNVW5waW5TbmFwc2hvdBIYLnYxLlVucGluU25hcHNob3RSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASQQoHUmVzdG9yZRIaLnYxLlJlc3RvcmVTbmFwc2hvdFJlcXVlc3QaGC52MS5TY2hlZHVsZVRhc2tSZXNwb25zZSIAEj4KBkNhbmNlbBIaLnYxLkNhbmNlbE9wZXJhdGlvblJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI0CgdHZXRMb2dzEhIudjEuTG9nRGF0YVJlcXVlc3QaES50eXBlcy5CeXRlc1ZhbHVlIgAwARI9CgpSdW5Db21tYW5kEhUudjEuUnVuQ29tbWFuZFJlcXVlc3QaFi52MS5SdW5Db21tYW5kUmVzcG9uc2UiABI3CghUZXN0SG9vaxITLnYxLlRlc3RIb29rUmVxdWVzdBoULnYxLlRlc3RIb29rUmVzcG9uc2UiABJBCg5HZXREb3dubG9hZFVSTBIZLnYxLkdldERvd25sb2FkVVJMUmVxdWVzdBoSLnR5cGVzLlN0cmluZ1ZhbHVlIgASQQoMQ2xlYXJIaXN0b3J5EhcudjEuQ2xlYXJIaXN0b3J5UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEjsKEFBhdGhBdXRvY29tcGxldGUSEi50eXBlcy5TdHJpbmdWYWx1ZRoRLnR5cGVzLlN0cmluZ0xpc3QiABJNChNHZXRTdW1tYXJ5RGFzaGJvYXJkEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GhwudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlIgASWwoUR2VuZXJhdGVQYWlyaW5nVG9rZW4SHy52MS5HZW5lcmF0ZVBhaXJpbmdUb2tlblJlcXVlc3QaIC52MS5HZW5lcmF0ZVBhaXJpbmdUb2tlblJlc3BvbnNlIgBCLFoqZ2l0aHViLmNvbS9nYXJldGhnZW9yZ2UvYmFja3Jlc3QvZ2VuL2dvL3YxYgZwcm90bzM", [file_v1_config, file_v1_restic, file_v1_operations, file_types_value, file_google_protobuf_empty, file_google_api_annotations]);
 
 /**
  * @generated from message v1.BackupRequest
@@ -311,6 +311,11 @@ export enum DoRepoTaskRequest_Task {
    * @generated from enum value: TASK_FORGET = 6;
    */
   FORGET = 6,
+
+  /**
+   * @generated from enum value: TASK_COPY = 7;
+   */
+  COPY = 7,
 }
 
 /**
@@ -319,6 +324,120 @@ export enum DoRepoTaskRequest_Task {
 export const DoRepoTaskRequest_TaskSchema: GenEnum<DoRepoTaskRequest_Task> = /*@__PURE__*/
   enumDesc(file_v1_service, 8, 0);
 
+/**
+ * @generated from message v1.ListRepoKeysRequest
+ */
+export type ListRepoKeysRequest = Message<"v1.ListRepoKeysRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+};
+
+/**
+ * Describes the message v1.ListRepoKeysRequest.
+ * Use `create(ListRepoKeysRequestSchema)` to create a new message.
+ */
+export const ListRepoKeysRequestSchema: GenMessage<ListRepoKeysRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 9);
+
+/**
+ * @generated from message v1.ListRepoKeysResponse
+ */
+export type ListRepoKeysResponse = Message<"v1.ListRepoKeysResponse"> & {
+  /**
+   * @generated from field: repeated v1.RepoKey keys = 1;
+   */
+  keys: RepoKey[];
+};
+
+/**
+ * Describes the message v1.ListRepoKeysResponse.
+ * Use `create(ListRepoKeysResponseSchema)` to create a new message.
+ */
+export const ListRepoKeysResponseSchema: GenMessage<ListRepoKeysResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 10);
+
+/**
+ * @generated from message v1.AddRepoKeyRequest
+ */
+export type AddRepoKeyRequest = Message<"v1.AddRepoKeyRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string password = 2;
+   */
+  password: string;
+
+  /**
+   * optional, username recorded with the key.
+   *
+   * @generated from field: string username = 3;
+   */
+  username: string;
+
+  /**
+   * optional, hostname recorded with the key.
+   *
+   * @generated from field: string hostname = 4;
+   */
+  hostname: string;
+};
+
+/**
+ * Describes the message v1.AddRepoKeyRequest.
+ * Use `create(AddRepoKeyRequestSchema)` to create a new message.
+ */
+export const AddRepoKeyRequestSchema: GenMessage<AddRepoKeyRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 11);
+
+/**
+ * @generated from message v1.RemoveRepoKeyRequest
+ */
+export type RemoveRepoKeyRequest = Message<"v1.RemoveRepoKeyRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string key_id = 2;
+   */
+  keyId: string;
+};
+
+/**
+ * Describes the message v1.RemoveRepoKeyRequest.
+ * Use `create(RemoveRepoKeyRequestSchema)` to create a new message.
+ */
+export const RemoveRepoKeyRequestSchema: GenMessage<RemoveRepoKeyRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 12);
+
+/**
+ * @generated from message v1.RotateRepoPasswordRequest
+ */
+export type RotateRepoPasswordRequest = Message<"v1.RotateRepoPasswordRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword: string;
+};
+
+/**
+ * Describes the message v1.RotateRepoPasswordRequest.
+ * Use `create(RotateRepoPasswordRequestSchema)` to create a new message.
+ */
+export const RotateRepoPasswordRequestSchema: GenMessage<RotateRepoPasswordRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 13);
+
 /**
  * @generated from message v1.ClearHistoryRequest
  */
@@ -339,7 +458,7 @@ export type ClearHistoryRequest = Message<"v1.ClearHistoryRequest"> & {
  * Use `create(ClearHistoryRequestSchema)` to create a new message.
  */
 export const ClearHistoryRequestSchema: GenMessage<ClearHistoryRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 9);
+  messageDesc(file_v1_service, 14);
 
 /**
  * @generated from message v1.ForgetRequest
@@ -366,7 +485,139 @@ export type ForgetRequest = Message<"v1.ForgetRequest"> & {
  * Use `create(ForgetRequestSchema)` to create a new message.
  */
 export const ForgetRequestSchema: GenMessage<ForgetRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 10);
+  messageDesc(file_v1_service, 15);
+
+/**
+ * @generated from message v1.PinSnapshotRequest
+ */
+export type PinSnapshotRequest = Message<"v1.PinSnapshotRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+};
+
+/**
+ * Describes the message v1.PinSnapshotRequest.
+ * Use `create(PinSnapshotRequestSchema)` to create a new message.
+ */
+export const PinSnapshotRequestSchema: GenMessage<PinSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 16);
+
+/**
+ * @generated from message v1.UnpinSnapshotRequest
+ */
+export type UnpinSnapshotRequest = Message<"v1.UnpinSnapshotRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+};
+
+/**
+ * Describes the message v1.UnpinSnapshotRequest.
+ * Use `create(UnpinSnapshotRequestSchema)` to create a new message.
+ */
+export const UnpinSnapshotRequestSchema: GenMessage<UnpinSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 17);
+
+/**
+ * @generated from message v1.PreviewForgetRequest
+ */
+export type PreviewForgetRequest = Message<"v1.PreviewForgetRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * optional, previews the plan's snapshots. If empty, previews the repo's forget policy scope i.e. all snapshots grouped by tags.
+   *
+   * @generated from field: string plan_id = 2;
+   */
+  planId: string;
+
+  /**
+   * the proposed retention policy, need not be saved in the config.
+   *
+   * @generated from field: v1.RetentionPolicy retention = 3;
+   */
+  retention?: RetentionPolicy;
+
+  /**
+   * optional, the proposed forget policy group by when previewing the repo's forget policy. Defaults to the repo's configured group by.
+   *
+   * @generated from field: string group_by = 4;
+   */
+  groupBy: string;
+};
+
+/**
+ * Describes the message v1.PreviewForgetRequest.
+ * Use `create(PreviewForgetRequestSchema)` to create a new message.
+ */
+export const PreviewForgetRequestSchema: GenMessage<PreviewForgetRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 18);
+
+/**
+ * @generated from message v1.PreviewForgetResponse
+ */
+export type PreviewForgetResponse = Message<"v1.PreviewForgetResponse"> & {
+  /**
+   * snapshots the policy would keep.
+   *
+   * @generated from field: repeated v1.PreviewForgetResponse.KeptSnapshot keep = 1;
+   */
+  keep: PreviewForgetResponse_KeptSnapshot[];
+
+  /**
+   * snapshots the policy would remove.
+   *
+   * @generated from field: repeated v1.ResticSnapshot remove = 2;
+   */
+  remove: ResticSnapshot[];
+};
+
+/**
+ * Describes the message v1.PreviewForgetResponse.
+ * Use `create(PreviewForgetResponseSchema)` to create a new message.
+ */
+export const PreviewForgetResponseSchema: GenMessage<PreviewForgetResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 19);
+
+/**
+ * @generated from message v1.PreviewForgetResponse.KeptSnapshot
+ */
+export type PreviewForgetResponse_KeptSnapshot = Message<"v1.PreviewForgetResponse.KeptSnapshot"> & {
+  /**
+   * @generated from field: v1.ResticSnapshot snapshot = 1;
+   */
+  snapshot?: ResticSnapshot;
+
+  /**
+   * the keep rules the snapshot matched as reported by restic e.g. "daily snapshot".
+   *
+   * @generated from field: repeated string reasons = 2;
+   */
+  reasons: string[];
+};
+
+/**
+ * Describes the message v1.PreviewForgetResponse.KeptSnapshot.
+ * Use `create(PreviewForgetResponse_KeptSnapshotSchema)` to create a new message.
+ */
+export const PreviewForgetResponse_KeptSnapshotSchema: GenMessage<PreviewForgetResponse_KeptSnapshot> = /*@__PURE__*/
+  messageDesc(file_v1_service, 19, 0);
 
 /**
  * @generated from message v1.ListSnapshotsRequest
@@ -388,7 +639,7 @@ export type ListSnapshotsRequest = Message<"v1.ListSnapshotsRequest"> & {
  * Use `create(ListSnapshotsRequestSchema)` to create a new message.
  */
 export const ListSnapshotsRequestSchema: GenMessage<ListSnapshotsRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 11);
+  messageDesc(file_v1_service, 20);
 
 /**
  * @generated from message v1.GetOperationsRequest
@@ -412,7 +663,7 @@ export type GetOperationsRequest = Message<"v1.GetOperationsRequest"> & {
  * Use `create(GetOperationsRequestSchema)` to create a new message.
  */
 export const GetOperationsRequestSchema: GenMessage<GetOperationsRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 12);
+  messageDesc(file_v1_service, 21);
 
 /**
  * @generated from message v1.RestoreSnapshotRequest
@@ -442,6 +693,13 @@ export type RestoreSnapshotRequest = Message<"v1.RestoreSnapshotRequest"> & {
    * @generated from field: string target = 4;
    */
   target: string;
+
+  /**
+   * optional, defaults to restoring everything into a new target.
+   *
+   * @generated from field: v1.RestoreOptions options = 6;
+   */
+  options?: RestoreOptions;
 };
 
 /**
@@ -449,7 +707,7 @@ export type RestoreSnapshotRequest = Message<"v1.RestoreSnapshotRequest"> & {
  * Use `create(RestoreSnapshotRequestSchema)` to create a new message.
  */
 export const RestoreSnapshotRequestSchema: GenMessage<RestoreSnapshotRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 13);
+  messageDesc(file_v1_service, 22);
 
 /**
  * @generated from message v1.ListSnapshotFilesRequest
@@ -476,7 +734,7 @@ export type ListSnapshotFilesRequest = Message<"v1.ListSnapshotFilesRequest"> &
  * Use `create(ListSnapshotFilesRequestSchema)` to create a new message.
  */
 export const ListSnapshotFilesRequestSchema: GenMessage<ListSnapshotFilesRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 14);
+  messageDesc(file_v1_service, 23);
 
 /**
  * @generated from message v1.ListSnapshotFilesResponse
@@ -498,7 +756,159 @@ export type ListSnapshotFilesResponse = Message<"v1.ListSnapshotFilesResponse">
  * Use `create(ListSnapshotFilesResponseSchema)` to create a new message.
  */
 export const ListSnapshotFilesResponseSchema: GenMessage<ListSnapshotFilesResponse> = /*@__PURE__*/
-  messageDesc(file_v1_service, 15);
+  messageDesc(file_v1_service, 24);
+
+/**
+ * @generated from message v1.FindFilesRequest
+ */
+export type FindFilesRequest = Message<"v1.FindFilesRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: v1.FindFilesQuery query = 2;
+   */
+  query?: FindFilesQuery;
+};
+
+/**
+ * Describes the message v1.FindFilesRequest.
+ * Use `create(FindFilesRequestSchema)` to create a new message.
+ */
+export const FindFilesRequestSchema: GenMessage<FindFilesRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 25);
+
+/**
+ * @generated from message v1.GetFindFilesResultsRequest
+ */
+export type GetFindFilesResultsRequest = Message<"v1.GetFindFilesResultsRequest"> & {
+  /**
+   * ID of the FindFiles operation.
+   *
+   * @generated from field: int64 operation_id = 1;
+   */
+  operationId: bigint;
+
+  /**
+   * number of matches to skip.
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset: bigint;
+
+  /**
+   * max number of matches to return, defaults to 100 and is capped at 1000.
+   *
+   * @generated from field: int32 limit = 3;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message v1.GetFindFilesResultsRequest.
+ * Use `create(GetFindFilesResultsRequestSchema)` to create a new message.
+ */
+export const GetFindFilesResultsRequestSchema: GenMessage<GetFindFilesResultsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 26);
+
+/**
+ * @generated from message v1.GetFindFilesResultsResponse
+ */
+export type GetFindFilesResultsResponse = Message<"v1.GetFindFilesResultsResponse"> & {
+  /**
+   * @generated from field: repeated v1.FileMatch matches = 1;
+   */
+  matches: FileMatch[];
+
+  /**
+   * total number of matches found by the operation.
+   *
+   * @generated from field: int64 total = 2;
+   */
+  total: bigint;
+
+  /**
+   * offset of the next page, or 0 if there are no more matches.
+   *
+   * @generated from field: int64 next_offset = 3;
+   */
+  nextOffset: bigint;
+};
+
+/**
+ * Describes the message v1.GetFindFilesResultsResponse.
+ * Use `create(GetFindFilesResultsResponseSchema)` to create a new message.
+ */
+export const GetFindFilesResultsResponseSchema: GenMessage<GetFindFilesResultsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 27);
+
+/**
+ * @generated from message v1.DiffSnapshotsRequest
+ */
+export type DiffSnapshotsRequest = Message<"v1.DiffSnapshotsRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * snapshot to compare from e.g. the older snapshot.
+   *
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+
+  /**
+   * snapshot to compare to e.g. the newer snapshot.
+   *
+   * @generated from field: string other_snapshot_id = 3;
+   */
+  otherSnapshotId: string;
+
+  /**
+   * optional, only compare entries under this path.
+   *
+   * @generated from field: string path = 4;
+   */
+  path: string;
+};
+
+/**
+ * Describes the message v1.DiffSnapshotsRequest.
+ * Use `create(DiffSnapshotsRequestSchema)` to create a new message.
+ */
+export const DiffSnapshotsRequestSchema: GenMessage<DiffSnapshotsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 28);
+
+/**
+ * @generated from message v1.DiffSnapshotsResponse
+ */
+export type DiffSnapshotsResponse = Message<"v1.DiffSnapshotsResponse"> & {
+  /**
+   * logref of the changed entries, one JSON object per line e.g. {"path":"/etc/hosts","modifier":"M"}.
+   * Modifiers are "+" added, "-" removed, "M" content modified, "T" type changed, "U" metadata changed, "?" bitrot.
+   * Entries have no size, see stats.
+   *
+   * @generated from field: string logref = 1;
+   */
+  logref: string;
+
+  /**
+   * totals of the diff, including the aggregate size delta in bytes_delta.
+   *
+   * @generated from field: v1.SnapshotDiffStats stats = 2;
+   */
+  stats?: SnapshotDiffStats;
+};
+
+/**
+ * Describes the message v1.DiffSnapshotsResponse.
+ * Use `create(DiffSnapshotsResponseSchema)` to create a new message.
+ */
+export const DiffSnapshotsResponseSchema: GenMessage<DiffSnapshotsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 29);
 
 /**
  * @generated from message v1.LogDataRequest
@@ -515,7 +925,7 @@ export type LogDataRequest = Message<"v1.LogDataRequest"> & {
  * Use `create(LogDataRequestSchema)` to create a new message.
  */
 export const LogDataRequestSchema: GenMessage<LogDataRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 16);
+  messageDesc(file_v1_service, 30);
 
 /**
  * @generated from message v1.GetDownloadURLRequest
@@ -537,7 +947,7 @@ export type GetDownloadURLRequest = Message<"v1.GetDownloadURLRequest"> & {
  * Use `create(GetDownloadURLRequestSchema)` to create a new message.
  */
 export const GetDownloadURLRequestSchema: GenMessage<GetDownloadURLRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 17);
+  messageDesc(file_v1_service, 31);
 
 /**
  * @generated from message v1.LsEntry
@@ -599,7 +1009,7 @@ export type LsEntry = Message<"v1.LsEntry"> & {
  * Use `create(LsEntrySchema)` to create a new message.
  */
 export const LsEntrySchema: GenMessage<LsEntry> = /*@__PURE__*/
-  messageDesc(file_v1_service, 18);
+  messageDesc(file_v1_service, 32);
 
 /**
  * @generated from message v1.RunCommandRequest
@@ -621,7 +1031,7 @@ export type RunCommandRequest = Message<"v1.RunCommandRequest"> & {
  * Use `create(RunCommandRequestSchema)` to create a new message.
  */
 export const RunCommandRequestSchema: GenMessage<RunCommandRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 19);
+  messageDesc(file_v1_service, 33);
 
 /**
  * @generated from message v1.RunCommandResponse
@@ -638,7 +1048,80 @@ export type RunCommandResponse = Message<"v1.RunCommandResponse"> & {
  * Use `create(RunCommandResponseSchema)` to create a new message.
  */
 export const RunCommandResponseSchema: GenMessage<RunCommandResponse> = /*@__PURE__*/
-  messageDesc(file_v1_service, 20);
+  messageDesc(file_v1_service, 34);
+
+/**
+ * @generated from message v1.TestHookRequest
+ */
+export type TestHookRequest = Message<"v1.TestHookRequest"> & {
+  /**
+   * the hook to fire, need not be saved in the config.
+   *
+   * @generated from field: v1.Hook hook = 1;
+   */
+  hook?: Hook;
+
+  /**
+   * the event to fire the hook for.
+   *
+   * @generated from field: v1.Hook.Condition condition = 2;
+   */
+  condition: Hook_Condition;
+
+  /**
+   * optional, defaults to the plan's repo.
+   *
+   * @generated from field: string repo_id = 3;
+   */
+  repoId: string;
+
+  /**
+   * optional, variables are filled from the plan's most recent backup if it has one, otherwise with sample values.
+   *
+   * @generated from field: string plan_id = 4;
+   */
+  planId: string;
+};
+
+/**
+ * Describes the message v1.TestHookRequest.
+ * Use `create(TestHookRequestSchema)` to create a new message.
+ */
+export const TestHookRequestSchema: GenMessage<TestHookRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 35);
+
+/**
+ * @generated from message v1.TestHookResponse
+ */
+export type TestHookResponse = Message<"v1.TestHookResponse"> & {
+  /**
+   * the hook's message template, or command, rendered with the test variables.
+   *
+   * @generated from field: string rendered_template = 1;
+   */
+  renderedTemplate: string;
+
+  /**
+   * output logged by the hook e.g. a command's output.
+   *
+   * @generated from field: string output = 2;
+   */
+  output: string;
+
+  /**
+   * the error returned by the hook, empty if it succeeded.
+   *
+   * @generated from field: string error = 3;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message v1.TestHookResponse.
+ * Use `create(TestHookResponseSchema)` to create a new message.
+ */
+export const TestHookResponseSchema: GenMessage<TestHookResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 36);
 
 /**
  * @generated from message v1.RemoveRepoRequest
@@ -655,7 +1138,7 @@ export type RemoveRepoRequest = Message<"v1.RemoveRepoRequest"> & {
  * Use `create(RemoveRepoRequestSchema)` to create a new message.
  */
 export const RemoveRepoRequestSchema: GenMessage<RemoveRepoRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 21);
+  messageDesc(file_v1_service, 37);
 
 /**
  * @generated from message v1.CancelOperationRequest
@@ -672,7 +1155,7 @@ export type CancelOperationRequest = Message<"v1.CancelOperationRequest"> & {
  * Use `create(CancelOperationRequestSchema)` to create a new message.
  */
 export const CancelOperationRequestSchema: GenMessage<CancelOperationRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 22);
+  messageDesc(file_v1_service, 38);
 
 /**
  * @generated from message v1.SummaryDashboardResponse
@@ -704,7 +1187,7 @@ export type SummaryDashboardResponse = Message<"v1.SummaryDashboardResponse"> &
  * Use `create(SummaryDashboardResponseSchema)` to create a new message.
  */
 export const SummaryDashboardResponseSchema: GenMessage<SummaryDashboardResponse> = /*@__PURE__*/
-  messageDesc(file_v1_service, 23);
+  messageDesc(file_v1_service, 39);
 
 /**
  * @generated from message v1.SummaryDashboardResponse.Summary
@@ -789,7 +1272,7 @@ export type SummaryDashboardResponse_Summary = Message<"v1.SummaryDashboardRespo
  * Use `create(SummaryDashboardResponse_SummarySchema)` to create a new message.
  */
 export const SummaryDashboardResponse_SummarySchema: GenMessage<SummaryDashboardResponse_Summary> = /*@__PURE__*/
-  messageDesc(file_v1_service, 23, 0);
+  messageDesc(file_v1_service, 39, 0);
 
 /**
  * @generated from message v1.SummaryDashboardResponse.BackupChart
@@ -826,7 +1309,7 @@ export type SummaryDashboardResponse_BackupChart = Message<"v1.SummaryDashboardR
  * Use `create(SummaryDashboardResponse_BackupChartSchema)` to create a new message.
  */
 export const SummaryDashboardResponse_BackupChartSchema: GenMessage<SummaryDashboardResponse_BackupChart> = /*@__PURE__*/
-  messageDesc(file_v1_service, 23, 1);
+  messageDesc(file_v1_service, 39, 1);
 
 /**
  * @generated from message v1.SummaryDashboardResponse.DayStatusBucket
@@ -873,7 +1356,7 @@ export type SummaryDashboardResponse_DayStatusBucket = Message<"v1.SummaryDashbo
  * Use `create(SummaryDashboardResponse_DayStatusBucketSchema)` to create a new message.
  */
 export const SummaryDashboardResponse_DayStatusBucketSchema: GenMessage<SummaryDashboardResponse_DayStatusBucket> = /*@__PURE__*/
-  messageDesc(file_v1_service, 23, 2);
+  messageDesc(file_v1_service, 39, 2);
 
 /**
  * @generated from message v1.SummaryDashboardResponse.StatusAndCount
@@ -895,7 +1378,7 @@ export type SummaryDashboardResponse_StatusAndCount = Message<"v1.SummaryDashboa
  * Use `create(SummaryDashboardResponse_StatusAndCountSchema)` to create a new message.
  */
 export const SummaryDashboardResponse_StatusAndCountSchema: GenMessage<SummaryDashboardResponse_StatusAndCount> = /*@__PURE__*/
-  messageDesc(file_v1_service, 23, 3);
+  messageDesc(file_v1_service, 39, 3);
 
 /**
  * @generated from message v1.GeneratePairingTokenRequest
@@ -935,7 +1418,7 @@ export type GeneratePairingTokenRequest = Message<"v1.GeneratePairingTokenReques
  * Use `create(GeneratePairingTokenRequestSchema)` to create a new message.
  */
 export const GeneratePairingTokenRequestSchema: GenMessage<GeneratePairingTokenRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 24);
+  messageDesc(file_v1_service, 40);
 
 /**
  * @generated from message v1.GeneratePairingTokenResponse
@@ -954,7 +1437,7 @@ export type GeneratePairingTokenResponse = Message<"v1.GeneratePairingTokenRespo
  * Use `create(GeneratePairingTokenResponseSchema)` to create a new message.
  */
 export const GeneratePairingTokenResponseSchema: GenMessage<GeneratePairingTokenResponse> = /*@__PURE__*/
-  messageDesc(file_v1_service, 25);
+  messageDesc(file_v1_service, 41);
 
 /**
  * @generated from service v1.Backrest
@@ -1016,6 +1499,47 @@ export const Backrest: GenService<{
     input: typeof EmptySchema;
     output: typeof OperationEventSchema;
   },
+  /**
+   * ListRepoKeys lists the keys (passwords) that can open a repo.
+   *
+   * @generated from rpc v1.Backrest.ListRepoKeys
+   */
+  listRepoKeys: {
+    methodKind: "unary";
+    input: typeof ListRepoKeysRequestSchema;
+    output: typeof ListRepoKeysResponseSchema;
+  },
+  /**
+   * AddRepoKey adds a key (password) to a repo. The repo's configured password is not changed.
+   *
+   * @generated from rpc v1.Backrest.AddRepoKey
+   */
+  addRepoKey: {
+    methodKind: "unary";
+    input: typeof AddRepoKeyRequestSchema;
+    output: typeof RepoKeySchema;
+  },
+  /**
+   * RemoveRepoKey removes a key from a repo. The key backrest uses to open the repo can not be removed.
+   *
+   * @generated from rpc v1.Backrest.RemoveRepoKey
+   */
+  removeRepoKey: {
+    methodKind: "unary";
+    input: typeof RemoveRepoKeyRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * RotateRepoPassword replaces the repo's password: a key for the new password is added and verified,
+   * the config is updated, and then the key for the old password is removed.
+   *
+   * @generated from rpc v1.Backrest.RotateRepoPassword
+   */
+  rotateRepoPassword: {
+    methodKind: "unary";
+    input: typeof RotateRepoPasswordRequestSchema;
+    output: typeof ConfigSchema;
+  },
   /**
    * @generated from rpc v1.Backrest.GetOperations
    */
@@ -1040,6 +1564,38 @@ export const Backrest: GenService<{
     input: typeof ListSnapshotFilesRequestSchema;
     output: typeof ListSnapshotFilesResponseSchema;
   },
+  /**
+   * FindFiles schedules a search for files across the snapshots of a repo and returns the search's operation ID.
+   * The search can be cancelled with Cancel and its results are fetched with GetFindFilesResults.
+   *
+   * @generated from rpc v1.Backrest.FindFiles
+   */
+  findFiles: {
+    methodKind: "unary";
+    input: typeof FindFilesRequestSchema;
+    output: typeof ScheduleTaskResponseSchema;
+  },
+  /**
+   * GetFindFilesResults returns a page of the results of a completed FindFiles operation.
+   *
+   * @generated from rpc v1.Backrest.GetFindFilesResults
+   */
+  getFindFilesResults: {
+    methodKind: "unary";
+    input: typeof GetFindFilesResultsRequestSchema;
+    output: typeof GetFindFilesResultsResponseSchema;
+  },
+  /**
+   * DiffSnapshots compares two snapshots. The changed entries are written to a log that can be streamed with GetLogs.
+   * restic diff doesn't report sizes per entry, size deltas are only available in aggregate in the response's stats.
+   *
+   * @generated from rpc v1.Backrest.DiffSnapshots
+   */
+  diffSnapshots: {
+    methodKind: "unary";
+    input: typeof DiffSnapshotsRequestSchema;
+    output: typeof DiffSnapshotsResponseSchema;
+  },
   /**
    * Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
    *
@@ -1070,6 +1626,36 @@ export const Backrest: GenService<{
     input: typeof ForgetRequestSchema;
     output: typeof ScheduleTaskResponseSchema;
   },
+  /**
+   * PreviewForget simulates applying a retention policy with `restic forget --dry-run`, no snapshots are removed.
+   *
+   * @generated from rpc v1.Backrest.PreviewForget
+   */
+  previewForget: {
+    methodKind: "unary";
+    input: typeof PreviewForgetRequestSchema;
+    output: typeof PreviewForgetResponseSchema;
+  },
+  /**
+   * PinSnapshot tags a snapshot so that it is always kept by retention policies.
+   *
+   * @generated from rpc v1.Backrest.PinSnapshot
+   */
+  pinSnapshot: {
+    methodKind: "unary";
+    input: typeof PinSnapshotRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * UnpinSnapshot removes the pin from a snapshot, it is then subject to retention policies again.
+   *
+   * @generated from rpc v1.Backrest.UnpinSnapshot
+   */
+  unpinSnapshot: {
+    methodKind: "unary";
+    input: typeof UnpinSnapshotRequestSchema;
+    output: typeof EmptySchema;
+  },
   /**
    * Restore schedules a restore operation.
    *
@@ -1110,6 +1696,16 @@ export const Backrest: GenService<{
     input: typeof RunCommandRequestSchema;
     output: typeof RunCommandResponseSchema;
   },
+  /**
+   * TestHook fires a hook once, without applying its notify mode or error policy and without recording an operation.
+   *
+   * @generated from rpc v1.Backrest.TestHook
+   */
+  testHook: {
+    methodKind: "unary";
+    input: typeof TestHookRequestSchema;
+    output: typeof TestHookResponseSchema;
+  },
   /**
    * GetDownloadURL returns a signed download URL given an operation ID and file path.
    *